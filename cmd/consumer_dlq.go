@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	shareComponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dlqListLimit int
+	dlqReplayAll bool
+)
+
+var consumerDlqCmd = &cobra.Command{
+	Use:   "dlq",
+	Short: "Inspect and replay the dead letter queue",
+}
+
+var consumerDlqListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the messages in the dead letter queue",
+	Run: func(cmd *cobra.Command, args []string) {
+		withJetStream(func(ctx context.Context, js dlqStore) {
+			deadLetters, err := js.ListDeadLetters(ctx, dlqListLimit)
+			if err != nil {
+				log.Fatal("failed to list dead letters", err)
+			}
+
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			for _, deadLetter := range deadLetters {
+				_ = encoder.Encode(deadLetter)
+			}
+			log.Printf("%d dead letter(s)", len(deadLetters))
+		})
+	},
+}
+
+var consumerDlqReplayCmd = &cobra.Command{
+	Use:   "replay [sequence...]",
+	Short: "Publish dead letters again for the consumer which failed them",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if dlqReplayAll == (len(args) > 0) {
+			return fmt.Errorf("give either the sequences to replay or --all")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		withJetStream(func(ctx context.Context, js dlqStore) {
+			var sequences []uint64
+			if dlqReplayAll {
+				deadLetters, err := js.ListDeadLetters(ctx, dlqListLimit)
+				if err != nil {
+					log.Fatal("failed to list dead letters", err)
+				}
+				for _, deadLetter := range deadLetters {
+					sequences = append(sequences, deadLetter.Sequence)
+				}
+			} else {
+				for _, arg := range args {
+					seq, err := strconv.ParseUint(arg, 10, 64)
+					if err != nil {
+						log.Fatalf("invalid sequence %q", arg)
+					}
+					sequences = append(sequences, seq)
+				}
+			}
+
+			replayed := 0
+			for _, seq := range sequences {
+				if err := js.ReplayDeadLetter(ctx, seq); err != nil {
+					log.Printf("Failed to replay dead letter %d: %v", seq, err)
+					continue
+				}
+				replayed++
+			}
+			log.Printf("Replayed %d/%d dead letter(s)", replayed, len(sequences))
+		})
+	},
+}
+
+type dlqStore interface {
+	ListDeadLetters(ctx context.Context, limit int) ([]shareComponent.DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, seq uint64) error
+}
+
+func withJetStream(fn func(ctx context.Context, js dlqStore)) {
+	nc, err := nats.Connect(os.Getenv("NATS_URL"))
+	if err != nil {
+		log.Fatal("failed to connect nats", err)
+	}
+	defer nc.Close()
+
+	js, err := shareComponent.NewJetStreamComp(nc)
+	if err != nil {
+		log.Fatal("failed to connect jetstream", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := js.EnsureStreams(ctx); err != nil {
+		log.Fatal("failed to create streams", err)
+	}
+
+	fn(ctx, js)
+}
+
+func setupConsumerDlqCmd() {
+	consumerDlqListCmd.Flags().IntVar(&dlqListLimit, "limit", 100, "maximum number of dead letters")
+	consumerDlqReplayCmd.Flags().IntVar(&dlqListLimit, "limit", 100, "maximum number of dead letters replayed with --all")
+	consumerDlqReplayCmd.Flags().BoolVar(&dlqReplayAll, "all", false, "replay every dead letter")
+
+	consumerDlqCmd.AddCommand(consumerDlqListCmd)
+	consumerDlqCmd.AddCommand(consumerDlqReplayCmd)
+}
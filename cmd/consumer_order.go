@@ -2,11 +2,12 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/nats-io/nats.go"
 	orderRepo "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/gorm-mysql"
@@ -15,165 +16,215 @@ import (
 	shareComponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
 	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+	sharerpc "github.com/ntttrang/go-food-delivery-backend-service/shared/infras/rpc"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/otel"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
+// orderNotificationConsumer is the durable consumer sending the order notifications.
+// A failed message is retried after each backoff, then moved to the dead letter stream.
+var orderNotificationConsumer = shareComponent.JetStreamConsumerConfig{
+	Durable:    "order-notification",
+	AckWait:    30 * time.Second,
+	MaxDeliver: 5,
+	BackOff:    []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute, 10 * time.Minute},
+}
+
 var consumerOrderCmd = &cobra.Command{
 	Use:   "order-create-cmd",
 	Short: "Start consumer send email when creating an order",
 	Run: func(cmd *cobra.Command, args []string) {
-		dsn := os.Getenv("DB_DSN")
-		dbMaster, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		runOrderConsumer(orderNotificationConsumer, func(appCtx shareinfras.IAppContext) map[string]shareComponent.MsgHandler {
+			return orderNotificationHandlers(newOrderNotificationService(appCtx))
+		})
+	},
+}
 
-		if err != nil {
-			log.Fatal("failed to connect database", err)
-		}
+// runOrderConsumer consumes the order events with the handlers until SIGINT or SIGTERM,
+// the workers run beside the consumer until then
+func runOrderConsumer(
+	consumer shareComponent.JetStreamConsumerConfig,
+	newHandlers func(appCtx shareinfras.IAppContext) map[string]shareComponent.MsgHandler,
+	workers ...func(ctx context.Context, appCtx shareinfras.IAppContext),
+) {
+	dsn := os.Getenv("DB_DSN")
+	dbMaster, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
 
-		db := dbMaster.Debug()
+	if err != nil {
+		log.Fatal("failed to connect database", err)
+	}
 
-		nc, err := nats.Connect(os.Getenv("NATS_URL"))
+	db := dbMaster.Debug()
 
-		if err != nil {
-			log.Fatal("failed to connect nats", err)
-		}
+	nc, err := nats.Connect(os.Getenv("NATS_URL"))
 
-		appCtx := shareinfras.NewAppContext(db)
-		dbCtx := appCtx.DbContext()
-		orderRepo := orderRepo.NewOrderRepo(dbCtx)
-		restaurantRpcClientRepo := rpcclient.NewRestaurantRPCClient(appCtx.GetConfig().RestaurantServiceURL)
-		userRpcClientRepo := rpcclient.NewUserRPCClient(appCtx.GetConfig().UserServiceURL)
-		emailSvc := shareComponent.NewEmailService(appCtx.GetConfig().EmailConfig)
-
-		notificationService := service.NewOrderNotificationService(
-			orderRepo,
-			userRpcClientRepo,
-			restaurantRpcClientRepo,
-			emailSvc,
-		)
-
-		_, dbSpanOrderCreate := otel.Tracer("").Start(context.Background(), "subs-order-create")
-		nc.Subscribe(datatype.EvtNotifyOrderCreate, func(msg *nats.Msg) {
-			log.Println("Subscribe: ORDER CREATE")
-			type evtNotifyOrderCreateMsg struct {
-				OrderID      string
-				UserID       string
-				RestaurantID string
-			}
+	if err != nil {
+		log.Fatal("failed to connect nats", err)
+	}
 
-			var data evtNotifyOrderCreateMsg
+	js, err := shareComponent.NewJetStreamComp(nc)
+	if err != nil {
+		log.Fatal("failed to connect jetstream", err)
+	}
 
-			if err := json.Unmarshal(msg.Data, &data); err != nil {
-				log.Println("failed to unmarshal data", err)
-				return
-			}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-			notificationService.NotifyOrderCreated(context.Background(), data.OrderID, data.UserID, data.RestaurantID)
+	if err := js.EnsureStreams(ctx); err != nil {
+		log.Fatal("failed to create streams", err)
+	}
 
-			log.Printf("Send email notification to parties: %v \n", data)
-		})
-		dbSpanOrderCreate.AddEvent("Subs msg: ORDER CREATED")
-		dbSpanOrderCreate.End()
+	appCtx := shareinfras.NewAppContext(db)
 
-		nc.Subscribe(datatype.EvtNotifyOrderCancel, func(msg *nats.Msg) {
-			log.Println("Subscribe: CANCEL ORDER")
-			type evtNotifyOrderCancelMsg struct {
-				OrderID            string
-				CancellationReason string
-			}
+	consumeCtx, err := js.Consume(ctx, consumer, newHandlers(appCtx))
+	if err != nil {
+		log.Fatal("failed to start consumer", err)
+	}
 
-			var data evtNotifyOrderCancelMsg
+	for _, worker := range workers {
+		go worker(ctx, appCtx)
+	}
 
-			if err := json.Unmarshal(msg.Data, &data); err != nil {
-				log.Println("failed to unmarshal data", err)
-				return
-			}
+	// Block until we receive a signal
+	log.Printf("Consumer %s started. Press Ctrl+C to exit...", consumer.Durable)
+	<-ctx.Done()
 
-			notificationService.NotifyOrderCancelled(context.Background(), data.OrderID, data.CancellationReason)
+	log.Println("Shutting down consumer...")
 
-			log.Printf("Send email notification to parties: %v \n", data)
-		})
+	// Stop pulling, the messages being handled are finished or redelivered after AckWait
+	consumeCtx.Stop()
+	<-consumeCtx.Closed()
 
-		nc.Subscribe(datatype.EvtNotifyPaymentStatusChange, func(msg *nats.Msg) {
-			log.Println("Subscribe: CHANGE PAYMENT STATUS")
-			type evtNotifyChangePaymentStatusMsg struct {
-				OrderID       string
-				PaymentStatus string
-			}
+	// Drain connection (process pending messages before closing)
+	if err := nc.Drain(); err != nil {
+		log.Printf("Error draining NATS connection: %v", err)
+	}
 
-			var data evtNotifyChangePaymentStatusMsg
+	// Close NATS connection
+	nc.Close()
 
-			if err := json.Unmarshal(msg.Data, &data); err != nil {
-				log.Println("failed to unmarshal data", err)
-				return
-			}
-
-			notificationService.NotifyPaymentStatusChange(context.Background(), data.OrderID, data.PaymentStatus)
+	log.Println("Consumer shutdown complete")
+}
 
-			log.Printf("Send email notification to parties: %v \n", data)
-		})
+func newOrderNotificationService(appCtx shareinfras.IAppContext) *service.OrderNotificationService {
+	orderRepo := orderRepo.NewOrderRepo(appCtx.DbContext())
+	restaurantRpcClientRepo := rpcclient.NewRestaurantRPCClient(appCtx.GetConfig().RestaurantServiceURL)
+	userRpcClientRepo := rpcclient.NewUserRPCClient(appCtx.GetConfig().UserServiceURL, appCtx.GetConfig().AuthConfig.InternalToken)
+	notificationRpcClientRepo := rpcclient.NewNotificationRPCClient(appCtx.GetConfig().NotificationServiceURL, appCtx.GetConfig().AuthConfig.InternalToken)
+	emailSvc := sharerpc.NewEmailOutboxRpcClient(appCtx.GetConfig().NotificationServiceURL, appCtx.GetConfig().AuthConfig.InternalToken)
+	emailRenderer := shareComponent.MustNewEmailTemplateRenderer(appCtx.GetConfig().EmailConfig.DefaultLocale)
+	smsSvc := shareComponent.NewFakeSmsProvider(appCtx.GetConfig().NotificationConfig.SinkDir)
+	pushSvc := shareComponent.NewFakePushProvider(appCtx.GetConfig().NotificationConfig.SinkDir)
+
+	return service.NewOrderNotificationService(
+		orderRepo,
+		userRpcClientRepo,
+		restaurantRpcClientRepo,
+		emailSvc,
+		emailRenderer,
+		smsSvc,
+		pushSvc,
+		notificationRpcClientRepo,
+	)
+}
 
-		nc.Subscribe(datatype.EvtNotifyShipperAssign, func(msg *nats.Msg) {
-			log.Println("Subscribe: ASSIGN SHIPPER")
-			type evtNotifyAssignShipperMsg struct {
-				OrderID   string
-				ShipperId string
-			}
+// startInProcessOrderConsumer runs the outbox relay and the order notification and webhook consumers in the app process,
+// used with the in-memory broker whose events cannot be consumed by another process
+func startInProcessOrderConsumer(appCtx shareinfras.IAppContext, subscriber shareinfras.IMsgSubscriber) {
+	notificationService := newOrderNotificationService(appCtx)
+	for topic, handler := range orderNotificationHandlers(notificationService) {
+		subscriber.Subscribe(topic, handler)
+	}
+	for topic, handler := range orderWebhookHandlers(newOrderWebhookService(appCtx)) {
+		subscriber.Subscribe(topic, handler)
+	}
+
+	repo := orderRepo.NewOrderRepo(appCtx.DbContext())
+	relay := service.NewOutboxRelay(repo, appCtx.MsgBroker(), appCtx.GetConfig().OutboxConfig)
+	go relay.Run(context.Background())
+
+	log.Println("Order notification and webhook consumers started in process")
+}
 
-			var data evtNotifyAssignShipperMsg
+// orderNotificationHandlers returns the handler of each order event.
+// An event which cannot be decoded is a poison message, any other error is retried.
+func orderNotificationHandlers(notificationService *service.OrderNotificationService) map[string]shareComponent.MsgHandler {
+	return map[string]shareComponent.MsgHandler{
+		datatype.EvtNotifyOrderCreate: evtHandler(func(ctx context.Context, data *datatype.OrderCreatedEvt) error {
+			_, span := otel.Tracer("").Start(ctx, "subs-order-create")
+			defer span.End()
 
-			if err := json.Unmarshal(msg.Data, &data); err != nil {
-				log.Println("failed to unmarshal data", err)
-				return
+			log.Println("Subscribe: ORDER CREATE")
+			if err := notificationService.NotifyOrderCreated(ctx, data.OrderID, data.UserID, data.RestaurantID); err != nil {
+				return err
 			}
 
-			notificationService.NotifyShipperAssignment(context.Background(), data.OrderID, data.ShipperId)
-
 			log.Printf("Send email notification to parties: %v \n", data)
-		})
-
-		nc.Subscribe(datatype.EvtNotifyOrderStateChange, func(msg *nats.Msg) {
-			log.Println("Subscribe: ORDER CHANGED STATE")
-			type evtNotifyOrderStateChangeMsg struct {
-				OrderID  string
-				OldState string
-				NewState string
+			return nil
+		}),
+		datatype.EvtNotifyOrderCancel: evtHandler(func(ctx context.Context, data *datatype.OrderCancelledEvt) error {
+			log.Println("Subscribe: CANCEL ORDER")
+			if err := notificationService.NotifyOrderCancelled(ctx, data.OrderID, data.CancelReason); err != nil {
+				return err
 			}
 
-			var data evtNotifyOrderStateChangeMsg
-
-			if err := json.Unmarshal(msg.Data, &data); err != nil {
-				log.Println("failed to unmarshal data", err)
-				return
+			log.Printf("Send email notification to parties: %v \n", data)
+			return nil
+		}),
+		datatype.EvtNotifyPaymentStatusChange: evtHandler(func(ctx context.Context, data *datatype.PaymentStatusChangedEvt) error {
+			log.Println("Subscribe: CHANGE PAYMENT STATUS")
+			if err := notificationService.NotifyPaymentStatusChange(ctx, data.OrderID, data.PaymentStatus); err != nil {
+				return err
 			}
 
-			notificationService.NotifyOrderStateChange(context.Background(), data.OrderID, data.OldState, data.NewState)
-
 			log.Printf("Send email notification to parties: %v \n", data)
-		})
+			return nil
+		}),
+		datatype.EvtNotifyShipperAssign: evtHandler(func(ctx context.Context, data *datatype.ShipperAssignedEvt) error {
+			log.Println("Subscribe: ASSIGN SHIPPER")
+			if err := notificationService.NotifyShipperAssignment(ctx, data.OrderID, data.ShipperID); err != nil {
+				return err
+			}
 
-		// Setup graceful shutdown
-		signalChan := make(chan os.Signal, 1)
-		signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+			log.Printf("Send email notification to parties: %v \n", data)
+			return nil
+		}),
+		datatype.EvtNotifyOrderStateChange: evtHandler(func(ctx context.Context, data *datatype.OrderStateChangedEvt) error {
+			log.Println("Subscribe: ORDER CHANGED STATE")
+			if err := notificationService.NotifyOrderStateChange(ctx, data.OrderID, data.OldState, data.NewState); err != nil {
+				return err
+			}
 
-		// Block until we receive a signal
-		log.Println("Consumer started. Press Ctrl+C to exit...")
-		<-signalChan
+			log.Printf("Send email notification to parties: %v \n", data)
+			return nil
+		}),
+	}
+}
 
-		log.Println("Shutting down consumer...")
+// evtHandler decodes the event envelope and continues the trace of the producer
+func evtHandler[T datatype.EvtPayload](handle func(ctx context.Context, data T) error) shareComponent.MsgHandler {
+	return evtEnvelopeHandler(func(ctx context.Context, evt *datatype.AppEvent, data T) error {
+		return handle(ctx, data)
+	})
+}
 
-		// Drain connection (process pending messages before closing)
-		if err := nc.Drain(); err != nil {
-			log.Printf("Error draining NATS connection: %v", err)
+// evtEnvelopeHandler is an evtHandler also given the envelope, e.g. for the event id
+func evtEnvelopeHandler[T datatype.EvtPayload](handle func(ctx context.Context, evt *datatype.AppEvent, data T) error) shareComponent.MsgHandler {
+	return func(ctx context.Context, msg []byte) error {
+		evt, err := datatype.DecodeEvt(msg)
+		if err != nil {
+			return fmt.Errorf("%w: %v", shareComponent.ErrPoisonMessage, err)
 		}
 
-		// Close NATS connection
-		nc.Close()
+		data, ok := evt.Data.(T)
+		if !ok {
+			return fmt.Errorf("%w: unexpected payload %T for %s", shareComponent.ErrPoisonMessage, evt.Data, evt.Topic)
+		}
 
-		log.Println("Consumer shutdown complete")
-	},
+		return handle(evt.ContextWithTrace(ctx), evt, data)
+	}
 }
 
 var consumerCmd = &cobra.Command{
@@ -183,5 +234,7 @@ var consumerCmd = &cobra.Command{
 
 func setupConsumerCmd() {
 	consumerCmd.AddCommand(consumerOrderCmd)
-
+	consumerCmd.AddCommand(consumerOrderWebhookCmd)
+	setupConsumerDlqCmd()
+	consumerCmd.AddCommand(consumerDlqCmd)
 }
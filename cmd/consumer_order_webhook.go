@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"time"
+
+	orderRepo "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/gorm-mysql"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/rpc-client"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	shareComponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+	"github.com/spf13/cobra"
+)
+
+// orderWebhookConsumer is the durable consumer forwarding the order events to the webhooks,
+// apart from the notifications so that a slow one does not hold the other back
+var orderWebhookConsumer = shareComponent.JetStreamConsumerConfig{
+	Durable:    "order-webhook",
+	AckWait:    30 * time.Second,
+	MaxDeliver: 5,
+	BackOff:    []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute, 10 * time.Minute},
+}
+
+var consumerOrderWebhookCmd = &cobra.Command{
+	Use:   "order-webhook-cmd",
+	Short: "Start consumer forwarding the order events to the restaurant webhooks",
+	Run: func(cmd *cobra.Command, args []string) {
+		runOrderConsumer(orderWebhookConsumer, func(appCtx shareinfras.IAppContext) map[string]shareComponent.MsgHandler {
+			return orderWebhookHandlers(newOrderWebhookService(appCtx))
+		})
+	},
+}
+
+func newOrderWebhookService(appCtx shareinfras.IAppContext) *service.OrderWebhookService {
+	orderRepo := orderRepo.NewOrderRepo(appCtx.DbContext())
+	webhookRpcClient := rpcclient.NewWebhookRPCClient(appCtx.GetConfig().WebhookServiceURL, appCtx.GetConfig().AuthConfig.InternalToken)
+
+	return service.NewOrderWebhookService(orderRepo, webhookRpcClient)
+}
+
+// orderWebhookHandlers returns the handler of each order event delivered to the webhooks.
+// The webhook module queues the deliveries, an event is forwarded again when it is redelivered.
+func orderWebhookHandlers(webhookService *service.OrderWebhookService) map[string]shareComponent.MsgHandler {
+	return map[string]shareComponent.MsgHandler{
+		datatype.EvtNotifyOrderCreate: evtEnvelopeHandler(func(ctx context.Context, evt *datatype.AppEvent, data *datatype.OrderCreatedEvt) error {
+			log.Printf("Webhook: ORDER CREATE %s", data.OrderID)
+			return webhookService.OrderCreated(ctx, evt, data)
+		}),
+		datatype.EvtNotifyOrderStateChange: evtEnvelopeHandler(func(ctx context.Context, evt *datatype.AppEvent, data *datatype.OrderStateChangedEvt) error {
+			log.Printf("Webhook: ORDER CHANGED STATE %s", data.OrderID)
+			return webhookService.OrderStateChanged(ctx, evt, data)
+		}),
+		datatype.EvtNotifyOrderCancel: evtEnvelopeHandler(func(ctx context.Context, evt *datatype.AppEvent, data *datatype.OrderCancelledEvt) error {
+			log.Printf("Webhook: CANCEL ORDER %s", data.OrderID)
+			return webhookService.OrderCancelled(ctx, evt, data)
+		}),
+	}
+}
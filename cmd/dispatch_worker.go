@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	dispatchmodule "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch"
+	dispatchservice "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/service"
+	shareComponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+	"github.com/spf13/cobra"
+)
+
+// orderDispatchConsumer is the durable consumer starting the dispatch of the accepted orders
+// and following their cancellation, assignment and delivery
+var orderDispatchConsumer = shareComponent.JetStreamConsumerConfig{
+	Durable:    "order-dispatch",
+	AckWait:    30 * time.Second,
+	MaxDeliver: 5,
+	BackOff:    []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute, 10 * time.Minute},
+}
+
+var dispatchWorkerCmd = &cobra.Command{
+	Use:   "dispatch-worker",
+	Short: "Start worker offering the orders waiting for a shipper to the nearest available shippers",
+	Run: func(cmd *cobra.Command, args []string) {
+		runOrderConsumer(orderDispatchConsumer, func(appCtx shareinfras.IAppContext) map[string]shareComponent.MsgHandler {
+			return orderDispatchHandlers(dispatchmodule.NewDispatchEngine(appCtx))
+		}, func(ctx context.Context, appCtx shareinfras.IAppContext) {
+			// Expire the unanswered offers and search again for the orders without shipper
+			dispatchmodule.NewDispatchEngine(appCtx).Run(ctx)
+		})
+	},
+}
+
+// startInProcessDispatchWorker runs the dispatch in the app process, used with the in-memory broker
+// whose events cannot be consumed by the dispatch-worker command
+func startInProcessDispatchWorker(appCtx shareinfras.IAppContext, subscriber shareinfras.IMsgSubscriber) {
+	engine := dispatchmodule.NewDispatchEngine(appCtx)
+	for topic, handler := range orderDispatchHandlers(engine) {
+		subscriber.Subscribe(topic, handler)
+	}
+	go engine.Run(context.Background())
+
+	log.Println("Dispatch worker started in process")
+}
+
+// orderDispatchHandlers returns the handler of each order event followed by the dispatch.
+// The dispatch starts once the restaurant accepts the order, not when it is placed.
+func orderDispatchHandlers(engine *dispatchservice.DispatchEngine) map[string]shareComponent.MsgHandler {
+	return map[string]shareComponent.MsgHandler{
+		datatype.EvtNotifyOrderCancel: evtHandler(func(ctx context.Context, data *datatype.OrderCancelledEvt) error {
+			orderId, err := parseEvtId(data.OrderID)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Dispatch: CANCEL ORDER %s", data.OrderID)
+			return engine.CancelDispatch(ctx, orderId)
+		}),
+		datatype.EvtNotifyShipperAssign: evtHandler(func(ctx context.Context, data *datatype.ShipperAssignedEvt) error {
+			orderId, err := parseEvtId(data.OrderID)
+			if err != nil {
+				return err
+			}
+			shipperId, err := parseEvtId(data.ShipperID)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Dispatch: ASSIGN SHIPPER %s", data.OrderID)
+			return engine.ShipperAssigned(ctx, orderId, shipperId)
+		}),
+		datatype.EvtNotifyOrderStateChange: evtHandler(func(ctx context.Context, data *datatype.OrderStateChangedEvt) error {
+			orderId, err := parseEvtId(data.OrderID)
+			if err != nil {
+				return err
+			}
+			// Only the acceptance needs the restaurant, the events of the other states may lack it
+			restaurantId := uuid.Nil
+			if data.RestaurantID != "" {
+				if restaurantId, err = parseEvtId(data.RestaurantID); err != nil {
+					return err
+				}
+			}
+			return engine.OrderStateChanged(ctx, orderId, restaurantId, data.NewState)
+		}),
+	}
+}
+
+// parseEvtId parses an id of an event, an invalid one makes the event a poison message
+func parseEvtId(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid id %q: %v", shareComponent.ErrPoisonMessage, id, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	notificationRepo "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/infras/repository/gorm-mysql"
+	notificationService "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	shareComponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+var emailDispatcherCmd = &cobra.Command{
+	Use:   "email-dispatcher",
+	Short: "Start dispatcher sending the emails queued in the outbox",
+	Run: func(cmd *cobra.Command, args []string) {
+		dsn := os.Getenv("DB_DSN")
+		db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		if err != nil {
+			log.Fatalf("failed to connect database: %v", err)
+		}
+
+		appCtx := shareinfras.NewAppContext(db)
+		dispatcher := newEmailDispatcher(appCtx)
+
+		// Setup graceful shutdown
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		log.Println("Email dispatcher started. Press Ctrl+C to exit...")
+		dispatcher.Run(ctx)
+		log.Println("Email dispatcher shutdown complete")
+	},
+}
+
+func newEmailDispatcher(appCtx shareinfras.IAppContext) *notificationService.EmailDispatcher {
+	repo := notificationRepo.NewNotificationRepo(appCtx.DbContext())
+	emailSvc := shareComponent.NewEmailSender(appCtx.GetConfig().EmailConfig)
+
+	return notificationService.NewEmailDispatcher(repo, emailSvc, appCtx.GetConfig().EmailOutboxConfig)
+}
+
+// startInProcessEmailDispatcher sends the queued emails from the app process,
+// disabled with EMAIL_OUTBOX_IN_PROCESS=false when the email-dispatcher command runs instead
+func startInProcessEmailDispatcher(appCtx shareinfras.IAppContext) {
+	go newEmailDispatcher(appCtx).Run(context.Background())
+
+	log.Println("Email dispatcher started in process")
+}
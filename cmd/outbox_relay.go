@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
+	orderHttpgin "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/controller/http-gin"
+	orderRepo "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/gorm-mysql"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+var outboxRelayCmd = &cobra.Command{
+	Use:   "outbox-relay",
+	Short: "Start relay publishing the order events saved in the outbox",
+	Run: func(cmd *cobra.Command, args []string) {
+		dsn := os.Getenv("DB_DSN")
+		db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		if err != nil {
+			log.Fatalf("failed to connect database: %v", err)
+		}
+
+		appCtx := shareinfras.NewAppContext(db)
+		if _, ok := appCtx.MsgBroker().(shareinfras.IMsgSubscriber); ok {
+			log.Fatal("outbox-relay needs MSG_BROKER=nats, with the in-memory broker the app process relays the events")
+		}
+		repo := orderRepo.NewOrderRepo(appCtx.DbContext())
+		relay := service.NewOutboxRelay(repo, appCtx.MsgBroker(), appCtx.GetConfig().OutboxConfig)
+
+		// Metrics
+		port := os.Getenv("OUTBOX_METRICS_PORT")
+		if port == "" {
+			port = "9100"
+		}
+		r := gin.New()
+		r.Use(middleware.Recover())
+		r.GET("/health", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "Ok"})
+		})
+		r.GET("/metrics", orderHttpgin.OutboxMetricsAPI(relay))
+		srv := &http.Server{Addr: fmt.Sprintf(":%s", port), Handler: r}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("Outbox relay: metrics server stopped: %v", err)
+			}
+		}()
+
+		// Setup graceful shutdown
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		log.Println("Outbox relay started. Press Ctrl+C to exit...")
+		relay.Run(ctx)
+
+		log.Println("Shutting down outbox relay...")
+		if err := srv.Shutdown(context.Background()); err != nil {
+			log.Printf("Error shutting down metrics server: %v", err)
+		}
+		log.Println("Outbox relay shutdown complete")
+	},
+}
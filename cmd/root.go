@@ -20,15 +20,19 @@ import (
 	categorymodule "github.com/ntttrang/go-food-delivery-backend-service/modules/category"
 	categorygrpcctl "github.com/ntttrang/go-food-delivery-backend-service/modules/category/infras/controller/grpc-ctrl"
 	categorygormmysql "github.com/ntttrang/go-food-delivery-backend-service/modules/category/infras/repository/gorm-mysql"
+	dispatchmodule "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch"
 	foodmodule "github.com/ntttrang/go-food-delivery-backend-service/modules/food"
 	foodgrpcctl "github.com/ntttrang/go-food-delivery-backend-service/modules/food/infras/controller/grpc-ctrl"
 	foodgormmysql "github.com/ntttrang/go-food-delivery-backend-service/modules/food/infras/repository/gorm-mysql"
 	foodservice "github.com/ntttrang/go-food-delivery-backend-service/modules/food/service"
 	mediamodule "github.com/ntttrang/go-food-delivery-backend-service/modules/media"
+	notificationmodule "github.com/ntttrang/go-food-delivery-backend-service/modules/notification"
 	ordermodule "github.com/ntttrang/go-food-delivery-backend-service/modules/order"
 	paymentmodule "github.com/ntttrang/go-food-delivery-backend-service/modules/payment"
 	restaurantmodule "github.com/ntttrang/go-food-delivery-backend-service/modules/restaurant"
+	shippermodule "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper"
 	usermodule "github.com/ntttrang/go-food-delivery-backend-service/modules/user"
+	webhookmodule "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook"
 	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
 )
 
@@ -64,6 +68,11 @@ var rootCmd = &cobra.Command{
 		v1 := r.Group("/v1")
 		appCtx := shareinfras.NewAppContext(db)
 
+		// The client ip keys the login lockout, a forged X-Forwarded-For must not choose it
+		if err := r.SetTrustedProxies(appCtx.GetConfig().TrustedProxies); err != nil {
+			log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+		}
+
 		usermodule.SetupUserModule(appCtx, v1)
 		mediamodule.SetupMediaModule(appCtx, v1)
 		categorymodule.SetupCategoryModule(appCtx, v1)
@@ -72,6 +81,22 @@ var rootCmd = &cobra.Command{
 		cartmodule.SetupCartModule(appCtx, v1)
 		paymentmodule.SetupPaymentModule(appCtx, v1)
 		ordermodule.SetupOrderModule(appCtx, v1)
+		notificationmodule.SetupNotificationModule(appCtx, v1)
+		webhookmodule.SetupWebhookModule(appCtx, v1)
+		dispatchmodule.SetupDispatchModule(appCtx, v1)
+		shippermodule.SetupShipperModule(appCtx, v1)
+
+		// Events of the in-memory broker are only seen by this process
+		if subscriber, ok := appCtx.MsgBroker().(shareinfras.IMsgSubscriber); ok {
+			startInProcessOrderConsumer(appCtx, subscriber)
+			startInProcessDispatchWorker(appCtx, subscriber)
+		}
+		if appCtx.GetConfig().EmailOutboxConfig.InProcess {
+			startInProcessEmailDispatcher(appCtx)
+		}
+		if appCtx.GetConfig().WebhookConfig.InProcess {
+			startInProcessWebhookDispatcher(appCtx)
+		}
 
 		go func() {
 
@@ -92,10 +117,11 @@ var rootCmd = &cobra.Command{
 			// Register GRPC
 			category.RegisterCategoryServer(s, categorygrpcctl.NewCategoryGrpcServer(categorygormmysql.NewCategoryRepo(appCtx.DbContext())))
 
-			// Setup food gRPC server with update service
+			// Setup food gRPC server with update and stock services
 			foodRepo := foodgormmysql.NewFoodRepo(appCtx.DbContext())
 			updateService := foodservice.NewUpdateCommandHandler(foodRepo)
-			food.RegisterFoodServer(s, foodgrpcctl.NewFoodGrpcServer(foodRepo, updateService))
+			stockService := foodservice.NewFoodStockCommandHandler(foodRepo)
+			food.RegisterFoodServer(s, foodgrpcctl.NewFoodGrpcServer(foodRepo, updateService, stockService))
 
 			// Serve gRPC Server
 			log.Printf("Serving gRPC on 0.0.0.0:%s \n", grpcPort)
@@ -113,6 +139,10 @@ func Execute() {
 	setupConsumerCmd()
 	// Add command
 	rootCmd.AddCommand(consumerCmd)
+	rootCmd.AddCommand(outboxRelayCmd)
+	rootCmd.AddCommand(emailDispatcherCmd)
+	rootCmd.AddCommand(webhookDispatcherCmd)
+	rootCmd.AddCommand(dispatchWorkerCmd)
 	// Start server
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal("failed to execute command", err)
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	webhookmodule "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+var webhookDispatcherCmd = &cobra.Command{
+	Use:   "webhook-dispatcher",
+	Short: "Start dispatcher delivering the queued webhooks to the restaurant partners",
+	Run: func(cmd *cobra.Command, args []string) {
+		dsn := os.Getenv("DB_DSN")
+		db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		if err != nil {
+			log.Fatalf("failed to connect database: %v", err)
+		}
+
+		appCtx := shareinfras.NewAppContext(db)
+		dispatcher := webhookmodule.NewWebhookDispatcher(appCtx)
+
+		// Setup graceful shutdown
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		log.Println("Webhook dispatcher started. Press Ctrl+C to exit...")
+		dispatcher.Run(ctx)
+		log.Println("Webhook dispatcher shutdown complete")
+	},
+}
+
+// startInProcessWebhookDispatcher delivers the queued webhooks from the app process,
+// disabled with WEBHOOK_IN_PROCESS=false when the webhook-dispatcher command runs instead
+func startInProcessWebhookDispatcher(appCtx shareinfras.IAppContext) {
+	go webhookmodule.NewWebhookDispatcher(appCtx).Run(context.Background())
+
+	log.Println("Webhook dispatcher started in process")
+}
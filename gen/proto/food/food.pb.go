@@ -121,6 +121,8 @@ type FoodDTO struct {
 	CategoryId    string                 `protobuf:"bytes,8,opt,name=categoryId,proto3" json:"categoryId,omitempty"`
 	RestaurantId  string                 `protobuf:"bytes,9,opt,name=restaurantId,proto3" json:"restaurantId,omitempty"`
 	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	StockTracked  bool                   `protobuf:"varint,11,opt,name=stockTracked,proto3" json:"stockTracked,omitempty"`
+	Stock         int64                  `protobuf:"varint,12,opt,name=stock,proto3" json:"stock,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -225,6 +227,20 @@ func (x *FoodDTO) GetStatus() string {
 	return ""
 }
 
+func (x *FoodDTO) GetStockTracked() bool {
+	if x != nil {
+		return x.StockTracked
+	}
+	return false
+}
+
+func (x *FoodDTO) GetStock() int64 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
 type UpdateFoodRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -361,6 +377,198 @@ func (x *UpdateFoodResp) GetId() string {
 	return ""
 }
 
+type StockItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FoodId        string                 `protobuf:"bytes,1,opt,name=foodId,proto3" json:"foodId,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockItem) Reset() {
+	*x = StockItem{}
+	mi := &file_proto_food_food_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockItem) ProtoMessage() {}
+
+func (x *StockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_food_food_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
+	return file_proto_food_food_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StockItem) GetFoodId() string {
+	if x != nil {
+		return x.FoodId
+	}
+	return ""
+}
+
+func (x *StockItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Items         []*StockItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_proto_food_food_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_food_food_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_food_food_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReserveStockRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type StockReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservationRequest) Reset() {
+	*x = StockReservationRequest{}
+	mi := &file_proto_food_food_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservationRequest) ProtoMessage() {}
+
+func (x *StockReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_food_food_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservationRequest.ProtoReflect.Descriptor instead.
+func (*StockReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_food_food_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StockReservationRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type StockReservationResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservationResp) Reset() {
+	*x = StockReservationResp{}
+	mi := &file_proto_food_food_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservationResp) ProtoMessage() {}
+
+func (x *StockReservationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_food_food_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservationResp.ProtoReflect.Descriptor instead.
+func (*StockReservationResp) Descriptor() ([]byte, []int) {
+	return file_proto_food_food_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StockReservationResp) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
 var File_proto_food_food_proto protoreflect.FileDescriptor
 
 const file_proto_food_food_proto_rawDesc = "" +
@@ -369,7 +577,7 @@ const file_proto_food_food_proto_rawDesc = "" +
 	"\x11GetFoodIdsRequest\x12\x10\n" +
 	"\x03ids\x18\x01 \x03(\tR\x03ids\"+\n" +
 	"\vFoodIdsResp\x12\x1c\n" +
-	"\x04data\x18\x01 \x03(\v2\b.FoodDTOR\x04data\"\x95\x02\n" +
+	"\x04data\x18\x01 \x03(\v2\b.FoodDTOR\x04data\"\xcf\x02\n" +
 	"\aFoodDTO\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -385,7 +593,9 @@ const file_proto_food_food_proto_rawDesc = "" +
 	"categoryId\x12\"\n" +
 	"\frestaurantId\x18\t \x01(\tR\frestaurantId\x12\x16\n" +
 	"\x06status\x18\n" +
-	" \x01(\tR\x06status\"\xcb\x01\n" +
+	" \x01(\tR\x06status\x12\"\n" +
+	"\fstockTracked\x18\v \x01(\bR\fstockTracked\x12\x14\n" +
+	"\x05stock\x18\f \x01(\x03R\x05stock\"\xcb\x01\n" +
 	"\x11UpdateFoodRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
@@ -397,10 +607,24 @@ const file_proto_food_food_proto_rawDesc = "" +
 	"\x05image\x18\x06 \x01(\tR\x05image\x12\x0e\n" +
 	"\x02id\x18\a \x01(\tR\x02id\" \n" +
 	"\x0eUpdateFoodResp\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2u\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\tStockItem\x12\x16\n" +
+	"\x06foodId\x18\x01 \x01(\tR\x06foodId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"Q\n" +
+	"\x13ReserveStockRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12 \n" +
+	"\x05items\x18\x02 \x03(\v2\n" +
+	".StockItemR\x05items\"3\n" +
+	"\x17StockReservationRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\"0\n" +
+	"\x14StockReservationResp\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId2\xb9\x02\n" +
 	"\x04Food\x124\n" +
 	"\x0eGetFooodsByIds\x12\x12.GetFoodIdsRequest\x1a\f.FoodIdsResp\"\x00\x127\n" +
-	"\x0eUpdateFoodById\x12\x12.UpdateFoodRequest\x1a\x0f.UpdateFoodResp\"\x00B\aZ\x05food/b\x06proto3"
+	"\x0eUpdateFoodById\x12\x12.UpdateFoodRequest\x1a\x0f.UpdateFoodResp\"\x00\x12=\n" +
+	"\fReserveStock\x12\x14.ReserveStockRequest\x1a\x15.StockReservationResp\"\x00\x12@\n" +
+	"\vCommitStock\x12\x18.StockReservationRequest\x1a\x15.StockReservationResp\"\x00\x12A\n" +
+	"\fReleaseStock\x12\x18.StockReservationRequest\x1a\x15.StockReservationResp\"\x00B\aZ\x05food/b\x06proto3"
 
 var (
 	file_proto_food_food_proto_rawDescOnce sync.Once
@@ -414,25 +638,36 @@ func file_proto_food_food_proto_rawDescGZIP() []byte {
 	return file_proto_food_food_proto_rawDescData
 }
 
-var file_proto_food_food_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_food_food_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_proto_food_food_proto_goTypes = []any{
-	(*GetFoodIdsRequest)(nil), // 0: GetFoodIdsRequest
-	(*FoodIdsResp)(nil),       // 1: FoodIdsResp
-	(*FoodDTO)(nil),           // 2: FoodDTO
-	(*UpdateFoodRequest)(nil), // 3: UpdateFoodRequest
-	(*UpdateFoodResp)(nil),    // 4: UpdateFoodResp
+	(*GetFoodIdsRequest)(nil),       // 0: GetFoodIdsRequest
+	(*FoodIdsResp)(nil),             // 1: FoodIdsResp
+	(*FoodDTO)(nil),                 // 2: FoodDTO
+	(*UpdateFoodRequest)(nil),       // 3: UpdateFoodRequest
+	(*UpdateFoodResp)(nil),          // 4: UpdateFoodResp
+	(*StockItem)(nil),               // 5: StockItem
+	(*ReserveStockRequest)(nil),     // 6: ReserveStockRequest
+	(*StockReservationRequest)(nil), // 7: StockReservationRequest
+	(*StockReservationResp)(nil),    // 8: StockReservationResp
 }
 var file_proto_food_food_proto_depIdxs = []int32{
 	2, // 0: FoodIdsResp.data:type_name -> FoodDTO
-	0, // 1: Food.GetFooodsByIds:input_type -> GetFoodIdsRequest
-	3, // 2: Food.UpdateFoodById:input_type -> UpdateFoodRequest
-	1, // 3: Food.GetFooodsByIds:output_type -> FoodIdsResp
-	4, // 4: Food.UpdateFoodById:output_type -> UpdateFoodResp
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5, // 1: ReserveStockRequest.items:type_name -> StockItem
+	0, // 2: Food.GetFooodsByIds:input_type -> GetFoodIdsRequest
+	3, // 3: Food.UpdateFoodById:input_type -> UpdateFoodRequest
+	6, // 4: Food.ReserveStock:input_type -> ReserveStockRequest
+	7, // 5: Food.CommitStock:input_type -> StockReservationRequest
+	7, // 6: Food.ReleaseStock:input_type -> StockReservationRequest
+	1, // 7: Food.GetFooodsByIds:output_type -> FoodIdsResp
+	4, // 8: Food.UpdateFoodById:output_type -> UpdateFoodResp
+	8, // 9: Food.ReserveStock:output_type -> StockReservationResp
+	8, // 10: Food.CommitStock:output_type -> StockReservationResp
+	8, // 11: Food.ReleaseStock:output_type -> StockReservationResp
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_proto_food_food_proto_init() }
@@ -446,7 +681,7 @@ func file_proto_food_food_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_food_food_proto_rawDesc), len(file_proto_food_food_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
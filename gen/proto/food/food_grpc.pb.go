@@ -21,6 +21,9 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Food_GetFooodsByIds_FullMethodName = "/Food/GetFooodsByIds"
 	Food_UpdateFoodById_FullMethodName = "/Food/UpdateFoodById"
+	Food_ReserveStock_FullMethodName   = "/Food/ReserveStock"
+	Food_CommitStock_FullMethodName    = "/Food/CommitStock"
+	Food_ReleaseStock_FullMethodName   = "/Food/ReleaseStock"
 )
 
 // FoodClient is the client API for Food service.
@@ -29,6 +32,9 @@ const (
 type FoodClient interface {
 	GetFooodsByIds(ctx context.Context, in *GetFoodIdsRequest, opts ...grpc.CallOption) (*FoodIdsResp, error)
 	UpdateFoodById(ctx context.Context, in *UpdateFoodRequest, opts ...grpc.CallOption) (*UpdateFoodResp, error)
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*StockReservationResp, error)
+	CommitStock(ctx context.Context, in *StockReservationRequest, opts ...grpc.CallOption) (*StockReservationResp, error)
+	ReleaseStock(ctx context.Context, in *StockReservationRequest, opts ...grpc.CallOption) (*StockReservationResp, error)
 }
 
 type foodClient struct {
@@ -59,12 +65,45 @@ func (c *foodClient) UpdateFoodById(ctx context.Context, in *UpdateFoodRequest,
 	return out, nil
 }
 
+func (c *foodClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*StockReservationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockReservationResp)
+	err := c.cc.Invoke(ctx, Food_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foodClient) CommitStock(ctx context.Context, in *StockReservationRequest, opts ...grpc.CallOption) (*StockReservationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockReservationResp)
+	err := c.cc.Invoke(ctx, Food_CommitStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foodClient) ReleaseStock(ctx context.Context, in *StockReservationRequest, opts ...grpc.CallOption) (*StockReservationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockReservationResp)
+	err := c.cc.Invoke(ctx, Food_ReleaseStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FoodServer is the server API for Food service.
 // All implementations must embed UnimplementedFoodServer
 // for forward compatibility.
 type FoodServer interface {
 	GetFooodsByIds(context.Context, *GetFoodIdsRequest) (*FoodIdsResp, error)
 	UpdateFoodById(context.Context, *UpdateFoodRequest) (*UpdateFoodResp, error)
+	ReserveStock(context.Context, *ReserveStockRequest) (*StockReservationResp, error)
+	CommitStock(context.Context, *StockReservationRequest) (*StockReservationResp, error)
+	ReleaseStock(context.Context, *StockReservationRequest) (*StockReservationResp, error)
 	mustEmbedUnimplementedFoodServer()
 }
 
@@ -81,6 +120,15 @@ func (UnimplementedFoodServer) GetFooodsByIds(context.Context, *GetFoodIdsReques
 func (UnimplementedFoodServer) UpdateFoodById(context.Context, *UpdateFoodRequest) (*UpdateFoodResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateFoodById not implemented")
 }
+func (UnimplementedFoodServer) ReserveStock(context.Context, *ReserveStockRequest) (*StockReservationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedFoodServer) CommitStock(context.Context, *StockReservationRequest) (*StockReservationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitStock not implemented")
+}
+func (UnimplementedFoodServer) ReleaseStock(context.Context, *StockReservationRequest) (*StockReservationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseStock not implemented")
+}
 func (UnimplementedFoodServer) mustEmbedUnimplementedFoodServer() {}
 func (UnimplementedFoodServer) testEmbeddedByValue()              {}
 
@@ -138,6 +186,60 @@ func _Food_UpdateFoodById_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Food_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Food_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Food_CommitStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServer).CommitStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Food_CommitStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServer).CommitStock(ctx, req.(*StockReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Food_ReleaseStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServer).ReleaseStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Food_ReleaseStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServer).ReleaseStock(ctx, req.(*StockReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Food_ServiceDesc is the grpc.ServiceDesc for Food service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +255,18 @@ var Food_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateFoodById",
 			Handler:    _Food_UpdateFoodById_Handler,
 		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _Food_ReserveStock_Handler,
+		},
+		{
+			MethodName: "CommitStock",
+			Handler:    _Food_CommitStock_Handler,
+		},
+		{
+			MethodName: "ReleaseStock",
+			Handler:    _Food_ReleaseStock_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/food/food.proto",
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"errors"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -11,7 +10,7 @@ import (
 func extractToken(authorizationStr string) (string, error) {
 	token := strings.TrimPrefix(authorizationStr, "Bearer ")
 	if token == "" {
-		panic(errors.New("token is required"))
+		return "", datatype.ErrUnauthorized.WithError("token is required")
 	}
 	return token, nil
 }
@@ -22,17 +21,28 @@ type ITokenValidator interface {
 
 func Auth(tokenValidator ITokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token, err := extractToken(c.GetHeader("Authorization"))
-		if err != nil {
-			panic(err)
-		}
+		authenticate(c, tokenValidator)
+		c.Next()
+	}
+}
+
+// authenticate validates the bearer token and sets the requester in the context.
+// A requester set by a previous middleware of the route is reused, the token is validated once.
+func authenticate(c *gin.Context, tokenValidator ITokenValidator) datatype.Requester {
+	if requester, ok := c.Get(datatype.KeyRequester); ok {
+		return requester.(datatype.Requester)
+	}
 
-		requester, err := tokenValidator.Validate(token)
-		if err != nil {
-			panic(err)
-		}
+	token, err := extractToken(c.GetHeader("Authorization"))
+	if err != nil {
+		panic(err)
+	}
 
-		c.Set(datatype.KeyRequester, requester)
-		c.Next()
+	requester, err := tokenValidator.Validate(token)
+	if err != nil {
+		panic(err)
 	}
+
+	c.Set(datatype.KeyRequester, requester)
+	return requester
 }
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// IOwnershipChecker tells if a user owns a resource, e.g. the owner of a restaurant
+type IOwnershipChecker interface {
+	IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error)
+}
+
+// ResourceIdFunc extracts the id of the resource to check from the request
+type ResourceIdFunc func(c *gin.Context) string
+
+// FromParam reads the resource id from a path param
+func FromParam(name string) ResourceIdFunc {
+	return func(c *gin.Context) string {
+		return c.Param(name)
+	}
+}
+
+// FromQuery reads the resource id from a query param
+func FromQuery(name string) ResourceIdFunc {
+	return func(c *gin.Context) string {
+		return c.Query(name)
+	}
+}
+
+// FromJSONBody reads the resource id from a field of the JSON body, the body is kept for the handler
+func FromJSONBody(field string) ResourceIdFunc {
+	return func(c *gin.Context) string {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			panic(datatype.ErrBadRequest.WithError(err.Error()))
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var data map[string]interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			panic(datatype.ErrBadRequest.WithError(err.Error()))
+		}
+
+		id, _ := data[field].(string)
+		return id
+	}
+}
+
+// SelfChecker treats a user id as a resource owned by that user, for routes like /users/:userId/...
+var SelfChecker IOwnershipChecker = selfChecker{}
+
+type selfChecker struct{}
+
+func (selfChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	return resourceId == userId.String(), nil
+}
+
+// RequireRole allows requesters having one of the roles. Must run after Auth.
+func RequireRole(roles ...datatype.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checkRole(mustGetRequester(c), roles)
+		c.Next()
+	}
+}
+
+// RequireOwner allows the owner of the resource, and admins. Must run after Auth.
+func RequireOwner(resourceId ResourceIdFunc, checker IOwnershipChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checkOwner(c, mustGetRequester(c), resourceId, checker)
+		c.Next()
+	}
+}
+
+func checkRole(requester datatype.Requester, roles []datatype.UserRole) {
+	for _, role := range roles {
+		if requester.GetRole() == string(role) {
+			return
+		}
+	}
+
+	panic(datatype.ErrForbidden.WithError("you do not have permission to perform this action"))
+}
+
+func checkOwner(c *gin.Context, requester datatype.Requester, resourceId ResourceIdFunc, checker IOwnershipChecker) {
+	if requester.GetRole() == string(datatype.RoleAdmin) {
+		return
+	}
+
+	id := resourceId(c)
+	if id == "" {
+		panic(datatype.ErrBadRequest.WithError("resource id is required"))
+	}
+
+	isOwner, err := checker.IsOwner(c.Request.Context(), id, requester.Subject())
+	if err != nil {
+		panic(err)
+	}
+	if !isOwner {
+		panic(datatype.ErrForbidden.WithError("you can only manage your own resources"))
+	}
+}
+
+func mustGetRequester(c *gin.Context) datatype.Requester {
+	value, ok := c.Get(datatype.KeyRequester)
+	if !ok {
+		panic(datatype.ErrUnauthorized.WithError("authentication required"))
+	}
+	requester, ok := value.(datatype.Requester)
+	if !ok {
+		panic(datatype.ErrUnauthorized.WithError("authentication required"))
+	}
+	return requester
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeRequester struct {
+	id   uuid.UUID
+	role datatype.UserRole
+}
+
+func (r fakeRequester) Subject() uuid.UUID { return r.id }
+func (r fakeRequester) GetRole() string    { return string(r.role) }
+
+type fakeTokenValidator map[string]datatype.Requester
+
+func (v fakeTokenValidator) Validate(token string) (datatype.Requester, error) {
+	requester, ok := v[token]
+	if !ok {
+		return nil, datatype.ErrUnauthorized
+	}
+	return requester, nil
+}
+
+type fakeOwnershipChecker map[string]uuid.UUID
+
+func (f fakeOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	ownerId, ok := f[resourceId]
+	if !ok {
+		return false, datatype.ErrNotFound
+	}
+	return ownerId == userId, nil
+}
+
+func TestMiddlewareProvider_Authorize(t *testing.T) {
+	t.Setenv("ENV", "prod")
+	gin.SetMode(gin.TestMode)
+
+	owner := fakeRequester{id: uuid.New(), role: datatype.RoleUser}
+	other := fakeRequester{id: uuid.New(), role: datatype.RoleUser}
+	admin := fakeRequester{id: uuid.New(), role: datatype.RoleAdmin}
+	provider := NewMiddlewareProvider(fakeTokenValidator{"owner": owner, "other": other, "admin": admin}, "secret")
+	checker := fakeOwnershipChecker{"r1": owner.id}
+
+	r := gin.New()
+	r.Use(Recover())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.POST("/admin", provider.RequireRole(datatype.RoleAdmin), ok)
+	r.PATCH("/restaurants/:id", provider.RequireOwner(FromParam("id"), checker), ok)
+	r.POST("/menu-item", provider.RequireOwner(FromJSONBody("restaurantId"), checker), func(c *gin.Context) {
+		var body struct {
+			RestaurantId string `json:"restaurantId"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil || body.RestaurantId != "r1" {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	r.POST("/foods", provider.RequireRole(datatype.RoleUser, datatype.RoleAdmin), provider.RequireOwner(FromJSONBody("restaurantId"), checker), ok)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		token  string
+		want   int
+	}{
+		{"role without token", http.MethodPost, "/admin", "", "", http.StatusUnauthorized},
+		{"role with invalid token", http.MethodPost, "/admin", "", "unknown", http.StatusUnauthorized},
+		{"role forbidden", http.MethodPost, "/admin", "", "owner", http.StatusForbidden},
+		{"role allowed", http.MethodPost, "/admin", "", "admin", http.StatusOK},
+		{"owner allowed", http.MethodPatch, "/restaurants/r1", "", "owner", http.StatusOK},
+		{"not owner forbidden", http.MethodPatch, "/restaurants/r1", "", "other", http.StatusForbidden},
+		{"admin bypasses owner check", http.MethodPatch, "/restaurants/r1", "", "admin", http.StatusOK},
+		{"checker error", http.MethodPatch, "/restaurants/r2", "", "owner", http.StatusNotFound},
+		{"owner from body, body kept", http.MethodPost, "/menu-item", `{"restaurantId":"r1"}`, "owner", http.StatusOK},
+		{"not owner from body", http.MethodPost, "/menu-item", `{"restaurantId":"r1"}`, "other", http.StatusForbidden},
+		{"missing id in body", http.MethodPost, "/menu-item", `{}`, "owner", http.StatusBadRequest},
+		{"role then owner allowed", http.MethodPost, "/foods", `{"restaurantId":"r1"}`, "owner", http.StatusOK},
+		{"role then owner forbidden", http.MethodPost, "/foods", `{"restaurantId":"r1"}`, "other", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireInternal(t *testing.T) {
+	t.Setenv("ENV", "prod")
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		internalToken string
+		header        string
+		want          int
+	}{
+		{"valid token", "secret", "secret", http.StatusOK},
+		{"missing token", "secret", "", http.StatusUnauthorized},
+		{"wrong token", "secret", "guess", http.StatusUnauthorized},
+		{"no token configured", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Recover())
+			r.POST("/rpc", RequireInternal(tt.internalToken), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodPost, "/rpc", nil)
+			if tt.header != "" {
+				req.Header.Set(datatype.HeaderInternalToken, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// RequireInternal allows the requests carrying the internal token shared by the services.
+// Every request is refused when no token is configured.
+func RequireInternal(internalToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(datatype.HeaderInternalToken)
+		if internalToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(internalToken)) != 1 {
+			panic(datatype.ErrUnauthorized.WithError("internal token is invalid"))
+		}
+		c.Next()
+	}
+}
@@ -1,17 +1,43 @@
 package middleware
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
 
 type MiddlewareProvider struct {
 	tokenInstropecter ITokenValidator
+	internalToken     string
 }
 
-func NewMiddlewareProvider(tokenInstropecter ITokenValidator) *MiddlewareProvider {
+func NewMiddlewareProvider(tokenInstropecter ITokenValidator, internalToken string) *MiddlewareProvider {
 	return &MiddlewareProvider{
 		tokenInstropecter: tokenInstropecter,
+		internalToken:     internalToken,
 	}
 }
 
 func (p *MiddlewareProvider) Auth() gin.HandlerFunc {
 	return Auth(p.tokenInstropecter)
 }
+
+// RequireRole authenticates the requester then allows one of the roles
+func (p *MiddlewareProvider) RequireRole(roles ...datatype.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checkRole(authenticate(c, p.tokenInstropecter), roles)
+		c.Next()
+	}
+}
+
+// RequireOwner authenticates the requester then allows the owner of the resource, admins are always allowed
+func (p *MiddlewareProvider) RequireOwner(resourceId ResourceIdFunc, checker IOwnershipChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checkOwner(c, authenticate(c, p.tokenInstropecter), resourceId, checker)
+		c.Next()
+	}
+}
+
+// RequireInternal allows the RPC calls of the other services only
+func (p *MiddlewareProvider) RequireInternal() gin.HandlerFunc {
+	return RequireInternal(p.internalToken)
+}
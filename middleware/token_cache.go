@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type cachedRequester struct {
+	requester datatype.Requester
+	expiresAt time.Time
+}
+
+// CachedTokenValidator keeps valid introspection results for a short time,
+// a revoked token is still accepted until its entry expires.
+type CachedTokenValidator struct {
+	validator ITokenValidator
+	ttl       time.Duration
+
+	mu        sync.Mutex
+	entries   map[[sha256.Size]byte]cachedRequester
+	lastSweep time.Time
+}
+
+func NewCachedTokenValidator(validator ITokenValidator, ttl time.Duration) *CachedTokenValidator {
+	return &CachedTokenValidator{
+		validator: validator,
+		ttl:       ttl,
+		entries:   make(map[[sha256.Size]byte]cachedRequester),
+	}
+}
+
+func (v *CachedTokenValidator) Validate(token string) (datatype.Requester, error) {
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+
+	v.mu.Lock()
+	entry, ok := v.entries[key]
+	v.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.requester, nil
+	}
+
+	requester, err := v.validator.Validate(token)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.entries[key] = cachedRequester{requester: requester, expiresAt: now.Add(v.ttl)}
+	// Drop expired entries once per ttl so the map does not grow with every token seen
+	if now.Sub(v.lastSweep) > v.ttl {
+		for k, e := range v.entries {
+			if !now.Before(e.expiresAt) {
+				delete(v.entries, k)
+			}
+		}
+		v.lastSweep = now
+	}
+	v.mu.Unlock()
+
+	return requester, nil
+}
@@ -2,14 +2,13 @@ package httpgin
 
 import (
 	"context"
-	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
 	gormmysql "github.com/ntttrang/go-food-delivery-backend-service/modules/cart/infras/repository/gorm-mysql"
 	"github.com/ntttrang/go-food-delivery-backend-service/modules/cart/service"
-	sharedrpc "github.com/ntttrang/go-food-delivery-backend-service/shared/infras/rpc"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
 )
 
 type ICreateCommandHandler interface {
@@ -50,6 +49,7 @@ type CartHttpController struct {
 	updateCmdHdl         IUpdateCommandHandler
 	deleteCmdHdl         IDeleteCommandHandler
 	repo                 ICartRepository // Direct repository access for simple operations
+	ownerChecker         middleware.IOwnershipChecker
 }
 
 func NewCartHttpController(
@@ -60,6 +60,7 @@ func NewCartHttpController(
 	updateCmdHdl IUpdateCommandHandler,
 	deleteCmdHdl IDeleteCommandHandler,
 	repo ICartRepository,
+	ownerChecker middleware.IOwnershipChecker,
 ) *CartHttpController {
 	return &CartHttpController{
 		createCmdHdl:         createCmdHdl,
@@ -69,17 +70,16 @@ func NewCartHttpController(
 		updateCmdHdl:         updateCmdHdl,
 		deleteCmdHdl:         deleteCmdHdl,
 		repo:                 repo,
+		ownerChecker:         ownerChecker,
 	}
 }
 
-func (ctrl *CartHttpController) SetupRoutes(g *gin.RouterGroup) {
-	introspectRpcClient := sharedrpc.NewIntrospectRpcClient(os.Getenv("USER_SERVICE_URL"))
-
-	// Cart routes
-	g.POST("", middleware.Auth(introspectRpcClient), ctrl.UpsertCartAPI)
-	g.GET("", ctrl.ListCartAPI)
-	g.GET("/cart-item", ctrl.ListCartItemAPI)
-	g.GET("/:userId/:foodId", ctrl.GetCartByUserIdAndFoodIdAPI)
-	g.PATCH("/:id", ctrl.UpdateCartByIdAPI)
-	g.DELETE("/:userId/:foodId", ctrl.DeleteCartByIdAPI)
+func (ctrl *CartHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	// Cart routes, users can only see and change their own carts
+	g.POST("", mldProvider.Auth(), ctrl.UpsertCartAPI)
+	g.GET("", mldProvider.RequireOwner(middleware.FromQuery("userId"), middleware.SelfChecker), ctrl.ListCartAPI)
+	g.GET("/cart-item", mldProvider.RequireOwner(middleware.FromQuery("userId"), middleware.SelfChecker), ctrl.ListCartItemAPI)
+	g.GET("/:userId/:foodId", mldProvider.RequireOwner(middleware.FromParam("userId"), middleware.SelfChecker), ctrl.GetCartByUserIdAndFoodIdAPI)
+	g.PATCH("/:id", mldProvider.RequireOwner(middleware.FromParam("id"), ctrl.ownerChecker), ctrl.UpdateCartByIdAPI)
+	g.DELETE("/:userId/:foodId", mldProvider.RequireOwner(middleware.FromParam("userId"), middleware.SelfChecker), ctrl.DeleteCartByIdAPI)
 }
@@ -40,6 +40,7 @@ func SetupCartModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 		updateCmdHdl,
 		deleteCmdHdl,
 		repo,
+		cartService.NewCartOwnershipChecker(repo),
 	)
 
 	// RPC endpoints for order service integration
@@ -48,5 +49,5 @@ func SetupCartModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 
 	// Setup routes
 	carts := g.Group("/carts")
-	cartCtl.SetupRoutes(carts)
+	cartCtl.SetupRoutes(carts, appCtx.MiddlewareProvider())
 }
@@ -71,3 +71,38 @@ func (hdl *ValidateCartOwnershipQueryHandler) Execute(ctx context.Context, req C
 		Valid: true,
 	}, nil
 }
+
+type ICartOwnerRepo interface {
+	FindById(ctx context.Context, id uuid.UUID) ([]cartmodel.Cart, error)
+}
+
+// CartOwnershipChecker allows only the user owning the cart to manage it
+type CartOwnershipChecker struct {
+	repo ICartOwnerRepo
+}
+
+func NewCartOwnershipChecker(repo ICartOwnerRepo) *CartOwnershipChecker {
+	return &CartOwnershipChecker{repo: repo}
+}
+
+func (c *CartOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	cartID, err := uuid.Parse(resourceId)
+	if err != nil {
+		return false, datatype.ErrBadRequest.WithError(cartmodel.ErrCartIdRequired.Error())
+	}
+
+	carts, err := c.repo.FindById(ctx, cartID)
+	if err != nil {
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	if len(carts) == 0 {
+		return false, datatype.ErrNotFound.WithDebug(cartmodel.ErrCartNotFound.Error())
+	}
+
+	for _, cart := range carts {
+		if cart.UserID != userId {
+			return false, nil
+		}
+	}
+	return true, nil
+}
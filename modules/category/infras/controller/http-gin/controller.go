@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	categorymodel "github.com/ntttrang/go-food-delivery-backend-service/modules/category/model"
 	"github.com/ntttrang/go-food-delivery-backend-service/modules/category/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
 )
 
@@ -58,11 +59,11 @@ func NewCategoryHttpController(createCmdHdl ICreateCommandHandler, listCmdHdl IL
 }
 
 func (ctrl *CategoryHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
-	g.POST("", mldProvider.Auth(), ctrl.CreateCategoryAPI)
+	g.POST("", mldProvider.RequireRole(datatype.RoleAdmin), ctrl.CreateCategoryAPI)
 	g.GET("", ctrl.ListCategoryAPI)
 	g.GET("/:id", ctrl.GetCategoryByIdAPI)
-	g.PATCH("/:id", mldProvider.Auth(), ctrl.UpdateCategoryByIdAPI)
-	g.DELETE("/:id", mldProvider.Auth(), ctrl.DeleteCategoryByIdAPI)
+	g.PATCH("/:id", mldProvider.RequireRole(datatype.RoleAdmin), ctrl.UpdateCategoryByIdAPI)
+	g.DELETE("/:id", mldProvider.RequireRole(datatype.RoleAdmin), ctrl.DeleteCategoryByIdAPI)
 }
 
 func (ctrl *CategoryHttpController) SetupRoutesRPC(g *gin.RouterGroup) {
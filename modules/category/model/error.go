@@ -9,5 +9,4 @@ var (
 	ErrCategoryNotFound      = errors.New("restaurant not found")
 	ErrIdRequired            = errors.New("id is required")
 	ErrRequesterRequired     = errors.New("requester information required")
-	ErrPermission            = errors.New("only admin and user roles can manage categories")
 )
@@ -55,21 +55,11 @@ func (s *CreateCommandHandler) Execute(ctx context.Context, data *CategoryInsert
 		return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
 	}
 
-	// Authorization check
+	// Authorization check, the admin role is checked by the RequireRole middleware
 	if data.Requester == nil {
 		return datatype.ErrUnauthorized.WithDebug(categorymodel.ErrRequesterRequired.Error())
 	}
 
-	// Check if requester is admin or user (not shipper)
-	// Use type assertion to get the role as a string
-	role := data.Requester.GetRole()
-
-	// Check role
-	isAuthorized := role == string(datatype.RoleAdmin) || role == string(datatype.RoleUser)
-	if !isAuthorized {
-		return datatype.ErrForbidden.WithDebug(categorymodel.ErrPermission.Error())
-	}
-
 	category := data.ConvertToCategory()
 	category.Id, _ = uuid.NewV7()
 	category.Status = string(datatype.StatusActive) // Always set Active Status when insert
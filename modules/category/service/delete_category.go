@@ -35,21 +35,11 @@ func (hdl *DeleteByIdCommandHandler) Execute(ctx context.Context, req CategoryDe
 	if req.Id == uuid.Nil {
 		return datatype.ErrBadRequest.WithDebug(categorymodel.ErrIdRequired.Error())
 	}
-	// Authorization check
+	// Authorization check, the admin role is checked by the RequireRole middleware
 	if req.Requester == nil {
 		return datatype.ErrUnauthorized.WithDebug(categorymodel.ErrRequesterRequired.Error())
 	}
 
-	// Check if requester is admin or user (not shipper)
-	// Use type assertion to get the role as a string
-	role := req.Requester.GetRole()
-
-	// Check role
-	isAuthorized := role == string(datatype.RoleAdmin) || role == string(datatype.RoleUser)
-	if !isAuthorized {
-		return datatype.ErrForbidden.WithDebug(categorymodel.ErrPermission.Error())
-	}
-
 	category, err := hdl.repo.FindById(ctx, req.Id)
 
 	if err != nil {
@@ -53,21 +53,11 @@ func (hdl *UpdateCommandHandler) Execute(ctx context.Context, req CategoryUpdate
 		return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
 	}
 
-	// Authorization check
+	// Authorization check, the admin role is checked by the RequireRole middleware
 	if req.Requester == nil {
 		return datatype.ErrUnauthorized.WithDebug(categorymodel.ErrRequesterRequired.Error())
 	}
 
-	// Check if requester is admin or user (not shipper)
-	// Use type assertion to get the role as a string
-	role := req.Requester.GetRole()
-
-	// Check role
-	isAuthorized := role == string(datatype.RoleAdmin) || role == string(datatype.RoleUser)
-	if !isAuthorized {
-		return datatype.ErrForbidden.WithDebug(categorymodel.ErrPermission.Error())
-	}
-
 	category, err := hdl.repo.FindById(ctx, req.Id)
 	if err != nil {
 		if errors.Is(err, categorymodel.ErrCategoryNotFound) {
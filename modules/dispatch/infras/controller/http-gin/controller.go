@@ -0,0 +1,72 @@
+package httpgin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+type IUpdateLocationCommandHandler interface {
+	Execute(ctx context.Context, req *service.UpdateLocationReq) (*dispatchmodel.ShipperLocation, error)
+}
+
+type IGetLocationQueryHandler interface {
+	Execute(ctx context.Context, shipperId uuid.UUID) (*dispatchmodel.ShipperLocationTrail, error)
+}
+
+type IListOfferQueryHandler interface {
+	Execute(ctx context.Context, shipperId uuid.UUID) ([]dispatchmodel.DispatchOffer, error)
+	ExecuteDetail(ctx context.Context, orderId uuid.UUID) (*service.DispatchDetailRes, error)
+}
+
+type IAnswerOfferCommandHandler interface {
+	AcceptOffer(ctx context.Context, offerId, shipperId uuid.UUID) (*dispatchmodel.DispatchOffer, error)
+	DeclineOffer(ctx context.Context, offerId, shipperId uuid.UUID) error
+}
+
+type DispatchHttpController struct {
+	updateLocationCmdHdl IUpdateLocationCommandHandler
+	getLocationQryHdl    IGetLocationQueryHandler
+	listOfferQryHdl      IListOfferQueryHandler
+	answerOfferCmdHdl    IAnswerOfferCommandHandler
+}
+
+func NewDispatchHttpController(
+	updateLocationCmdHdl IUpdateLocationCommandHandler,
+	getLocationQryHdl IGetLocationQueryHandler,
+	listOfferQryHdl IListOfferQueryHandler,
+	answerOfferCmdHdl IAnswerOfferCommandHandler,
+) *DispatchHttpController {
+	return &DispatchHttpController{
+		updateLocationCmdHdl: updateLocationCmdHdl,
+		getLocationQryHdl:    getLocationQryHdl,
+		listOfferQryHdl:      listOfferQryHdl,
+		answerOfferCmdHdl:    answerOfferCmdHdl,
+	}
+}
+
+func (ctrl *DispatchHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	dispatch := g.Group("/dispatch", mldProvider.Auth())
+
+	// Shippers report their location and answer the offers made to them
+	shipper := dispatch.Group("", mldProvider.RequireRole(datatype.RoleShipper))
+	{
+		shipper.PUT("/shippers/me/location", ctrl.UpdateLocationAPI)
+		shipper.GET("/offers", ctrl.ListOffersAPI)
+		shipper.POST("/offers/:id/accept", ctrl.AcceptOfferAPI)
+		shipper.POST("/offers/:id/decline", ctrl.DeclineOfferAPI)
+	}
+
+	// Dispatch of an order with the outcome of its offers
+	dispatch.GET("/orders/:orderId", mldProvider.RequireRole(datatype.RoleAdmin), ctrl.GetDispatchAPI)
+}
+
+// SetupRPCRoutes registers the RPC of the dispatch module, called by the other modules
+func (ctrl *DispatchHttpController) SetupRPCRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	g.POST("/rpc/dispatch/shippers/find-location", mldProvider.RequireInternal(), ctrl.RPCFindLocation)
+}
@@ -0,0 +1,28 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// UpdateLocationAPI records the location of the shipper, sent every few seconds while working.
+// Available shippers are offered the orders near it, and followed by the customers of the orders they deliver.
+func (ctrl *DispatchHttpController) UpdateLocationAPI(c *gin.Context) {
+	var req service.UpdateLocationReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+	req.ShipperId = requester.Subject()
+
+	location, err := ctrl.updateLocationCmdHdl.Execute(c.Request.Context(), &req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": location})
+}
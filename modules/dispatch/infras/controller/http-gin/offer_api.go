@@ -0,0 +1,60 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *DispatchHttpController) ListOffersAPI(c *gin.Context) {
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	offers, err := ctrl.listOfferQryHdl.Execute(c.Request.Context(), requester.Subject())
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": offers})
+}
+
+// AcceptOfferAPI assigns the order of the offer to the requesting shipper
+func (ctrl *DispatchHttpController) AcceptOfferAPI(c *gin.Context) {
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	offer, err := ctrl.answerOfferCmdHdl.AcceptOffer(c.Request.Context(), mustParseId(c, "id"), requester.Subject())
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": offer})
+}
+
+// DeclineOfferAPI offers the order to the next shipper
+func (ctrl *DispatchHttpController) DeclineOfferAPI(c *gin.Context) {
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	if err := ctrl.answerOfferCmdHdl.DeclineOffer(c.Request.Context(), mustParseId(c, "id"), requester.Subject()); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
+
+func (ctrl *DispatchHttpController) GetDispatchAPI(c *gin.Context) {
+	result, err := ctrl.listOfferQryHdl.ExecuteDetail(c.Request.Context(), mustParseId(c, "orderId"))
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func mustParseId(c *gin.Context, param string) uuid.UUID {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+	return id
+}
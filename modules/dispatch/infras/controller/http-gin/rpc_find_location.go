@@ -0,0 +1,37 @@
+package httpgin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type RPCFindLocationReq struct {
+	ShipperId uuid.UUID `json:"shipperId"`
+}
+
+// RPCFindLocation returns the last locations of a shipper, used to track the orders
+func (ctrl *DispatchHttpController) RPCFindLocation(c *gin.Context) {
+	var req RPCFindLocationReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	trail, err := ctrl.getLocationQryHdl.Execute(c.Request.Context(), req.ShipperId)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var appErr *datatype.DefaultError
+		if errors.As(err, &appErr) {
+			status = appErr.StatusCode()
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": trail})
+}
@@ -0,0 +1,82 @@
+package dispatchgormmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsertJob returns false when the order is already dispatched, dispatch_jobs is keyed by order_id
+func (r *DispatchRepo) InsertJob(ctx context.Context, job *dispatchmodel.DispatchJob) (bool, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(job)
+	if result.Error != nil {
+		return false, errors.WithStack(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *DispatchRepo) FindJob(ctx context.Context, orderId uuid.UUID) (*dispatchmodel.DispatchJob, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var job dispatchmodel.DispatchJob
+	if err := db.Where("order_id = ?", orderId).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dispatchmodel.ErrDispatchNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &job, nil
+}
+
+func (r *DispatchRepo) UpdateJob(ctx context.Context, job *dispatchmodel.DispatchJob) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Save(job).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// ClaimDueJobs returns the jobs due for a search and pushes their next search by lease,
+// so that another worker does not search for them while they are being handled
+func (r *DispatchRepo) ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]dispatchmodel.DispatchJob, error) {
+	db := r.dbCtx.GetMainConnection()
+	now := time.Now().UTC()
+
+	var jobs []dispatchmodel.DispatchJob
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_search_at <= ?", dispatchmodel.JobStatusSearching, now).
+			Order("next_search_at ASC").
+			Limit(limit).
+			Find(&jobs).Error; err != nil {
+			return errors.WithStack(err)
+		}
+
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(jobs))
+		for i := range jobs {
+			ids[i] = jobs[i].OrderId
+			jobs[i].NextSearchAt = now.Add(lease)
+		}
+		if err := tx.Model(&dispatchmodel.DispatchJob{}).
+			Where("order_id IN ?", ids).
+			Update("next_search_at", now.Add(lease)).Error; err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,121 @@
+package dispatchgormmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func (r *DispatchRepo) InsertOffer(ctx context.Context, offer *dispatchmodel.DispatchOffer) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Create(offer).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *DispatchRepo) FindOffer(ctx context.Context, id uuid.UUID) (*dispatchmodel.DispatchOffer, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var offer dispatchmodel.DispatchOffer
+	if err := db.Where("id = ?", id).First(&offer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dispatchmodel.ErrOfferNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &offer, nil
+}
+
+func (r *DispatchRepo) FindOffersByOrderId(ctx context.Context, orderId uuid.UUID) ([]dispatchmodel.DispatchOffer, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var offers []dispatchmodel.DispatchOffer
+	if err := db.Where("order_id = ?", orderId).Order("`rank` ASC").Find(&offers).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return offers, nil
+}
+
+// FindPendingOffersByShipperId returns the offers the shipper can still answer, the oldest first
+func (r *DispatchRepo) FindPendingOffersByShipperId(ctx context.Context, shipperId uuid.UUID) ([]dispatchmodel.DispatchOffer, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var offers []dispatchmodel.DispatchOffer
+	if err := db.Where("shipper_id = ? AND status = ? AND expires_at > ?", shipperId, dispatchmodel.OfferStatusPending, time.Now().UTC()).
+		Order("created_at ASC").
+		Find(&offers).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return offers, nil
+}
+
+func (r *DispatchRepo) FindExpiredOffers(ctx context.Context, now time.Time, limit int) ([]dispatchmodel.DispatchOffer, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var offers []dispatchmodel.DispatchOffer
+	if err := db.Where("status = ? AND expires_at <= ?", dispatchmodel.OfferStatusPending, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&offers).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return offers, nil
+}
+
+// UpdateOfferStatus saves the status of the offer only if it is still fromStatus,
+// and returns false when another worker or the shipper changed it first
+func (r *DispatchRepo) UpdateOfferStatus(ctx context.Context, offer *dispatchmodel.DispatchOffer, fromStatus string) (bool, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	result := db.Model(&dispatchmodel.DispatchOffer{}).
+		Where("id = ? AND status = ?", offer.Id, fromStatus).
+		Updates(map[string]any{
+			"status":       offer.Status,
+			"responded_at": offer.RespondedAt,
+		})
+	if result.Error != nil {
+		return false, errors.WithStack(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *DispatchRepo) CancelPendingOffers(ctx context.Context, orderId uuid.UUID) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	if err := db.Model(&dispatchmodel.DispatchOffer{}).
+		Where("order_id = ? AND status = ?", orderId, dispatchmodel.OfferStatusPending).
+		Update("status", dispatchmodel.OfferStatusCancelled).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindShipperWorkloads counts the pending offers and the orders to deliver of each shipper
+func (r *DispatchRepo) FindShipperWorkloads(ctx context.Context, shipperIds []uuid.UUID) (map[uuid.UUID]int, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	workloads := make(map[uuid.UUID]int)
+
+	var offered []uuid.UUID
+	if err := db.Model(&dispatchmodel.DispatchOffer{}).
+		Where("shipper_id IN ? AND status = ?", shipperIds, dispatchmodel.OfferStatusPending).
+		Pluck("shipper_id", &offered).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var assigned []uuid.UUID
+	if err := db.Model(&dispatchmodel.DispatchJob{}).
+		Where("shipper_id IN ? AND status = ?", shipperIds, dispatchmodel.JobStatusAssigned).
+		Pluck("shipper_id", &assigned).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, id := range append(offered, assigned...) {
+		workloads[id]++
+	}
+	return workloads, nil
+}
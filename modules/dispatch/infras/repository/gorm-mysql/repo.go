@@ -0,0 +1,11 @@
+package dispatchgormmysql
+
+import shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+
+type DispatchRepo struct {
+	dbCtx shareinfras.IDbContext
+}
+
+func NewDispatchRepo(dbCtx shareinfras.IDbContext) *DispatchRepo {
+	return &DispatchRepo{dbCtx: dbCtx}
+}
@@ -0,0 +1,88 @@
+package dispatchredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	seenShippersKey   = "dispatch:shippers:seen" // Sorted set of the shippers, scored by their last update
+	locationRetention = time.Hour                // The location of a silent shipper is forgotten after it
+)
+
+// LocationRepo keeps the locations reported by the shippers, sent every few seconds
+type LocationRepo struct {
+	cache       *sharecomponent.RedisAdapter
+	historySize int64
+}
+
+func NewLocationRepo(cache *sharecomponent.RedisAdapter, cfg datatype.DispatchConfig) *LocationRepo {
+	historySize := int64(cfg.HistorySize)
+	if historySize <= 0 {
+		historySize = 20
+	}
+	return &LocationRepo{cache: cache, historySize: historySize}
+}
+
+func locationKey(shipperId uuid.UUID) string {
+	return fmt.Sprintf("dispatch:shippers:%s:location", shipperId)
+}
+
+func historyKey(shipperId uuid.UUID) string {
+	return fmt.Sprintf("dispatch:shippers:%s:history", shipperId)
+}
+
+func (r *LocationRepo) UpsertShipperLocation(ctx context.Context, location *dispatchmodel.ShipperLocation) error {
+	if err := r.cache.Set(ctx, locationKey(location.ShipperId), location, locationRetention); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+	if err := r.cache.PushCapped(ctx, historyKey(location.ShipperId), location, r.historySize, locationRetention); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+	return pkgerrors.WithStack(r.cache.SetScore(ctx, seenShippersKey, location.ShipperId.String(), float64(location.UpdatedAt.Unix())))
+}
+
+// FindShippersSeenSince returns the last location of the shippers who reported it since seenSince
+func (r *LocationRepo) FindShippersSeenSince(ctx context.Context, seenSince time.Time) ([]dispatchmodel.ShipperLocation, error) {
+	members, err := r.cache.MembersFrom(ctx, seenShippersKey, float64(seenSince.Unix()))
+	if err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	keys := make([]string, 0, len(members))
+	for _, member := range members {
+		if shipperId, err := uuid.Parse(member); err == nil {
+			keys = append(keys, locationKey(shipperId))
+		}
+	}
+
+	var locations []dispatchmodel.ShipperLocation
+	if err := r.cache.GetMany(ctx, keys, &locations); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+	return locations, nil
+}
+
+// FindShipperLocation returns the last location of the shipper with the previous ones
+func (r *LocationRepo) FindShipperLocation(ctx context.Context, shipperId uuid.UUID) (*dispatchmodel.ShipperLocationTrail, error) {
+	var trail dispatchmodel.ShipperLocationTrail
+	if err := r.cache.Get(ctx, locationKey(shipperId), &trail.ShipperLocation); err != nil {
+		if errors.Is(err, sharecomponent.ErrCacheMiss) {
+			return nil, dispatchmodel.ErrLocationNotFound
+		}
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	if err := r.cache.GetList(ctx, historyKey(shipperId), r.historySize, &trail.History); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+	return &trail, nil
+}
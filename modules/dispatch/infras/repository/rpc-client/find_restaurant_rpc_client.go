@@ -0,0 +1,55 @@
+package rpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"resty.dev/v3"
+)
+
+type RestaurantRPCClient struct {
+	restaurantServiceURL string
+}
+
+type RPCGetByIdsResponseDTO struct {
+	Id      uuid.UUID `json:"id"`
+	OwnerId uuid.UUID `json:"ownerId"`
+	Name    string    `json:"name"`
+	Lat     float64   `json:"lat"`
+	Lng     float64   `json:"lng"`
+}
+
+func NewRestaurantRPCClient(restaurantServiceURL string) *RestaurantRPCClient {
+	return &RestaurantRPCClient{restaurantServiceURL: restaurantServiceURL}
+}
+
+func (c *RestaurantRPCClient) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]RPCGetByIdsResponseDTO, error) {
+	client := resty.New()
+
+	type ResponseDTO struct {
+		Data []RPCGetByIdsResponseDTO `json:"data"`
+	}
+
+	var response ResponseDTO
+
+	url := fmt.Sprintf("%s/find-by-ids", c.restaurantServiceURL)
+
+	_, err := client.R().
+		SetContext(ctx).
+		SetBody(map[string]interface{}{
+			"ids": ids,
+		}).
+		SetResult(&response).
+		Post(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	restaurantMap := make(map[uuid.UUID]RPCGetByIdsResponseDTO, len(response.Data))
+	for _, r := range response.Data {
+		restaurantMap[r.Id] = r
+	}
+	return restaurantMap, nil
+}
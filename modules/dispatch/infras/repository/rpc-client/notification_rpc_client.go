@@ -0,0 +1,43 @@
+package rpcclient
+
+import (
+	"context"
+	"fmt"
+
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+
+	"resty.dev/v3"
+)
+
+type NotificationRPCClient struct {
+	notificationServiceURL string
+	internalToken          string
+}
+
+func NewNotificationRPCClient(notificationServiceURL string, internalToken string) *NotificationRPCClient {
+	return &NotificationRPCClient{notificationServiceURL: notificationServiceURL, internalToken: internalToken}
+}
+
+// Create adds the notifications to the in-app inboxes of their recipients
+func (c *NotificationRPCClient) Create(ctx context.Context, notifications ...dispatchmodel.InboxNotification) error {
+	client := resty.New()
+
+	url := fmt.Sprintf("%s/create", c.notificationServiceURL)
+
+	resp, err := client.R().
+		SetContext(ctx).
+		SetHeader(datatype.HeaderInternalToken, c.internalToken).
+		SetBody(map[string]interface{}{
+			"notifications": notifications,
+		}).
+		Post(url)
+
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("notification service responded %s: %s", resp.Status(), resp.String())
+	}
+	return nil
+}
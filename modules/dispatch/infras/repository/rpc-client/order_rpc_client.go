@@ -0,0 +1,55 @@
+package rpcclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"resty.dev/v3"
+)
+
+type OrderRPCClient struct {
+	orderServiceURL string
+	internalToken   string
+}
+
+func NewOrderRPCClient(orderServiceURL string, internalToken string) *OrderRPCClient {
+	return &OrderRPCClient{orderServiceURL: orderServiceURL, internalToken: internalToken}
+}
+
+// AssignShipper assigns the shipper to the order. A conflict means the shipper cannot take it,
+// another client error that the order cannot be assigned anymore.
+func (c *OrderRPCClient) AssignShipper(ctx context.Context, orderId, shipperId uuid.UUID) error {
+	client := resty.New()
+
+	url := fmt.Sprintf("%s/%s/assign-shipper", c.orderServiceURL, orderId)
+
+	resp, err := client.R().
+		SetContext(ctx).
+		SetHeader(datatype.HeaderInternalToken, c.internalToken).
+		SetBody(map[string]interface{}{
+			"shipperId": shipperId,
+		}).
+		Post(url)
+
+	if err != nil {
+		return err
+	}
+	// A refused internal token is a misconfiguration, the order stays assignable
+	if resp.StatusCode() == http.StatusUnauthorized {
+		return fmt.Errorf("order service responded %s: %s", resp.Status(), resp.String())
+	}
+	if resp.StatusCode() == http.StatusConflict {
+		return fmt.Errorf("%w: %s", dispatchmodel.ErrShipperUnavailable, resp.String())
+	}
+	if resp.StatusCode() >= 400 && resp.StatusCode() < 500 {
+		return fmt.Errorf("%w: %s", dispatchmodel.ErrOrderNotAssignable, resp.String())
+	}
+	if resp.IsError() {
+		return fmt.Errorf("order service responded %s: %s", resp.Status(), resp.String())
+	}
+	return nil
+}
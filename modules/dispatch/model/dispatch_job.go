@@ -0,0 +1,45 @@
+package dispatchmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JobStatusSearching = "searching" // No shipper available, searched again at NextSearchAt
+	JobStatusOffered   = "offered"   // An offer is pending
+	JobStatusAssigned  = "assigned"
+	JobStatusCompleted = "completed" // The assigned shipper delivered the order
+	JobStatusFailed    = "failed"    // No shipper found after the max searches
+	JobStatusCancelled = "cancelled" // The order was cancelled
+)
+
+// Order states seen by the dispatch, from the order events
+const (
+	OrderStateRestaurantAccepted = "restaurant_accepted"
+	OrderStateDelivered          = "delivered"
+)
+
+// DispatchJob is the search of a shipper for an order, one per order
+type DispatchJob struct {
+	OrderId       uuid.UUID  `gorm:"column:order_id;primaryKey" json:"orderId"`
+	RestaurantId  uuid.UUID  `gorm:"column:restaurant_id" json:"restaurantId"`
+	RestaurantLat float64    `gorm:"column:restaurant_lat" json:"restaurantLat"`
+	RestaurantLng float64    `gorm:"column:restaurant_lng" json:"restaurantLng"`
+	Status        string     `gorm:"column:status" json:"status"`
+	ShipperId     *uuid.UUID `gorm:"column:shipper_id" json:"shipperId,omitempty"`
+	Searches      int        `gorm:"column:searches" json:"searches"` // Searches without any shipper available
+	NextSearchAt  time.Time  `gorm:"column:next_search_at" json:"nextSearchAt"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+func (DispatchJob) TableName() string {
+	return "dispatch_jobs"
+}
+
+// IsFinished reports whether the job no longer looks for a shipper nor holds one
+func (j *DispatchJob) IsFinished() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed || j.Status == JobStatusCancelled
+}
@@ -0,0 +1,42 @@
+package dispatchmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OfferStatusPending   = "pending"
+	OfferStatusAccepted  = "accepted"
+	OfferStatusDeclined  = "declined"
+	OfferStatusExpired   = "expired"   // Not answered before ExpiresAt
+	OfferStatusCancelled = "cancelled" // The order was cancelled or assigned meanwhile
+)
+
+// DispatchOffer is an order offered to a shipper, who accepts or declines it before it expires.
+// The offers of an order are kept with their outcome.
+type DispatchOffer struct {
+	Id          uuid.UUID  `gorm:"column:id;primaryKey" json:"id"`
+	OrderId     uuid.UUID  `gorm:"column:order_id" json:"orderId"`
+	ShipperId   uuid.UUID  `gorm:"column:shipper_id" json:"shipperId"`
+	Rank        int        `gorm:"column:rank" json:"rank"` // 1 for the first shipper offered the order
+	DistanceKm  float64    `gorm:"column:distance_km" json:"distanceKm"`
+	Status      string     `gorm:"column:status" json:"status"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at" json:"expiresAt"`
+	RespondedAt *time.Time `gorm:"column:responded_at" json:"respondedAt,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at" json:"createdAt"`
+}
+
+func (DispatchOffer) TableName() string {
+	return "dispatch_offers"
+}
+
+// InboxNotification is an entry of the recipient's in-app inbox, owned by the notification module
+type InboxNotification struct {
+	UserId uuid.UUID         `json:"userId"`
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Body   string            `json:"body"`
+	Data   map[string]string `json:"data"`
+}
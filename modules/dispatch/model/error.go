@@ -0,0 +1,19 @@
+package dispatchmodel
+
+import "errors"
+
+var (
+	ErrOrderIdRequired           = errors.New("order id is required")
+	ErrRestaurantIdRequired      = errors.New("restaurant id is required")
+	ErrRestaurantLocationMissing = errors.New("restaurant location is not set")
+	ErrDispatchNotFound          = errors.New("dispatch not found")
+	ErrOfferNotFound             = errors.New("dispatch offer not found")
+	ErrOfferNotPending           = errors.New("dispatch offer is no longer pending")
+	ErrOfferExpired              = errors.New("dispatch offer has expired")
+	ErrOrderNotAssignable        = errors.New("order cannot be assigned to a shipper anymore")
+	ErrShipperUnavailable        = errors.New("shipper is offline or cannot take more orders")
+	ErrLocationInvalid           = errors.New("lat must be between -90 and 90 and lng between -180 and 180")
+	ErrHeadingInvalid            = errors.New("heading must be between 0 and 360")
+	ErrTimestampInFuture         = errors.New("timestamp of the location is in the future")
+	ErrLocationNotFound          = errors.New("shipper location not found")
+)
@@ -0,0 +1,10 @@
+package dispatchmodel
+
+import "github.com/google/uuid"
+
+// Shipper is the availability of a shipper, kept by the shipper module
+type Shipper struct {
+	Id                  uuid.UUID `json:"id"`
+	MaxConcurrentOrders int       `json:"maxConcurrentOrders"`
+	Available           bool      `json:"available"` // Online and on shift
+}
@@ -0,0 +1,24 @@
+package dispatchmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShipperLocation is a location reported by a shipper, whose online status is kept by the shipper module.
+// The last one and a short history are kept in Redis.
+type ShipperLocation struct {
+	ShipperId  uuid.UUID `json:"shipperId"`
+	Lat        float64   `json:"lat"`
+	Lng        float64   `json:"lng"`
+	Heading    *float64  `json:"heading,omitempty"` // Degrees clockwise from the north
+	RecordedAt time.Time `json:"recordedAt"`        // When the device took the location
+	UpdatedAt  time.Time `json:"updatedAt"`         // When the location was received
+}
+
+// ShipperLocationTrail is the last location of a shipper followed by the previous ones, newest first
+type ShipperLocationTrail struct {
+	ShipperLocation
+	History []ShipperLocation `json:"history"`
+}
@@ -0,0 +1,55 @@
+package dispatchmodule
+
+import (
+	"github.com/gin-gonic/gin"
+	httpgin "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/infras/controller/http-gin"
+	gormmysql "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/infras/repository/gorm-mysql"
+	dispatchredis "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/infras/repository/redis"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/infras/repository/rpc-client"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/service"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+func SetupDispatchModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
+	dispatchRepo := gormmysql.NewDispatchRepo(appCtx.DbContext())
+	locationRepo := newLocationRepo(appCtx)
+
+	// Setup handlers
+	updateLocationCmdHdl := service.NewUpdateLocationCommandHandler(locationRepo)
+	getLocationQryHdl := service.NewGetLocationQueryHandler(locationRepo)
+	listOfferQryHdl := service.NewListOfferQueryHandler(dispatchRepo)
+
+	// Setup controllers
+	dispatchCtrl := httpgin.NewDispatchHttpController(
+		updateLocationCmdHdl,
+		getLocationQryHdl,
+		listOfferQryHdl,
+		NewDispatchEngine(appCtx),
+	)
+
+	// Setup routes
+	dispatchCtrl.SetupRoutes(g, appCtx.MiddlewareProvider())
+	dispatchCtrl.SetupRPCRoutes(g, appCtx.MiddlewareProvider())
+}
+
+// NewDispatchEngine offers the orders to the shippers, run by the dispatch-worker command
+func NewDispatchEngine(appCtx shareinfras.IAppContext) *service.DispatchEngine {
+	config := appCtx.GetConfig()
+
+	return service.NewDispatchEngine(
+		gormmysql.NewDispatchRepo(appCtx.DbContext()),
+		newLocationRepo(appCtx),
+		rpcclient.NewShipperRPCClient(config.ShipperServiceURL),
+		rpcclient.NewRestaurantRPCClient(config.RestaurantServiceURL),
+		rpcclient.NewOrderRPCClient(config.OrderServiceURL, config.AuthConfig.InternalToken),
+		rpcclient.NewNotificationRPCClient(config.NotificationServiceURL, config.AuthConfig.InternalToken),
+		config.DispatchConfig,
+	)
+}
+
+// newLocationRepo keeps the shipper locations in Redis, they are updated every few seconds
+func newLocationRepo(appCtx shareinfras.IAppContext) *dispatchredis.LocationRepo {
+	config := appCtx.GetConfig()
+	return dispatchredis.NewLocationRepo(sharecomponent.NewRedisAdapter(config.RedisConfig), config.DispatchConfig)
+}
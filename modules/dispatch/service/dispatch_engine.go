@@ -0,0 +1,548 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/infras/repository/rpc-client"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+const (
+	// dispatchClaimLease is how long a claimed search is hidden from other workers
+	dispatchClaimLease = time.Minute
+	// NotifyShipperOrderOffered is the inbox notification of a new offer
+	NotifyShipperOrderOffered = "shipper.order_offered"
+)
+
+type IDispatchRepo interface {
+	InsertJob(ctx context.Context, job *dispatchmodel.DispatchJob) (bool, error)
+	FindJob(ctx context.Context, orderId uuid.UUID) (*dispatchmodel.DispatchJob, error)
+	UpdateJob(ctx context.Context, job *dispatchmodel.DispatchJob) error
+	ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]dispatchmodel.DispatchJob, error)
+
+	InsertOffer(ctx context.Context, offer *dispatchmodel.DispatchOffer) error
+	FindOffer(ctx context.Context, id uuid.UUID) (*dispatchmodel.DispatchOffer, error)
+	FindOffersByOrderId(ctx context.Context, orderId uuid.UUID) ([]dispatchmodel.DispatchOffer, error)
+	FindExpiredOffers(ctx context.Context, now time.Time, limit int) ([]dispatchmodel.DispatchOffer, error)
+	UpdateOfferStatus(ctx context.Context, offer *dispatchmodel.DispatchOffer, fromStatus string) (bool, error)
+	CancelPendingOffers(ctx context.Context, orderId uuid.UUID) error
+
+	FindShipperWorkloads(ctx context.Context, shipperIds []uuid.UUID) (map[uuid.UUID]int, error)
+}
+
+type IDispatchLocationRepo interface {
+	FindShippersSeenSince(ctx context.Context, seenSince time.Time) ([]dispatchmodel.ShipperLocation, error)
+}
+
+// IDispatchShipperRepo returns whether the shippers are online and on shift, with their limit of orders
+type IDispatchShipperRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]dispatchmodel.Shipper, error)
+}
+
+type IDispatchRestaurantRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO, error)
+}
+
+// IDispatchOrderRepo assigns the shipper to the order, which publishes EvtNotifyShipperAssign.
+// It returns dispatchmodel.ErrOrderNotAssignable when the order was cancelled or assigned meanwhile,
+// and dispatchmodel.ErrShipperUnavailable when the shipper went offline or took other orders.
+type IDispatchOrderRepo interface {
+	AssignShipper(ctx context.Context, orderId, shipperId uuid.UUID) error
+}
+
+type IDispatchNotificationRepo interface {
+	Create(ctx context.Context, notifications ...dispatchmodel.InboxNotification) error
+}
+
+// ShipperCandidate is a shipper who reported a location in the search radius of the restaurant
+type ShipperCandidate struct {
+	ShipperId  uuid.UUID
+	DistanceKm float64
+}
+
+// DispatchEngine offers the orders waiting for a shipper to the nearest available shippers, one at a time.
+// An offer which is declined or not answered before its timeout goes to the next shipper;
+// when no shipper is available the search is done again later, until MaxSearches.
+type DispatchEngine struct {
+	repo             IDispatchRepo
+	locationRepo     IDispatchLocationRepo
+	shipperRepo      IDispatchShipperRepo
+	restaurantRepo   IDispatchRestaurantRepo
+	orderRepo        IDispatchOrderRepo
+	notificationRepo IDispatchNotificationRepo
+	cfg              datatype.DispatchConfig
+}
+
+func NewDispatchEngine(
+	repo IDispatchRepo,
+	locationRepo IDispatchLocationRepo,
+	shipperRepo IDispatchShipperRepo,
+	restaurantRepo IDispatchRestaurantRepo,
+	orderRepo IDispatchOrderRepo,
+	notificationRepo IDispatchNotificationRepo,
+	cfg datatype.DispatchConfig,
+) *DispatchEngine {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 20
+	}
+	if cfg.OfferTimeout <= 0 {
+		cfg.OfferTimeout = 45 * time.Second
+	}
+	if cfg.SearchRadiusKm <= 0 {
+		cfg.SearchRadiusKm = 10
+	}
+	if cfg.LocationTTL <= 0 {
+		cfg.LocationTTL = 5 * time.Minute
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = 30 * time.Second
+	}
+	if cfg.MaxSearches <= 0 {
+		cfg.MaxSearches = 20
+	}
+
+	return &DispatchEngine{
+		repo:             repo,
+		locationRepo:     locationRepo,
+		shipperRepo:      shipperRepo,
+		restaurantRepo:   restaurantRepo,
+		orderRepo:        orderRepo,
+		notificationRepo: notificationRepo,
+		cfg:              cfg,
+	}
+}
+
+// StartDispatch looks for a shipper for an order accepted by its restaurant.
+// An order already dispatched is skipped, so that a redelivered event is harmless.
+func (e *DispatchEngine) StartDispatch(ctx context.Context, orderId, restaurantId uuid.UUID) error {
+	if orderId == uuid.Nil {
+		return dispatchmodel.ErrOrderIdRequired
+	}
+	if restaurantId == uuid.Nil {
+		return dispatchmodel.ErrRestaurantIdRequired
+	}
+
+	restaurants, err := e.restaurantRepo.FindByIds(ctx, []uuid.UUID{restaurantId})
+	if err != nil {
+		return err
+	}
+
+	// Claimed by this call, the worker searches again if no offer is made before the lease expires
+	now := time.Now().UTC()
+	job := &dispatchmodel.DispatchJob{
+		OrderId:      orderId,
+		RestaurantId: restaurantId,
+		Status:       dispatchmodel.JobStatusSearching,
+		NextSearchAt: now.Add(dispatchClaimLease),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	restaurant, ok := restaurants[restaurantId]
+	if !ok || (restaurant.Lat == 0 && restaurant.Lng == 0) {
+		// Nobody can be ranked without the restaurant location, the job is kept as failed
+		log.Printf("Dispatch: order %s not dispatched: %v", orderId, dispatchmodel.ErrRestaurantLocationMissing)
+		job.Status = dispatchmodel.JobStatusFailed
+	}
+	job.RestaurantLat, job.RestaurantLng = restaurant.Lat, restaurant.Lng
+
+	inserted, err := e.repo.InsertJob(ctx, job)
+	if err != nil || !inserted || job.Status != dispatchmodel.JobStatusSearching {
+		return err
+	}
+	return e.offerNext(ctx, job)
+}
+
+// Run expires the unanswered offers and searches again for the orders without shipper until ctx is cancelled
+func (e *DispatchEngine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while batches are full, the backlog is drained before waiting again
+		for ctx.Err() == nil {
+			count, err := e.HandleDue(ctx)
+			if err != nil {
+				log.Printf("Dispatch: failed to handle due offers and searches: %v", err)
+				break
+			}
+			if count < e.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// HandleDue handles one batch of expired offers and one of due searches, and returns the largest of both
+func (e *DispatchEngine) HandleDue(ctx context.Context) (int, error) {
+	offers, err := e.repo.FindExpiredOffers(ctx, time.Now().UTC(), e.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i := range offers {
+		if err := e.expireOffer(ctx, &offers[i]); err != nil {
+			log.Printf("Dispatch: failed to expire offer %s of order %s: %v", offers[i].Id, offers[i].OrderId, err)
+		}
+	}
+
+	jobs, err := e.repo.ClaimDueJobs(ctx, e.cfg.BatchSize, dispatchClaimLease)
+	if err != nil {
+		return 0, err
+	}
+	for i := range jobs {
+		// The claim lease expires and the search is done again on failure
+		if err := e.offerNext(ctx, &jobs[i]); err != nil {
+			log.Printf("Dispatch: failed to search a shipper for order %s: %v", jobs[i].OrderId, err)
+		}
+	}
+
+	return max(len(offers), len(jobs)), nil
+}
+
+func (e *DispatchEngine) expireOffer(ctx context.Context, offer *dispatchmodel.DispatchOffer) error {
+	offer.Status = dispatchmodel.OfferStatusExpired
+	expired, err := e.repo.UpdateOfferStatus(ctx, offer, dispatchmodel.OfferStatusPending)
+	if err != nil || !expired {
+		// Answered meanwhile
+		return err
+	}
+	return e.offerNextForOrder(ctx, offer.OrderId)
+}
+
+// AcceptOffer assigns the order to the shipper of a pending offer
+func (e *DispatchEngine) AcceptOffer(ctx context.Context, offerId, shipperId uuid.UUID) (*dispatchmodel.DispatchOffer, error) {
+	offer, err := e.findPendingOffer(ctx, offerId, shipperId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accepting first wins over the expiry of the offer
+	now := time.Now().UTC()
+	offer.Status = dispatchmodel.OfferStatusAccepted
+	offer.RespondedAt = &now
+	accepted, err := e.repo.UpdateOfferStatus(ctx, offer, dispatchmodel.OfferStatusPending)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	if !accepted {
+		return nil, datatype.ErrBadRequest.WithWrap(dispatchmodel.ErrOfferNotPending).WithDebug(dispatchmodel.ErrOfferNotPending.Error())
+	}
+
+	if err := e.orderRepo.AssignShipper(ctx, offer.OrderId, shipperId); err != nil {
+		if errors.Is(err, dispatchmodel.ErrOrderNotAssignable) {
+			offer.Status = dispatchmodel.OfferStatusCancelled
+			if _, err := e.repo.UpdateOfferStatus(ctx, offer, dispatchmodel.OfferStatusAccepted); err != nil {
+				log.Printf("Dispatch: failed to cancel offer %s: %v", offer.Id, err)
+			}
+			return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+		}
+		if errors.Is(err, dispatchmodel.ErrShipperUnavailable) {
+			// The order goes to the next shipper, this one is not offered it again
+			offer.Status = dispatchmodel.OfferStatusCancelled
+			if _, err := e.repo.UpdateOfferStatus(ctx, offer, dispatchmodel.OfferStatusAccepted); err != nil {
+				log.Printf("Dispatch: failed to cancel offer %s: %v", offer.Id, err)
+			}
+			if err := e.offerNextForOrder(ctx, offer.OrderId); err != nil {
+				log.Printf("Dispatch: failed to offer order %s to the next shipper: %v", offer.OrderId, err)
+			}
+			return nil, datatype.ErrConflict.WithWrap(err).WithDebug(err.Error())
+		}
+
+		// Pending again so that the shipper may retry, or the offer expires
+		offer.Status = dispatchmodel.OfferStatusPending
+		offer.RespondedAt = nil
+		if _, err := e.repo.UpdateOfferStatus(ctx, offer, dispatchmodel.OfferStatusAccepted); err != nil {
+			log.Printf("Dispatch: failed to reopen offer %s: %v", offer.Id, err)
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if err := e.assignJob(ctx, offer.OrderId, shipperId); err != nil {
+		// The order has the shipper, the job is fixed when EvtNotifyShipperAssign is consumed
+		log.Printf("Dispatch: failed to update the job of order %s: %v", offer.OrderId, err)
+	}
+	return offer, nil
+}
+
+// DeclineOffer offers the order to the next shipper
+func (e *DispatchEngine) DeclineOffer(ctx context.Context, offerId, shipperId uuid.UUID) error {
+	offer, err := e.findPendingOffer(ctx, offerId, shipperId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	offer.Status = dispatchmodel.OfferStatusDeclined
+	offer.RespondedAt = &now
+	declined, err := e.repo.UpdateOfferStatus(ctx, offer, dispatchmodel.OfferStatusPending)
+	if err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	if !declined {
+		return datatype.ErrBadRequest.WithWrap(dispatchmodel.ErrOfferNotPending).WithDebug(dispatchmodel.ErrOfferNotPending.Error())
+	}
+
+	if err := e.offerNextForOrder(ctx, offer.OrderId); err != nil {
+		log.Printf("Dispatch: failed to offer order %s to the next shipper: %v", offer.OrderId, err)
+	}
+	return nil
+}
+
+// ShipperAssigned records a shipper assigned to the order, by an accepted offer or by hand
+func (e *DispatchEngine) ShipperAssigned(ctx context.Context, orderId, shipperId uuid.UUID) error {
+	return e.assignJob(ctx, orderId, shipperId)
+}
+
+// CancelDispatch stops looking for a shipper for a cancelled order and withdraws its pending offer
+func (e *DispatchEngine) CancelDispatch(ctx context.Context, orderId uuid.UUID) error {
+	return e.finishJob(ctx, orderId, dispatchmodel.JobStatusCancelled)
+}
+
+// OrderStateChanged starts the dispatch of an order accepted by its restaurant
+// and frees the shipper of a delivered order for the next offers
+func (e *DispatchEngine) OrderStateChanged(ctx context.Context, orderId, restaurantId uuid.UUID, newState string) error {
+	switch newState {
+	case dispatchmodel.OrderStateRestaurantAccepted:
+		return e.StartDispatch(ctx, orderId, restaurantId)
+	case dispatchmodel.OrderStateDelivered:
+		return e.finishJob(ctx, orderId, dispatchmodel.JobStatusCompleted)
+	}
+	return nil
+}
+
+func (e *DispatchEngine) findPendingOffer(ctx context.Context, offerId, shipperId uuid.UUID) (*dispatchmodel.DispatchOffer, error) {
+	offer, err := e.repo.FindOffer(ctx, offerId)
+	if err != nil {
+		if errors.Is(err, dispatchmodel.ErrOfferNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	// The offers of the other shippers are not visible
+	if offer.ShipperId != shipperId {
+		return nil, datatype.ErrNotFound.WithWrap(dispatchmodel.ErrOfferNotFound).WithDebug(dispatchmodel.ErrOfferNotFound.Error())
+	}
+	if offer.Status != dispatchmodel.OfferStatusPending {
+		return nil, datatype.ErrBadRequest.WithWrap(dispatchmodel.ErrOfferNotPending).WithDebug("offer is " + offer.Status)
+	}
+	if time.Now().After(offer.ExpiresAt) {
+		return nil, datatype.ErrBadRequest.WithWrap(dispatchmodel.ErrOfferExpired).WithDebug(dispatchmodel.ErrOfferExpired.Error())
+	}
+	return offer, nil
+}
+
+func (e *DispatchEngine) assignJob(ctx context.Context, orderId, shipperId uuid.UUID) error {
+	job, err := e.repo.FindJob(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, dispatchmodel.ErrDispatchNotFound) {
+			// Order not dispatched, e.g. created before the dispatch engine
+			return nil
+		}
+		return err
+	}
+	if job.IsFinished() || (job.Status == dispatchmodel.JobStatusAssigned && job.ShipperId != nil && *job.ShipperId == shipperId) {
+		return nil
+	}
+
+	job.Status = dispatchmodel.JobStatusAssigned
+	job.ShipperId = &shipperId
+	job.UpdatedAt = time.Now().UTC()
+	if err := e.repo.UpdateJob(ctx, job); err != nil {
+		return err
+	}
+	return e.repo.CancelPendingOffers(ctx, orderId)
+}
+
+func (e *DispatchEngine) finishJob(ctx context.Context, orderId uuid.UUID, status string) error {
+	job, err := e.repo.FindJob(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, dispatchmodel.ErrDispatchNotFound) {
+			return nil
+		}
+		return err
+	}
+	if job.IsFinished() {
+		return nil
+	}
+	// Only the order of an assigned job is delivered
+	if status == dispatchmodel.JobStatusCompleted && job.Status != dispatchmodel.JobStatusAssigned {
+		return nil
+	}
+
+	job.Status = status
+	job.UpdatedAt = time.Now().UTC()
+	if err := e.repo.UpdateJob(ctx, job); err != nil {
+		return err
+	}
+	return e.repo.CancelPendingOffers(ctx, orderId)
+}
+
+func (e *DispatchEngine) offerNextForOrder(ctx context.Context, orderId uuid.UUID) error {
+	job, err := e.repo.FindJob(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if job.Status != dispatchmodel.JobStatusOffered {
+		// Assigned or cancelled meanwhile
+		return nil
+	}
+
+	// Claimed for a search first, so that the worker searches again if the offer cannot be made now
+	job.Status = dispatchmodel.JobStatusSearching
+	job.NextSearchAt = time.Now().UTC().Add(dispatchClaimLease)
+	if err := e.repo.UpdateJob(ctx, job); err != nil {
+		return err
+	}
+	return e.offerNext(ctx, job)
+}
+
+// offerNext offers the order to the nearest available shipper who was not offered it yet,
+// or schedules another search when there is none
+func (e *DispatchEngine) offerNext(ctx context.Context, job *dispatchmodel.DispatchJob) error {
+	offers, err := e.repo.FindOffersByOrderId(ctx, job.OrderId)
+	if err != nil {
+		return err
+	}
+	offered := make(map[uuid.UUID]bool, len(offers))
+	for _, offer := range offers {
+		offered[offer.ShipperId] = true
+	}
+
+	candidates, err := e.findCandidates(ctx, job, offered)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	job.UpdatedAt = now
+
+	if len(candidates) == 0 {
+		job.Searches++
+		if job.Searches >= e.cfg.MaxSearches {
+			job.Status = dispatchmodel.JobStatusFailed
+			log.Printf("Dispatch: no shipper found for order %s after %d searches", job.OrderId, job.Searches)
+		} else {
+			job.Status = dispatchmodel.JobStatusSearching
+			job.NextSearchAt = now.Add(e.cfg.RetryInterval)
+		}
+		return e.repo.UpdateJob(ctx, job)
+	}
+
+	offer := &dispatchmodel.DispatchOffer{
+		Id:         uuid.New(),
+		OrderId:    job.OrderId,
+		ShipperId:  candidates[0].ShipperId,
+		Rank:       len(offers) + 1,
+		DistanceKm: candidates[0].DistanceKm,
+		Status:     dispatchmodel.OfferStatusPending,
+		ExpiresAt:  now.Add(e.cfg.OfferTimeout),
+		CreatedAt:  now,
+	}
+	if err := e.repo.InsertOffer(ctx, offer); err != nil {
+		return err
+	}
+
+	job.Status = dispatchmodel.JobStatusOffered
+	if err := e.repo.UpdateJob(ctx, job); err != nil {
+		return err
+	}
+
+	e.notifyOffer(ctx, offer)
+	return nil
+}
+
+// findCandidates returns the shippers who are online, on shift and below their limit of orders, nearest first
+func (e *DispatchEngine) findCandidates(ctx context.Context, job *dispatchmodel.DispatchJob, offered map[uuid.UUID]bool) ([]ShipperCandidate, error) {
+	locations, err := e.locationRepo.FindShippersSeenSince(ctx, time.Now().UTC().Add(-e.cfg.LocationTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := RankShippers(locations, job.RestaurantLat, job.RestaurantLng, e.cfg.SearchRadiusKm, offered)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.ShipperId
+	}
+	shippers, err := e.shipperRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	workloads, err := e.repo.FindShipperWorkloads(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	available := candidates[:0]
+	for _, candidate := range candidates {
+		shipper, ok := shippers[candidate.ShipperId]
+		if ok && shipper.Available && workloads[candidate.ShipperId] < shipper.MaxConcurrentOrders {
+			available = append(available, candidate)
+		}
+	}
+	return available, nil
+}
+
+// RankShippers sorts the shippers in the radius of the restaurant by distance, skipping the excluded ones
+func RankShippers(locations []dispatchmodel.ShipperLocation, lat, lng, radiusKm float64, excluded map[uuid.UUID]bool) []ShipperCandidate {
+	var candidates []ShipperCandidate
+	for _, location := range locations {
+		if excluded[location.ShipperId] {
+			continue
+		}
+		distance := sharecomponent.Haversine(lat, lng, location.Lat, location.Lng)
+		if distance > radiusKm {
+			continue
+		}
+		candidates = append(candidates, ShipperCandidate{ShipperId: location.ShipperId, DistanceKm: distance})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].DistanceKm < candidates[j].DistanceKm
+	})
+	return candidates
+}
+
+// notifyOffer adds the offer to the inbox of the shipper, a failure is only logged as the offer is listed anyway
+func (e *DispatchEngine) notifyOffer(ctx context.Context, offer *dispatchmodel.DispatchOffer) {
+	if e.notificationRepo == nil {
+		return
+	}
+
+	notification := dispatchmodel.InboxNotification{
+		UserId: offer.ShipperId,
+		Type:   NotifyShipperOrderOffered,
+		Title:  "New delivery offer",
+		Body:   fmt.Sprintf("Order %s is %.1f km away, accept it within %d seconds.", offer.OrderId, offer.DistanceKm, int(e.cfg.OfferTimeout.Seconds())),
+		Data: map[string]string{
+			"offerId":   offer.Id.String(),
+			"orderId":   offer.OrderId.String(),
+			"expiresAt": strconv.FormatInt(offer.ExpiresAt.Unix(), 10),
+		},
+	}
+	if err := e.notificationRepo.Create(ctx, notification); err != nil {
+		log.Printf("Dispatch: failed to notify shipper %s of offer %s: %v", offer.ShipperId, offer.Id, err)
+	}
+}
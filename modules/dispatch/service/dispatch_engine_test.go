@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/infras/repository/rpc-client"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeDispatchRepo struct {
+	jobs      map[uuid.UUID]*dispatchmodel.DispatchJob
+	offers    map[uuid.UUID]*dispatchmodel.DispatchOffer
+	locations []dispatchmodel.ShipperLocation
+}
+
+func newFakeDispatchRepo(locations ...dispatchmodel.ShipperLocation) *fakeDispatchRepo {
+	return &fakeDispatchRepo{
+		jobs:      make(map[uuid.UUID]*dispatchmodel.DispatchJob),
+		offers:    make(map[uuid.UUID]*dispatchmodel.DispatchOffer),
+		locations: locations,
+	}
+}
+
+func (r *fakeDispatchRepo) InsertJob(ctx context.Context, job *dispatchmodel.DispatchJob) (bool, error) {
+	if _, ok := r.jobs[job.OrderId]; ok {
+		return false, nil
+	}
+	stored := *job
+	r.jobs[job.OrderId] = &stored
+	return true, nil
+}
+
+func (r *fakeDispatchRepo) FindJob(ctx context.Context, orderId uuid.UUID) (*dispatchmodel.DispatchJob, error) {
+	job, ok := r.jobs[orderId]
+	if !ok {
+		return nil, dispatchmodel.ErrDispatchNotFound
+	}
+	found := *job
+	return &found, nil
+}
+
+func (r *fakeDispatchRepo) UpdateJob(ctx context.Context, job *dispatchmodel.DispatchJob) error {
+	stored := *job
+	r.jobs[job.OrderId] = &stored
+	return nil
+}
+
+func (r *fakeDispatchRepo) ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]dispatchmodel.DispatchJob, error) {
+	now := time.Now().UTC()
+	var due []dispatchmodel.DispatchJob
+	for _, job := range r.jobs {
+		if job.Status == dispatchmodel.JobStatusSearching && !job.NextSearchAt.After(now) && len(due) < limit {
+			job.NextSearchAt = now.Add(lease)
+			due = append(due, *job)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeDispatchRepo) InsertOffer(ctx context.Context, offer *dispatchmodel.DispatchOffer) error {
+	stored := *offer
+	r.offers[offer.Id] = &stored
+	return nil
+}
+
+func (r *fakeDispatchRepo) FindOffer(ctx context.Context, id uuid.UUID) (*dispatchmodel.DispatchOffer, error) {
+	offer, ok := r.offers[id]
+	if !ok {
+		return nil, dispatchmodel.ErrOfferNotFound
+	}
+	found := *offer
+	return &found, nil
+}
+
+func (r *fakeDispatchRepo) FindOffersByOrderId(ctx context.Context, orderId uuid.UUID) ([]dispatchmodel.DispatchOffer, error) {
+	var offers []dispatchmodel.DispatchOffer
+	for _, offer := range r.offers {
+		if offer.OrderId == orderId {
+			offers = append(offers, *offer)
+		}
+	}
+	sort.Slice(offers, func(i, j int) bool { return offers[i].Rank < offers[j].Rank })
+	return offers, nil
+}
+
+func (r *fakeDispatchRepo) FindExpiredOffers(ctx context.Context, now time.Time, limit int) ([]dispatchmodel.DispatchOffer, error) {
+	var offers []dispatchmodel.DispatchOffer
+	for _, offer := range r.offers {
+		if offer.Status == dispatchmodel.OfferStatusPending && !offer.ExpiresAt.After(now) && len(offers) < limit {
+			offers = append(offers, *offer)
+		}
+	}
+	return offers, nil
+}
+
+func (r *fakeDispatchRepo) UpdateOfferStatus(ctx context.Context, offer *dispatchmodel.DispatchOffer, fromStatus string) (bool, error) {
+	stored, ok := r.offers[offer.Id]
+	if !ok || stored.Status != fromStatus {
+		return false, nil
+	}
+	stored.Status = offer.Status
+	stored.RespondedAt = offer.RespondedAt
+	return true, nil
+}
+
+func (r *fakeDispatchRepo) CancelPendingOffers(ctx context.Context, orderId uuid.UUID) error {
+	for _, offer := range r.offers {
+		if offer.OrderId == orderId && offer.Status == dispatchmodel.OfferStatusPending {
+			offer.Status = dispatchmodel.OfferStatusCancelled
+		}
+	}
+	return nil
+}
+
+func (r *fakeDispatchRepo) FindShippersSeenSince(ctx context.Context, seenSince time.Time) ([]dispatchmodel.ShipperLocation, error) {
+	var locations []dispatchmodel.ShipperLocation
+	for _, location := range r.locations {
+		if !location.UpdatedAt.Before(seenSince) {
+			locations = append(locations, location)
+		}
+	}
+	return locations, nil
+}
+
+func (r *fakeDispatchRepo) FindShipperWorkloads(ctx context.Context, shipperIds []uuid.UUID) (map[uuid.UUID]int, error) {
+	workloads := make(map[uuid.UUID]int)
+	for _, offer := range r.offers {
+		if offer.Status == dispatchmodel.OfferStatusPending {
+			workloads[offer.ShipperId]++
+		}
+	}
+	for _, job := range r.jobs {
+		if job.Status == dispatchmodel.JobStatusAssigned && job.ShipperId != nil {
+			workloads[*job.ShipperId]++
+		}
+	}
+	return workloads, nil
+}
+
+// pendingOffer returns the only pending offer of the order
+func (r *fakeDispatchRepo) pendingOffer(t *testing.T, orderId uuid.UUID) *dispatchmodel.DispatchOffer {
+	t.Helper()
+	var pending []*dispatchmodel.DispatchOffer
+	for _, offer := range r.offers {
+		if offer.OrderId == orderId && offer.Status == dispatchmodel.OfferStatusPending {
+			pending = append(pending, offer)
+		}
+	}
+	if len(pending) != 1 {
+		t.Fatalf("pending offers = %d, want 1", len(pending))
+	}
+	return pending[0]
+}
+
+type fakeDispatchShipperRepo map[uuid.UUID]dispatchmodel.Shipper
+
+func (r fakeDispatchShipperRepo) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]dispatchmodel.Shipper, error) {
+	shippers := make(map[uuid.UUID]dispatchmodel.Shipper)
+	for _, id := range ids {
+		if shipper, ok := r[id]; ok {
+			shippers[id] = shipper
+		}
+	}
+	return shippers, nil
+}
+
+type fakeDispatchRestaurantRepo struct {
+	restaurant rpcclient.RPCGetByIdsResponseDTO
+}
+
+func (r *fakeDispatchRestaurantRepo) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO, error) {
+	return map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO{r.restaurant.Id: r.restaurant}, nil
+}
+
+type fakeDispatchOrderRepo struct {
+	err      error
+	assigned map[uuid.UUID]uuid.UUID
+}
+
+func (r *fakeDispatchOrderRepo) AssignShipper(ctx context.Context, orderId, shipperId uuid.UUID) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.assigned[orderId] = shipperId
+	return nil
+}
+
+type fakeDispatchNotificationRepo struct {
+	notifications []dispatchmodel.InboxNotification
+}
+
+func (r *fakeDispatchNotificationRepo) Create(ctx context.Context, notifications ...dispatchmodel.InboxNotification) error {
+	r.notifications = append(r.notifications, notifications...)
+	return nil
+}
+
+func TestRankShippers(t *testing.T) {
+	near, far, outOfRadius, excluded := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	locations := []dispatchmodel.ShipperLocation{
+		{ShipperId: far, Lat: 10.80, Lng: 106.70},
+		{ShipperId: outOfRadius, Lat: 11.50, Lng: 106.70},
+		{ShipperId: near, Lat: 10.78, Lng: 106.70},
+		{ShipperId: excluded, Lat: 10.77, Lng: 106.70},
+	}
+
+	candidates := RankShippers(locations, 10.77, 106.70, 10, map[uuid.UUID]bool{excluded: true})
+	if len(candidates) != 2 || candidates[0].ShipperId != near || candidates[1].ShipperId != far {
+		t.Fatalf("candidates = %+v, want near then far", candidates)
+	}
+	if candidates[0].DistanceKm <= 0 || candidates[0].DistanceKm >= candidates[1].DistanceKm {
+		t.Errorf("distances = %.2f, %.2f", candidates[0].DistanceKm, candidates[1].DistanceKm)
+	}
+}
+
+func TestDispatchEngine(t *testing.T) {
+	ctx := context.Background()
+	restaurant := rpcclient.RPCGetByIdsResponseDTO{Id: uuid.New(), Lat: 10.77, Lng: 106.70}
+	cfg := datatype.DispatchConfig{OfferTimeout: time.Minute, SearchRadiusKm: 10, RetryInterval: time.Minute, MaxSearches: 2}
+
+	shipperRepo := fakeDispatchShipperRepo{}
+
+	newEngine := func(repo *fakeDispatchRepo, orderRepo *fakeDispatchOrderRepo) (*DispatchEngine, *fakeDispatchNotificationRepo) {
+		notificationRepo := &fakeDispatchNotificationRepo{}
+		return NewDispatchEngine(repo, repo, shipperRepo, &fakeDispatchRestaurantRepo{restaurant: restaurant}, orderRepo, notificationRepo, cfg), notificationRepo
+	}
+	online := func(lat float64) dispatchmodel.ShipperLocation {
+		location := dispatchmodel.ShipperLocation{ShipperId: uuid.New(), Lat: lat, Lng: 106.70, UpdatedAt: time.Now().UTC()}
+		shipperRepo[location.ShipperId] = dispatchmodel.Shipper{Id: location.ShipperId, Available: true, MaxConcurrentOrders: 1}
+		return location
+	}
+
+	t.Run("TC 1: offer goes to the nearest shipper, then the next one when declined, then is accepted", func(t *testing.T) {
+		nearest, next := online(10.78), online(10.80)
+		repo := newFakeDispatchRepo(next, nearest)
+		orderRepo := &fakeDispatchOrderRepo{assigned: map[uuid.UUID]uuid.UUID{}}
+		engine, notificationRepo := newEngine(repo, orderRepo)
+		orderId := uuid.New()
+
+		// A placed order waits for its restaurant, the dispatch starts once it is accepted
+		if err := engine.OrderStateChanged(ctx, orderId, restaurant.Id, "pending_restaurant"); err != nil || len(repo.jobs) != 0 {
+			t.Fatalf("OrderStateChanged(pending_restaurant) error = %v, jobs = %d", err, len(repo.jobs))
+		}
+		if err := engine.OrderStateChanged(ctx, orderId, restaurant.Id, dispatchmodel.OrderStateRestaurantAccepted); err != nil {
+			t.Fatalf("OrderStateChanged(restaurant_accepted) error = %v", err)
+		}
+		first := repo.pendingOffer(t, orderId)
+		if first.ShipperId != nearest.ShipperId || first.Rank != 1 {
+			t.Fatalf("first offer = %+v, want the nearest shipper", first)
+		}
+		if len(notificationRepo.notifications) != 1 || notificationRepo.notifications[0].UserId != nearest.ShipperId {
+			t.Errorf("notifications = %+v", notificationRepo.notifications)
+		}
+
+		if err := engine.DeclineOffer(ctx, first.Id, nearest.ShipperId); err != nil {
+			t.Fatalf("DeclineOffer() error = %v", err)
+		}
+		second := repo.pendingOffer(t, orderId)
+		if second.ShipperId != next.ShipperId || second.Rank != 2 {
+			t.Fatalf("second offer = %+v, want the next shipper", second)
+		}
+
+		if _, err := engine.AcceptOffer(ctx, second.Id, nearest.ShipperId); !errors.Is(err, datatype.ErrNotFound) {
+			t.Errorf("AcceptOffer() by another shipper error = %v, want not found", err)
+		}
+		if _, err := engine.AcceptOffer(ctx, second.Id, next.ShipperId); err != nil {
+			t.Fatalf("AcceptOffer() error = %v", err)
+		}
+		if orderRepo.assigned[orderId] != next.ShipperId {
+			t.Errorf("order assigned to %s, want %s", orderRepo.assigned[orderId], next.ShipperId)
+		}
+		if job := repo.jobs[orderId]; job.Status != dispatchmodel.JobStatusAssigned || *job.ShipperId != next.ShipperId {
+			t.Errorf("job = %+v, want assigned", job)
+		}
+		if repo.offers[first.Id].Status != dispatchmodel.OfferStatusDeclined || repo.offers[second.Id].Status != dispatchmodel.OfferStatusAccepted {
+			t.Errorf("offer outcomes = %s, %s", repo.offers[first.Id].Status, repo.offers[second.Id].Status)
+		}
+
+		// Redelivered event
+		if err := engine.StartDispatch(ctx, orderId, restaurant.Id); err != nil || len(repo.offers) != 2 {
+			t.Errorf("StartDispatch() again error = %v, offers = %d", err, len(repo.offers))
+		}
+	})
+
+	t.Run("TC 2: unanswered offer expires and goes to the next shipper", func(t *testing.T) {
+		nearest, next := online(10.78), online(10.80)
+		repo := newFakeDispatchRepo(nearest, next)
+		engine, _ := newEngine(repo, &fakeDispatchOrderRepo{assigned: map[uuid.UUID]uuid.UUID{}})
+		orderId := uuid.New()
+
+		if err := engine.StartDispatch(ctx, orderId, restaurant.Id); err != nil {
+			t.Fatalf("StartDispatch() error = %v", err)
+		}
+		first := repo.pendingOffer(t, orderId)
+		first.ExpiresAt = time.Now().Add(-time.Second)
+
+		if _, err := engine.HandleDue(ctx); err != nil {
+			t.Fatalf("HandleDue() error = %v", err)
+		}
+		if repo.offers[first.Id].Status != dispatchmodel.OfferStatusExpired {
+			t.Errorf("first offer status = %s, want expired", repo.offers[first.Id].Status)
+		}
+		if second := repo.pendingOffer(t, orderId); second.ShipperId != next.ShipperId {
+			t.Errorf("second offer to %s, want %s", second.ShipperId, next.ShipperId)
+		}
+	})
+
+	t.Run("TC 3: busy, stale and far shippers are skipped until the dispatch fails", func(t *testing.T) {
+		stale := online(10.78)
+		stale.UpdatedAt = time.Now().Add(-time.Hour)
+		busy := online(10.78)
+		repo := newFakeDispatchRepo(stale, busy, online(12.00))
+		repo.jobs[uuid.New()] = &dispatchmodel.DispatchJob{Status: dispatchmodel.JobStatusAssigned, ShipperId: &busy.ShipperId}
+		engine, _ := newEngine(repo, &fakeDispatchOrderRepo{assigned: map[uuid.UUID]uuid.UUID{}})
+		orderId := uuid.New()
+
+		if err := engine.StartDispatch(ctx, orderId, restaurant.Id); err != nil {
+			t.Fatalf("StartDispatch() error = %v", err)
+		}
+		if job := repo.jobs[orderId]; job.Status != dispatchmodel.JobStatusSearching || job.Searches != 1 {
+			t.Fatalf("job = %+v, want searching again", job)
+		}
+
+		repo.jobs[orderId].NextSearchAt = time.Now().Add(-time.Second)
+		if _, err := engine.HandleDue(ctx); err != nil {
+			t.Fatalf("HandleDue() error = %v", err)
+		}
+		if job := repo.jobs[orderId]; job.Status != dispatchmodel.JobStatusFailed {
+			t.Errorf("job status = %s, want failed after %d searches", job.Status, cfg.MaxSearches)
+		}
+	})
+
+	t.Run("TC 4: accepting an order cancelled meanwhile cancels the offer", func(t *testing.T) {
+		shipper := online(10.78)
+		repo := newFakeDispatchRepo(shipper)
+		engine, _ := newEngine(repo, &fakeDispatchOrderRepo{err: dispatchmodel.ErrOrderNotAssignable})
+		orderId := uuid.New()
+
+		if err := engine.StartDispatch(ctx, orderId, restaurant.Id); err != nil {
+			t.Fatalf("StartDispatch() error = %v", err)
+		}
+		offer := repo.pendingOffer(t, orderId)
+
+		if _, err := engine.AcceptOffer(ctx, offer.Id, shipper.ShipperId); !errors.Is(err, datatype.ErrBadRequest) {
+			t.Errorf("AcceptOffer() error = %v, want bad request", err)
+		}
+		if repo.offers[offer.Id].Status != dispatchmodel.OfferStatusCancelled {
+			t.Errorf("offer status = %s, want cancelled", repo.offers[offer.Id].Status)
+		}
+
+		if err := engine.CancelDispatch(ctx, orderId); err != nil {
+			t.Fatalf("CancelDispatch() error = %v", err)
+		}
+		if repo.jobs[orderId].Status != dispatchmodel.JobStatusCancelled {
+			t.Errorf("job status = %s, want cancelled", repo.jobs[orderId].Status)
+		}
+	})
+
+	t.Run("TC 5: offline shippers and users without a shipper profile are skipped and shippers below their limit get more orders", func(t *testing.T) {
+		offline, withRoom := online(10.78), online(10.80)
+		shipperRepo[offline.ShipperId] = dispatchmodel.Shipper{Id: offline.ShipperId, MaxConcurrentOrders: 2}
+		shipperRepo[withRoom.ShipperId] = dispatchmodel.Shipper{Id: withRoom.ShipperId, Available: true, MaxConcurrentOrders: 2}
+		withoutProfile := dispatchmodel.ShipperLocation{ShipperId: uuid.New(), Lat: 10.77, Lng: 106.70, UpdatedAt: time.Now().UTC()}
+		repo := newFakeDispatchRepo(offline, withRoom, withoutProfile)
+		repo.jobs[uuid.New()] = &dispatchmodel.DispatchJob{Status: dispatchmodel.JobStatusAssigned, ShipperId: &withRoom.ShipperId}
+		engine, _ := newEngine(repo, &fakeDispatchOrderRepo{assigned: map[uuid.UUID]uuid.UUID{}})
+		orderId := uuid.New()
+
+		if err := engine.StartDispatch(ctx, orderId, restaurant.Id); err != nil {
+			t.Fatalf("StartDispatch() error = %v", err)
+		}
+		if offer := repo.pendingOffer(t, orderId); offer.ShipperId != withRoom.ShipperId {
+			t.Errorf("offer to %s, want the shipper below their limit %s", offer.ShipperId, withRoom.ShipperId)
+		}
+	})
+
+	t.Run("TC 6: shipper unavailable when accepting, the order goes to the next one", func(t *testing.T) {
+		nearest, next := online(10.78), online(10.80)
+		repo := newFakeDispatchRepo(nearest, next)
+		orderRepo := &fakeDispatchOrderRepo{err: dispatchmodel.ErrShipperUnavailable}
+		engine, _ := newEngine(repo, orderRepo)
+		orderId := uuid.New()
+
+		if err := engine.StartDispatch(ctx, orderId, restaurant.Id); err != nil {
+			t.Fatalf("StartDispatch() error = %v", err)
+		}
+		first := repo.pendingOffer(t, orderId)
+
+		if _, err := engine.AcceptOffer(ctx, first.Id, nearest.ShipperId); !errors.Is(err, datatype.ErrConflict) {
+			t.Errorf("AcceptOffer() error = %v, want conflict", err)
+		}
+		if repo.offers[first.Id].Status != dispatchmodel.OfferStatusCancelled {
+			t.Errorf("offer status = %s, want cancelled", repo.offers[first.Id].Status)
+		}
+		if second := repo.pendingOffer(t, orderId); second.ShipperId != next.ShipperId {
+			t.Errorf("second offer to %s, want %s", second.ShipperId, next.ShipperId)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Initilize service
+type IGetLocationRepo interface {
+	FindShipperLocation(ctx context.Context, shipperId uuid.UUID) (*dispatchmodel.ShipperLocationTrail, error)
+}
+
+type GetLocationQueryHandler struct {
+	repo IGetLocationRepo
+}
+
+func NewGetLocationQueryHandler(repo IGetLocationRepo) *GetLocationQueryHandler {
+	return &GetLocationQueryHandler{repo: repo}
+}
+
+// Implement
+// Execute returns the last location of the shipper with the previous ones, used to track an order
+func (hdl *GetLocationQueryHandler) Execute(ctx context.Context, shipperId uuid.UUID) (*dispatchmodel.ShipperLocationTrail, error) {
+	trail, err := hdl.repo.FindShipperLocation(ctx, shipperId)
+	if err != nil {
+		if errors.Is(err, dispatchmodel.ErrLocationNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return trail, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type DispatchDetailRes struct {
+	dispatchmodel.DispatchJob
+	Offers []dispatchmodel.DispatchOffer `json:"offers"` // Oldest first, with their outcome
+}
+
+// Initilize service
+type IListOfferRepo interface {
+	FindPendingOffersByShipperId(ctx context.Context, shipperId uuid.UUID) ([]dispatchmodel.DispatchOffer, error)
+	FindJob(ctx context.Context, orderId uuid.UUID) (*dispatchmodel.DispatchJob, error)
+	FindOffersByOrderId(ctx context.Context, orderId uuid.UUID) ([]dispatchmodel.DispatchOffer, error)
+}
+
+type ListOfferQueryHandler struct {
+	repo IListOfferRepo
+}
+
+func NewListOfferQueryHandler(repo IListOfferRepo) *ListOfferQueryHandler {
+	return &ListOfferQueryHandler{repo: repo}
+}
+
+// Implement
+// Execute returns the offers waiting for an answer of the shipper
+func (hdl *ListOfferQueryHandler) Execute(ctx context.Context, shipperId uuid.UUID) ([]dispatchmodel.DispatchOffer, error) {
+	offers, err := hdl.repo.FindPendingOffersByShipperId(ctx, shipperId)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return offers, nil
+}
+
+// ExecuteDetail returns the dispatch of an order with all its offers
+func (hdl *ListOfferQueryHandler) ExecuteDetail(ctx context.Context, orderId uuid.UUID) (*DispatchDetailRes, error) {
+	job, err := hdl.repo.FindJob(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, dispatchmodel.ErrDispatchNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	offers, err := hdl.repo.FindOffersByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return &DispatchDetailRes{DispatchJob: *job, Offers: offers}, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	dispatchmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/dispatch/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type UpdateLocationReq struct {
+	ShipperId uuid.UUID  `json:"-"`
+	Lat       float64    `json:"lat"`
+	Lng       float64    `json:"lng"`
+	Heading   *float64   `json:"heading"`   // Degrees clockwise from the north
+	Timestamp *time.Time `json:"timestamp"` // When the device took the location, the reception time when omitted
+}
+
+// maxClockSkew tolerates the devices whose clock is slightly ahead
+const maxClockSkew = time.Minute
+
+func (r *UpdateLocationReq) Validate() error {
+	if r.Lat < -90 || r.Lat > 90 || r.Lng < -180 || r.Lng > 180 {
+		return dispatchmodel.ErrLocationInvalid
+	}
+	if r.Heading != nil && (*r.Heading < 0 || *r.Heading > 360) {
+		return dispatchmodel.ErrHeadingInvalid
+	}
+	if r.Timestamp != nil && r.Timestamp.After(time.Now().Add(maxClockSkew)) {
+		return dispatchmodel.ErrTimestampInFuture
+	}
+	return nil
+}
+
+// Initilize service
+type IUpdateLocationRepo interface {
+	UpsertShipperLocation(ctx context.Context, location *dispatchmodel.ShipperLocation) error
+}
+
+type UpdateLocationCommandHandler struct {
+	repo IUpdateLocationRepo
+}
+
+func NewUpdateLocationCommandHandler(repo IUpdateLocationRepo) *UpdateLocationCommandHandler {
+	return &UpdateLocationCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute keeps the location of the shipper, who is ranked by distance from it and followed by the customers.
+// Shippers send it every few seconds while working.
+func (hdl *UpdateLocationCommandHandler) Execute(ctx context.Context, req *UpdateLocationReq) (*dispatchmodel.ShipperLocation, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	now := time.Now().UTC()
+	location := &dispatchmodel.ShipperLocation{
+		ShipperId:  req.ShipperId,
+		Lat:        req.Lat,
+		Lng:        req.Lng,
+		Heading:    req.Heading,
+		RecordedAt: now,
+		UpdatedAt:  now,
+	}
+	if req.Timestamp != nil {
+		location.RecordedAt = req.Timestamp.UTC()
+	}
+	if err := hdl.repo.UpsertShipperLocation(ctx, location); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return location, nil
+}
@@ -2,12 +2,15 @@ package grpcctrl
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"github.com/google/uuid"
 	"github.com/ntttrang/go-food-delivery-backend-service/gen/proto/food"
 	foodmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/food/model"
 	"github.com/ntttrang/go-food-delivery-backend-service/modules/food/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type FoodRepository interface {
@@ -18,16 +21,24 @@ type UpdateService interface {
 	Execute(ctx context.Context, req service.FoodUpdateReq) error
 }
 
+type StockService interface {
+	Reserve(ctx context.Context, req service.StockReserveReq) error
+	Commit(ctx context.Context, orderId uuid.UUID) error
+	Release(ctx context.Context, orderId uuid.UUID) error
+}
+
 type FoodGrpcServer struct {
 	food.UnimplementedFoodServer
 	repo          FoodRepository
 	updateService UpdateService
+	stockService  StockService
 }
 
-func NewFoodGrpcServer(repo FoodRepository, updateService UpdateService) *FoodGrpcServer {
+func NewFoodGrpcServer(repo FoodRepository, updateService UpdateService, stockService StockService) *FoodGrpcServer {
 	return &FoodGrpcServer{
 		repo:          repo,
 		updateService: updateService,
+		stockService:  stockService,
 	}
 }
 
@@ -47,6 +58,10 @@ func (f *FoodGrpcServer) GetFooodsByIds(ctx context.Context, req *food.GetFoodId
 	result := make([]*food.FoodDTO, len(cats))
 
 	for i, cat := range cats {
+		var stock int64
+		if cat.Stock != nil {
+			stock = int64(*cat.Stock)
+		}
 		result[i] = &food.FoodDTO{
 			Id:           cat.Id.String(),
 			Name:         cat.Name,
@@ -58,6 +73,8 @@ func (f *FoodGrpcServer) GetFooodsByIds(ctx context.Context, req *food.GetFoodId
 			CategoryId:   cat.CategoryId.String(),
 			RestaurantId: cat.RestaurantId.String(),
 			Status:       cat.Status,
+			StockTracked: cat.Stock != nil,
+			Stock:        stock,
 		}
 	}
 	log.Println("[END] GRPC - GetFooodsByIds")
@@ -103,3 +120,71 @@ func (f *FoodGrpcServer) UpdateFoodById(ctx context.Context, req *food.UpdateFoo
 	log.Println("[END] GRPC - UpdateFoodById")
 	return &food.UpdateFoodResp{Id: req.Id}, nil
 }
+
+func (f *FoodGrpcServer) ReserveStock(ctx context.Context, req *food.ReserveStockRequest) (*food.StockReservationResp, error) {
+	log.Println("[START] GRPC - ReserveStock")
+	orderId, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, foodmodel.ErrOrderIdRequired.Error())
+	}
+
+	items := make([]service.StockItemDto, len(req.Items))
+	for i, item := range req.Items {
+		foodId, err := uuid.Parse(item.FoodId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, foodmodel.ErrFoodIdRequired.Error())
+		}
+		items[i] = service.StockItemDto{FoodId: foodId, Quantity: int(item.Quantity)}
+	}
+
+	if err := f.stockService.Reserve(ctx, service.StockReserveReq{OrderId: orderId, Items: items}); err != nil {
+		log.Printf("Failed to reserve stock: %v", err)
+		return nil, toGrpcError(err)
+	}
+	log.Println("[END] GRPC - ReserveStock")
+	return &food.StockReservationResp{OrderId: req.OrderId}, nil
+}
+
+func (f *FoodGrpcServer) CommitStock(ctx context.Context, req *food.StockReservationRequest) (*food.StockReservationResp, error) {
+	log.Println("[START] GRPC - CommitStock")
+	orderId, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, foodmodel.ErrOrderIdRequired.Error())
+	}
+
+	if err := f.stockService.Commit(ctx, orderId); err != nil {
+		log.Printf("Failed to commit stock: %v", err)
+		return nil, toGrpcError(err)
+	}
+	log.Println("[END] GRPC - CommitStock")
+	return &food.StockReservationResp{OrderId: req.OrderId}, nil
+}
+
+func (f *FoodGrpcServer) ReleaseStock(ctx context.Context, req *food.StockReservationRequest) (*food.StockReservationResp, error) {
+	log.Println("[START] GRPC - ReleaseStock")
+	orderId, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, foodmodel.ErrOrderIdRequired.Error())
+	}
+
+	if err := f.stockService.Release(ctx, orderId); err != nil {
+		log.Printf("Failed to release stock: %v", err)
+		return nil, toGrpcError(err)
+	}
+	log.Println("[END] GRPC - ReleaseStock")
+	return &food.StockReservationResp{OrderId: req.OrderId}, nil
+}
+
+// toGrpcError maps domain errors to gRPC codes so clients can tell them apart
+func toGrpcError(err error) error {
+	switch {
+	case errors.Is(err, foodmodel.ErrStockInsufficient):
+		return status.Error(codes.FailedPrecondition, foodmodel.ErrStockInsufficient.Error())
+	case errors.Is(err, foodmodel.ErrFoodUnavailable):
+		return status.Error(codes.InvalidArgument, foodmodel.ErrFoodUnavailable.Error())
+	case errors.Is(err, foodmodel.ErrFoodNotFound):
+		return status.Error(codes.NotFound, foodmodel.ErrFoodNotFound.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -2,7 +2,6 @@ package httpgin
 
 import (
 	"context"
-	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -10,7 +9,8 @@ import (
 	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
 	foodmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/food/model"
 	"github.com/ntttrang/go-food-delivery-backend-service/modules/food/service"
-	sharerpc "github.com/ntttrang/go-food-delivery-backend-service/shared/infras/rpc"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
 )
 
 type ICreateCommandHandler interface {
@@ -86,6 +86,10 @@ type FoodHttpController struct {
 	searchFoodQueryHandler      ISearchFoodQueryHandler
 	syncFoodByIdCommandHandler  ISyncFoodByIdCommandHandler
 	syncFoodIndexCommandHandler ISyncFoodIndexCommandHandler
+
+	foodOwnerChecker       middleware.IOwnershipChecker
+	commentOwnerChecker    middleware.IOwnershipChecker
+	restaurantOwnerChecker middleware.IOwnershipChecker
 }
 
 func NewFoodHttpController(createCmdHdl ICreateCommandHandler, listCmdHdl IListCommandHandler, getDetailCmdHdl IGetDetailCommandHandler,
@@ -93,7 +97,8 @@ func NewFoodHttpController(createCmdHdl ICreateCommandHandler, listCmdHdl IListC
 	rpcRepo IRepoRPCFood,
 	addFavoritesCmdHdl IAddFavoritesCommandHandler, favoriteFoodQueryHdl IListFavoritesQueryHandler,
 	createCommentFoodCmdHandler ICreateFoodCommentCommandHandler, listFoodCommentQueryHandler IListFoodCommentsQueryHandler, deleteFoodCmdHdl IDeleteCommentCommandHandler,
-	searchFoodQueryHandler ISearchFoodQueryHandler, syncFoodByIdCommandHandler ISyncFoodByIdCommandHandler, syncFoodIndexCommandHandler ISyncFoodIndexCommandHandler) *FoodHttpController {
+	searchFoodQueryHandler ISearchFoodQueryHandler, syncFoodByIdCommandHandler ISyncFoodByIdCommandHandler, syncFoodIndexCommandHandler ISyncFoodIndexCommandHandler,
+	foodOwnerChecker middleware.IOwnershipChecker, commentOwnerChecker middleware.IOwnershipChecker, restaurantOwnerChecker middleware.IOwnershipChecker) *FoodHttpController {
 	return &FoodHttpController{
 		createCmdHdl:             createCmdHdl,
 		listCmdHdl:               listCmdHdl,
@@ -112,31 +117,38 @@ func NewFoodHttpController(createCmdHdl ICreateCommandHandler, listCmdHdl IListC
 		searchFoodQueryHandler:      searchFoodQueryHandler,
 		syncFoodByIdCommandHandler:  syncFoodByIdCommandHandler,
 		syncFoodIndexCommandHandler: syncFoodIndexCommandHandler,
+
+		foodOwnerChecker:       foodOwnerChecker,
+		commentOwnerChecker:    commentOwnerChecker,
+		restaurantOwnerChecker: restaurantOwnerChecker,
 	}
 }
 
-func (ctrl *FoodHttpController) SetupRoutes(g *gin.RouterGroup) {
-	g.POST("", ctrl.CreateFoodAPI)
+func (ctrl *FoodHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	auth := mldProvider.Auth()
+	isFoodOwner := mldProvider.RequireOwner(middleware.FromParam("id"), ctrl.foodOwnerChecker)
+	isRestaurantOwner := mldProvider.RequireOwner(middleware.FromJSONBody("restaurantId"), ctrl.restaurantOwnerChecker)
+
+	g.POST("", mldProvider.RequireRole(datatype.RoleUser, datatype.RoleAdmin), isRestaurantOwner, ctrl.CreateFoodAPI)
 	g.GET("", ctrl.ListFoodAPI)
 	g.GET("/:id", ctrl.GetFoodByIdAPI)
-	g.PATCH("/:id", ctrl.UpdateFoodByIdAPI)
-	g.DELETE("/:id", ctrl.DeleteFoodByIdAPI)
+	g.PATCH("/:id", isFoodOwner, ctrl.UpdateFoodByIdAPI)
+	g.DELETE("/:id", isFoodOwner, ctrl.DeleteFoodByIdAPI)
 
 	// Favorites Food
-	introspectRpcClient := sharerpc.NewIntrospectRpcClient(os.Getenv("USER_SERVICE_URL"))
-	g.POST("/favorites", middleware.Auth(introspectRpcClient), ctrl.UpdateFavoritesFoodAPI)
-	g.GET("/favorites", middleware.Auth(introspectRpcClient), ctrl.ListFavoriteFoodAPI)
+	g.POST("/favorites", auth, ctrl.UpdateFavoritesFoodAPI)
+	g.GET("/favorites", auth, ctrl.ListFavoriteFoodAPI)
 
 	// Food Comments
-	g.POST("/comments", middleware.Auth(introspectRpcClient), ctrl.CreateFoodCommentAPI)
+	g.POST("/comments", auth, ctrl.CreateFoodCommentAPI)
 	g.GET("/comments", ctrl.ListFoodCommentAPI)
-	g.DELETE("/comments/:id", ctrl.DeleteFoodCommentAPI)
+	g.DELETE("/comments/:id", mldProvider.RequireOwner(middleware.FromParam("id"), ctrl.commentOwnerChecker), ctrl.DeleteFoodCommentAPI)
 
 	// Search
 	g.POST("/search", ctrl.SearchFoodAPI)
 
 	// Admin endpoints for Elasticsearch index management
-	adminGroup := g.Group("/admin")
-	adminGroup.POST("/sync-index", middleware.Auth(introspectRpcClient), ctrl.SyncFoodIndexAPI)
-	adminGroup.POST("/sync-index/:id", middleware.Auth(introspectRpcClient), ctrl.SyncFoodByIdAPI)
+	adminGroup := g.Group("/admin", mldProvider.RequireRole(datatype.RoleAdmin))
+	adminGroup.POST("/sync-index", ctrl.SyncFoodIndexAPI)
+	adminGroup.POST("/sync-index/:id", ctrl.SyncFoodByIdAPI)
 }
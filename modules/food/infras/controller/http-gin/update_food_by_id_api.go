@@ -22,6 +22,7 @@ func (ctrl *FoodHttpController) UpdateFoodByIdAPI(c *gin.Context) {
 		panic(datatype.ErrBadRequest.WithError(err.Error()))
 	}
 	req.Id = id
+	req.Requester = c.MustGet(datatype.KeyRequester).(datatype.Requester)
 
 	if err := ctrl.updateByIdCommandHandler.Execute(c.Request.Context(), req); err != nil {
 		panic(err)
@@ -31,6 +31,7 @@ func (r *FoodRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]foodmodel.
 				f.price, 
 				f.category_id,
 				f.status,
+				f.stock,
 				COUNT(fr.comment) AS comment_qty,
 				AVG(fr.point) AS avg_point,
 				f.restaurant_id
@@ -38,7 +39,7 @@ func (r *FoodRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]foodmodel.
 			LEFT JOIN food_ratings fr
 			ON f.id = fr.food_id
 			WHERE f.id IN (?) AND f.status = ?
-			GROUP BY f.id, f.name, f.description, f.images, f.price, f.category_id, f.restaurant_id`, ids, string(datatype.StatusActive)).
+			GROUP BY f.id, f.name, f.description, f.images, f.price, f.category_id, f.restaurant_id, f.stock`, ids, string(datatype.StatusActive)).
 		Find(&foods).Error; err != nil {
 		return nil, err
 	}
@@ -0,0 +1,144 @@
+package foodgormmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	foodmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/food/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/food/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReserveStock decrements stock with a conditional update so concurrent checkouts can never oversell.
+// An inactive food cannot be reserved, whether it tracks stock or not. Calling it again for the same order is a no-op.
+func (r *FoodRepo) ReserveStock(ctx context.Context, orderId uuid.UUID, items []service.StockItemDto) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx).Begin()
+
+	var count int64
+	if err := db.Table(foodmodel.FoodStockReservation{}.TableName()).
+		Where("order_id = ?", orderId).
+		Count(&count).Error; err != nil {
+		db.Rollback()
+		return errors.WithStack(err)
+	}
+	if count > 0 {
+		db.Rollback()
+		return nil
+	}
+
+	now := time.Now()
+	for _, item := range items {
+		var food foodmodel.Food
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status", "stock").
+			Where("id = ?", item.FoodId).
+			First(&food).Error; err != nil {
+			db.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return foodmodel.ErrFoodNotFound
+			}
+			return errors.WithStack(err)
+		}
+		if food.Status != string(datatype.StatusActive) {
+			db.Rollback()
+			return foodmodel.ErrFoodUnavailable
+		}
+
+		// A food without stock tracking is reserved without a decrement
+		if food.Stock != nil {
+			result := db.Table(foodmodel.Food{}.TableName()).
+				Where("id = ? AND stock >= ?", item.FoodId, item.Quantity).
+				Update("stock", gorm.Expr("stock - ?", item.Quantity))
+			if result.Error != nil {
+				db.Rollback()
+				return errors.WithStack(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				db.Rollback()
+				return foodmodel.ErrStockInsufficient
+			}
+		}
+
+		reservationId, _ := uuid.NewV7()
+		reservation := foodmodel.FoodStockReservation{
+			Id:        reservationId,
+			OrderId:   orderId,
+			FoodId:    item.FoodId,
+			Quantity:  item.Quantity,
+			Status:    foodmodel.ReservationStatusReserved,
+			CreatedAt: &now,
+			UpdatedAt: &now,
+		}
+		if err := db.Create(&reservation).Error; err != nil {
+			db.Rollback()
+			return errors.WithStack(err)
+		}
+	}
+
+	if err := db.Commit().Error; err != nil {
+		db.Rollback()
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *FoodRepo) CommitStock(ctx context.Context, orderId uuid.UUID) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	if err := db.Table(foodmodel.FoodStockReservation{}.TableName()).
+		Where("order_id = ? AND status = ?", orderId, foodmodel.ReservationStatusReserved).
+		Updates(map[string]interface{}{
+			"status":     foodmodel.ReservationStatusCommitted,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// ReleaseStock puts the reserved quantity back. Committed or already released reservations are left untouched.
+func (r *FoodRepo) ReleaseStock(ctx context.Context, orderId uuid.UUID) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx).Begin()
+
+	var reservations []foodmodel.FoodStockReservation
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status = ?", orderId, foodmodel.ReservationStatusReserved).
+		Find(&reservations).Error; err != nil {
+		db.Rollback()
+		return errors.WithStack(err)
+	}
+
+	for _, reservation := range reservations {
+		if err := db.Table(foodmodel.Food{}.TableName()).
+			Where("id = ? AND stock IS NOT NULL", reservation.FoodId).
+			Update("stock", gorm.Expr("stock + ?", reservation.Quantity)).Error; err != nil {
+			db.Rollback()
+			return errors.WithStack(err)
+		}
+	}
+
+	if len(reservations) > 0 {
+		if err := db.Table(foodmodel.FoodStockReservation{}.TableName()).
+			Where("order_id = ? AND status = ?", orderId, foodmodel.ReservationStatusReserved).
+			Updates(map[string]interface{}{
+				"status":     foodmodel.ReservationStatusReleased,
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+			db.Rollback()
+			return errors.WithStack(err)
+		}
+	}
+
+	if err := db.Commit().Error; err != nil {
+		db.Rollback()
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
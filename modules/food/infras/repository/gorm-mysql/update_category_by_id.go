@@ -15,6 +15,7 @@ type UpdateFoodDto struct {
 	RestaurantId *string `json:"restaurantId"` // Pointer => Update value => empty
 	CategoryId   *string `json:"categoryId"`
 	Images       *string `json:"images"`
+	Stock        *int    `json:"stock"`
 }
 
 func (r *FoodRepo) Update(ctx context.Context, id uuid.UUID, req service.FoodUpdateReq) error {
@@ -31,6 +32,7 @@ func (r *FoodRepo) Update(ctx context.Context, id uuid.UUID, req service.FoodUpd
 		RestaurantId: req.RestaurantId,
 		CategoryId:   req.CategoryId,
 		Images:       req.Image,
+		Stock:        req.Stock,
 	}
 	if err := db.Table(req.TableName()).Where("id = ?", id).Updates(updateDto).Error; err != nil {
 		db.Rollback()
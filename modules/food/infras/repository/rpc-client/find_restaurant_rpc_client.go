@@ -14,6 +14,7 @@ type RestaurantRPCClient struct {
 
 type RPCGetByIdsResponseDTO struct {
 	Id               uuid.UUID `json:"id"`
+	OwnerId          uuid.UUID `json:"ownerId"`
 	Name             string    `json:"name"`
 	Addr             string    `json:"addr"`
 	CityId           int       `json:"cityId"`
@@ -16,4 +16,11 @@ var (
 	ErrFoodRatingNotFound     = errors.New("food ratings not found")
 	ErrFoodRatingIsDeleted    = errors.New("food ratings is deleted")
 	ErrRestaurantIdEmpty      = errors.New("restaurant Id is empty")
+	ErrStockInvalid           = errors.New("stock must be greater than or equal to 0")
+	ErrStockInsufficient      = errors.New("not enough stock")
+	ErrFoodUnavailable        = errors.New("food is not available")
+	ErrOrderIdRequired        = errors.New("order id is required")
+	ErrQuantityInvalid        = errors.New("quantity must be greater than 0")
+	ErrReservationItemsEmpty  = errors.New("reservation items are required")
+	ErrRestaurantChangeDenied = errors.New("only admins can move a food to another restaurant")
 )
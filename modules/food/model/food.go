@@ -15,6 +15,7 @@ type Food struct {
 	Price        float64    `json:"price"`
 	Images       string     `json:"images"`
 	Status       string     `json:"status"`
+	Stock        *int       `json:"stock,omitempty"` // nil means stock is not tracked
 	CreatedAt    *time.Time `json:"created_at"`
 	UpdatedAt    *time.Time `json:"updated_at"`
 }
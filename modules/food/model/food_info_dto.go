@@ -13,4 +13,5 @@ type FoodInfoDto struct {
 	CategoryId   uuid.UUID `json:"categoryId"`
 	RestaurantId uuid.UUID `json:"restaurantId"`
 	Status       string    `json:"status"`
+	Stock        *int      `json:"stock"`
 }
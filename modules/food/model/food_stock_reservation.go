@@ -0,0 +1,29 @@
+package foodmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reservation status
+const (
+	ReservationStatusReserved  = "RESERVED"
+	ReservationStatusCommitted = "COMMITTED"
+	ReservationStatusReleased  = "RELEASED"
+)
+
+// FoodStockReservation holds the stock taken by an order until it is committed or released
+type FoodStockReservation struct {
+	Id        uuid.UUID  `json:"id"`
+	OrderId   uuid.UUID  `json:"order_id"`
+	FoodId    uuid.UUID  `json:"food_id"`
+	Quantity  int        `json:"quantity"`
+	Status    string     `json:"status"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func (FoodStockReservation) TableName() string {
+	return "food_stock_reservations"
+}
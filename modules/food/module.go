@@ -53,6 +53,8 @@ func SetupFoodModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 		createFoodFavoriteCmdl, favoriteFoodQueryHdl,
 		createCommentFoodCmdl, listCommentFoodCmdl, deleteCommentFoodCmdl,
 		searchFoodQueryHdl, syncFoodByIdCmdHdl, syncFoodIndexCmdHdl,
+		foodservice.NewFoodOwnershipChecker(foodRepo, rpcRestaurantRepo), foodservice.NewCommentOwnershipChecker(foodRatingRepo),
+		foodservice.NewRestaurantOwnershipChecker(rpcRestaurantRepo),
 	)
 
 	// Setup router
@@ -62,5 +64,5 @@ func SetupFoodModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 
 	// Foods
 	foods := g.Group("/foods")
-	foodCtrl.SetupRoutes(foods)
+	foodCtrl.SetupRoutes(foods, appCtx.MiddlewareProvider())
 }
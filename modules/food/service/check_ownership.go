@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	rpcrclient "github.com/ntttrang/go-food-delivery-backend-service/modules/food/infras/repository/rpc-client"
+	foodmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/food/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type IFoodOwnerRepo interface {
+	FindById(ctx context.Context, id uuid.UUID) (foodmodel.Food, error)
+}
+
+type IRPCRestaurantOwnerRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcrclient.RPCGetByIdsResponseDTO, error)
+}
+
+// FoodOwnershipChecker allows only the owner of the restaurant selling the food to manage it.
+// A food which is not linked to any restaurant can be managed by admins only.
+type FoodOwnershipChecker struct {
+	foodRepo       IFoodOwnerRepo
+	restaurantRepo IRPCRestaurantOwnerRepo
+}
+
+func NewFoodOwnershipChecker(foodRepo IFoodOwnerRepo, restaurantRepo IRPCRestaurantOwnerRepo) *FoodOwnershipChecker {
+	return &FoodOwnershipChecker{foodRepo: foodRepo, restaurantRepo: restaurantRepo}
+}
+
+func (c *FoodOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	id, err := uuid.Parse(resourceId)
+	if err != nil {
+		return false, datatype.ErrBadRequest.WithError(foodmodel.ErrFoodIdRequired.Error())
+	}
+
+	food, err := c.foodRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, foodmodel.ErrFoodNotFound) {
+			return false, datatype.ErrNotFound.WithDebug(foodmodel.ErrFoodNotFound.Error())
+		}
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if food.RestaurantId == uuid.Nil {
+		return false, nil
+	}
+
+	restaurants, err := c.restaurantRepo.FindByIds(ctx, []uuid.UUID{food.RestaurantId})
+	if err != nil {
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	restaurant, ok := restaurants[food.RestaurantId]
+	return ok && restaurant.OwnerId == userId, nil
+}
+
+// RestaurantOwnershipChecker allows only the owner of a restaurant to add foods to it
+type RestaurantOwnershipChecker struct {
+	restaurantRepo IRPCRestaurantOwnerRepo
+}
+
+func NewRestaurantOwnershipChecker(restaurantRepo IRPCRestaurantOwnerRepo) *RestaurantOwnershipChecker {
+	return &RestaurantOwnershipChecker{restaurantRepo: restaurantRepo}
+}
+
+func (c *RestaurantOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	id, err := uuid.Parse(resourceId)
+	if err != nil {
+		return false, datatype.ErrBadRequest.WithError(foodmodel.ErrRestaurantIdEmpty.Error())
+	}
+
+	restaurants, err := c.restaurantRepo.FindByIds(ctx, []uuid.UUID{id})
+	if err != nil {
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	restaurant, ok := restaurants[id]
+	if !ok {
+		return false, datatype.ErrNotFound.WithDebug("restaurant not found")
+	}
+	return restaurant.OwnerId == userId, nil
+}
+
+type IFoodCommentOwnerRepo interface {
+	FindById(ctx context.Context, id uuid.UUID) (*foodmodel.FoodRatings, error)
+}
+
+// CommentOwnershipChecker allows only the author of a comment to manage it
+type CommentOwnershipChecker struct {
+	repo IFoodCommentOwnerRepo
+}
+
+func NewCommentOwnershipChecker(repo IFoodCommentOwnerRepo) *CommentOwnershipChecker {
+	return &CommentOwnershipChecker{repo: repo}
+}
+
+func (c *CommentOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	id, err := uuid.Parse(resourceId)
+	if err != nil {
+		return false, datatype.ErrBadRequest.WithError(err.Error())
+	}
+
+	comment, err := c.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, foodmodel.ErrFoodRatingNotFound) || errors.Is(err, foodmodel.ErrFoodNotFound) {
+			return false, datatype.ErrNotFound.WithDebug(foodmodel.ErrFoodRatingNotFound.Error())
+		}
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return comment.UserId == userId, nil
+}
@@ -15,6 +15,7 @@ type FoodInsertDto struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	Stock       *int    `json:"stock"` // Optional, leave empty to not track stock
 
 	Id uuid.UUID `json:"-"`
 }
@@ -26,6 +27,10 @@ func (c *FoodInsertDto) Validate() error {
 		return foodmodel.ErrNameRequired
 	}
 
+	if c.Stock != nil && *c.Stock < 0 {
+		return foodmodel.ErrStockInvalid
+	}
+
 	return nil
 }
 
@@ -34,6 +39,7 @@ func (c FoodInsertDto) ConvertToFood() *foodmodel.Food {
 		Name:        c.Name,
 		Description: c.Description,
 		Price:       c.Price,
+		Stock:       c.Stock,
 	}
 }
 
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	foodmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/food/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type StockItemDto struct {
+	FoodId   uuid.UUID `json:"foodId"`
+	Quantity int       `json:"quantity"`
+}
+
+type StockReserveReq struct {
+	OrderId uuid.UUID      `json:"orderId"`
+	Items   []StockItemDto `json:"items"`
+}
+
+func (r StockReserveReq) Validate() error {
+	if r.OrderId == uuid.Nil {
+		return foodmodel.ErrOrderIdRequired
+	}
+	if len(r.Items) == 0 {
+		return foodmodel.ErrReservationItemsEmpty
+	}
+	for _, item := range r.Items {
+		if item.FoodId == uuid.Nil {
+			return foodmodel.ErrFoodIdRequired
+		}
+		if item.Quantity <= 0 {
+			return foodmodel.ErrQuantityInvalid
+		}
+	}
+	return nil
+}
+
+// Initilize service
+type IFoodStockRepo interface {
+	// ReserveStock decrements stock of all items atomically, or none of them
+	ReserveStock(ctx context.Context, orderId uuid.UUID, items []StockItemDto) error
+	// CommitStock finalizes the reservations of an order
+	CommitStock(ctx context.Context, orderId uuid.UUID) error
+	// ReleaseStock gives back the reserved stock of an order
+	ReleaseStock(ctx context.Context, orderId uuid.UUID) error
+}
+
+type FoodStockCommandHandler struct {
+	repo IFoodStockRepo
+}
+
+func NewFoodStockCommandHandler(repo IFoodStockRepo) *FoodStockCommandHandler {
+	return &FoodStockCommandHandler{repo: repo}
+}
+
+// Implement
+func (hdl *FoodStockCommandHandler) Reserve(ctx context.Context, req StockReserveReq) error {
+	if err := req.Validate(); err != nil {
+		return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if err := hdl.repo.ReserveStock(ctx, req.OrderId, req.Items); err != nil {
+		if err == foodmodel.ErrStockInsufficient {
+			return datatype.ErrConflict.WithWrap(err).WithDebug(err.Error())
+		}
+		if err == foodmodel.ErrFoodUnavailable {
+			return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+		}
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return nil
+}
+
+func (hdl *FoodStockCommandHandler) Commit(ctx context.Context, orderId uuid.UUID) error {
+	if orderId == uuid.Nil {
+		return datatype.ErrBadRequest.WithError(foodmodel.ErrOrderIdRequired.Error())
+	}
+
+	if err := hdl.repo.CommitStock(ctx, orderId); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return nil
+}
+
+func (hdl *FoodStockCommandHandler) Release(ctx context.Context, orderId uuid.UUID) error {
+	if orderId == uuid.Nil {
+		return datatype.ErrBadRequest.WithError(foodmodel.ErrOrderIdRequired.Error())
+	}
+
+	if err := hdl.repo.ReleaseStock(ctx, orderId); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return nil
+}
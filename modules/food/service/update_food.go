@@ -19,8 +19,10 @@ type FoodUpdateReq struct {
 	RestaurantId *string `json:"restaurantId"` // Can be empty or missing if data type = string. Otherwise, uuid.UUID isn't
 	CategoryId   *string `json:"categoryId"`   // Can be empty or missing if data type = string. Otherwise, uuid.UUID isn't
 	Image        *string `json:"image"`
+	Stock        *int    `json:"stock"`
 
-	Id uuid.UUID `json:"-"`
+	Id        uuid.UUID          `json:"-"`
+	Requester datatype.Requester `json:"-"` // Nil for the internal calls
 }
 
 func (FoodUpdateReq) TableName() string {
@@ -28,9 +30,12 @@ func (FoodUpdateReq) TableName() string {
 }
 
 func (c FoodUpdateReq) Validate() error {
-	if c.Status != nil && *c.Status != string(datatype.StatusDeleted) && *c.Status != string(datatype.StatusDeleted) && *c.Status != string(datatype.StatusInactive) {
+	if c.Status != nil && *c.Status != string(datatype.StatusActive) && *c.Status != string(datatype.StatusDeleted) && *c.Status != string(datatype.StatusInactive) {
 		return foodmodel.ErrFoodStatusInvalid
 	}
+	if c.Stock != nil && *c.Stock < 0 {
+		return foodmodel.ErrStockInvalid
+	}
 	return nil
 }
 
@@ -66,6 +71,13 @@ func (hdl *UpdateCommandHandler) Execute(ctx context.Context, req FoodUpdateReq)
 		return datatype.ErrDeleted.WithError(foodmodel.ErrFoodIsDeleted.Error())
 	}
 
+	// The route checks the owner of the current restaurant only, an owner cannot put the food in another menu.
+	// The internal calls have no requester, the restaurant module moves the foods added to a menu it checked.
+	if req.RestaurantId != nil && *req.RestaurantId != category.RestaurantId.String() &&
+		req.Requester != nil && req.Requester.GetRole() != string(datatype.RoleAdmin) {
+		return datatype.ErrForbidden.WithError(foodmodel.ErrRestaurantChangeDenied.Error())
+	}
+
 	if err := hdl.repo.Update(ctx, req.Id, req); err != nil {
 		return err
 	}
@@ -0,0 +1,109 @@
+package httpgin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+type ICreateNotificationsCommandHandler interface {
+	Execute(ctx context.Context, req *service.CreateNotificationsReq) error
+}
+
+type IListQueryHandler interface {
+	Execute(ctx context.Context, req service.NotificationListReq) (service.NotificationListRes, error)
+}
+
+type IMarkReadCommandHandler interface {
+	Execute(ctx context.Context, req service.MarkReadReq) error
+	ExecuteAll(ctx context.Context, userId uuid.UUID) (int64, error)
+}
+
+type ICountUnreadQueryHandler interface {
+	Execute(ctx context.Context, userId uuid.UUID) (int64, error)
+}
+
+type IPreviewEmailQueryHandler interface {
+	ListTemplates(ctx context.Context) service.EmailTemplatesRes
+	Execute(ctx context.Context, req *service.PreviewEmailReq) (*sharedmodel.EmailContent, error)
+}
+
+type IEnqueueEmailCommandHandler interface {
+	Execute(ctx context.Context, req *service.EnqueueEmailReq) (uuid.UUID, error)
+}
+
+type IListEmailQueryHandler interface {
+	Execute(ctx context.Context, req service.EmailListReq) (service.EmailListRes, error)
+}
+
+type IResendEmailCommandHandler interface {
+	Execute(ctx context.Context, id uuid.UUID) (*notificationmodel.OutboxEmail, error)
+}
+
+type NotificationHttpController struct {
+	createCmdHdl       ICreateNotificationsCommandHandler
+	listQueryHdl       IListQueryHandler
+	markReadCmdHdl     IMarkReadCommandHandler
+	countUnreadQryHdl  ICountUnreadQueryHandler
+	previewEmailQryHdl IPreviewEmailQueryHandler
+	enqueueEmailCmdHdl IEnqueueEmailCommandHandler
+	listEmailQryHdl    IListEmailQueryHandler
+	resendEmailCmdHdl  IResendEmailCommandHandler
+}
+
+func NewNotificationHttpController(
+	createCmdHdl ICreateNotificationsCommandHandler,
+	listQueryHdl IListQueryHandler,
+	markReadCmdHdl IMarkReadCommandHandler,
+	countUnreadQryHdl ICountUnreadQueryHandler,
+	previewEmailQryHdl IPreviewEmailQueryHandler,
+	enqueueEmailCmdHdl IEnqueueEmailCommandHandler,
+	listEmailQryHdl IListEmailQueryHandler,
+	resendEmailCmdHdl IResendEmailCommandHandler,
+) *NotificationHttpController {
+	return &NotificationHttpController{
+		createCmdHdl:       createCmdHdl,
+		listQueryHdl:       listQueryHdl,
+		markReadCmdHdl:     markReadCmdHdl,
+		countUnreadQryHdl:  countUnreadQryHdl,
+		previewEmailQryHdl: previewEmailQryHdl,
+		enqueueEmailCmdHdl: enqueueEmailCmdHdl,
+		listEmailQryHdl:    listEmailQryHdl,
+		resendEmailCmdHdl:  resendEmailCmdHdl,
+	}
+}
+
+func (ctrl *NotificationHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	// RPC
+	g.POST("/rpc/notifications/create", mldProvider.RequireInternal(), ctrl.RPCCreateNotifications)
+	g.POST("/rpc/notifications/emails", mldProvider.RequireInternal(), ctrl.RPCEnqueueEmail)
+
+	// My in-app inbox
+	notifications := g.Group("/notifications", mldProvider.Auth())
+	{
+		notifications.GET("", ctrl.ListNotificationsAPI)
+		notifications.GET("/unread-count", ctrl.CountUnreadAPI)
+		notifications.PATCH("/read-all", ctrl.MarkAllReadAPI)
+		notifications.PATCH("/:id/read", ctrl.MarkReadAPI)
+	}
+
+	// Email templates, previewed by the admins
+	emailTemplates := g.Group("/notifications/email-templates", mldProvider.RequireRole(datatype.RoleAdmin))
+	{
+		emailTemplates.GET("", ctrl.ListEmailTemplatesAPI)
+		emailTemplates.POST("/preview", ctrl.PreviewEmailAPI)
+	}
+
+	// Email outbox, admins resend the failed emails
+	emails := g.Group("/notifications/emails", mldProvider.RequireRole(datatype.RoleAdmin))
+	{
+		emails.GET("", ctrl.ListEmailsAPI)
+		emails.POST("/:id/resend", ctrl.ResendEmailAPI)
+	}
+}
@@ -0,0 +1,64 @@
+package httpgin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// RPCEnqueueEmail queues an email for the dispatcher, used by the modules sending emails
+func (ctrl *NotificationHttpController) RPCEnqueueEmail(c *gin.Context) {
+	var req service.EnqueueEmailReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := ctrl.enqueueEmailCmdHdl.Execute(c.Request.Context(), &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var appErr *datatype.DefaultError
+		if errors.As(err, &appErr) {
+			status = appErr.StatusCode()
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"id": id}})
+}
+
+func (ctrl *NotificationHttpController) ListEmailsAPI(c *gin.Context) {
+	var req service.EmailListReq
+	if err := c.ShouldBind(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	req.PagingDto.Process()
+
+	result, err := ctrl.listEmailQryHdl.Execute(c.Request.Context(), req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func (ctrl *NotificationHttpController) ResendEmailAPI(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	email, err := ctrl.resendEmailCmdHdl.Execute(c.Request.Context(), id)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": email})
+}
@@ -0,0 +1,37 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *NotificationHttpController) ListNotificationsAPI(c *gin.Context) {
+	var req service.NotificationListReq
+	if err := c.ShouldBind(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	req.PagingDto.Process()
+	req.UserId = c.MustGet(datatype.KeyRequester).(datatype.Requester).Subject()
+
+	result, err := ctrl.listQueryHdl.Execute(c.Request.Context(), req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func (ctrl *NotificationHttpController) CountUnreadAPI(c *gin.Context) {
+	userId := c.MustGet(datatype.KeyRequester).(datatype.Requester).Subject()
+
+	count, err := ctrl.countUnreadQryHdl.Execute(c.Request.Context(), userId)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"unread": count}})
+}
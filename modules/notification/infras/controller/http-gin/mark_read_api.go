@@ -0,0 +1,39 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *NotificationHttpController) MarkReadAPI(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	req := service.MarkReadReq{
+		Id:     id,
+		UserId: c.MustGet(datatype.KeyRequester).(datatype.Requester).Subject(),
+	}
+
+	if err := ctrl.markReadCmdHdl.Execute(c.Request.Context(), req); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
+
+func (ctrl *NotificationHttpController) MarkAllReadAPI(c *gin.Context) {
+	userId := c.MustGet(datatype.KeyRequester).(datatype.Requester).Subject()
+
+	count, err := ctrl.markReadCmdHdl.ExecuteAll(c.Request.Context(), userId)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"marked": count}})
+}
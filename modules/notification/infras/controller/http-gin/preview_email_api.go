@@ -0,0 +1,27 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *NotificationHttpController) ListEmailTemplatesAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": ctrl.previewEmailQryHdl.ListTemplates(c.Request.Context())})
+}
+
+func (ctrl *NotificationHttpController) PreviewEmailAPI(c *gin.Context) {
+	var req service.PreviewEmailReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	content, err := ctrl.previewEmailQryHdl.Execute(c.Request.Context(), &req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": content})
+}
@@ -0,0 +1,25 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+)
+
+// RPCCreateNotifications adds entries to the inboxes of their recipients, used by the order notifications
+func (ctrl *NotificationHttpController) RPCCreateNotifications(c *gin.Context) {
+	var req service.CreateNotificationsReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ctrl.createCmdHdl.Execute(c.Request.Context(), &req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
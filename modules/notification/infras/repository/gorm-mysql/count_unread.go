@@ -0,0 +1,19 @@
+package notificationgormmysql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/pkg/errors"
+)
+
+func (r *NotificationRepo) CountUnread(ctx context.Context, userId uuid.UUID) (int64, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var count int64
+	if err := db.Model(&notificationmodel.Notification{}).Where("user_id = ? AND read_at IS NULL", userId).Count(&count).Error; err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return count, nil
+}
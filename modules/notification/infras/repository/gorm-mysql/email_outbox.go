@@ -0,0 +1,96 @@
+package notificationgormmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *NotificationRepo) InsertEmail(ctx context.Context, email *notificationmodel.OutboxEmail) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Create(email).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// ClaimPendingEmails returns the due emails and pushes their next attempt by lease,
+// so that another dispatcher does not send them while they are being handled
+func (r *NotificationRepo) ClaimPendingEmails(ctx context.Context, limit int, lease time.Duration) ([]notificationmodel.OutboxEmail, error) {
+	db := r.dbCtx.GetMainConnection()
+	now := time.Now().UTC()
+
+	var emails []notificationmodel.OutboxEmail
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", notificationmodel.EmailStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&emails).Error; err != nil {
+			return errors.WithStack(err)
+		}
+
+		if len(emails) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(emails))
+		for i, email := range emails {
+			ids[i] = email.Id
+		}
+		if err := tx.Model(&notificationmodel.OutboxEmail{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+func (r *NotificationRepo) UpdateEmail(ctx context.Context, email *notificationmodel.OutboxEmail) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.WithContext(ctx).Save(email).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *NotificationRepo) FindEmailById(ctx context.Context, id uuid.UUID) (*notificationmodel.OutboxEmail, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var email notificationmodel.OutboxEmail
+	if err := db.Where("id = ?", id).First(&email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notificationmodel.ErrEmailNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &email, nil
+}
+
+func (r *NotificationRepo) FindEmails(ctx context.Context, req service.EmailListReq) ([]notificationmodel.OutboxEmail, int64, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx).
+		Table(notificationmodel.OutboxEmail{}.TableName())
+
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+
+	var result []notificationmodel.OutboxEmail
+	var total int64
+	if err := db.Count(&total).Offset((req.Page - 1) * req.Limit).Limit(req.Limit).Order("created_at DESC").Find(&result).Error; err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	return result, total, nil
+}
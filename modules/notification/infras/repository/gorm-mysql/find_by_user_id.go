@@ -0,0 +1,26 @@
+package notificationgormmysql
+
+import (
+	"context"
+
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	"github.com/pkg/errors"
+)
+
+func (r *NotificationRepo) FindByUserId(ctx context.Context, req service.NotificationListReq) ([]notificationmodel.Notification, int64, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx).
+		Table(notificationmodel.Notification{}.TableName()).
+		Where("user_id = ?", req.UserId)
+
+	if req.Unread {
+		db = db.Where("read_at IS NULL")
+	}
+
+	var result []notificationmodel.Notification
+	var total int64
+	if err := db.Count(&total).Offset((req.Page - 1) * req.Limit).Limit(req.Limit).Order("created_at DESC").Find(&result).Error; err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	return result, total, nil
+}
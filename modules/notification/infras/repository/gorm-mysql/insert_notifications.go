@@ -0,0 +1,16 @@
+package notificationgormmysql
+
+import (
+	"context"
+
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/pkg/errors"
+)
+
+func (r *NotificationRepo) Insert(ctx context.Context, notifications []notificationmodel.Notification) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Create(&notifications).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
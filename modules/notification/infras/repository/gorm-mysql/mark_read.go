@@ -0,0 +1,47 @@
+package notificationgormmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/pkg/errors"
+)
+
+// MarkRead sets the read time of an unread notification of the user
+func (r *NotificationRepo) MarkRead(ctx context.Context, userId, id uuid.UUID, readAt time.Time) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	result := db.Model(&notificationmodel.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userId).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return errors.WithStack(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// Nothing updated: already read, or not a notification of the user
+	var count int64
+	if err := db.Model(&notificationmodel.Notification{}).Where("id = ? AND user_id = ?", id, userId).Count(&count).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	if count == 0 {
+		return notificationmodel.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRepo) MarkAllRead(ctx context.Context, userId uuid.UUID, readAt time.Time) (int64, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	result := db.Model(&notificationmodel.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return 0, errors.WithStack(result.Error)
+	}
+	return result.RowsAffected, nil
+}
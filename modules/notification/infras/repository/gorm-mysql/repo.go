@@ -0,0 +1,11 @@
+package notificationgormmysql
+
+import shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+
+type NotificationRepo struct {
+	dbCtx shareinfras.IDbContext
+}
+
+func NewNotificationRepo(dbCtx shareinfras.IDbContext) *NotificationRepo {
+	return &NotificationRepo{dbCtx: dbCtx}
+}
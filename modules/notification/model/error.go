@@ -0,0 +1,18 @@
+package notificationmodel
+
+import "errors"
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrUserIdRequired       = errors.New("user id is required")
+	ErrTypeRequired         = errors.New("type is required")
+	ErrTitleRequired        = errors.New("title is required")
+	ErrTemplateRequired     = errors.New("template name is required")
+	ErrLocaleInvalid        = errors.New("locale must be en or vi")
+	ErrEmailNotFound        = errors.New("email not found")
+	ErrRecipientRequired    = errors.New("email recipient is required")
+	ErrSubjectRequired      = errors.New("email subject is required")
+	ErrEmailStatusInvalid   = errors.New("email status must be pending, sent or failed")
+	ErrEmailPending         = errors.New("email is already waiting to be sent")
+	ErrAttachmentInvalid    = errors.New("attachment must be a file name of the attachment directory")
+)
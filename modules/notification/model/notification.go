@@ -0,0 +1,27 @@
+package notificationmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is an entry of a user's in-app inbox
+type Notification struct {
+	Id        uuid.UUID         `gorm:"column:id" json:"id"`
+	UserId    uuid.UUID         `gorm:"column:user_id" json:"userId"`
+	Type      string            `gorm:"column:type" json:"type"` // Event, e.g. customer.order_created
+	Title     string            `gorm:"column:title" json:"title"`
+	Body      string            `gorm:"column:body" json:"body"`
+	Data      map[string]string `gorm:"column:data;serializer:json" json:"data"` // Deep link, e.g. orderId
+	ReadAt    *time.Time        `gorm:"column:read_at" json:"readAt"`
+	CreatedAt *time.Time        `gorm:"column:created_at" json:"createdAt"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+func (n Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
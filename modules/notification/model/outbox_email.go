@@ -0,0 +1,70 @@
+package notificationmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// Outbox email status
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed" // Gave up after too many attempts, can be resent by an admin
+)
+
+var EmailStatuses = []string{EmailStatusPending, EmailStatusSent, EmailStatusFailed}
+
+// OutboxEmail is an email queued for the email dispatcher, kept after it is sent
+type OutboxEmail struct {
+	Id            uuid.UUID  `gorm:"column:id" json:"id"`
+	From          string     `gorm:"column:from_address" json:"from"` // The configured sender when empty
+	To            []string   `gorm:"column:to_addresses;serializer:json" json:"to"`
+	Subject       string     `gorm:"column:subject" json:"subject"`
+	Body          string     `gorm:"column:body" json:"body"`
+	TextBody      string     `gorm:"column:text_body" json:"textBody"`
+	IsHTML        bool       `gorm:"column:is_html" json:"isHtml"`
+	Attachments   []string   `gorm:"column:attachments;serializer:json" json:"attachments"` // File paths readable by the dispatcher
+	Status        string     `gorm:"column:status" json:"status"`
+	Attempts      int        `gorm:"column:attempts" json:"attempts"`
+	LastError     *string    `gorm:"column:last_error" json:"lastError,omitempty"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at" json:"nextAttemptAt"`
+	SentAt        *time.Time `gorm:"column:sent_at" json:"sentAt,omitempty"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+func (OutboxEmail) TableName() string {
+	return "email_outbox"
+}
+
+// NewOutboxEmail queues the message, due right away
+func NewOutboxEmail(message sharedmodel.EmailMessage, now time.Time) *OutboxEmail {
+	return &OutboxEmail{
+		Id:            uuid.New(),
+		From:          message.From,
+		To:            message.To,
+		Subject:       message.Subject,
+		Body:          message.Body,
+		TextBody:      message.TextBody,
+		IsHTML:        message.IsHTML,
+		Attachments:   message.Attachments,
+		Status:        EmailStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func (e OutboxEmail) Message() sharedmodel.EmailMessage {
+	return sharedmodel.EmailMessage{
+		From:        e.From,
+		To:          e.To,
+		Subject:     e.Subject,
+		Body:        e.Body,
+		TextBody:    e.TextBody,
+		IsHTML:      e.IsHTML,
+		Attachments: e.Attachments,
+	}
+}
@@ -0,0 +1,44 @@
+package notificationmodule
+
+import (
+	"github.com/gin-gonic/gin"
+	httpgin "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/infras/controller/http-gin"
+	gormmysql "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/infras/repository/gorm-mysql"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/notification/service"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+func SetupNotificationModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
+	dbCtx := appCtx.DbContext()
+
+	// Setup repositories
+	notificationRepo := gormmysql.NewNotificationRepo(dbCtx)
+
+	// Setup handlers
+	createCmdHdl := service.NewCreateNotificationsCommandHandler(notificationRepo)
+	listQueryHdl := service.NewListQueryHandler(notificationRepo)
+	markReadCmdHdl := service.NewMarkReadCommandHandler(notificationRepo)
+	countUnreadQryHdl := service.NewCountUnreadQueryHandler(notificationRepo)
+	enqueueEmailCmdHdl := service.NewEnqueueEmailCommandHandler(notificationRepo)
+	listEmailQryHdl := service.NewListEmailQueryHandler(notificationRepo)
+	resendEmailCmdHdl := service.NewResendEmailCommandHandler(notificationRepo)
+	previewEmailQryHdl := service.NewPreviewEmailQueryHandler(
+		sharecomponent.MustNewEmailTemplateRenderer(appCtx.GetConfig().EmailConfig.DefaultLocale),
+	)
+
+	// Setup controllers
+	notificationCtrl := httpgin.NewNotificationHttpController(
+		createCmdHdl,
+		listQueryHdl,
+		markReadCmdHdl,
+		countUnreadQryHdl,
+		previewEmailQryHdl,
+		enqueueEmailCmdHdl,
+		listEmailQryHdl,
+		resendEmailCmdHdl,
+	)
+
+	// Setup routes
+	notificationCtrl.SetupRoutes(g, appCtx.MiddlewareProvider())
+}
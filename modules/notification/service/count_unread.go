@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Initilize service
+type ICountUnreadRepo interface {
+	CountUnread(ctx context.Context, userId uuid.UUID) (int64, error)
+}
+
+type CountUnreadQueryHandler struct {
+	repo ICountUnreadRepo
+}
+
+func NewCountUnreadQueryHandler(repo ICountUnreadRepo) *CountUnreadQueryHandler {
+	return &CountUnreadQueryHandler{repo: repo}
+}
+
+// Implement
+// Execute returns the number of unread notifications of the requester, shown on the bell badge
+func (hdl *CountUnreadQueryHandler) Execute(ctx context.Context, userId uuid.UUID) (int64, error) {
+	count, err := hdl.repo.CountUnread(ctx, userId)
+	if err != nil {
+		return 0, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return count, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type CreateNotificationReq struct {
+	UserId uuid.UUID         `json:"userId"`
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Body   string            `json:"body"`
+	Data   map[string]string `json:"data"`
+}
+
+func (r *CreateNotificationReq) Validate() error {
+	if r.UserId == uuid.Nil {
+		return notificationmodel.ErrUserIdRequired
+	}
+
+	r.Type = strings.TrimSpace(r.Type)
+	if r.Type == "" {
+		return notificationmodel.ErrTypeRequired
+	}
+
+	r.Title = strings.TrimSpace(r.Title)
+	if r.Title == "" {
+		return notificationmodel.ErrTitleRequired
+	}
+	return nil
+}
+
+type CreateNotificationsReq struct {
+	Notifications []CreateNotificationReq `json:"notifications"`
+}
+
+// Initilize service
+type ICreateNotificationsRepo interface {
+	Insert(ctx context.Context, notifications []notificationmodel.Notification) error
+}
+
+type CreateNotificationsCommandHandler struct {
+	repo ICreateNotificationsRepo
+}
+
+func NewCreateNotificationsCommandHandler(repo ICreateNotificationsRepo) *CreateNotificationsCommandHandler {
+	return &CreateNotificationsCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute adds the entries to the inboxes of their recipients
+func (hdl *CreateNotificationsCommandHandler) Execute(ctx context.Context, req *CreateNotificationsReq) error {
+	now := time.Now().UTC()
+	notifications := make([]notificationmodel.Notification, 0, len(req.Notifications))
+	for i := range req.Notifications {
+		item := &req.Notifications[i]
+		if err := item.Validate(); err != nil {
+			return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+		}
+
+		notifications = append(notifications, notificationmodel.Notification{
+			Id:        uuid.New(),
+			UserId:    item.UserId,
+			Type:      item.Type,
+			Title:     item.Title,
+			Body:      item.Body,
+			Data:      item.Data,
+			CreatedAt: &now,
+		})
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	if err := hdl.repo.Insert(ctx, notifications); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return nil
+}
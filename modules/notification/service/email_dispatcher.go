@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+const (
+	// emailClaimLease is how long a claimed email is hidden from other dispatchers, longer than an SMTP timeout
+	emailClaimLease = 2 * time.Minute
+	// emailBaseBackoff is the delay before the first retry, it doubles on every attempt
+	emailBaseBackoff = 30 * time.Second
+)
+
+// IEmailService delivers an email, over SMTP or to the .eml sink
+type IEmailService interface {
+	SendEmail(message sharedmodel.EmailMessage) error
+}
+
+type IEmailOutboxRepo interface {
+	ClaimPendingEmails(ctx context.Context, limit int, lease time.Duration) ([]notificationmodel.OutboxEmail, error)
+	UpdateEmail(ctx context.Context, email *notificationmodel.OutboxEmail) error
+}
+
+// EmailDispatcher sends the queued emails, retrying the failed ones with an exponential backoff.
+// Delivery is at least once: an email is sent again if it cannot be marked as sent.
+type EmailDispatcher struct {
+	repo     IEmailOutboxRepo
+	emailSvc IEmailService
+	cfg      datatype.EmailOutboxConfig
+}
+
+func NewEmailDispatcher(repo IEmailOutboxRepo, emailSvc IEmailService, cfg datatype.EmailOutboxConfig) *EmailDispatcher {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 20
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 30 * time.Minute
+	}
+
+	return &EmailDispatcher{repo: repo, emailSvc: emailSvc, cfg: cfg}
+}
+
+// Run sends due emails until ctx is cancelled
+func (d *EmailDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while batches are full, the backlog is drained before waiting again
+		for ctx.Err() == nil {
+			count, err := d.DispatchBatch(ctx)
+			if err != nil {
+				log.Printf("Email dispatcher: failed to claim emails: %v", err)
+				break
+			}
+			if count < d.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchBatch sends one batch of due emails and returns how many were handled
+func (d *EmailDispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	emails, err := d.repo.ClaimPendingEmails(ctx, d.cfg.BatchSize, emailClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range emails {
+		d.dispatch(ctx, &emails[i])
+	}
+
+	return len(emails), nil
+}
+
+func (d *EmailDispatcher) dispatch(ctx context.Context, email *notificationmodel.OutboxEmail) {
+	sendErr := d.emailSvc.SendEmail(email.Message())
+
+	now := time.Now().UTC()
+	email.Attempts++
+	email.UpdatedAt = now
+
+	if sendErr == nil {
+		email.Status = notificationmodel.EmailStatusSent
+		email.SentAt = &now
+		email.LastError = nil
+	} else {
+		lastError := sendErr.Error()
+		email.LastError = &lastError
+
+		if email.Attempts >= d.cfg.MaxAttempts {
+			email.Status = notificationmodel.EmailStatusFailed
+			log.Printf("Email dispatcher: giving up email %s to %v after %d attempts: %v", email.Id, email.To, email.Attempts, sendErr)
+		} else {
+			email.NextAttemptAt = now.Add(d.backoff(email.Attempts))
+			log.Printf("Email dispatcher: failed to send email %s to %v, attempt %d: %v", email.Id, email.To, email.Attempts, sendErr)
+		}
+	}
+
+	if err := d.repo.UpdateEmail(ctx, email); err != nil {
+		// The claim lease expires and the email is handled again
+		log.Printf("Email dispatcher: failed to update email %s: %v", email.Id, err)
+	}
+}
+
+// backoff doubles the delay on every attempt, up to MaxBackoff
+func (d *EmailDispatcher) backoff(attempts int) time.Duration {
+	delay := emailBaseBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.MaxBackoff {
+		delay = d.cfg.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+type fakeEmailOutboxRepo struct {
+	emails map[uuid.UUID]*notificationmodel.OutboxEmail
+}
+
+func (r *fakeEmailOutboxRepo) ClaimPendingEmails(ctx context.Context, limit int, lease time.Duration) ([]notificationmodel.OutboxEmail, error) {
+	now := time.Now().UTC()
+	var due []notificationmodel.OutboxEmail
+	for _, email := range r.emails {
+		if email.Status == notificationmodel.EmailStatusPending && !email.NextAttemptAt.After(now) && len(due) < limit {
+			email.NextAttemptAt = now.Add(lease)
+			due = append(due, *email)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeEmailOutboxRepo) UpdateEmail(ctx context.Context, email *notificationmodel.OutboxEmail) error {
+	stored := *email
+	r.emails[email.Id] = &stored
+	return nil
+}
+
+func (r *fakeEmailOutboxRepo) FindEmailById(ctx context.Context, id uuid.UUID) (*notificationmodel.OutboxEmail, error) {
+	email, ok := r.emails[id]
+	if !ok {
+		return nil, notificationmodel.ErrEmailNotFound
+	}
+	found := *email
+	return &found, nil
+}
+
+type fakeEmailService struct {
+	err  error
+	sent []sharedmodel.EmailMessage
+}
+
+func (s *fakeEmailService) SendEmail(message sharedmodel.EmailMessage) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+func newFakeEmailOutboxRepo() (*fakeEmailOutboxRepo, *notificationmodel.OutboxEmail) {
+	email := notificationmodel.NewOutboxEmail(sharedmodel.EmailMessage{
+		To:          []string{"a@b.c"},
+		Subject:     "Order 1",
+		Body:        "<p>Order 1</p>",
+		IsHTML:      true,
+		Attachments: []string{"invoice.pdf"},
+	}, time.Now().UTC())
+	return &fakeEmailOutboxRepo{emails: map[uuid.UUID]*notificationmodel.OutboxEmail{email.Id: email}}, email
+}
+
+func TestEmailDispatcher_DispatchBatch(t *testing.T) {
+	ctx := context.Background()
+	cfg := datatype.EmailOutboxConfig{BatchSize: 10, MaxAttempts: 2, MaxBackoff: time.Hour}
+
+	t.Run("TC 1: sent email is marked sent", func(t *testing.T) {
+		repo, email := newFakeEmailOutboxRepo()
+		emailSvc := &fakeEmailService{}
+		dispatcher := NewEmailDispatcher(repo, emailSvc, cfg)
+
+		count, err := dispatcher.DispatchBatch(ctx)
+		if err != nil || count != 1 {
+			t.Fatalf("DispatchBatch() = %d, %v, want 1, nil", count, err)
+		}
+		if len(emailSvc.sent) != 1 || emailSvc.sent[0].Attachments[0] != "invoice.pdf" {
+			t.Errorf("sent = %+v", emailSvc.sent)
+		}
+		stored := repo.emails[email.Id]
+		if stored.Status != notificationmodel.EmailStatusSent || stored.SentAt == nil || stored.Attempts != 1 {
+			t.Errorf("email = %+v, want sent after 1 attempt", stored)
+		}
+	})
+
+	t.Run("TC 2: failed email is retried after a backoff, then given up", func(t *testing.T) {
+		repo, email := newFakeEmailOutboxRepo()
+		dispatcher := NewEmailDispatcher(repo, &fakeEmailService{err: errors.New("smtp unavailable")}, cfg)
+
+		if _, err := dispatcher.DispatchBatch(ctx); err != nil {
+			t.Fatalf("DispatchBatch() error = %v", err)
+		}
+		stored := repo.emails[email.Id]
+		if stored.Status != notificationmodel.EmailStatusPending || stored.LastError == nil || !stored.NextAttemptAt.After(time.Now().Add(emailBaseBackoff/2)) {
+			t.Errorf("email = %+v, want pending with a backoff", stored)
+		}
+
+		// Not due yet
+		if count, _ := dispatcher.DispatchBatch(ctx); count != 0 {
+			t.Errorf("DispatchBatch() = %d before the backoff, want 0", count)
+		}
+
+		stored.NextAttemptAt = time.Now().UTC()
+		if _, err := dispatcher.DispatchBatch(ctx); err != nil {
+			t.Fatalf("DispatchBatch() error = %v", err)
+		}
+		if stored := repo.emails[email.Id]; stored.Status != notificationmodel.EmailStatusFailed || stored.Attempts != 2 {
+			t.Errorf("email = %+v, want failed after 2 attempts", stored)
+		}
+	})
+
+	t.Run("TC 3: failed email is resent by an admin", func(t *testing.T) {
+		repo, email := newFakeEmailOutboxRepo()
+		repo.emails[email.Id].Status = notificationmodel.EmailStatusFailed
+		repo.emails[email.Id].Attempts = 2
+		emailSvc := &fakeEmailService{}
+
+		resent, err := NewResendEmailCommandHandler(repo).Execute(ctx, email.Id)
+		if err != nil {
+			t.Fatalf("ResendEmailCommandHandler.Execute() error = %v", err)
+		}
+		if resent.Status != notificationmodel.EmailStatusPending || resent.Attempts != 0 {
+			t.Errorf("email = %+v, want pending without attempts", resent)
+		}
+		if _, err := NewResendEmailCommandHandler(repo).Execute(ctx, email.Id); err == nil {
+			t.Error("resend of a pending email error = nil, want an error")
+		}
+
+		if _, err := NewEmailDispatcher(repo, emailSvc, cfg).DispatchBatch(ctx); err != nil {
+			t.Fatalf("DispatchBatch() error = %v", err)
+		}
+		if len(emailSvc.sent) != 1 || repo.emails[email.Id].Status != notificationmodel.EmailStatusSent {
+			t.Errorf("sent = %d, email = %+v", len(emailSvc.sent), repo.emails[email.Id])
+		}
+	})
+}
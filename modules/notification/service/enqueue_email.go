@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// Define DTOs & validate
+// EnqueueEmailReq is sent by the configured sender
+type EnqueueEmailReq struct {
+	To          []string `json:"to"`
+	Subject     string   `json:"subject"`
+	Body        string   `json:"body"`
+	TextBody    string   `json:"textBody"`
+	IsHTML      bool     `json:"isHtml"`
+	Attachments []string `json:"attachments"` // File names of EMAIL_ATTACHMENT_DIR, not paths
+}
+
+func (r *EnqueueEmailReq) Validate() error {
+	to := make([]string, 0, len(r.To))
+	for _, addr := range r.To {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	if len(to) == 0 {
+		return notificationmodel.ErrRecipientRequired
+	}
+	r.To = to
+
+	r.Subject = strings.TrimSpace(r.Subject)
+	if r.Subject == "" {
+		return notificationmodel.ErrSubjectRequired
+	}
+
+	for _, name := range r.Attachments {
+		if !sharecomponent.ValidAttachmentName(name) {
+			return notificationmodel.ErrAttachmentInvalid
+		}
+	}
+	return nil
+}
+
+func (r *EnqueueEmailReq) message() sharedmodel.EmailMessage {
+	return sharedmodel.EmailMessage{
+		To:          r.To,
+		Subject:     r.Subject,
+		Body:        r.Body,
+		TextBody:    r.TextBody,
+		IsHTML:      r.IsHTML,
+		Attachments: r.Attachments,
+	}
+}
+
+// Initilize service
+type IEnqueueEmailRepo interface {
+	InsertEmail(ctx context.Context, email *notificationmodel.OutboxEmail) error
+}
+
+type EnqueueEmailCommandHandler struct {
+	repo IEnqueueEmailRepo
+}
+
+func NewEnqueueEmailCommandHandler(repo IEnqueueEmailRepo) *EnqueueEmailCommandHandler {
+	return &EnqueueEmailCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute queues the email for the dispatcher and returns its id
+func (hdl *EnqueueEmailCommandHandler) Execute(ctx context.Context, req *EnqueueEmailReq) (uuid.UUID, error) {
+	if err := req.Validate(); err != nil {
+		return uuid.Nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	email := notificationmodel.NewOutboxEmail(req.message(), time.Now().UTC())
+	if err := hdl.repo.InsertEmail(ctx, email); err != nil {
+		return uuid.Nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return email.Id, nil
+}
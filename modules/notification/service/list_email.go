@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharemodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// Define DTOs & validate
+type EmailListReq struct {
+	Status string `json:"status" form:"status"` // Every status when empty
+	sharemodel.PagingDto
+}
+
+func (r *EmailListReq) Validate() error {
+	if r.Status != "" && !slices.Contains(notificationmodel.EmailStatuses, r.Status) {
+		return notificationmodel.ErrEmailStatusInvalid
+	}
+	return nil
+}
+
+type EmailListRes struct {
+	Items      []notificationmodel.OutboxEmail `json:"items"`
+	Pagination sharemodel.PagingDto            `json:"pagination"`
+}
+
+// Initilize service
+type IListEmailRepo interface {
+	FindEmails(ctx context.Context, req EmailListReq) ([]notificationmodel.OutboxEmail, int64, error)
+}
+
+type ListEmailQueryHandler struct {
+	repo IListEmailRepo
+}
+
+func NewListEmailQueryHandler(repo IListEmailRepo) *ListEmailQueryHandler {
+	return &ListEmailQueryHandler{repo: repo}
+}
+
+// Implement
+// Execute returns the queued and sent emails, newest first
+func (hdl *ListEmailQueryHandler) Execute(ctx context.Context, req EmailListReq) (EmailListRes, error) {
+	if err := req.Validate(); err != nil {
+		return EmailListRes{}, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	emails, total, err := hdl.repo.FindEmails(ctx, req)
+	if err != nil {
+		return EmailListRes{}, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	var resp EmailListRes
+	resp.Items = emails
+	resp.Pagination = sharemodel.PagingDto{
+		Page:  req.Page,
+		Limit: req.Limit,
+		Total: total,
+	}
+	return resp, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharemodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// Define DTOs & validate
+type NotificationListReq struct {
+	Unread bool `json:"unread" form:"unread"` // Only the unread notifications
+	sharemodel.PagingDto
+
+	UserId uuid.UUID `json:"-"`
+}
+
+type NotificationListRes struct {
+	Items      []notificationmodel.Notification `json:"items"`
+	Pagination sharemodel.PagingDto             `json:"pagination"`
+}
+
+// Initilize service
+type IListNotificationRepo interface {
+	FindByUserId(ctx context.Context, req NotificationListReq) ([]notificationmodel.Notification, int64, error)
+}
+
+type ListQueryHandler struct {
+	repo IListNotificationRepo
+}
+
+func NewListQueryHandler(repo IListNotificationRepo) *ListQueryHandler {
+	return &ListQueryHandler{repo: repo}
+}
+
+// Implement
+// Execute returns the requester's notifications, newest first
+func (hdl *ListQueryHandler) Execute(ctx context.Context, req NotificationListReq) (NotificationListRes, error) {
+	notifications, total, err := hdl.repo.FindByUserId(ctx, req)
+	if err != nil {
+		return NotificationListRes{}, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	var resp NotificationListRes
+	resp.Items = notifications
+	resp.Pagination = sharemodel.PagingDto{
+		Page:  req.Page,
+		Limit: req.Limit,
+		Total: total,
+	}
+	return resp, nil
+}
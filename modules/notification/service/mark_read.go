@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type MarkReadReq struct {
+	Id     uuid.UUID `json:"-"`
+	UserId uuid.UUID `json:"-"`
+}
+
+// Initilize service
+type IMarkReadRepo interface {
+	MarkRead(ctx context.Context, userId, id uuid.UUID, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userId uuid.UUID, readAt time.Time) (int64, error)
+}
+
+type MarkReadCommandHandler struct {
+	repo IMarkReadRepo
+}
+
+func NewMarkReadCommandHandler(repo IMarkReadRepo) *MarkReadCommandHandler {
+	return &MarkReadCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute marks one of the requester's notifications as read, marking it again keeps the first read time
+func (hdl *MarkReadCommandHandler) Execute(ctx context.Context, req MarkReadReq) error {
+	if err := hdl.repo.MarkRead(ctx, req.UserId, req.Id, time.Now().UTC()); err != nil {
+		if errors.Is(err, notificationmodel.ErrNotificationNotFound) {
+			return datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return nil
+}
+
+// ExecuteAll marks every unread notification of the requester as read and returns how many there were
+func (hdl *MarkReadCommandHandler) ExecuteAll(ctx context.Context, userId uuid.UUID) (int64, error) {
+	count, err := hdl.repo.MarkAllRead(ctx, userId, time.Now().UTC())
+	if err != nil {
+		return 0, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return count, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// Define DTOs & validate
+// PreviewEmailReq renders a template with its sample data, the given data overrides the sample
+type PreviewEmailReq struct {
+	Name   string         `json:"name"`
+	Locale string         `json:"locale"` // Default locale when empty
+	Data   map[string]any `json:"data"`
+}
+
+func (r *PreviewEmailReq) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return notificationmodel.ErrTemplateRequired
+	}
+
+	r.Locale = strings.ToLower(strings.TrimSpace(r.Locale))
+	if r.Locale != "" && !slices.Contains(datatype.SupportedLocales, r.Locale) {
+		return notificationmodel.ErrLocaleInvalid
+	}
+	return nil
+}
+
+type EmailTemplatesRes struct {
+	Templates     []string `json:"templates"`
+	Locales       []string `json:"locales"`
+	DefaultLocale string   `json:"defaultLocale"`
+}
+
+// Initilize service
+type IEmailPreviewer interface {
+	Preview(locale, name string, data map[string]any) (*sharedmodel.EmailContent, error)
+	Templates() []string
+	Locales() []string
+	DefaultLocale() string
+}
+
+type PreviewEmailQueryHandler struct {
+	previewer IEmailPreviewer
+}
+
+func NewPreviewEmailQueryHandler(previewer IEmailPreviewer) *PreviewEmailQueryHandler {
+	return &PreviewEmailQueryHandler{previewer: previewer}
+}
+
+// Implement
+// ListTemplates returns the email templates which can be previewed
+func (hdl *PreviewEmailQueryHandler) ListTemplates(_ context.Context) EmailTemplatesRes {
+	return EmailTemplatesRes{
+		Templates:     hdl.previewer.Templates(),
+		Locales:       hdl.previewer.Locales(),
+		DefaultLocale: hdl.previewer.DefaultLocale(),
+	}
+}
+
+// Execute renders the subject, HTML and plain text of an email as it would be sent
+func (hdl *PreviewEmailQueryHandler) Execute(_ context.Context, req *PreviewEmailReq) (*sharedmodel.EmailContent, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	content, err := hdl.previewer.Preview(req.Locale, req.Name, req.Data)
+	if err != nil {
+		if errors.Is(err, sharecomponent.ErrEmailTemplateNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		// Data of the wrong type for the template
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+	return content, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	notificationmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/notification/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Initilize service
+type IResendEmailRepo interface {
+	FindEmailById(ctx context.Context, id uuid.UUID) (*notificationmodel.OutboxEmail, error)
+	UpdateEmail(ctx context.Context, email *notificationmodel.OutboxEmail) error
+}
+
+type ResendEmailCommandHandler struct {
+	repo IResendEmailRepo
+}
+
+func NewResendEmailCommandHandler(repo IResendEmailRepo) *ResendEmailCommandHandler {
+	return &ResendEmailCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute queues a failed or sent email again with a fresh number of attempts, the last error is kept until it is sent
+func (hdl *ResendEmailCommandHandler) Execute(ctx context.Context, id uuid.UUID) (*notificationmodel.OutboxEmail, error) {
+	email, err := hdl.repo.FindEmailById(ctx, id)
+	if err != nil {
+		if errors.Is(err, notificationmodel.ErrEmailNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if email.Status == notificationmodel.EmailStatusPending {
+		return nil, datatype.ErrBadRequest.WithWrap(notificationmodel.ErrEmailPending).WithDebug(notificationmodel.ErrEmailPending.Error())
+	}
+
+	now := time.Now().UTC()
+	email.Status = notificationmodel.EmailStatusPending
+	email.Attempts = 0
+	email.NextAttemptAt = now
+	email.UpdatedAt = now
+
+	if err := hdl.repo.UpdateEmail(ctx, email); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return email, nil
+}
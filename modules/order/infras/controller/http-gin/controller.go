@@ -2,12 +2,13 @@ package httpgin
 
 import (
 	"context"
-	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
 	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
-	sharedrpc "github.com/ntttrang/go-food-delivery-backend-service/shared/infras/rpc"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
 )
 
 type ICreateCommandHandler interface {
@@ -26,10 +27,36 @@ type IGetDetailQueryHandler interface {
 	Execute(ctx context.Context, req service.OrderDetailReq) (*service.OrderDetailRes, error)
 }
 
+type IGetTimelineQueryHandler interface {
+	Execute(ctx context.Context, orderId string) (*service.OrderTimelineRes, error)
+}
+
+type ITrackOrderQueryHandler interface {
+	Stream(ctx context.Context, orderId string, emit func(res *service.OrderTrackRes) error) error
+}
+
 type IUpdateOrderStateCommandHandler interface {
 	Execute(ctx context.Context, req *service.StateTransitionRequest) error
 }
 
+type IAssignShipperCommandHandler interface {
+	Execute(ctx context.Context, req *service.AssignShipperReq) error
+}
+
+type IDeliveryQuoteQueryHandler interface {
+	Execute(ctx context.Context, req *service.DeliveryQuoteReq) (*service.DeliveryQuoteRes, error)
+}
+
+type IRefundService interface {
+	Execute(ctx context.Context, data *service.RefundCreateDto) error
+	RetryRefund(ctx context.Context, refundID string, updatedBy string) (*ordermodel.Refund, error)
+	ListRefunds(ctx context.Context, req service.RefundListReq) (*service.RefundListRes, error)
+}
+
+type IRequeueOutboxEventCommandHandler interface {
+	Execute(ctx context.Context, id string) (*ordermodel.OutboxEvent, error)
+}
+
 // Note: We can remove these interfaces since we'll use the unified state management
 
 type IDeleteCommandHandler interface {
@@ -37,12 +64,20 @@ type IDeleteCommandHandler interface {
 }
 
 type OrderHttpController struct {
-	createCmdHdl           ICreateCommandHandler
-	createFromCartCmdHdl   ICreateFromCartCommandHandler
-	listQueryHdl           IListQueryHandler
-	getDetailQueryHdl      IGetDetailQueryHandler
-	updateOrderStateCmdHdl IUpdateOrderStateCommandHandler
-	deleteCmdHdl           IDeleteCommandHandler
+	createCmdHdl             ICreateCommandHandler
+	createFromCartCmdHdl     ICreateFromCartCommandHandler
+	listQueryHdl             IListQueryHandler
+	getDetailQueryHdl        IGetDetailQueryHandler
+	timelineQueryHdl         IGetTimelineQueryHandler
+	trackQueryHdl            ITrackOrderQueryHandler
+	updateOrderStateCmdHdl   IUpdateOrderStateCommandHandler
+	assignShipperCmdHdl      IAssignShipperCommandHandler
+	deleteCmdHdl             IDeleteCommandHandler
+	deliveryQuoteHdl         IDeliveryQuoteQueryHandler
+	refundService            IRefundService
+	requeueOutboxEventCmdHdl IRequeueOutboxEventCommandHandler
+	ownerChecker             middleware.IOwnershipChecker
+	watcherChecker           middleware.IOwnershipChecker
 }
 
 func NewOrderHttpController(
@@ -50,27 +85,60 @@ func NewOrderHttpController(
 	createFromCartCmdHdl ICreateFromCartCommandHandler,
 	listQueryHdl IListQueryHandler,
 	getDetailQueryHdl IGetDetailQueryHandler,
+	timelineQueryHdl IGetTimelineQueryHandler,
+	trackQueryHdl ITrackOrderQueryHandler,
 	updateOrderStateCmdHdl IUpdateOrderStateCommandHandler,
+	assignShipperCmdHdl IAssignShipperCommandHandler,
 	deleteCmdHdl IDeleteCommandHandler,
+	deliveryQuoteHdl IDeliveryQuoteQueryHandler,
+	refundService IRefundService,
+	requeueOutboxEventCmdHdl IRequeueOutboxEventCommandHandler,
+	ownerChecker middleware.IOwnershipChecker,
+	watcherChecker middleware.IOwnershipChecker,
 ) *OrderHttpController {
 	return &OrderHttpController{
-		createCmdHdl:           createCmdHdl,
-		createFromCartCmdHdl:   createFromCartCmdHdl,
-		listQueryHdl:           listQueryHdl,
-		getDetailQueryHdl:      getDetailQueryHdl,
-		updateOrderStateCmdHdl: updateOrderStateCmdHdl,
-		deleteCmdHdl:           deleteCmdHdl,
+		createCmdHdl:             createCmdHdl,
+		createFromCartCmdHdl:     createFromCartCmdHdl,
+		listQueryHdl:             listQueryHdl,
+		getDetailQueryHdl:        getDetailQueryHdl,
+		timelineQueryHdl:         timelineQueryHdl,
+		trackQueryHdl:            trackQueryHdl,
+		updateOrderStateCmdHdl:   updateOrderStateCmdHdl,
+		assignShipperCmdHdl:      assignShipperCmdHdl,
+		deleteCmdHdl:             deleteCmdHdl,
+		deliveryQuoteHdl:         deliveryQuoteHdl,
+		refundService:            refundService,
+		requeueOutboxEventCmdHdl: requeueOutboxEventCmdHdl,
+		ownerChecker:             ownerChecker,
+		watcherChecker:           watcherChecker,
 	}
 }
 
-func (ctrl *OrderHttpController) SetupRoutes(g *gin.RouterGroup) {
-	introspectRpcClient := sharedrpc.NewIntrospectRpcClient(os.Getenv("USER_SERVICE_URL"))
+func (ctrl *OrderHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	auth := mldProvider.Auth()
+	isAdmin := mldProvider.RequireRole(datatype.RoleAdmin)
 
 	// Order routes
-	g.POST("", middleware.Auth(introspectRpcClient), ctrl.CreateOrderAPI)
-	g.POST("/from-cart", middleware.Auth(introspectRpcClient), ctrl.CreateOrderFromCartAPI)
-	g.GET("", ctrl.ListOrdersAPI)
-	g.GET("/:id", ctrl.GetOrderDetailAPI)
-	g.DELETE("/:id", ctrl.DeleteOrderAPI)
-	g.PATCH("/:id/state", middleware.Auth(introspectRpcClient), ctrl.UpdateOrderStateAPI)
+	g.POST("", isAdmin, ctrl.CreateOrderAPI)
+	g.POST("/from-cart", auth, ctrl.CreateOrderFromCartAPI)
+	g.POST("/quote", auth, ctrl.QuoteDeliveryAPI)
+	g.GET("", mldProvider.RequireOwner(middleware.FromQuery("userId"), middleware.SelfChecker), ctrl.ListOrdersAPI)
+	g.GET("/:id", mldProvider.RequireOwner(middleware.FromParam("id"), ctrl.ownerChecker), ctrl.GetOrderDetailAPI)
+	g.GET("/:id/timeline", mldProvider.RequireOwner(middleware.FromParam("id"), ctrl.ownerChecker), ctrl.GetOrderTimelineAPI)
+	g.GET("/:id/track", mldProvider.RequireOwner(middleware.FromParam("id"), ctrl.watcherChecker), ctrl.TrackOrderAPI)
+	g.DELETE("/:id", isAdmin, ctrl.DeleteOrderAPI)
+	g.PATCH("/:id/state", auth, ctrl.UpdateOrderStateAPI)
+
+	// Refund routes (admin)
+	g.POST("/:id/refunds", isAdmin, ctrl.CreateRefundAPI)
+	g.GET("/admin/refunds", isAdmin, ctrl.ListRefundsAPI)
+	g.POST("/admin/refunds/:refundId/retry", isAdmin, ctrl.RetryRefundAPI)
+
+	// Outbox routes (admin), the events given up by the relay are queued again
+	g.POST("/admin/outbox/:eventId/requeue", isAdmin, ctrl.RequeueOutboxEventAPI)
+}
+
+// SetupRPCRoutes registers the RPC of the order module, called by the other modules
+func (ctrl *OrderHttpController) SetupRPCRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	g.POST("/rpc/orders/:id/assign-shipper", mldProvider.RequireInternal(), ctrl.RPCAssignShipper)
 }
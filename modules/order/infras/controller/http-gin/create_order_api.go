@@ -16,14 +16,8 @@ func (ctrl *OrderHttpController) CreateOrderAPI(c *gin.Context) {
 		panic(datatype.ErrBadRequest.WithError(err.Error()))
 	}
 
-	// Get user ID and role from requester context
+	// Get user ID from requester context, the ADMIN role is checked by the RequireRole middleware
 	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
-
-	// Check if user has ADMIN role - only ADMIN can use this API
-	if requester.GetRole() != string(datatype.RoleAdmin) {
-		panic(datatype.ErrForbidden.WithError("only administrators can create orders manually"))
-	}
-
 	req.UserID = requester.Subject().String()
 
 	// Call business logic in service
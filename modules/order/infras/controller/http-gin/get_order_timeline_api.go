@@ -0,0 +1,23 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// GetOrderTimelineAPI returns the state changes of an order with the time spent between them
+func (ctrl *OrderHttpController) GetOrderTimelineAPI(c *gin.Context) {
+	orderID := c.Param("id")
+	if orderID == "" {
+		panic(datatype.ErrBadRequest.WithError("order ID is required"))
+	}
+
+	timeline, err := ctrl.timelineQueryHdl.Execute(c.Request.Context(), orderID)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": timeline})
+}
@@ -0,0 +1,41 @@
+package httpgin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+)
+
+type IOutboxMetricsQueryHandler interface {
+	Metrics(ctx context.Context) (*service.OutboxMetrics, error)
+}
+
+// OutboxMetricsAPI exposes the outbox relay metrics in the Prometheus text format
+func OutboxMetricsAPI(hdl IOutboxMetricsQueryHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		metrics, err := hdl.Metrics(c.Request.Context())
+		if err != nil {
+			panic(err)
+		}
+
+		var b strings.Builder
+		writeMetric(&b, "outbox_pending_events", "gauge", "Events waiting to be published.", float64(metrics.Pending))
+		writeMetric(&b, "outbox_failed_events", "gauge", "Events given up after too many attempts.", float64(metrics.Failed))
+		writeMetric(&b, "outbox_lag_seconds", "gauge", "Age of the oldest pending event.", metrics.LagSeconds)
+		writeMetric(&b, "outbox_published_total", "counter", "Events published by this relay.", float64(metrics.Published))
+		writeMetric(&b, "outbox_publish_errors_total", "counter", "Failed publish attempts of this relay.", float64(metrics.PublishErrors))
+		writeMetric(&b, "outbox_gave_up_total", "counter", "Events given up by this relay.", float64(metrics.GaveUp))
+
+		c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+	}
+}
+
+func writeMetric(b *strings.Builder, name string, metricType string, help string, value float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", name, metricType)
+	fmt.Fprintf(b, "%s %g\n", name, value)
+}
@@ -0,0 +1,26 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// QuoteDeliveryAPI returns the delivery fee, distance and ETA for a checkout
+func (ctrl *OrderHttpController) QuoteDeliveryAPI(c *gin.Context) {
+	var req service.DeliveryQuoteReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	// Call business logic in service
+	result, err := ctrl.deliveryQuoteHdl.Execute(c.Request.Context(), &req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
@@ -0,0 +1,55 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// CreateRefundAPI creates a full or partial refund for an order. Restricted to ADMIN users.
+func (ctrl *OrderHttpController) CreateRefundAPI(c *gin.Context) {
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	var req service.RefundCreateDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	req.OrderID = c.Param("id")
+	req.CreatedBy = requester.Subject().String()
+
+	if err := ctrl.refundService.Execute(c.Request.Context(), &req); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": gin.H{"refundId": req.RefundID}})
+}
+
+// ListRefundsAPI lists the refund ledger. Restricted to ADMIN users.
+func (ctrl *OrderHttpController) ListRefundsAPI(c *gin.Context) {
+	var req service.RefundListReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	result, err := ctrl.refundService.ListRefunds(c.Request.Context(), req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RetryRefundAPI processes a failed refund again. Restricted to ADMIN users.
+func (ctrl *OrderHttpController) RetryRefundAPI(c *gin.Context) {
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	refund, err := ctrl.refundService.RetryRefund(c.Request.Context(), c.Param("refundId"), requester.Subject().String())
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": refund})
+}
@@ -0,0 +1,17 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequeueOutboxEventAPI queues an order event given up by the outbox relay again. Restricted to ADMIN users.
+func (ctrl *OrderHttpController) RequeueOutboxEventAPI(c *gin.Context) {
+	evt, err := ctrl.requeueOutboxEventCmdHdl.Execute(c.Request.Context(), c.Param("eventId"))
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": evt})
+}
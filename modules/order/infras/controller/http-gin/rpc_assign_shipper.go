@@ -0,0 +1,34 @@
+package httpgin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// RPCAssignShipper assigns the shipper who accepted a dispatch offer, used by the dispatch engine.
+// The status of the error tells whether the order is no longer assignable (4xx) or the call may be retried.
+func (ctrl *OrderHttpController) RPCAssignShipper(c *gin.Context) {
+	var req service.AssignShipperReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.OrderID = c.Param("id")
+
+	if err := ctrl.assignShipperCmdHdl.Execute(c.Request.Context(), &req); err != nil {
+		status := http.StatusInternalServerError
+		var appErr *datatype.DefaultError
+		if errors.As(err, &appErr) {
+			status = appErr.StatusCode()
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
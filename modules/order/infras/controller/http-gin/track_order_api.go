@@ -0,0 +1,33 @@
+package httpgin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+)
+
+// TrackOrderAPI streams the tracking of an order as Server-Sent Events: its state, the location of its shipper and the ETA.
+// A "tracking" event is sent whenever they change, the stream ends once the order is delivered or cancelled.
+func (ctrl *OrderHttpController) TrackOrderAPI(c *gin.Context) {
+	streaming := false
+
+	err := ctrl.trackQueryHdl.Stream(c.Request.Context(), c.Param("id"), func(res *service.OrderTrackRes) error {
+		if !streaming {
+			c.Header("Cache-Control", "no-cache")
+			c.Header("Connection", "keep-alive")
+			c.Header("X-Accel-Buffering", "no") // Not buffered by nginx
+			streaming = true
+		}
+
+		c.SSEvent("tracking", res)
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if !streaming {
+			panic(err)
+		}
+		// The response has started, the error ends the stream
+		c.SSEvent("error", gin.H{"error": err.Error()})
+		c.Writer.Flush()
+	}
+}
@@ -10,15 +10,15 @@ import (
 
 // UpdateOrderStateRequest represents the unified request to update order state
 // This single endpoint can handle:
-// 1. State transitions (preparing, on_the_way, delivered, cancel)
-// 2. Shipper assignment (via shipperId field)
+// 1. State transitions allowed to the requester by the order state machine
+// 2. Shipper assignment (via shipperId field) until the order is ready for pickup
 // 3. Payment status updates (via paymentStatus field)
 // 4. Order cancellation (via cancellationReason field)
 type UpdateOrderStateRequest struct {
 	NewState           string  `json:"newState" binding:"required"`  // Required: target state
 	ShipperID          *string `json:"shipperId,omitempty"`          // Optional: assign shipper
 	PaymentStatus      *string `json:"paymentStatus,omitempty"`      // Optional: update payment status
-	CancellationReason *string `json:"cancellationReason,omitempty"` // Required when newState is "cancel"
+	CancellationReason *string `json:"cancellationReason,omitempty"` // Required when newState is "cancel" or "restaurant_rejected"
 }
 
 // UpdateOrderStateAPI handles order state transitions, assign shipper, update payment status and cancel an order
@@ -44,6 +44,7 @@ func (ctrl *OrderHttpController) UpdateOrderStateAPI(c *gin.Context) {
 		ShipperID:          req.ShipperID,
 		PaymentStatus:      req.PaymentStatus,
 		UpdatedBy:          updatedBy,
+		UpdatedByRole:      requester.GetRole(),
 		CancellationReason: req.CancellationReason,
 	}
 
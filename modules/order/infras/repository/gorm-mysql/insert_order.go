@@ -8,7 +8,7 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
-func (r *OrderRepo) Insert(ctx context.Context, order *ordermodel.Order, orderTracking *ordermodel.OrderTracking, orderDetails []ordermodel.OrderDetail) error {
+func (r *OrderRepo) Insert(ctx context.Context, order *ordermodel.Order, orderTracking *ordermodel.OrderTracking, orderDetails []ordermodel.OrderDetail, events ...*ordermodel.OutboxEvent) error {
 	_, dbSpanCrtOrder := otel.Tracer("").Start(ctx, "Insert order, order tracking, order detail")
 	defer dbSpanCrtOrder.End()
 
@@ -32,6 +32,17 @@ func (r *OrderRepo) Insert(ctx context.Context, order *ordermodel.Order, orderTr
 		return errors.WithStack(err)
 	}
 
+	// The creation is the first step of the state history
+	history, err := ordermodel.NewOrderStateHistory(orderTracking, "")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(history).Error; err != nil {
+		tx.Rollback()
+		return errors.WithStack(err)
+	}
+
 	// Insert order details
 	for _, detail := range orderDetails {
 		if err := tx.Create(&detail).Error; err != nil {
@@ -40,6 +51,12 @@ func (r *OrderRepo) Insert(ctx context.Context, order *ordermodel.Order, orderTr
 		}
 	}
 
+	// Insert outbox events, they are published by the outbox relay once committed
+	if err := insertOutboxEvents(tx, events); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
 		return errors.WithStack(err)
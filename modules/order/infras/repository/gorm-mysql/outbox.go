@@ -0,0 +1,120 @@
+package ordergormmysql
+
+import (
+	"context"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// insertOutboxEvents writes the events in the transaction of the order change
+func insertOutboxEvents(tx *gorm.DB, events []*ordermodel.OutboxEvent) error {
+	for _, evt := range events {
+		if evt == nil {
+			continue
+		}
+		if err := tx.Create(evt).Error; err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// ClaimPendingOutboxEvents returns the due events and pushes their next attempt by lease,
+// so that another relay does not publish them while they are being handled.
+// An event waits while an earlier event of its order is not sent, also when that one failed,
+// so that the consumers see the changes of an order in sequence.
+func (r *OrderRepo) ClaimPendingOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]ordermodel.OutboxEvent, error) {
+	db := r.dbCtx.GetMainConnection()
+	now := time.Now().UTC()
+
+	var events []ordermodel.OutboxEvent
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", ordermodel.OutboxStatusPending, now).
+			Where(`NOT EXISTS (SELECT 1 FROM outbox_events AS earlier
+				WHERE earlier.aggregate_id = outbox_events.aggregate_id AND earlier.status <> ?
+				AND (earlier.created_at < outbox_events.created_at OR (earlier.created_at = outbox_events.created_at AND earlier.id < outbox_events.id)))`,
+				ordermodel.OutboxStatusSent).
+			Order("created_at ASC").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return errors.WithStack(err)
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(events))
+		for i, evt := range events {
+			ids[i] = evt.ID
+		}
+		if err := tx.Model(&ordermodel.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *OrderRepo) FindOutboxEventById(ctx context.Context, id string) (*ordermodel.OutboxEvent, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var evt ordermodel.OutboxEvent
+	if err := db.Where("id = ?", id).First(&evt).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ordermodel.ErrOutboxEventNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &evt, nil
+}
+
+func (r *OrderRepo) UpdateOutboxEvent(ctx context.Context, evt *ordermodel.OutboxEvent) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.WithContext(ctx).Save(evt).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *OrderRepo) GetOutboxStats(ctx context.Context) (*ordermodel.OutboxStats, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var stats ordermodel.OutboxStats
+	if err := db.Model(&ordermodel.OutboxEvent{}).
+		Where("status = ?", ordermodel.OutboxStatusPending).
+		Count(&stats.Pending).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := db.Model(&ordermodel.OutboxEvent{}).
+		Where("status = ?", ordermodel.OutboxStatusFailed).
+		Count(&stats.Failed).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if stats.Pending > 0 {
+		var oldest ordermodel.OutboxEvent
+		if err := db.Where("status = ?", ordermodel.OutboxStatusPending).
+			Order("created_at ASC").
+			First(&oldest).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WithStack(err)
+		}
+		if !oldest.CreatedAt.IsZero() {
+			stats.OldestPendingAt = &oldest.CreatedAt
+		}
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,26 @@
+package ordergormmysql
+
+import (
+	"context"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/pkg/errors"
+)
+
+func (r *OrderRepo) InsertPaymentTransaction(ctx context.Context, txn *ordermodel.PaymentTransaction) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.Create(txn).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *OrderRepo) FindPaymentTransactions(ctx context.Context, orderID string) ([]ordermodel.PaymentTransaction, error) {
+	db := r.dbCtx.GetMainConnection()
+
+	var txns []ordermodel.PaymentTransaction
+	if err := db.Where("order_id = ?", orderID).Order("created_at ASC").Find(&txns).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return txns, nil
+}
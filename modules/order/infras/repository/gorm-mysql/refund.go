@@ -0,0 +1,165 @@
+package ordergormmysql
+
+import (
+	"context"
+	"math"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *OrderRepo) InsertRefund(ctx context.Context, refund *ordermodel.Refund) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.Create(refund).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// ClaimRefund records a new or retried refund of the order read in fromState, with the payment status of tracking.
+// The order row is locked so that concurrent refunds are summed one after the other. It returns
+// ordermodel.ErrRefundAmountExceeded when the held refunds and this one exceed the total of the order,
+// and ordermodel.ErrOrderStateChanged when the stored state is not fromState anymore.
+func (r *OrderRepo) ClaimRefund(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, fromState string, refund *ordermodel.Refund) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	// Start a transaction
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	var locked ordermodel.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "total_price").
+		Where("id = ?", order.ID).
+		First(&locked).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ordermodel.ErrOrderNotFound
+		}
+		return errors.WithStack(err)
+	}
+
+	storedState, err := lockState(tx, tracking.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if storedState != fromState {
+		tx.Rollback()
+		return ordermodel.ErrOrderStateChanged
+	}
+
+	// A failed refund does not hold its amount, a retried one is claimed again
+	var refunded float64
+	if err := tx.Model(&ordermodel.Refund{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("order_id = ? AND id <> ? AND status IN ?", order.ID, refund.ID, ordermodel.RefundHeldStatuses).
+		Scan(&refunded).Error; err != nil {
+		tx.Rollback()
+		return errors.WithStack(err)
+	}
+	if math.Round((refunded+refund.Amount)*100) > math.Round(locked.TotalPrice*100) {
+		tx.Rollback()
+		return ordermodel.ErrRefundAmountExceeded
+	}
+
+	// A retried refund is already in the ledger
+	if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(refund).Error; err != nil {
+		tx.Rollback()
+		return errors.WithStack(err)
+	}
+
+	if err := tx.Save(tracking).Error; err != nil {
+		tx.Rollback()
+		return errors.WithStack(err)
+	}
+
+	// Commit the transaction
+	if err := tx.Commit().Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// UpdatePaymentStatus saves the payment status of tracking whatever its state, it records the outcome of a refund
+func (r *OrderRepo) UpdatePaymentStatus(ctx context.Context, tracking *ordermodel.OrderTracking) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Model(&ordermodel.OrderTracking{}).
+		Where("id = ?", tracking.ID).
+		Updates(map[string]any{
+			"payment_status": tracking.PaymentStatus,
+			"updated_by":     tracking.UpdatedBy,
+			"updated_at":     tracking.UpdatedAt,
+		}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *OrderRepo) UpdateRefund(ctx context.Context, refund *ordermodel.Refund) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.Save(refund).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *OrderRepo) FindRefundById(ctx context.Context, id string) (*ordermodel.Refund, error) {
+	db := r.dbCtx.GetMainConnection()
+
+	var refund ordermodel.Refund
+	if err := db.Where("id = ?", id).First(&refund).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ordermodel.ErrRefundNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &refund, nil
+}
+
+func (r *OrderRepo) FindRefundsByOrderId(ctx context.Context, orderID string) ([]ordermodel.Refund, error) {
+	db := r.dbCtx.GetMainConnection()
+
+	var refunds []ordermodel.Refund
+	if err := db.Where("order_id = ?", orderID).Order("created_at ASC").Find(&refunds).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return refunds, nil
+}
+
+func (r *OrderRepo) ListRefunds(ctx context.Context, req service.RefundListReq) ([]ordermodel.Refund, int64, error) {
+	db := r.dbCtx.GetMainConnection()
+	var refunds []ordermodel.Refund
+	var total int64
+
+	query := db.Model(&ordermodel.Refund{})
+
+	// Apply filters
+	if req.OrderID != nil {
+		query = query.Where("order_id = ?", *req.OrderID)
+	}
+
+	if req.Status != nil {
+		query = query.Where("status = ?", *req.Status)
+	}
+
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	// Apply pagination
+	if err := query.Offset((req.Page - 1) * req.Limit).Limit(req.Limit).
+		Order("created_at DESC").
+		Find(&refunds).Error; err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	return refunds, total, nil
+}
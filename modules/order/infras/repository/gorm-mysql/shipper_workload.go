@@ -0,0 +1,59 @@
+package ordergormmysql
+
+import (
+	"context"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CountActiveByShipperId counts the orders of the shipper which are neither delivered nor cancelled
+func (r *OrderRepo) CountActiveByShipperId(ctx context.Context, shipperId string) (int, error) {
+	return countActiveByShipperId(r.dbCtx.GetMainConnection().WithContext(ctx), shipperId, "")
+}
+
+// checkShipperLimit returns ordermodel.ErrShipperAtCapacity when the shipper newly assigned to the order
+// already has ShipperLimit active orders. The workload row of the shipper stays locked until the transaction ends.
+func checkShipperLimit(tx *gorm.DB, order *ordermodel.Order) error {
+	if order.ShipperID == nil || order.ShipperLimit <= 0 {
+		return nil
+	}
+
+	// The upsert takes the row lock right away, also for the first order of the shipper
+	workload := ordermodel.ShipperWorkload{ShipperID: *order.ShipperID, UpdatedAt: time.Now()}
+	if err := tx.Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"updated_at"})}).
+		Create(&workload).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	active, err := countActiveByShipperId(tx, *order.ShipperID, order.ID)
+	if err != nil {
+		return err
+	}
+	if active >= order.ShipperLimit {
+		return ordermodel.ErrShipperAtCapacity
+	}
+	return nil
+}
+
+// countActiveByShipperId counts the active orders of the shipper, but the excluded one
+func countActiveByShipperId(db *gorm.DB, shipperId string, excludedOrderId string) (int, error) {
+	finishedStates := []string{service.StateDelivered, service.StateCancelled, service.StateRestaurantRejected}
+
+	query := db.Model(&ordermodel.Order{}).
+		Joins("JOIN order_trackings ON order_trackings.order_id = orders.id").
+		Where("orders.shipper_id = ? AND order_trackings.state NOT IN ?", shipperId, finishedStates)
+	if excludedOrderId != "" {
+		query = query.Where("orders.id <> ?", excludedOrderId)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return int(count), nil
+}
@@ -0,0 +1,49 @@
+package ordergormmysql
+
+import (
+	"context"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lockState returns the stored state of the tracking, locked until the end of the transaction
+func lockState(tx *gorm.DB, trackingId string) (string, error) {
+	var stored ordermodel.OrderTracking
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("state").
+		Where("id = ?", trackingId).
+		First(&stored).Error; err != nil {
+		return "", errors.WithStack(err)
+	}
+	return stored.State, nil
+}
+
+// insertStateHistory appends a history row when the state of the tracking differs from the stored one
+func insertStateHistory(tx *gorm.DB, tracking *ordermodel.OrderTracking, storedState string) error {
+	if storedState == tracking.State {
+		return nil
+	}
+
+	history, err := ordermodel.NewOrderStateHistory(tracking, storedState)
+	if err != nil {
+		return err
+	}
+	if err := tx.Create(history).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindStateHistory returns the state changes of an order, oldest first
+func (r *OrderRepo) FindStateHistory(ctx context.Context, orderId string) ([]ordermodel.OrderStateHistory, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var history []ordermodel.OrderStateHistory
+	if err := db.Where("order_id = ?", orderId).Order("created_at ASC").Find(&history).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return history, nil
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/pkg/errors"
 )
 
-func (r *OrderRepo) Update(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking) error {
-	db := r.dbCtx.GetMainConnection()
+// Update saves the order read in fromState. It returns ordermodel.ErrOrderStateChanged when the stored state
+// is not fromState anymore, so that a stale writer does not overwrite a newer state.
+func (r *OrderRepo) Update(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, fromState string, events ...*ordermodel.OutboxEvent) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
 
 	// Start a transaction
 	tx := db.Begin()
@@ -16,18 +18,47 @@ func (r *OrderRepo) Update(ctx context.Context, order *ordermodel.Order, trackin
 		return errors.WithStack(err)
 	}
 
+	// The stored state is locked so that concurrent changes are checked and recorded in order
+	storedState, err := lockState(tx, tracking.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if storedState != fromState {
+		tx.Rollback()
+		return ordermodel.ErrOrderStateChanged
+	}
+
+	// A newly assigned shipper is counted with their workload locked, concurrent assignments wait for this one
+	if err := checkShipperLimit(tx, order); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Update order
 	if err := tx.Save(order).Error; err != nil {
 		tx.Rollback()
 		return errors.WithStack(err)
 	}
 
+	// Record a state change in the history
+	if err := insertStateHistory(tx, tracking, storedState); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Update order tracking
 	if err := tx.Save(tracking).Error; err != nil {
 		tx.Rollback()
 		return errors.WithStack(err)
 	}
 
+	// Insert outbox events, they are published by the outbox relay once committed
+	if err := insertOutboxEvents(tx, events); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
 		return errors.WithStack(err)
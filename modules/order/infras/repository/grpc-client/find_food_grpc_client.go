@@ -58,6 +58,10 @@ func (c *FoodGRPCClient) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uu
 			RestaurantId: uuid.MustParse(r.RestaurantId),
 			Status:       r.Status,
 		}
+		if r.StockTracked {
+			stock := int(r.Stock)
+			v.Stock = &stock
+		}
 		foodsMap[uuidId] = v
 	}
 	return foodsMap, nil
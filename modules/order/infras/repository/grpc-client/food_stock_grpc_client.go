@@ -0,0 +1,39 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/gen/proto/food"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c *FoodGRPCClient) ReserveStock(ctx context.Context, orderID string, items map[uuid.UUID]int) error {
+	stockItems := make([]*food.StockItem, 0, len(items))
+	for foodID, quantity := range items {
+		stockItems = append(stockItems, &food.StockItem{FoodId: foodID.String(), Quantity: int32(quantity)})
+	}
+
+	_, err := c.client.ReserveStock(ctx, &food.ReserveStockRequest{OrderId: orderID, Items: stockItems})
+	switch status.Code(err) {
+	case codes.FailedPrecondition:
+		return ordermodel.ErrInventoryInsufficient
+	case codes.InvalidArgument:
+		return ordermodel.ErrFoodNotAvailable
+	case codes.NotFound:
+		return ordermodel.ErrFoodNotFound
+	}
+	return err
+}
+
+func (c *FoodGRPCClient) CommitStock(ctx context.Context, orderID string) error {
+	_, err := c.client.CommitStock(ctx, &food.StockReservationRequest{OrderId: orderID})
+	return err
+}
+
+func (c *FoodGRPCClient) ReleaseStock(ctx context.Context, orderID string) error {
+	_, err := c.client.ReleaseStock(ctx, &food.StockReservationRequest{OrderId: orderID})
+	return err
+}
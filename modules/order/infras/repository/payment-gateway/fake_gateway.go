@@ -0,0 +1,166 @@
+package paymentgateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Default test cards, same numbers as the Stripe test cards
+var (
+	DefaultDeclineCards = []string{"4000000000000002"}
+	DefaultTimeoutCards = []string{"4000000000000119"}
+)
+
+type fakeAuthorization struct {
+	amount   float64
+	captured float64
+	refunded float64
+	voided   bool
+}
+
+// FakeGateway is a deterministic in-process payment gateway for dev and tests.
+// Cards whose last 4 digits match a decline entry are declined, cards matching a timeout entry
+// wait for the configured timeout (or the context deadline) and time out.
+type FakeGateway struct {
+	declineCards []string
+	timeoutCards []string
+	timeout      time.Duration
+
+	mu             sync.Mutex
+	seq            int
+	authorizations map[string]*fakeAuthorization
+	references     map[string]string // capture reference -> authorization reference
+}
+
+func NewFakeGateway(cfg datatype.PaymentConfig) *FakeGateway {
+	declineCards := cfg.FakeDeclineCards
+	if len(declineCards) == 0 {
+		declineCards = DefaultDeclineCards
+	}
+	timeoutCards := cfg.FakeTimeoutCards
+	if len(timeoutCards) == 0 {
+		timeoutCards = DefaultTimeoutCards
+	}
+
+	return &FakeGateway{
+		declineCards:   declineCards,
+		timeoutCards:   timeoutCards,
+		authorizations: make(map[string]*fakeAuthorization),
+		references:     make(map[string]string),
+	}
+}
+
+// WithTimeout sets how long a timeout card blocks before failing
+func (g *FakeGateway) WithTimeout(timeout time.Duration) *FakeGateway {
+	g.timeout = timeout
+	return g
+}
+
+func (g *FakeGateway) Authorize(ctx context.Context, req service.GatewayAuthorizeRequest) (*service.GatewayResult, error) {
+	if req.Amount <= 0 {
+		return nil, ordermodel.ErrPaymentAmountInvalid
+	}
+
+	if req.Card == nil {
+		return nil, ordermodel.ErrCardIdRequired
+	}
+
+	if matchCard(req.Card.Last4, g.timeoutCards) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(g.timeout):
+		}
+		return nil, ordermodel.ErrPaymentGatewayTimeout
+	}
+
+	if matchCard(req.Card.Last4, g.declineCards) {
+		return nil, ordermodel.ErrPaymentDeclined
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ref := g.nextRef("auth")
+	g.authorizations[ref] = &fakeAuthorization{amount: req.Amount}
+	return &service.GatewayResult{Reference: ref}, nil
+}
+
+func (g *FakeGateway) Capture(ctx context.Context, reference string, amount float64) (*service.GatewayResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	auth, ok := g.authorizations[reference]
+	if !ok || auth.voided {
+		return nil, ordermodel.ErrPaymentReferenceNotFound
+	}
+
+	if amount <= 0 || auth.captured+amount > auth.amount {
+		return nil, ordermodel.ErrPaymentAmountInvalid
+	}
+
+	auth.captured += amount
+	ref := g.nextRef("capture")
+	g.references[ref] = reference
+	return &service.GatewayResult{Reference: ref}, nil
+}
+
+func (g *FakeGateway) Void(ctx context.Context, reference string) (*service.GatewayResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	auth, ok := g.authorizations[reference]
+	if !ok || auth.captured > 0 {
+		return nil, ordermodel.ErrPaymentReferenceNotFound
+	}
+
+	auth.voided = true
+	return &service.GatewayResult{Reference: g.nextRef("void")}, nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, reference string, amount float64) (*service.GatewayResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	authRef, ok := g.references[reference]
+	if !ok {
+		// Captures made before a restart are unknown but still refundable
+		if strings.HasPrefix(reference, "fake_capture_") && amount > 0 {
+			return &service.GatewayResult{Reference: g.nextRef("refund")}, nil
+		}
+		return nil, ordermodel.ErrPaymentReferenceNotFound
+	}
+
+	auth := g.authorizations[authRef]
+	if amount <= 0 || auth.refunded+amount > auth.captured {
+		return nil, ordermodel.ErrPaymentAmountInvalid
+	}
+
+	auth.refunded += amount
+	return &service.GatewayResult{Reference: g.nextRef("refund")}, nil
+}
+
+// nextRef returns a predictable reference, the caller must hold the lock
+func (g *FakeGateway) nextRef(kind string) string {
+	g.seq++
+	return fmt.Sprintf("fake_%s_%06d", kind, g.seq)
+}
+
+// matchCard compares the last 4 digits with test cards given as full numbers or last digits
+func matchCard(last4 string, cards []string) bool {
+	if last4 == "" {
+		return false
+	}
+	for _, c := range cards {
+		if strings.HasSuffix(strings.ReplaceAll(c, " ", ""), last4) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package paymentgateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func TestFakeGateway_Authorize(t *testing.T) {
+	tests := []struct {
+		name    string
+		last4   string
+		wantErr error
+	}{
+		{name: "TC 1: approved", last4: "4242", wantErr: nil},
+		{name: "TC 2: declined", last4: "0002", wantErr: ordermodel.ErrPaymentDeclined},
+		{name: "TC 3: timeout", last4: "0119", wantErr: ordermodel.ErrPaymentGatewayTimeout},
+		{name: "TC 4: configured decline by last 4 digits", last4: "4444", wantErr: ordermodel.ErrPaymentDeclined},
+	}
+	g := NewFakeGateway(datatype.PaymentConfig{FakeDeclineCards: []string{"4000000000000002", "4444"}})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := g.Authorize(context.Background(), service.GatewayAuthorizeRequest{
+				OrderID: "order-1",
+				Amount:  100000,
+				Card:    &ordermodel.Card{Last4: tt.last4},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FakeGateway.Authorize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFakeGateway_CaptureAndRefund(t *testing.T) {
+	g := NewFakeGateway(datatype.PaymentConfig{})
+	ctx := context.Background()
+
+	auth, err := g.Authorize(ctx, service.GatewayAuthorizeRequest{Amount: 100, Card: &ordermodel.Card{Last4: "4242"}})
+	if err != nil {
+		t.Fatalf("FakeGateway.Authorize() error = %v", err)
+	}
+
+	if _, err := g.Capture(ctx, auth.Reference, 150); !errors.Is(err, ordermodel.ErrPaymentAmountInvalid) {
+		t.Errorf("FakeGateway.Capture() over authorized amount error = %v", err)
+	}
+
+	capture, err := g.Capture(ctx, auth.Reference, 100)
+	if err != nil {
+		t.Fatalf("FakeGateway.Capture() error = %v", err)
+	}
+
+	if _, err := g.Void(ctx, auth.Reference); err == nil {
+		t.Errorf("FakeGateway.Void() of a captured authorization should fail")
+	}
+
+	if _, err := g.Refund(ctx, capture.Reference, 60); err != nil {
+		t.Errorf("FakeGateway.Refund() partial error = %v", err)
+	}
+	if _, err := g.Refund(ctx, capture.Reference, 60); !errors.Is(err, ordermodel.ErrPaymentAmountInvalid) {
+		t.Errorf("FakeGateway.Refund() over captured amount error = %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package rpcclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"resty.dev/v3"
+)
+
+type ShipperLocationRPCClient struct {
+	dispatchServiceURL string
+	internalToken      string
+}
+
+func NewShipperLocationRPCClient(dispatchServiceURL string, internalToken string) *ShipperLocationRPCClient {
+	return &ShipperLocationRPCClient{dispatchServiceURL: dispatchServiceURL, internalToken: internalToken}
+}
+
+// FindByShipperId returns the last locations of the shipper, nil when the shipper has not reported any lately
+func (c *ShipperLocationRPCClient) FindByShipperId(ctx context.Context, shipperId uuid.UUID) (*ordermodel.ShipperLocationTrail, error) {
+	client := resty.New()
+
+	type ResponseDTO struct {
+		Data ordermodel.ShipperLocationTrail `json:"data"`
+	}
+
+	var response ResponseDTO
+
+	url := fmt.Sprintf("%s/shippers/find-location", c.dispatchServiceURL)
+
+	resp, err := client.R().
+		SetContext(ctx).
+		SetHeader(datatype.HeaderInternalToken, c.internalToken).
+		SetBody(map[string]interface{}{
+			"shipperId": shipperId,
+		}).
+		SetResult(&response).
+		Post(url)
+
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("dispatch service responded %s: %s", resp.Status(), resp.String())
+	}
+	return &response.Data, nil
+}
@@ -0,0 +1,52 @@
+package rpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"resty.dev/v3"
+)
+
+type ShipperRPCClient struct {
+	shipperServiceURL string
+}
+
+func NewShipperRPCClient(shipperServiceURL string) *ShipperRPCClient {
+	return &ShipperRPCClient{shipperServiceURL: shipperServiceURL}
+}
+
+// FindByIds returns the shippers with their availability, users without a shipper profile are left out
+func (c *ShipperRPCClient) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]ordermodel.Shipper, error) {
+	client := resty.New()
+
+	type ResponseDTO struct {
+		Data []ordermodel.Shipper `json:"data"`
+	}
+
+	var response ResponseDTO
+
+	url := fmt.Sprintf("%s/find-by-ids", c.shipperServiceURL)
+
+	resp, err := client.R().
+		SetContext(ctx).
+		SetBody(map[string]interface{}{
+			"ids": ids,
+		}).
+		SetResult(&response).
+		Post(url)
+
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("shipper service responded %s: %s", resp.Status(), resp.String())
+	}
+
+	shippers := make(map[uuid.UUID]ordermodel.Shipper, len(response.Data))
+	for _, s := range response.Data {
+		shippers[s.Id] = s
+	}
+	return shippers, nil
+}
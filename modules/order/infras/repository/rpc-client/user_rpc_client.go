@@ -6,16 +6,18 @@ import (
 
 	"github.com/google/uuid"
 	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 
 	"resty.dev/v3"
 )
 
 type UserRPCClient struct {
 	userServiceURL string
+	internalToken  string
 }
 
-func NewUserRPCClient(userServiceURL string) *UserRPCClient {
-	return &UserRPCClient{userServiceURL: userServiceURL}
+func NewUserRPCClient(userServiceURL string, internalToken string) *UserRPCClient {
+	return &UserRPCClient{userServiceURL: userServiceURL, internalToken: internalToken}
 }
 
 func (c *UserRPCClient) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]ordermodel.User, error) {
@@ -47,3 +49,70 @@ func (c *UserRPCClient) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uui
 	}
 	return userMap, nil
 }
+
+// FindDeviceTokens returns the push notification tokens of each user
+func (c *UserRPCClient) FindDeviceTokens(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID][]string, error) {
+	client := resty.New()
+
+	type ResponseDTO struct {
+		Data []struct {
+			UserId uuid.UUID `json:"userId"`
+			Token  string    `json:"token"`
+		} `json:"data"`
+	}
+
+	var response ResponseDTO
+
+	url := fmt.Sprintf("%s/device-tokens/find-by-user-ids", c.userServiceURL)
+
+	_, err := client.R().
+		SetContext(ctx).
+		SetHeader(datatype.HeaderInternalToken, c.internalToken).
+		SetBody(map[string]interface{}{
+			"userIds": userIds,
+		}).
+		SetResult(&response).
+		Post(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokenMap := make(map[uuid.UUID][]string, len(userIds))
+	for _, t := range response.Data {
+		tokenMap[t.UserId] = append(tokenMap[t.UserId], t.Token)
+	}
+	return tokenMap, nil
+}
+
+// FindNotificationPreferences returns the notification preference of each user, defaults included
+func (c *UserRPCClient) FindNotificationPreferences(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]ordermodel.NotificationPreference, error) {
+	client := resty.New()
+
+	type ResponseDTO struct {
+		Data []ordermodel.NotificationPreference `json:"data"`
+	}
+
+	var response ResponseDTO
+
+	url := fmt.Sprintf("%s/notification-preferences/find-by-user-ids", c.userServiceURL)
+
+	_, err := client.R().
+		SetContext(ctx).
+		SetHeader(datatype.HeaderInternalToken, c.internalToken).
+		SetBody(map[string]interface{}{
+			"userIds": userIds,
+		}).
+		SetResult(&response).
+		Post(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	prefMap := make(map[uuid.UUID]ordermodel.NotificationPreference, len(response.Data))
+	for _, p := range response.Data {
+		prefMap[p.UserId] = p
+	}
+	return prefMap, nil
+}
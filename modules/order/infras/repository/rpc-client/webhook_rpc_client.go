@@ -0,0 +1,41 @@
+package rpcclient
+
+import (
+	"context"
+	"fmt"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+
+	"resty.dev/v3"
+)
+
+type WebhookRPCClient struct {
+	webhookServiceURL string
+	internalToken     string
+}
+
+func NewWebhookRPCClient(webhookServiceURL string, internalToken string) *WebhookRPCClient {
+	return &WebhookRPCClient{webhookServiceURL: webhookServiceURL, internalToken: internalToken}
+}
+
+// Publish queues the event for the webhook endpoints of its restaurant
+func (c *WebhookRPCClient) Publish(ctx context.Context, evt ordermodel.WebhookEvent) error {
+	client := resty.New()
+
+	url := fmt.Sprintf("%s/events", c.webhookServiceURL)
+
+	resp, err := client.R().
+		SetContext(ctx).
+		SetHeader(datatype.HeaderInternalToken, c.internalToken).
+		SetBody(evt).
+		Post(url)
+
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("webhook service responded %s: %s", resp.Status(), resp.String())
+	}
+	return nil
+}
@@ -2,16 +2,23 @@ package ordermodel
 
 import "github.com/google/uuid"
 
+// Payment provider constants, same values as the payment module
+const (
+	ProviderStripe = "STRIPE"
+	ProviderPaypal = "PAYPAL"
+)
+
 type Card struct {
 	ID             uuid.UUID `gorm:"column:id" json:"id"`
 	Method         string    `gorm:"column:method" json:"method"`
 	Provider       string    `gorm:"column:provider" json:"provider"`
 	CardholderName string    `gorm:"column:cardholder_name" json:"cardholderName"`
-	CardNumber     string    `gorm:"column:card_number" json:"cardNumber"`
+	VaultToken     string    `gorm:"column:vault_token" json:"-"`
+	Last4          string    `gorm:"column:last4" json:"last4"`
+	Fingerprint    string    `gorm:"column:fingerprint" json:"fingerprint"`
 	CardType       string    `gorm:"column:card_type" json:"cardType"`
 	ExpiryMonth    string    `gorm:"column:expiry_month" json:"expiryMonth"`
 	ExpiryYear     string    `gorm:"column:expiry_year" json:"expiryYear"`
-	CVV            string    `gorm:"column:cvv" json:"cvv"`
 	UserID         uuid.UUID `gorm:"column:user_id" json:"userId"`
 	Status         string    `gorm:"column:status" json:"status"`
 }
@@ -7,6 +7,9 @@ var (
 	ErrOrderIdRequired           = errors.New("order id is required")
 	ErrUserIdRequired            = errors.New("user id is required")
 	ErrTotalPriceInvalid         = errors.New("total price must be greater than 0")
+	ErrTotalPriceMismatch        = errors.New("total price does not match the computed order total")
+	ErrOrderItemsRequired        = errors.New("order must contain at least one item")
+	ErrQuantityInvalid           = errors.New("quantity must be greater than 0")
 	ErrOrderStatusInvalid        = errors.New("order status is invalid")
 	ErrOrderIsProcessed          = errors.New("order is already processed")
 	ErrOrderIsDelivered          = errors.New("order is already delivered")
@@ -24,9 +27,35 @@ var (
 	ErrInventoryInsufficient     = errors.New("insufficient inventory")
 	ErrRestaurantNotAvailable    = errors.New("restaurant is not available")
 	ErrFoodNotAvailable          = errors.New("food item is not available")
+	ErrFoodRestaurantMismatch    = errors.New("food item is not sold by the restaurant of the order")
 	ErrInvalidOrderState         = errors.New("invalid order state transition")
+	ErrStateTransitionForbidden  = errors.New("requester is not allowed to perform this order state transition")
 	ErrShipperRequired           = errors.New("shipper id is required")
+	ErrShipperAlreadyAssigned    = errors.New("another shipper is already assigned to the order")
+	ErrShipperNotAssignable      = errors.New("shipper cannot be assigned to the order in its current state")
+	ErrShipperUnavailable        = errors.New("shipper is offline, off shift or has no shipper profile")
+	ErrShipperAtCapacity         = errors.New("shipper already delivers their maximum number of orders")
 	ErrMixedRestaurantItems      = errors.New("all cart items must be from the same restaurant")
 	ErrInvalidRestaurantIdFormat = errors.New("invalid restaurant ID format")
 	ErrInvalidFoodIdFormat       = errors.New("invalid food ID format")
+	ErrFoodIdRequired            = errors.New("food id is required")
+	ErrFoodNotFound              = errors.New("food not found")
+	ErrDeliveryLocationRequired  = errors.New("delivery address lat/lng is required")
+	ErrRestaurantLocationMissing = errors.New("restaurant location is not set")
+	ErrRefundNotFound            = errors.New("refund not found")
+	ErrRefundAmountInvalid       = errors.New("refund amount must be greater than 0")
+	ErrRefundAmountExceeded      = errors.New("refund amount exceeds the refundable amount")
+	ErrRefundNotRetryable        = errors.New("only failed refunds can be retried")
+	ErrRefundRetryExhausted      = errors.New("refund has reached the maximum number of retries")
+	ErrOrderNotPaid              = errors.New("order is not paid")
+	ErrPaymentDeclined           = errors.New("payment was declined")
+	ErrPaymentGatewayTimeout     = errors.New("payment gateway timed out")
+	ErrPaymentProviderNotSupport = errors.New("payment provider is not supported")
+	ErrPaymentReferenceNotFound  = errors.New("payment reference not found")
+	ErrPaymentAmountInvalid      = errors.New("payment amount is invalid")
+	ErrOrderStateChanged         = errors.New("order state was changed meanwhile, read the order again")
+	ErrPaymentStatusForbidden    = errors.New("only admins can set the payment status")
+	ErrPaymentStatusNotSettable  = errors.New("payment status cannot be set when cancelling or once refunds are made")
+	ErrOutboxEventNotFound       = errors.New("outbox event not found")
+	ErrOutboxEventNotFailed      = errors.New("only failed outbox events can be requeued")
 )
@@ -13,4 +13,5 @@ type Food struct {
 	CategoryId   uuid.UUID
 	RestaurantId uuid.UUID
 	Status       string
+	Stock        *int // nil means stock is not tracked
 }
@@ -0,0 +1,12 @@
+package ordermodel
+
+import "github.com/google/uuid"
+
+// InboxNotification is an entry of the recipient's in-app inbox, owned by the notification module
+type InboxNotification struct {
+	UserId uuid.UUID         `json:"userId"`
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Body   string            `json:"body"`
+	Data   map[string]string `json:"data"`
+}
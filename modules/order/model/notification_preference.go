@@ -0,0 +1,61 @@
+package ordermodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationPreference is the user's choice of channels per notification event, owned by the user module
+type NotificationPreference struct {
+	UserId          uuid.UUID           `json:"userId"`
+	Channels        map[string][]string `json:"channels"` // Event -> enabled channels
+	QuietHoursStart string              `json:"quietHoursStart"`
+	QuietHoursEnd   string              `json:"quietHoursEnd"`
+	Timezone        string              `json:"timezone"`
+	Locale          string              `json:"locale"` // Language of the emails
+}
+
+// Allows tells if the event may be sent on the channel at the given time.
+// Email is never muted, SMS and push are dropped during the quiet hours, they are not sent afterwards.
+func (p NotificationPreference) Allows(event, channel string, now time.Time) bool {
+	enabled := false
+	for _, c := range p.Channels[event] {
+		if c == channel {
+			enabled = true
+			break
+		}
+	}
+	if !enabled {
+		return false
+	}
+
+	if channel == "email" {
+		return true
+	}
+	return !p.InQuietHours(now)
+}
+
+// InQuietHours tells if the time falls in the quiet hours of the user's timezone, the range may span midnight
+func (p NotificationPreference) InQuietHours(now time.Time) bool {
+	start, errStart := time.Parse("15:04", p.QuietHoursStart)
+	end, errEnd := time.Parse("15:04", p.QuietHoursEnd)
+	if errStart != nil || errEnd != nil {
+		return false
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute
+	}
+	return minute >= startMinute || minute < endMinute
+}
@@ -1,18 +1,24 @@
 package ordermodel
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
 
 // Order represents the orders table.
 type Order struct {
-	ID         string    `json:"id"`
-	UserID     string    `json:"userId"`
-	TotalPrice float64   `json:"totalPrice"`
-	ShipperID  *string   `json:"shipperId,omitempty"`
-	Status     string    `json:"status"`
-	CreatedBy  *string   `json:"createdBy,omitempty"`
-	UpdatedBy  *string   `json:"updatedBy,omitempty"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID             string         `json:"id"`
+	UserID         string         `json:"userId"`
+	TotalPrice     float64        `json:"totalPrice"`
+	PriceBreakdown datatypes.JSON `json:"priceBreakdown"`
+	ShipperID      *string        `json:"shipperId,omitempty"`
+	ShipperLimit   int            `gorm:"-" json:"-"` // Max orders of a newly assigned shipper, enforced by the repository
+	Status         string         `json:"status"`
+	CreatedBy      *string        `json:"createdBy,omitempty"`
+	UpdatedBy      *string        `json:"updatedBy,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
 }
 
 // TableName overrides the table name for Order
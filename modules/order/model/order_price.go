@@ -0,0 +1,30 @@
+package ordermodel
+
+// PriceBreakdown is the server-computed pricing of an order.
+// It is persisted on the order so the amount charged can always be explained.
+type PriceBreakdown struct {
+	Lines       []PriceLine    `json:"lines"`
+	Subtotal    float64        `json:"subtotal"`
+	Discount    float64        `json:"discount"`
+	DeliveryFee float64        `json:"deliveryFee"`
+	Total       float64        `json:"total"`
+	Delivery    *DeliveryQuote `json:"delivery,omitempty"`
+}
+
+// PriceLine is the pricing of a single order item.
+type PriceLine struct {
+	FoodID    string  `json:"foodId"`
+	Name      string  `json:"name"`
+	UnitPrice float64 `json:"unitPrice"`
+	Quantity  int     `json:"quantity"`
+	Subtotal  float64 `json:"subtotal"`
+	Discount  float64 `json:"discount"`
+	Total     float64 `json:"total"`
+}
+
+// DeliveryQuote is the delivery cost and time from a restaurant to a delivery address.
+type DeliveryQuote struct {
+	DistanceKm    float64 `json:"distanceKm"`
+	DeliveryFee   float64 `json:"deliveryFee"`
+	EstimatedTime int     `json:"estimatedTime"` // minutes
+}
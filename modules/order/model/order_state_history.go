@@ -0,0 +1,67 @@
+package ordermodel
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+)
+
+// OrderStateHistory represents the order_state_history table, one append-only row per state change
+type OrderStateHistory struct {
+	ID        string         `json:"id"`
+	OrderID   string         `json:"orderId"`
+	FromState string         `json:"fromState"` // Empty when the order is created
+	ToState   string         `json:"toState"`
+	ActorID   *string        `json:"actorId,omitempty"`
+	ActorRole string         `json:"actorRole,omitempty"`
+	Reason    string         `json:"reason,omitempty"`
+	Metadata  datatypes.JSON `json:"metadata,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// TableName overrides the table name for OrderStateHistory
+func (OrderStateHistory) TableName() string {
+	return "order_state_history"
+}
+
+// OrderStateChange tells who changes the state of an order and why, recorded in its history by the repository
+type OrderStateChange struct {
+	ActorRole string
+	Reason    string
+	Metadata  map[string]any
+}
+
+// NewOrderStateHistory records the change of the tracking from fromState to its current state, made by its UpdatedBy
+func NewOrderStateHistory(tracking *OrderTracking, fromState string) (*OrderStateHistory, error) {
+	history := &OrderStateHistory{
+		ID:        uuid.NewString(),
+		OrderID:   tracking.OrderID,
+		FromState: fromState,
+		ToState:   tracking.State,
+		ActorID:   tracking.UpdatedBy,
+		CreatedAt: tracking.UpdatedAt,
+	}
+	if fromState == "" {
+		history.ActorID = tracking.CreatedBy
+		history.CreatedAt = tracking.CreatedAt
+	}
+	if history.CreatedAt.IsZero() {
+		history.CreatedAt = time.Now()
+	}
+
+	if change := tracking.StateChange; change != nil {
+		history.ActorRole = change.ActorRole
+		history.Reason = change.Reason
+		if len(change.Metadata) > 0 {
+			metadata, err := json.Marshal(change.Metadata)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			history.Metadata = metadata
+		}
+	}
+	return history, nil
+}
@@ -25,6 +25,8 @@ type OrderTracking struct {
 	UpdatedBy       *string        `json:"updatedBy,omitempty"`
 	CreatedAt       time.Time      `json:"createdAt"`
 	UpdatedAt       time.Time      `json:"updatedAt"`
+
+	StateChange *OrderStateChange `gorm:"-" json:"-"` // Context of a state change, saved in the history
 }
 
 // TableName overrides the table name for OrderTracking
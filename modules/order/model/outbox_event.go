@@ -0,0 +1,76 @@
+package ordermodel
+
+import (
+	"context"
+	"time"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"gorm.io/datatypes"
+)
+
+// Outbox event status
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed" // Gave up after too many attempts
+)
+
+// OutboxEvent represents the outbox_events table. Events are written in the same transaction
+// as the order change, then published by the outbox relay.
+type OutboxEvent struct {
+	ID            string         `json:"id"`
+	AggregateID   string         `json:"aggregateId"` // Order ID
+	Topic         string         `json:"topic"`
+	Payload       datatypes.JSON `json:"payload"` // Event envelope
+	Status        string         `json:"status"`
+	Attempts      int            `json:"attempts"`
+	LastError     *string        `json:"lastError,omitempty"`
+	NextAttemptAt time.Time      `json:"nextAttemptAt"`
+	SentAt        *time.Time     `json:"sentAt,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+// TableName overrides the table name for OutboxEvent
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// NewOutboxEvent saves the event envelope, so that the event keeps its id and the trace of ctx until it is published
+func NewOutboxEvent(ctx context.Context, aggregateID string, data datatype.EvtPayload) (*OutboxEvent, error) {
+	evt := datatype.NewAppEvent(
+		datatype.WithData(data),
+		datatype.WithTraceContext(ctx),
+	)
+	payload, err := datatype.EncodeEvt(ctx, evt)
+	if err != nil {
+		return nil, err
+	}
+
+	now := evt.OccurredAt
+	return &OutboxEvent{
+		ID:            evt.ID,
+		AggregateID:   aggregateID,
+		Topic:         evt.Topic,
+		Payload:       payload,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// OutboxStats describes the backlog of the outbox
+type OutboxStats struct {
+	Pending         int64
+	Failed          int64
+	OldestPendingAt *time.Time
+}
+
+// Lag is how long the oldest pending event has been waiting
+func (s OutboxStats) Lag(now time.Time) time.Duration {
+	if s.OldestPendingAt == nil {
+		return 0
+	}
+	return now.Sub(*s.OldestPendingAt)
+}
@@ -0,0 +1,42 @@
+package ordermodel
+
+import "time"
+
+// Payment transaction types
+const (
+	TransactionTypeCash      = "cash"
+	TransactionTypeAuthorize = "authorize"
+	TransactionTypeCapture   = "capture"
+	TransactionTypeVoid      = "void"
+	TransactionTypeRefund    = "refund"
+)
+
+// Payment transaction status
+const (
+	TransactionStatusPending   = "pending"
+	TransactionStatusSucceeded = "succeeded"
+	TransactionStatusFailed    = "failed"
+	TransactionStatusTimeout   = "timeout"
+)
+
+// PaymentTransaction represents the payment_transactions table.
+// Every call to a payment gateway is recorded, whatever its outcome.
+type PaymentTransaction struct {
+	ID           string    `json:"id"`
+	OrderID      string    `json:"orderId"`
+	UserID       string    `json:"userId"`
+	CardId       *string   `json:"cardId,omitempty"`
+	Provider     string    `json:"provider"`
+	Type         string    `json:"type"`
+	Amount       float64   `json:"amount"`
+	Status       string    `json:"status"`
+	ProviderRef  *string   `json:"providerRef,omitempty"`
+	ErrorMessage *string   `json:"errorMessage,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// TableName overrides the table name for PaymentTransaction
+func (PaymentTransaction) TableName() string {
+	return "payment_transactions"
+}
@@ -0,0 +1,41 @@
+package ordermodel
+
+import "time"
+
+// Refund status
+const (
+	RefundStatusRequested  = "requested"
+	RefundStatusProcessing = "processing"
+	RefundStatusSucceeded  = "succeeded"
+	RefundStatusFailed     = "failed"
+)
+
+// RefundReasonOrderNotSaved is the reason of the refund of a payment captured for an order which could not be saved
+const RefundReasonOrderNotSaved = "order could not be saved"
+
+// RefundHeldStatuses are the statuses of the refunds that hold their amount,
+// a failed refund gives it back until it is retried
+var RefundHeldStatuses = []string{RefundStatusRequested, RefundStatusProcessing, RefundStatusSucceeded}
+
+// Refund represents the refunds table. An order can have several partial refunds.
+type Refund struct {
+	ID            string    `json:"id"`
+	OrderID       string    `json:"orderId"`
+	Amount        float64   `json:"amount"`
+	Reason        string    `json:"reason"`
+	PaymentMethod string    `json:"paymentMethod"`
+	CardId        *string   `json:"cardId,omitempty"`
+	Status        string    `json:"status"`
+	ProviderRef   *string   `json:"providerRef,omitempty"`
+	Retries       int       `json:"retries"`
+	LastError     *string   `json:"lastError,omitempty"`
+	CreatedBy     *string   `json:"createdBy,omitempty"`
+	UpdatedBy     *string   `json:"updatedBy,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// TableName overrides the table name for Refund
+func (Refund) TableName() string {
+	return "refunds"
+}
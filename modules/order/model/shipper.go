@@ -0,0 +1,12 @@
+package ordermodel
+
+import "github.com/google/uuid"
+
+// Shipper is the profile of a shipper who may be assigned to orders
+type Shipper struct {
+	Id                  uuid.UUID `json:"id"`
+	Status              string    `json:"status"`
+	CityId              int       `json:"cityId"`
+	MaxConcurrentOrders int       `json:"maxConcurrentOrders"`
+	Available           bool      `json:"available"` // Online and on shift
+}
@@ -0,0 +1,22 @@
+package ordermodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShipperLocation is a location reported by the shipper delivering an order
+type ShipperLocation struct {
+	ShipperId  uuid.UUID `json:"shipperId"`
+	Lat        float64   `json:"lat"`
+	Lng        float64   `json:"lng"`
+	Heading    *float64  `json:"heading,omitempty"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// ShipperLocationTrail is the last location of a shipper followed by the previous ones, newest first
+type ShipperLocationTrail struct {
+	ShipperLocation
+	History []ShipperLocation `json:"history"`
+}
@@ -0,0 +1,15 @@
+package ordermodel
+
+import "time"
+
+// ShipperWorkload represents the shipper_workloads table, one row per shipper.
+// The row is locked while an order is assigned to the shipper, so that concurrent assignments are counted one after the other.
+type ShipperWorkload struct {
+	ShipperID string    `gorm:"primaryKey" json:"shipperId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TableName overrides the table name for ShipperWorkload
+func (ShipperWorkload) TableName() string {
+	return "shipper_workloads"
+}
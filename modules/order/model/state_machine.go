@@ -0,0 +1,143 @@
+package ordermodel
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// Actors of a state transition, resolved from the requester and the order
+const (
+	ActorCustomer        = "customer"         // User who placed the order
+	ActorRestaurantOwner = "restaurant_owner" // Owner of the restaurant of the order
+	ActorShipper         = "shipper"          // Shipper assigned to the order
+	ActorAdmin           = "admin"
+)
+
+var knownActors = []string{ActorCustomer, ActorRestaurantOwner, ActorShipper, ActorAdmin}
+
+// cancellationStates end an order, their reason is kept as the cancel reason of the order
+var cancellationStates = []string{"cancel", "restaurant_rejected"}
+
+// The default order lifecycle, replaced by the file of ORDER_STATE_MACHINE_FILE when set
+//
+//go:embed state_machine.json
+var defaultStateMachine []byte
+
+// StateTransition is a move of an order from one state to another, allowed to its actors
+type StateTransition struct {
+	From           string   `json:"from"`
+	To             string   `json:"to"`
+	Actors         []string `json:"actors"`
+	RequiresReason bool     `json:"requiresReason"` // The reason is kept as the cancel reason of the order
+	AssignsShipper bool     `json:"assignsShipper"` // A shipper may be assigned with the transition
+}
+
+// StateMachine is the transition table of the order lifecycle.
+// Override actors may perform any transition of the table, whatever its actors.
+type StateMachine struct {
+	OverrideActors []string          `json:"overrideActors"`
+	Transitions    []StateTransition `json:"transitions"`
+}
+
+// LoadStateMachine reads the transition table from a JSON file, or the default one when path is empty
+func LoadStateMachine(path string) (*StateMachine, error) {
+	data := defaultStateMachine
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	var sm StateMachine
+	if err := json.Unmarshal(data, &sm); err != nil {
+		return nil, errors.Wrap(err, "failed to parse order state machine")
+	}
+	if err := sm.Validate(); err != nil {
+		return nil, err
+	}
+	return &sm, nil
+}
+
+// MustLoadStateMachine panics when the transition table is broken, the app cannot run without it
+func MustLoadStateMachine(path string) *StateMachine {
+	sm, err := LoadStateMachine(path)
+	if err != nil {
+		panic(err)
+	}
+	return sm
+}
+
+func (sm *StateMachine) Validate() error {
+	if len(sm.Transitions) == 0 {
+		return errors.New("order state machine has no transitions")
+	}
+
+	seen := make(map[[2]string]bool, len(sm.Transitions))
+	for _, t := range sm.Transitions {
+		if t.From == "" || t.To == "" || t.From == t.To {
+			return errors.Errorf("invalid order state transition from %q to %q", t.From, t.To)
+		}
+		key := [2]string{t.From, t.To}
+		if seen[key] {
+			return errors.Errorf("duplicated order state transition from %s to %s", t.From, t.To)
+		}
+		seen[key] = true
+
+		if slices.Contains(cancellationStates, t.To) && !t.RequiresReason {
+			return errors.Errorf("order state transition from %s to %s must require a reason", t.From, t.To)
+		}
+
+		for _, actor := range t.Actors {
+			if !slices.Contains(knownActors, actor) {
+				return errors.Errorf("unknown actor %s of order state transition from %s to %s", actor, t.From, t.To)
+			}
+		}
+	}
+
+	for _, actor := range sm.OverrideActors {
+		if !slices.Contains(knownActors, actor) {
+			return errors.Errorf("unknown override actor %s of order state machine", actor)
+		}
+	}
+	return nil
+}
+
+// Transition returns the transition between the states, false when it is not allowed
+func (sm *StateMachine) Transition(from, to string) (StateTransition, bool) {
+	for _, t := range sm.Transitions {
+		if t.From == from && t.To == to {
+			return t, true
+		}
+	}
+	return StateTransition{}, false
+}
+
+// AllowedActors returns the actors of the transition, followed by the override actors
+func (sm *StateMachine) AllowedActors(t StateTransition) []string {
+	return append(slices.Clone(t.Actors), sm.OverrideActors...)
+}
+
+// IsTerminal reports whether no transition leaves the state
+func (sm *StateMachine) IsTerminal(state string) bool {
+	for _, t := range sm.Transitions {
+		if t.From == state {
+			return false
+		}
+	}
+	return true
+}
+
+// AssignsShipperFrom reports whether a shipper may still be assigned to an order in the state
+func (sm *StateMachine) AssignsShipperFrom(state string) bool {
+	for _, t := range sm.Transitions {
+		if t.From == state && t.AssignsShipper {
+			return true
+		}
+	}
+	return false
+}
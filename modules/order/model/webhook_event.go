@@ -0,0 +1,19 @@
+package ordermodel
+
+import "time"
+
+// Order events delivered to the webhooks of the restaurant partners, owned by the webhook module
+const (
+	WebhookOrderCreated      = "order.created"
+	WebhookOrderStateChanged = "order.state_changed"
+	WebhookOrderCancelled    = "order.cancelled"
+)
+
+// WebhookEvent is an order event for the webhooks of its restaurant
+type WebhookEvent struct {
+	Id           string    `json:"id"` // Id of the order event, the webhook module delivers it once per endpoint
+	Type         string    `json:"type"`
+	RestaurantId string    `json:"restaurantId"`
+	OccurredAt   time.Time `json:"occurredAt"`
+	Data         any       `json:"data"`
+}
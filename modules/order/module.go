@@ -1,14 +1,20 @@
 package ordermodule
 
 import (
+	"fmt"
+
 	"github.com/gin-gonic/gin"
 	orderHttpgin "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/controller/http-gin"
 	orderRepo "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/gorm-mysql"
 	grpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/grpc-client"
+	paymentgateway "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/payment-gateway"
 	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/rpc-client"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
 	orderService "github.com/ntttrang/go-food-delivery-backend-service/modules/order/service"
 	shareComponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+	sharerpc "github.com/ntttrang/go-food-delivery-backend-service/shared/infras/rpc"
 )
 
 func SetupOrderModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
@@ -22,16 +28,32 @@ func SetupOrderModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 	restaurantRpcClientRepo := rpcclient.NewRestaurantRPCClient(appCtx.GetConfig().RestaurantServiceURL)
 	cartRpcClientRepo := rpcclient.NewCartRPCClient(config.CartServiceURL)
 	cardRpcClientRepo := rpcclient.NewCardRPCClient(appCtx.GetConfig().PaymentServiceURL)
-	userRpcClientRepo := rpcclient.NewUserRPCClient(appCtx.GetConfig().UserServiceURL)
-	emailSvc := shareComponent.NewEmailService(appCtx.GetConfig().EmailConfig)
+	userRpcClientRepo := rpcclient.NewUserRPCClient(appCtx.GetConfig().UserServiceURL, appCtx.GetConfig().AuthConfig.InternalToken)
+	notificationRpcClientRepo := rpcclient.NewNotificationRPCClient(appCtx.GetConfig().NotificationServiceURL, appCtx.GetConfig().AuthConfig.InternalToken)
+	shipperLocationRpcClientRepo := rpcclient.NewShipperLocationRPCClient(config.DispatchServiceURL, config.AuthConfig.InternalToken)
+	emailSvc := sharerpc.NewEmailOutboxRpcClient(appCtx.GetConfig().NotificationServiceURL, appCtx.GetConfig().AuthConfig.InternalToken)
+	emailRenderer := shareComponent.MustNewEmailTemplateRenderer(config.EmailConfig.DefaultLocale)
+	smsSvc := shareComponent.NewFakeSmsProvider(config.NotificationConfig.SinkDir)
+	pushSvc := shareComponent.NewFakePushProvider(config.NotificationConfig.SinkDir)
 
 	// GRPC
 	foodGrpcClient := grpcclient.NewFoodGRPCClient(appCtx.GetConfig().GrpcFoodServiceURL)
 
 	// Setup service
 	cartConversionService := orderService.NewCartToOrderConversionService(cartRpcClientRepo, foodGrpcClient, restaurantRpcClientRepo)
+	deliveryQuoteService := orderService.NewDeliveryQuoteService(
+		restaurantRpcClientRepo,
+		orderService.NewHaversineDistanceProvider(),
+	)
+	pricingEngine := orderService.NewPricingEngine(foodGrpcClient, deliveryQuoteService)
+	gateways, err := setupPaymentGateways(config.PaymentConfig)
+	if err != nil {
+		panic(err)
+	}
 	paymentService := orderService.NewPaymentProcessingService(
 		cardRpcClientRepo,
+		orderRepo,
+		gateways,
 	)
 
 	inventoryService := orderService.NewInventoryCheckingService(
@@ -39,18 +61,29 @@ func SetupOrderModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 		restaurantRpcClientRepo,
 	)
 
+	stockService := orderService.NewInventoryService(foodGrpcClient)
+
+	refundService := orderService.NewRefundService(orderRepo, paymentService)
+
 	notificationService := orderService.NewOrderNotificationService(
 		orderRepo,
 		userRpcClientRepo,
 		restaurantRpcClientRepo,
 		emailSvc,
+		emailRenderer,
+		smsSvc,
+		pushSvc,
+		notificationRpcClientRepo,
 	)
 
 	// Create command handler with all services
 	createCmdHdl := orderService.NewCreateCommandHandler(
 		orderRepo,
+		pricingEngine,
 		paymentService,
+		refundService,
 		inventoryService,
+		stockService,
 		notificationService,
 	)
 	createFromCartCmdHdl := orderService.NewCreateFromCartCommandHandler(
@@ -59,11 +92,29 @@ func SetupOrderModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 		paymentService,
 		inventoryService,
 		notificationService,
-		appCtx.MsgBroker(),
 	)
 	listQueryHdl := orderService.NewListQueryHandler(orderRepo)
 	getDetailQueryHdl := orderService.NewGetDetailQueryHandler(orderRepo)
-	updateOrderStateCmdHdl := orderService.NewOrderStateManagementService(orderRepo, notificationService, appCtx.MsgBroker())
+	timelineQueryHdl := orderService.NewGetTimelineQueryHandler(orderRepo)
+	trackQueryHdl := orderService.NewTrackOrderQueryHandler(
+		orderRepo,
+		restaurantRpcClientRepo,
+		shipperLocationRpcClientRepo,
+		orderService.NewHaversineDistanceProvider(),
+		config.OrderTrackInterval,
+	)
+	stateMachine := ordermodel.MustLoadStateMachine(config.OrderStateMachineFile)
+	shipperAvailabilityChecker := orderService.NewShipperAvailabilityChecker(rpcclient.NewShipperRPCClient(config.ShipperServiceURL), orderRepo)
+	updateOrderStateCmdHdl := orderService.NewOrderStateManagementService(
+		orderRepo,
+		restaurantRpcClientRepo,
+		stateMachine,
+		notificationService,
+		stockService,
+		refundService,
+		shipperAvailabilityChecker,
+	)
+	assignShipperCmdHdl := orderService.NewAssignShipperCommandHandler(orderRepo, stateMachine, shipperAvailabilityChecker)
 	deleteCmdHdl := orderService.NewDeleteCommandHandler(orderRepo)
 
 	// Setup controller with unified state management
@@ -72,11 +123,38 @@ func SetupOrderModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 		createFromCartCmdHdl,
 		listQueryHdl,
 		getDetailQueryHdl,
+		timelineQueryHdl,
+		trackQueryHdl,
 		updateOrderStateCmdHdl,
+		assignShipperCmdHdl,
 		deleteCmdHdl,
+		deliveryQuoteService,
+		refundService,
+		orderService.NewRequeueOutboxEventCommandHandler(orderRepo),
+		orderService.NewOrderOwnershipChecker(orderRepo),
+		orderService.NewOrderWatcherChecker(orderRepo, restaurantRpcClientRepo),
 	)
 
 	// Setup routes
 	orders := g.Group("/orders")
-	orderCtl.SetupRoutes(orders)
+	orderCtl.SetupRoutes(orders, appCtx.MiddlewareProvider())
+	orderCtl.SetupRPCRoutes(g, appCtx.MiddlewareProvider())
+}
+
+// setupPaymentGateways selects the gateway of each card provider.
+// Real provider adapters are registered here when available. An unknown mode is an error,
+// the orders would fail at payment time otherwise.
+func setupPaymentGateways(cfg datatype.PaymentConfig) (map[string]orderService.PaymentGateway, error) {
+	gateways := make(map[string]orderService.PaymentGateway)
+
+	switch cfg.GatewayMode {
+	case "", "fake":
+		fakeGateway := paymentgateway.NewFakeGateway(cfg).WithTimeout(cfg.FakeTimeout)
+		gateways[ordermodel.ProviderStripe] = fakeGateway
+		gateways[ordermodel.ProviderPaypal] = fakeGateway
+	default:
+		return nil, fmt.Errorf("payment gateway mode %q is not supported", cfg.GatewayMode)
+	}
+
+	return gateways, nil
 }
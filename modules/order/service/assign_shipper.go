@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type AssignShipperReq struct {
+	OrderID   string `json:"-"`
+	ShipperID string `json:"shipperId"`
+}
+
+func (r *AssignShipperReq) Validate() error {
+	if r.OrderID == "" {
+		return ordermodel.ErrOrderIdRequired
+	}
+	if _, err := uuid.Parse(r.ShipperID); err != nil {
+		return ordermodel.ErrShipperRequired
+	}
+	return nil
+}
+
+// Initilize service
+type AssignShipperCommandHandler struct {
+	repo                IOrderStateRepo
+	stateMachine        *ordermodel.StateMachine
+	availabilityChecker IShipperAvailabilityChecker
+}
+
+func NewAssignShipperCommandHandler(repo IOrderStateRepo, stateMachine *ordermodel.StateMachine, availabilityChecker IShipperAvailabilityChecker) *AssignShipperCommandHandler {
+	return &AssignShipperCommandHandler{repo: repo, stateMachine: stateMachine, availabilityChecker: availabilityChecker}
+}
+
+// Implement
+// Execute assigns the shipper who accepted the dispatch offer, without changing the state of the order.
+// Assigning the same shipper again is a no-op, so that the dispatch engine may retry.
+func (hdl *AssignShipperCommandHandler) Execute(ctx context.Context, req *AssignShipperReq) error {
+	if err := req.Validate(); err != nil {
+		return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	order, tracking, _, err := hdl.repo.FindById(ctx, req.OrderID)
+	if err != nil {
+		if err == ordermodel.ErrOrderNotFound {
+			return datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if order.ShipperID != nil {
+		if *order.ShipperID == req.ShipperID {
+			return nil
+		}
+		return datatype.ErrBadRequest.WithWrap(ordermodel.ErrShipperAlreadyAssigned).WithDebug(ordermodel.ErrShipperAlreadyAssigned.Error())
+	}
+	if !hdl.stateMachine.AssignsShipperFrom(tracking.State) {
+		return datatype.ErrBadRequest.WithWrap(ordermodel.ErrShipperNotAssignable).WithDebug("order is " + tracking.State)
+	}
+	// The shipper may have gone offline or taken other orders since the offer was sent
+	limit, err := hdl.availabilityChecker.CheckAvailable(ctx, req.ShipperID)
+	if err != nil {
+		return err
+	}
+
+	order.ShipperID = &req.ShipperID
+	order.ShipperLimit = limit
+	order.UpdatedAt = time.Now()
+
+	// Published by the outbox relay as EvtNotifyShipperAssign
+	evt, err := ordermodel.NewOutboxEvent(ctx, order.ID, datatype.ShipperAssignedEvt{
+		OrderID:   order.ID,
+		ShipperID: req.ShipperID,
+	})
+	if err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if err := hdl.repo.Update(ctx, order, tracking, tracking.State, evt); err != nil {
+		return updateOrderError(err)
+	}
+	return nil
+}
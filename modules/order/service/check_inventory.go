@@ -96,6 +96,11 @@ func (s *InventoryCheckingService) CheckFoodAvailability(ctx context.Context, it
 		if food.Status != "ACTIVE" {
 			return datatype.ErrBadRequest.WithWrap(ordermodel.ErrFoodNotAvailable).WithDebug("food is not active: " + food.Name)
 		}
+
+		// Check stock when the food tracks it. The reservation is the final check.
+		if food.Stock != nil && *food.Stock < item.Quantity {
+			return datatype.ErrConflict.WithWrap(ordermodel.ErrInventoryInsufficient).WithError(ordermodel.ErrInventoryInsufficient.Error()).WithDebug("not enough stock: " + food.Name)
+		}
 	}
 
 	return nil
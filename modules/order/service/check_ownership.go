@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/rpc-client"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type IOrderOwnerRepo interface {
+	FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error)
+}
+
+// OrderOwnershipChecker allows the customer who placed the order and the shipper delivering it
+type OrderOwnershipChecker struct {
+	repo IOrderOwnerRepo
+}
+
+func NewOrderOwnershipChecker(repo IOrderOwnerRepo) *OrderOwnershipChecker {
+	return &OrderOwnershipChecker{repo: repo}
+}
+
+func (c *OrderOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	order, _, _, err := c.repo.FindById(ctx, resourceId)
+	if err != nil {
+		if errors.Is(err, ordermodel.ErrOrderNotFound) {
+			return false, datatype.ErrNotFound.WithDebug(ordermodel.ErrOrderNotFound.Error())
+		}
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if order.UserID == userId.String() {
+		return true, nil
+	}
+	return order.ShipperID != nil && *order.ShipperID == userId.String(), nil
+}
+
+type IOrderWatcherRestaurantRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO, error)
+}
+
+// OrderWatcherChecker allows the customer who placed the order, the shipper delivering it
+// and the owner of its restaurant
+type OrderWatcherChecker struct {
+	repo           IOrderOwnerRepo
+	restaurantRepo IOrderWatcherRestaurantRepo
+}
+
+func NewOrderWatcherChecker(repo IOrderOwnerRepo, restaurantRepo IOrderWatcherRestaurantRepo) *OrderWatcherChecker {
+	return &OrderWatcherChecker{repo: repo, restaurantRepo: restaurantRepo}
+}
+
+func (c *OrderWatcherChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	order, tracking, _, err := c.repo.FindById(ctx, resourceId)
+	if err != nil {
+		if errors.Is(err, ordermodel.ErrOrderNotFound) {
+			return false, datatype.ErrNotFound.WithDebug(ordermodel.ErrOrderNotFound.Error())
+		}
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if order.UserID == userId.String() || (order.ShipperID != nil && *order.ShipperID == userId.String()) {
+		return true, nil
+	}
+
+	restaurantId, err := uuid.Parse(tracking.RestaurantID)
+	if err != nil {
+		return false, nil
+	}
+	restaurants, err := c.restaurantRepo.FindByIds(ctx, []uuid.UUID{restaurantId})
+	if err != nil {
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	restaurant, ok := restaurants[restaurantId]
+	return ok && restaurant.OwnerId == userId, nil
+}
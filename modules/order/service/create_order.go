@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -48,7 +49,8 @@ func (o *OrderCreateDto) Validate() error {
 		return ordermodel.ErrUserIdRequired
 	}
 
-	if o.TotalPrice <= 0 {
+	// TotalPrice is optional: the order is priced server-side, the client value is only cross-checked
+	if o.TotalPrice < 0 {
 		return ordermodel.ErrTotalPriceInvalid
 	}
 
@@ -56,6 +58,13 @@ func (o *OrderCreateDto) Validate() error {
 		return ordermodel.ErrRestaurantRequired
 	}
 
+	// Checked before the inventory, which is looked up with the quantities
+	for _, detail := range o.OrderDetails {
+		if detail.Quantity <= 0 {
+			return ordermodel.ErrQuantityInvalid
+		}
+	}
+
 	if o.PaymentMethod == "" {
 		return ordermodel.ErrPaymentMethodRequired
 	}
@@ -74,26 +83,35 @@ func (o *OrderCreateDto) Validate() error {
 
 // Initialize service
 type ICreateOrderRepository interface {
-	Insert(ctx context.Context, order *ordermodel.Order, orderTracking *ordermodel.OrderTracking, orderDetails []ordermodel.OrderDetail) error
+	Insert(ctx context.Context, order *ordermodel.Order, orderTracking *ordermodel.OrderTracking, orderDetails []ordermodel.OrderDetail, events ...*ordermodel.OutboxEvent) error
 }
 
 type CreateCommandHandler struct {
 	repo                ICreateOrderRepository
+	pricingEngine       *PricingEngine
 	paymentService      *PaymentProcessingService
+	refundService       *RefundService
 	inventoryService    *InventoryCheckingService
+	stockService        *InventoryService
 	notificationService *OrderNotificationService
 }
 
 func NewCreateCommandHandler(
 	repo ICreateOrderRepository,
+	pricingEngine *PricingEngine,
 	paymentService *PaymentProcessingService,
+	refundService *RefundService,
 	inventoryService *InventoryCheckingService,
+	stockService *InventoryService,
 	notificationService *OrderNotificationService,
 ) *CreateCommandHandler {
 	return &CreateCommandHandler{
 		repo:                repo,
+		pricingEngine:       pricingEngine,
 		paymentService:      paymentService,
+		refundService:       refundService,
 		inventoryService:    inventoryService,
+		stockService:        stockService,
 		notificationService: notificationService,
 	}
 }
@@ -108,6 +126,22 @@ func (s *CreateCommandHandler) Execute(ctx context.Context, data *OrderCreateDto
 		return "", datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
 	}
 
+	// Convert order details to inventory items
+	var inventoryItems []OrderItem
+	for _, detail := range data.OrderDetails {
+		if detail.FoodOrigin == nil {
+			return "", datatype.ErrBadRequest.WithError(ordermodel.ErrFoodIdRequired.Error())
+		}
+		foodID, err := uuid.Parse(detail.FoodOrigin.Id)
+		if err != nil {
+			return "", datatype.ErrBadRequest.WithError(ordermodel.ErrInvalidFoodIdFormat.Error())
+		}
+		inventoryItems = append(inventoryItems, OrderItem{
+			FoodID:   foodID,
+			Quantity: detail.Quantity,
+		})
+	}
+
 	// Check inventory if service is available
 	if s.inventoryService != nil {
 		restaurantID, err := uuid.Parse(data.RestaurantID)
@@ -115,25 +149,22 @@ func (s *CreateCommandHandler) Execute(ctx context.Context, data *OrderCreateDto
 			return "", datatype.ErrBadRequest.WithError(ordermodel.ErrInvalidRestaurantIdFormat.Error())
 		}
 
-		// Convert order details to inventory items
-		var inventoryItems []OrderItem
-		for _, detail := range data.OrderDetails {
-			foodID, err := uuid.Parse(detail.FoodOrigin.Id)
-			if err != nil {
-				return "", datatype.ErrBadRequest.WithError(ordermodel.ErrInvalidFoodIdFormat.Error())
-			}
-			inventoryItems = append(inventoryItems, OrderItem{
-				FoodID:   foodID,
-				Quantity: detail.Quantity,
-			})
-		}
-
 		// Check inventory
 		if err := s.inventoryService.CheckOrderInventory(creatCtx, restaurantID, inventoryItems); err != nil {
 			return "", err
 		}
 	}
 
+	// Compute the order price from authoritative food prices
+	breakdown, err := s.pricingEngine.PriceOrder(creatCtx, data)
+	if err != nil {
+		return "", err
+	}
+	breakdownJson, _ := json.Marshal(breakdown)
+
+	// set data to response
+	data.TotalPrice = breakdown.Total
+
 	// Process payment if service is available
 	if s.paymentService != nil {
 		paymentReq := &PaymentRequest{
@@ -152,16 +183,37 @@ func (s *CreateCommandHandler) Execute(ctx context.Context, data *OrderCreateDto
 	// Generate new UUID for order
 	orderId := uuid.New().String()
 
+	// Reserve stock so concurrent checkouts cannot oversell
+	if s.stockService != nil {
+		if err := s.stockService.ReserveInventory(creatCtx, orderId, inventoryItems); err != nil {
+			return "", err
+		}
+	}
+
+	// Charge the order before saving it, the parties must not hear of an order which is not paid for
+	paymentStatus := PaymentStatusPending
+	if s.paymentService != nil {
+		if err := s.chargeOrder(creatCtx, orderId, data); err != nil {
+			s.releaseStock(creatCtx, orderId)
+			return "", err
+		}
+		// Card payments are captured at checkout, cash is collected on delivery
+		if data.PaymentMethod != MethodCash {
+			paymentStatus = PaymentStatusPaid
+		}
+	}
+
 	// Create order
 	order := &ordermodel.Order{
-		ID:         orderId,
-		UserID:     data.UserID,
-		TotalPrice: data.TotalPrice,
-		Status:     string(datatype.StatusActive),
-		CreatedBy:  &data.UserID,
-		UpdatedBy:  &data.UserID,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		ID:             orderId,
+		UserID:         data.UserID,
+		TotalPrice:     breakdown.Total,
+		PriceBreakdown: breakdownJson,
+		Status:         string(datatype.StatusActive),
+		CreatedBy:      &data.UserID,
+		UpdatedBy:      &data.UserID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	// Create order tracking
@@ -172,11 +224,13 @@ func (s *CreateCommandHandler) Execute(ctx context.Context, data *OrderCreateDto
 	orderTracking := &ordermodel.OrderTracking{
 		ID:              uuid.New().String(),
 		OrderID:         orderId,
-		State:           StateWaitingForShipper,
-		PaymentStatus:   PaymentStatusPending,
+		State:           StatePendingRestaurant,
+		PaymentStatus:   paymentStatus,
 		PaymentMethod:   data.PaymentMethod,
 		CardId:          &data.CardID,
 		DeliveryAddress: addressJson,
+		DeliveryFee:     breakdown.DeliveryFee,
+		EstimatedTime:   DefaultEstimatedTime,
 		RestaurantID:    data.RestaurantID,
 		Status:          string(datatype.StatusActive),
 		CreatedBy:       &data.UserID,
@@ -185,6 +239,10 @@ func (s *CreateCommandHandler) Execute(ctx context.Context, data *OrderCreateDto
 		UpdatedAt:       time.Now(),
 	}
 
+	if breakdown.Delivery != nil {
+		orderTracking.EstimatedTime = breakdown.Delivery.EstimatedTime
+	}
+
 	// Create order details
 	var orderDetails []ordermodel.OrderDetail
 	for _, detail := range data.OrderDetails {
@@ -207,10 +265,59 @@ func (s *CreateCommandHandler) Execute(ctx context.Context, data *OrderCreateDto
 		})
 	}
 
+	// Notify the parties once the order is saved
+	orderCreatedEvt, err := ordermodel.NewOutboxEvent(creatCtx, orderId, datatype.OrderCreatedEvt{
+		OrderID:      orderId,
+		UserID:       data.UserID,
+		RestaurantID: data.RestaurantID,
+	})
+	if err != nil {
+		return "", datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
 	// Insert to database
-	if err := s.repo.Insert(creatCtx, order, orderTracking, orderDetails); err != nil {
+	if err := s.repo.Insert(creatCtx, order, orderTracking, orderDetails, orderCreatedEvt); err != nil {
+		s.releaseStock(creatCtx, orderId)
+		if paymentStatus == PaymentStatusPaid {
+			// The card is captured but the order does not exist, give the money back.
+			// A failed refund stays in the ledger so that an admin can retry it.
+			if _, refundErr := s.refundService.RefundUnsavedOrder(creatCtx, orderId, breakdown.Total, data.PaymentMethod, &data.CardID, data.UserID); refundErr != nil {
+				log.Printf("Failed to refund the payment of unsaved order %s: %v", orderId, refundErr)
+			}
+		}
 		return "", datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
 
 	return orderId, nil
 }
+
+// chargeOrder records a cash payment or authorizes and captures the card of the order
+func (s *CreateCommandHandler) chargeOrder(ctx context.Context, orderId string, data *OrderCreateDto) error {
+	paymentReq := &PaymentRequest{
+		OrderID:       orderId,
+		UserID:        data.UserID,
+		Amount:        data.TotalPrice,
+		PaymentMethod: data.PaymentMethod,
+		CardID:        &data.CardID,
+	}
+
+	paymentResult, err := s.paymentService.ProcessPayment(ctx, paymentReq)
+	if err != nil {
+		log.Printf("Payment failed for order %s: %v", orderId, err)
+		return err
+	}
+	if !paymentResult.Success {
+		return datatype.ErrBadRequest.WithWrap(ordermodel.ErrPaymentFailed).WithError(ordermodel.ErrPaymentFailed.Error()).WithDebug(paymentResult.ErrorMessage)
+	}
+	return nil
+}
+
+// releaseStock gives back the stock reserved for an order which is not saved
+func (s *CreateCommandHandler) releaseStock(ctx context.Context, orderId string) {
+	if s.stockService == nil {
+		return
+	}
+	if err := s.stockService.RestoreInventory(ctx, orderId); err != nil {
+		log.Printf("Failed to release stock of order %s: %v", orderId, err)
+	}
+}
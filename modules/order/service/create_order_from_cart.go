@@ -6,7 +6,6 @@ import (
 
 	"github.com/google/uuid"
 	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
-	"github.com/ntttrang/go-food-delivery-backend-service/shared"
 	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 )
 
@@ -33,7 +32,7 @@ func (o *OrderCreateFromCartDto) Validate() error {
 	}
 
 	// Validate payment method and card requirement
-	if (o.PaymentMethod == MethodCreditCard || o.PaymentMethod == MethodDebitCard) && *o.CardID == "" {
+	if (o.PaymentMethod == MethodCreditCard || o.PaymentMethod == MethodDebitCard) && (o.CardID == nil || *o.CardID == "") {
 		return ordermodel.ErrCardIdRequired
 	}
 
@@ -44,10 +43,6 @@ func (o *OrderCreateFromCartDto) Validate() error {
 	return nil
 }
 
-type IEvtPublisher interface {
-	Publish(ctx context.Context, topic string, evt *datatype.AppEvent) error
-}
-
 // CreateFromCartCommandHandler handles creating orders from cart
 type CreateFromCartCommandHandler struct {
 	createHandler         *CreateCommandHandler
@@ -55,7 +50,6 @@ type CreateFromCartCommandHandler struct {
 	paymentService        *PaymentProcessingService
 	inventoryService      *InventoryCheckingService
 	notificationService   *OrderNotificationService
-	evtPublisher          IEvtPublisher
 }
 
 func NewCreateFromCartCommandHandler(
@@ -64,7 +58,6 @@ func NewCreateFromCartCommandHandler(
 	paymentService *PaymentProcessingService,
 	inventoryService *InventoryCheckingService,
 	notificationService *OrderNotificationService,
-	evtPublisher IEvtPublisher,
 ) *CreateFromCartCommandHandler {
 	return &CreateFromCartCommandHandler{
 		createHandler:         createHandler,
@@ -72,7 +65,6 @@ func NewCreateFromCartCommandHandler(
 		paymentService:        paymentService,
 		inventoryService:      inventoryService,
 		notificationService:   notificationService,
-		evtPublisher:          evtPublisher,
 	}
 }
 
@@ -111,60 +103,23 @@ func (s *CreateFromCartCommandHandler) ExecuteFromCart(ctx context.Context, data
 	// Set delivery address and payment method from request
 	orderData.DeliveryAddress = data.DeliveryAddress
 	orderData.PaymentMethod = data.PaymentMethod
-	orderData.CardID = *data.CardID
+	if data.CardID != nil {
+		orderData.CardID = *data.CardID
+	}
+	// The cart total excludes delivery fee, let the pricing engine compute the final amount
+	orderData.TotalPrice = 0
 
-	// Create the order using the standard flow
+	// Create the order using the standard flow, which charges it before announcing it
 	orderId, err := s.createHandler.Execute(ctx, orderData)
 	if err != nil {
 		return "", err
 	}
 
-	// Process payment after order creation
-	if s.paymentService != nil {
-		paymentReq := &PaymentRequest{
-			OrderID:       orderId,
-			UserID:        data.UserID,
-			Amount:        orderData.TotalPrice,
-			PaymentMethod: data.PaymentMethod,
-			CardID:        data.CardID,
-		}
-
-		paymentResult, err := s.paymentService.ProcessPayment(ctx, paymentReq)
-		if err != nil {
-			// TODO: In a real implementation, you might want to cancel the order here
-			// or mark it as payment failed
-			return "", datatype.ErrInternalServerError.WithWrap(err).WithDebug("payment processing failed")
-		}
-
-		if !paymentResult.Success {
-			log.Print("Payment failed! \n")
-			return "", datatype.ErrBadRequest.WithWrap(ordermodel.ErrPaymentFailed).WithDebug(paymentResult.ErrorMessage)
-		}
-	}
-
 	// Mark cart as processed
 	if err := s.cartConversionService.MarkCartAsProcessed(ctx, cartID); err != nil {
 		log.Print("update cart status = PROCESSED after order created \n")
 	}
 
-	// Send notifications
-	orderCreatedMsg := map[string]interface{}{
-		"orderId":      orderId,
-		"userId":       data.UserID,
-		"restaurantId": orderData.RestaurantID,
-	}
-	go func() {
-		log.Println("Publish msg: ORDER CREATED")
-		defer shared.Recover()
-
-		evt := datatype.NewAppEvent(
-			datatype.WithTopic(datatype.EvtNotifyOrderCreate),
-			datatype.WithData(orderCreatedMsg),
-		)
-		if err := s.evtPublisher.Publish(ctx, evt.Topic, evt); err != nil {
-			log.Println("Failed to publish event", err)
-		}
-	}()
-
+	// The order created event was saved with the order, the outbox relay publishes it
 	return orderId, nil
 }
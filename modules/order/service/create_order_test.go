@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+)
+
+func TestOrderCreateDto_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		wantErr  error
+	}{
+		{name: "TC 1: a positive quantity is valid", quantity: 2},
+		{name: "TC 2: a zero quantity is rejected before the inventory is checked", quantity: 0, wantErr: ordermodel.ErrQuantityInvalid},
+		{name: "TC 3: a negative quantity is rejected before the inventory is checked", quantity: -1, wantErr: ordermodel.ErrQuantityInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &OrderCreateDto{
+				UserID:          "user-1",
+				RestaurantID:    "restaurant-1",
+				PaymentMethod:   MethodCash,
+				DeliveryAddress: &ordermodel.Address{},
+				OrderDetails:    []OrderDetailCreateDto{{Quantity: tt.quantity}},
+			}
+			if err := data.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
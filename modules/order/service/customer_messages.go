@@ -7,98 +7,56 @@ import (
 	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
 )
 
-// Customer message creation methods (continued)
-
-// createCustomerShipperAssignmentMessage creates email subject and body for customer shipper assignment notifications
-func (s *OrderNotificationService) createCustomerShipperAssignmentMessage(orderID, _ /* shipperID */ string) (string, string) {
-	subject := fmt.Sprintf("Shipper Assigned - Order %s", orderID)
-
-	body := fmt.Sprintf(`Order %s: Shipper assigned!
-
-Your order is being prepared and will be delivered soon.
-
-Track your order in the app.
-
-Food Delivery Team`, orderID)
-
-	return subject, body
+// Customer short text creation methods, sent by SMS and push notification
+
+// createCustomerStateChangeText creates the short text of customer state change notifications
+func (s *OrderNotificationService) createCustomerStateChangeText(orderID, _ /* oldState */, newState string) string {
+	switch newState {
+	case StateRestaurantAccepted:
+		return fmt.Sprintf("Order %s accepted by the restaurant.", orderID)
+	case StateRestaurantRejected:
+		return fmt.Sprintf("Order %s rejected by the restaurant. Contact support for questions.", orderID)
+	case StatePreparing:
+		return fmt.Sprintf("Order %s is being prepared.", orderID)
+	case StateOnTheWay:
+		return fmt.Sprintf("Order %s is on the way, please be available to receive it.", orderID)
+	case StateDelivered:
+		return fmt.Sprintf("Order %s delivered. Enjoy your meal!", orderID)
+	case StateCancelled:
+		return fmt.Sprintf("Order %s cancelled. Contact support for questions.", orderID)
+	default:
+		return fmt.Sprintf("Order %s is %s.", orderID, s.getStateDisplayName(newState))
+	}
 }
 
-// createCustomerPaymentStatusChangeMessage creates email subject and body for customer payment status notifications
-func (s *OrderNotificationService) createCustomerPaymentStatusChangeMessage(orderID, paymentStatus string) (string, string) {
-	subject := fmt.Sprintf("Payment %s - Order %s", strings.ToUpper(paymentStatus), orderID)
+// createCustomerShipperAssignmentText creates the short text of customer shipper assignment notifications
+func (s *OrderNotificationService) createCustomerShipperAssignmentText(orderID, _ /* shipperID */ string) string {
+	return fmt.Sprintf("A shipper is assigned to order %s. Track it in the app.", orderID)
+}
 
-	var body string
+// createCustomerPaymentStatusChangeText creates the short text of customer payment status notifications
+func (s *OrderNotificationService) createCustomerPaymentStatusChangeText(orderID, paymentStatus string) string {
 	switch paymentStatus {
 	case PaymentStatusPaid:
-		body = fmt.Sprintf(`Order %s: Payment confirmed ✓
-
-Your order is now being prepared.
-
-Food Delivery Team`, orderID)
-
-	case "pending":
-		body = fmt.Sprintf(`Order %s: Payment processing...
-
-Please wait for confirmation.
-
-Food Delivery Team`, orderID)
-
-	case "failed":
-		body = fmt.Sprintf(`Order %s: Payment failed ✗
-
-Please retry payment in the app.
-
-Food Delivery Team`, orderID)
-
+		return fmt.Sprintf("Payment of order %s confirmed.", orderID)
+	case PaymentStatusPending:
+		return fmt.Sprintf("Payment of order %s is processing.", orderID)
+	case PaymentStatusFailed:
+		return fmt.Sprintf("Payment of order %s failed, please retry in the app.", orderID)
 	default:
-		body = fmt.Sprintf(`Order %s: Payment %s
-
-Check app for details.
-
-Food Delivery Team`, orderID, strings.ToUpper(paymentStatus))
+		return fmt.Sprintf("Payment of order %s: %s.", orderID, strings.ToUpper(paymentStatus))
 	}
-
-	return subject, body
 }
 
-// createCustomerOrderCreatedMessage creates email subject and body for customer order creation notifications
-func (s *OrderNotificationService) createCustomerOrderCreatedMessage(orderID, _ /* restaurantID */ string, order *ordermodel.Order, tracking *ordermodel.OrderTracking, _ /* orderDetails */ []ordermodel.OrderDetail) (string, string) {
-	subject := fmt.Sprintf("Order Confirmed #%s", orderID)
-
-	body := fmt.Sprintf(`Order #%s confirmed ✓
-
-Total: $%.2f
-Delivery: %d min
-
-Track in app.
-
-Food Delivery Team`, orderID, order.TotalPrice, tracking.EstimatedTime)
-
-	return subject, body
+// createCustomerOrderCreatedText creates the short text of customer order creation notifications
+func (s *OrderNotificationService) createCustomerOrderCreatedText(orderID, _ /* restaurantID */ string, order *ordermodel.Order, tracking *ordermodel.OrderTracking, _ /* orderDetails */ []ordermodel.OrderDetail) string {
+	return fmt.Sprintf("Order %s confirmed. Total $%.2f, delivery in %d min.", orderID, order.TotalPrice, tracking.EstimatedTime)
 }
 
-// createCustomerOrderCancelledMessage creates email subject and body for customer order cancellation notifications
-func (s *OrderNotificationService) createCustomerOrderCancelledMessage(orderID, reason string, order *ordermodel.Order, tracking *ordermodel.OrderTracking, _ /* orderDetails */ []ordermodel.OrderDetail) (string, string) {
-	subject := fmt.Sprintf("Order Cancelled #%s", orderID)
-
-	var refundInfo string
+// createCustomerOrderCancelledText creates the short text of customer order cancellation notifications
+func (s *OrderNotificationService) createCustomerOrderCancelledText(orderID, reason string, _ /* order */ *ordermodel.Order, tracking *ordermodel.OrderTracking, _ /* orderDetails */ []ordermodel.OrderDetail) string {
 	if tracking.PaymentStatus == PaymentStatusPaid {
-		refundInfo = "Refund will be processed within 3-5 business days."
-	} else {
-		refundInfo = "No payment was processed."
+		return fmt.Sprintf("Order %s cancelled: %s. Refund in 3-5 business days.", orderID, reason)
 	}
-
-	body := fmt.Sprintf(`Order #%s cancelled
-
-Reason: %s
-Total: $%.2f
-
-%s
-
-You can place a new order anytime.
-
-Food Delivery Team`, orderID, reason, order.TotalPrice, refundInfo)
-
-	return subject, body
+	return fmt.Sprintf("Order %s cancelled: %s.", orderID, reason)
 }
@@ -50,7 +50,7 @@ func (hdl *DeleteCommandHandler) Execute(ctx context.Context, req OrderDeleteReq
 	}
 
 	// Check if order can be deleted
-	if tracking.State != "waiting_for_shipper" && tracking.State != "preparing" {
+	if tracking.State != StatePendingRestaurant && tracking.State != StateRestaurantAccepted && tracking.State != StatePreparing {
 		return datatype.ErrBadRequest.WithWrap(ordermodel.ErrOrderIsProcessed).WithDebug(ordermodel.ErrOrderIsProcessed.Error())
 	}
 
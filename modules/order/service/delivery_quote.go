@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/rpc-client"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"go.opentelemetry.io/otel"
+)
+
+// Delivery ETA settings
+const (
+	PreparationTimeMinutes = 15   // average time for the restaurant to prepare an order
+	AverageSpeedKmPerHour  = 25.0 // average shipper speed in the city
+	DefaultEstimatedTime   = 30   // used when an order has no quoted ETA
+)
+
+// Define DTOs & validate
+type DeliveryQuoteReq struct {
+	RestaurantID    string              `json:"restaurantId"`
+	DeliveryAddress *ordermodel.Address `json:"deliveryAddress"`
+}
+
+func (r *DeliveryQuoteReq) Validate() error {
+	if r.RestaurantID == "" {
+		return ordermodel.ErrRestaurantRequired
+	}
+
+	if r.DeliveryAddress == nil {
+		return ordermodel.ErrDeliveryAddressRequired
+	}
+
+	return nil
+}
+
+type DeliveryQuoteRes struct {
+	RestaurantID string `json:"restaurantId"`
+	ordermodel.DeliveryQuote
+}
+
+// IDistanceProvider returns the distance in kilometers between two points.
+// Haversine is the default, a road-distance engine can be plugged in instead.
+type IDistanceProvider interface {
+	Distance(ctx context.Context, fromLat, fromLng, toLat, toLng float64) (float64, error)
+}
+
+type HaversineDistanceProvider struct{}
+
+func NewHaversineDistanceProvider() *HaversineDistanceProvider {
+	return &HaversineDistanceProvider{}
+}
+
+func (p *HaversineDistanceProvider) Distance(ctx context.Context, fromLat, fromLng, toLat, toLng float64) (float64, error) {
+	return sharecomponent.Haversine(fromLat, fromLng, toLat, toLng), nil
+}
+
+// Initialize service
+type IDeliveryQuoteRestaurantRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO, error)
+}
+
+type DeliveryQuoteService struct {
+	restaurantRepo   IDeliveryQuoteRestaurantRepo
+	distanceProvider IDistanceProvider
+}
+
+func NewDeliveryQuoteService(restaurantRepo IDeliveryQuoteRestaurantRepo, distanceProvider IDistanceProvider) *DeliveryQuoteService {
+	return &DeliveryQuoteService{
+		restaurantRepo:   restaurantRepo,
+		distanceProvider: distanceProvider,
+	}
+}
+
+// Execute handles POST /orders/quote
+func (s *DeliveryQuoteService) Execute(ctx context.Context, req *DeliveryQuoteReq) (*DeliveryQuoteRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	restaurantID, err := uuid.Parse(req.RestaurantID)
+	if err != nil {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrInvalidRestaurantIdFormat.Error())
+	}
+
+	quote, err := s.QuoteDelivery(ctx, restaurantID, req.DeliveryAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryQuoteRes{RestaurantID: req.RestaurantID, DeliveryQuote: *quote}, nil
+}
+
+// QuoteDelivery computes distance, fee and ETA from the restaurant to the delivery address
+func (s *DeliveryQuoteService) QuoteDelivery(ctx context.Context, restaurantID uuid.UUID, address *ordermodel.Address) (*ordermodel.DeliveryQuote, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "quote-delivery")
+	defer span.End()
+
+	if address == nil || address.Lat == nil || address.Lng == nil {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrDeliveryLocationRequired.Error())
+	}
+
+	restaurantMap, err := s.restaurantRepo.FindByIds(ctx, []uuid.UUID{restaurantID})
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	restaurant, ok := restaurantMap[restaurantID]
+	if !ok {
+		return nil, datatype.ErrNotFound.WithError(ordermodel.ErrRestaurantNotAvailable.Error())
+	}
+
+	if restaurant.Lat == 0 && restaurant.Lng == 0 {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrRestaurantLocationMissing.Error())
+	}
+
+	distance, err := s.distanceProvider.Distance(ctx, restaurant.Lat, restaurant.Lng, *address.Lat, *address.Lng)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return &ordermodel.DeliveryQuote{
+		DistanceKm:    math.Round(distance*100) / 100,
+		DeliveryFee:   roundPrice(distance * restaurant.ShippingFeePerKm),
+		EstimatedTime: PreparationTimeMinutes + travelMinutes(distance),
+	}, nil
+}
+
+// travelMinutes is the time for a shipper to ride the distance at the average speed
+func travelMinutes(distanceKm float64) int {
+	return int(math.Ceil(distanceKm / AverageSpeedKmPerHour * 60))
+}
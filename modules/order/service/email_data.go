@@ -0,0 +1,45 @@
+package service
+
+import (
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+)
+
+// orderEmailData is the data of the order email templates
+type orderEmailData struct {
+	OrderID         string
+	OldState        string
+	NewState        string
+	ShipperID       string
+	RestaurantID    string
+	PaymentStatus   string
+	PaymentMethod   string
+	Reason          string
+	TotalPrice      float64
+	EstimatedTime   int // minutes
+	DeliveryAddress string
+	Items           []orderEmailItem
+}
+
+type orderEmailItem struct {
+	Quantity int
+	Price    float64
+}
+
+// newOrderEmailData fills the template data from the order, its tracking and its details
+func newOrderEmailData(orderID string, order *ordermodel.Order, tracking *ordermodel.OrderTracking, orderDetails []ordermodel.OrderDetail) orderEmailData {
+	data := orderEmailData{OrderID: orderID}
+	if order != nil {
+		data.TotalPrice = order.TotalPrice
+	}
+	if tracking != nil {
+		data.RestaurantID = tracking.RestaurantID
+		data.PaymentStatus = tracking.PaymentStatus
+		data.PaymentMethod = tracking.PaymentMethod
+		data.EstimatedTime = tracking.EstimatedTime
+		data.DeliveryAddress = string(tracking.DeliveryAddress)
+	}
+	for _, detail := range orderDetails {
+		data.Items = append(data.Items, orderEmailItem{Quantity: detail.Quantity, Price: detail.Price})
+	}
+	return data
+}
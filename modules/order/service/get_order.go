@@ -15,22 +15,23 @@ type OrderDetailReq struct {
 }
 
 type OrderDetailRes struct {
-	ID              string               `json:"id"`
-	UserID          string               `json:"userId"`
-	TotalPrice      float64              `json:"totalPrice"`
-	ShipperID       *string              `json:"shipperId,omitempty"`
-	Status          string               `json:"status"`
-	State           string               `json:"state"`
-	PaymentStatus   string               `json:"paymentStatus"`
-	PaymentMethod   string               `json:"paymentMethod"`
-	DeliveryAddress ordermodel.Address   `json:"deliveryAddress"`
-	DeliveryFee     float64              `json:"deliveryFee"`
-	EstimatedTime   int                  `json:"estimatedTime"`
-	DeliveryTime    int                  `json:"deliveryTime"`
-	RestaurantID    string               `json:"restaurantId"`
-	CreatedAt       time.Time            `json:"createdAt"`
-	UpdatedAt       time.Time            `json:"updatedAt"`
-	OrderDetails    []OrderDetailItemDto `json:"orderDetails"`
+	ID              string                     `json:"id"`
+	UserID          string                     `json:"userId"`
+	TotalPrice      float64                    `json:"totalPrice"`
+	PriceBreakdown  *ordermodel.PriceBreakdown `json:"priceBreakdown,omitempty"`
+	ShipperID       *string                    `json:"shipperId,omitempty"`
+	Status          string                     `json:"status"`
+	State           string                     `json:"state"`
+	PaymentStatus   string                     `json:"paymentStatus"`
+	PaymentMethod   string                     `json:"paymentMethod"`
+	DeliveryAddress ordermodel.Address         `json:"deliveryAddress"`
+	DeliveryFee     float64                    `json:"deliveryFee"`
+	EstimatedTime   int                        `json:"estimatedTime"`
+	DeliveryTime    int                        `json:"deliveryTime"`
+	RestaurantID    string                     `json:"restaurantId"`
+	CreatedAt       time.Time                  `json:"createdAt"`
+	UpdatedAt       time.Time                  `json:"updatedAt"`
+	OrderDetails    []OrderDetailItemDto       `json:"orderDetails"`
 }
 
 type OrderDetailItemDto struct {
@@ -94,10 +95,16 @@ func (hdl *GetDetailQueryHandler) Execute(ctx context.Context, req OrderDetailRe
 	// Create response
 	var deliveryAddress ordermodel.Address
 	json.Unmarshal(tracking.DeliveryAddress, &deliveryAddress)
+	var priceBreakdown *ordermodel.PriceBreakdown
+	if len(order.PriceBreakdown) > 0 {
+		priceBreakdown = &ordermodel.PriceBreakdown{}
+		json.Unmarshal(order.PriceBreakdown, priceBreakdown)
+	}
 	return &OrderDetailRes{
 		ID:              order.ID,
 		UserID:          order.UserID,
 		TotalPrice:      order.TotalPrice,
+		PriceBreakdown:  priceBreakdown,
 		ShipperID:       order.ShipperID,
 		Status:          order.Status,
 		State:           tracking.State,
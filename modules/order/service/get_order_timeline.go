@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type OrderTimelineRes struct {
+	OrderID              string              `json:"orderId"`
+	CurrentState         string              `json:"currentState"`
+	Steps                []OrderTimelineStep `json:"steps"`
+	TotalDurationSeconds int64               `json:"totalDurationSeconds"` // From the creation to the last step
+}
+
+type OrderTimelineStep struct {
+	ordermodel.OrderStateHistory
+	DurationSeconds int64 `json:"durationSeconds"` // Since the previous step
+}
+
+// Initialize service
+type IOrderTimelineRepo interface {
+	FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error)
+	FindStateHistory(ctx context.Context, orderId string) ([]ordermodel.OrderStateHistory, error)
+}
+
+type GetTimelineQueryHandler struct {
+	repo IOrderTimelineRepo
+}
+
+func NewGetTimelineQueryHandler(repo IOrderTimelineRepo) *GetTimelineQueryHandler {
+	return &GetTimelineQueryHandler{repo: repo}
+}
+
+// Implement
+// Execute returns the lifecycle of an order from its state history
+func (hdl *GetTimelineQueryHandler) Execute(ctx context.Context, orderId string) (*OrderTimelineRes, error) {
+	if orderId == "" {
+		return nil, datatype.ErrBadRequest.WithWrap(ordermodel.ErrOrderIdRequired).WithDebug(ordermodel.ErrOrderIdRequired.Error())
+	}
+
+	_, tracking, _, err := hdl.repo.FindById(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, ordermodel.ErrOrderNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	history, err := hdl.repo.FindStateHistory(ctx, orderId)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return newOrderTimeline(orderId, tracking.State, history), nil
+}
+
+func newOrderTimeline(orderId, currentState string, history []ordermodel.OrderStateHistory) *OrderTimelineRes {
+	res := &OrderTimelineRes{
+		OrderID:      orderId,
+		CurrentState: currentState,
+		Steps:        make([]OrderTimelineStep, 0, len(history)),
+	}
+
+	for i, h := range history {
+		step := OrderTimelineStep{OrderStateHistory: h}
+		if i > 0 {
+			step.DurationSeconds = durationSeconds(history[i-1].CreatedAt, h.CreatedAt)
+		}
+		res.Steps = append(res.Steps, step)
+	}
+
+	if len(history) > 1 {
+		res.TotalDurationSeconds = durationSeconds(history[0].CreatedAt, history[len(history)-1].CreatedAt)
+	}
+	return res
+}
+
+func durationSeconds(from, to time.Time) int64 {
+	return int64(to.Sub(from) / time.Second)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeOrderTimelineRepo struct {
+	tracking *ordermodel.OrderTracking
+	history  []ordermodel.OrderStateHistory
+}
+
+func (r *fakeOrderTimelineRepo) FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error) {
+	if r.tracking == nil || r.tracking.OrderID != id {
+		return nil, nil, nil, ordermodel.ErrOrderNotFound
+	}
+	return &ordermodel.Order{ID: id}, r.tracking, nil, nil
+}
+
+func (r *fakeOrderTimelineRepo) FindStateHistory(ctx context.Context, orderId string) ([]ordermodel.OrderStateHistory, error) {
+	return r.history, nil
+}
+
+func TestGetTimelineQueryHandler_Execute(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	step := func(from, to string, after time.Duration) ordermodel.OrderStateHistory {
+		return ordermodel.OrderStateHistory{OrderID: "order-1", FromState: from, ToState: to, CreatedAt: createdAt.Add(after)}
+	}
+
+	t.Run("TC 1: steps with the durations between them", func(t *testing.T) {
+		repo := &fakeOrderTimelineRepo{
+			tracking: &ordermodel.OrderTracking{OrderID: "order-1", State: StateOnTheWay},
+			history: []ordermodel.OrderStateHistory{
+				step("", StatePendingRestaurant, 0),
+				step(StatePendingRestaurant, StatePreparing, 90*time.Second),
+				step(StatePreparing, StateOnTheWay, 20*time.Minute),
+			},
+		}
+
+		timeline, err := NewGetTimelineQueryHandler(repo).Execute(ctx, "order-1")
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if timeline.CurrentState != StateOnTheWay || len(timeline.Steps) != 3 {
+			t.Fatalf("timeline = %+v", timeline)
+		}
+		if got := []int64{timeline.Steps[0].DurationSeconds, timeline.Steps[1].DurationSeconds, timeline.Steps[2].DurationSeconds}; got[0] != 0 || got[1] != 90 || got[2] != 1110 {
+			t.Errorf("durations = %v, want [0 90 1110]", got)
+		}
+		if timeline.TotalDurationSeconds != 1200 {
+			t.Errorf("TotalDurationSeconds = %d, want 1200", timeline.TotalDurationSeconds)
+		}
+	})
+
+	t.Run("TC 2: unknown order", func(t *testing.T) {
+		_, err := NewGetTimelineQueryHandler(&fakeOrderTimelineRepo{}).Execute(ctx, "order-2")
+		if !errors.Is(err, datatype.ErrNotFound) {
+			t.Errorf("Execute() error = %v, want not found", err)
+		}
+	})
+
+	t.Run("TC 3: history of a cancellation keeps the actor, reason and metadata", func(t *testing.T) {
+		reason, paymentStatus, updatedBy := "Customer changed their mind", PaymentStatusPaid, "admin-1"
+		tracking := &ordermodel.OrderTracking{OrderID: "order-1", State: StateCancelled, UpdatedBy: &updatedBy, UpdatedAt: createdAt}
+		tracking.StateChange = newStateChange(&StateTransitionRequest{
+			NewState:           StateCancelled,
+			UpdatedByRole:      string(datatype.RoleAdmin),
+			CancellationReason: &reason,
+			PaymentStatus:      &paymentStatus,
+		})
+
+		history, err := ordermodel.NewOrderStateHistory(tracking, StatePreparing)
+		if err != nil {
+			t.Fatalf("NewOrderStateHistory() error = %v", err)
+		}
+		if history.FromState != StatePreparing || history.ToState != StateCancelled || *history.ActorID != updatedBy ||
+			history.ActorRole != string(datatype.RoleAdmin) || history.Reason != reason || !history.CreatedAt.Equal(createdAt) {
+			t.Errorf("history = %+v", history)
+		}
+
+		var metadata map[string]string
+		if err := json.Unmarshal(history.Metadata, &metadata); err != nil || metadata["paymentStatus"] != PaymentStatusPaid {
+			t.Errorf("metadata = %s, %v", history.Metadata, err)
+		}
+	})
+}
@@ -2,81 +2,67 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 
+	"github.com/google/uuid"
 	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 )
 
+// IInventoryStockRepo manages food stock reservations in the food service
+type IInventoryStockRepo interface {
+	ReserveStock(ctx context.Context, orderID string, items map[uuid.UUID]int) error
+	CommitStock(ctx context.Context, orderID string) error
+	ReleaseStock(ctx context.Context, orderID string) error
+}
+
 // InventoryService handles inventory operations for order management
 type InventoryService struct {
-	// In a real implementation, this would have dependencies like:
-	// - Food service client for inventory updates
-	// - Database for inventory tracking
-	// - Cache for inventory data
+	stockRepo IInventoryStockRepo
 }
 
 // NewInventoryService creates a new inventory service
-func NewInventoryService() *InventoryService {
-	return &InventoryService{}
+func NewInventoryService(stockRepo IInventoryStockRepo) *InventoryService {
+	return &InventoryService{stockRepo: stockRepo}
 }
 
-// RestoreInventory restores inventory for cancelled order items
-func (s *InventoryService) RestoreInventory(ctx context.Context, orderDetails []ordermodel.OrderDetail) error {
-	log.Printf("Restoring inventory for %d order items", len(orderDetails))
+// ReserveInventory reserves stock for an order at checkout.
+// The food service reserves all items or none of them, so an order can never oversell.
+func (s *InventoryService) ReserveInventory(ctx context.Context, orderID string, items []OrderItem) error {
+	// Merge quantities when the same food appears several times
+	quantities := make(map[uuid.UUID]int, len(items))
+	for _, item := range items {
+		quantities[item.FoodID] += item.Quantity
+	}
 
-	for _, detail := range orderDetails {
-		if err := s.restoreItemInventory(ctx, detail); err != nil {
-			log.Printf("Failed to restore inventory for item %s: %v", detail.ID, err)
-			// Continue with other items even if one fails
-			continue
+	if err := s.stockRepo.ReserveStock(ctx, orderID, quantities); err != nil {
+		if err == ordermodel.ErrInventoryInsufficient {
+			return datatype.ErrConflict.WithWrap(err).WithError(err.Error())
+		}
+		if err == ordermodel.ErrFoodNotFound || err == ordermodel.ErrFoodNotAvailable {
+			return datatype.ErrBadRequest.WithWrap(err).WithError(err.Error())
 		}
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
 
-	log.Printf("Inventory restoration completed for %d items", len(orderDetails))
+	log.Printf("Inventory reserved for order %s: %d items", orderID, len(quantities))
 	return nil
 }
 
-// restoreItemInventory restores inventory for a single order item
-func (s *InventoryService) restoreItemInventory(ctx context.Context, detail ordermodel.OrderDetail) error {
-	// Parse food origin to get food ID
-	var foodOrigin map[string]interface{}
-	if err := json.Unmarshal(detail.FoodOrigin, &foodOrigin); err != nil {
-		log.Printf("Failed to parse food origin for item %s: %v", detail.ID, err)
-		return err
+// CommitInventory finalizes the reservation once the order is delivered
+func (s *InventoryService) CommitInventory(ctx context.Context, orderID string) error {
+	if err := s.stockRepo.CommitStock(ctx, orderID); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
-
-	foodID, ok := foodOrigin["id"].(string)
-	if !ok {
-		log.Printf("Food ID not found in food origin for item %s", detail.ID)
-		return nil // Skip this item
-	}
-
-	// TODO: In a real implementation, this would:
-	// 1. Call the food service API to increase inventory
-	// 2. Update inventory cache
-	// 3. Handle inventory restoration failures
-	// 4. Log inventory changes for audit
-
-	log.Printf("Restoring inventory: foodID=%s, quantity=%d", foodID, detail.Quantity)
-
-	// Simulate inventory restoration
-	// In production, this would be actual API calls to the food service
-	log.Printf("Inventory restored for food %s: +%d units", foodID, detail.Quantity)
-
 	return nil
 }
 
-// CheckInventoryAvailability checks if items are available (for future use)
-func (s *InventoryService) CheckInventoryAvailability(ctx context.Context, orderDetails []ordermodel.OrderDetail) error {
-	// TODO: Implement inventory availability checking
-	// This would be used during order creation to ensure items are available
-	return nil
-}
+// RestoreInventory releases the stock reserved by a cancelled order
+func (s *InventoryService) RestoreInventory(ctx context.Context, orderID string) error {
+	if err := s.stockRepo.ReleaseStock(ctx, orderID); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
 
-// ReserveInventory reserves inventory for an order (for future use)
-func (s *InventoryService) ReserveInventory(ctx context.Context, orderDetails []ordermodel.OrderDetail) error {
-	// TODO: Implement inventory reservation
-	// This would be used during order creation to reserve items
+	log.Printf("Inventory restored for order %s", orderID)
 	return nil
 }
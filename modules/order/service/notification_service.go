@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/google/uuid"
 	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/rpc-client"
@@ -29,6 +31,27 @@ const (
 	ChannelPush  NotificationChannel = "push"
 )
 
+// Notification events of the user preferences, named <audience>.<order event>
+const (
+	NotifyCustomerOrderCreated         = "customer.order_created"
+	NotifyCustomerOrderStateChanged    = "customer.order_state_changed"
+	NotifyCustomerShipperAssigned      = "customer.shipper_assigned"
+	NotifyCustomerPaymentStatusChanged = "customer.payment_status_changed"
+	NotifyCustomerOrderCancelled       = "customer.order_cancelled"
+
+	NotifyRestaurantOrderCreated         = "restaurant.order_created"
+	NotifyRestaurantOrderStateChanged    = "restaurant.order_state_changed"
+	NotifyRestaurantShipperAssigned      = "restaurant.shipper_assigned"
+	NotifyRestaurantPaymentStatusChanged = "restaurant.payment_status_changed"
+	NotifyRestaurantOrderCancelled       = "restaurant.order_cancelled"
+
+	NotifyShipperOrderCreated         = "shipper.order_created"
+	NotifyShipperOrderStateChanged    = "shipper.order_state_changed"
+	NotifyShipperAssigned             = "shipper.shipper_assigned"
+	NotifyShipperPaymentStatusChanged = "shipper.payment_status_changed"
+	NotifyShipperOrderCancelled       = "shipper.order_cancelled"
+)
+
 // Repository interfaces for getting order details
 type IOrderNotificationRepo interface {
 	FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error)
@@ -37,6 +60,8 @@ type IOrderNotificationRepo interface {
 // Repository interfaces for getting user contact information
 type IUserNotificationRepo interface {
 	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]ordermodel.User, error)
+	FindDeviceTokens(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID][]string, error)
+	FindNotificationPreferences(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]ordermodel.NotificationPreference, error)
 }
 
 type IRestaurantNotificationRepo interface {
@@ -44,34 +69,62 @@ type IRestaurantNotificationRepo interface {
 }
 
 // External notification services interfaces
-// Email: Implemented
-// SMS: TBD
-// Push Notification: TBD
 type IEmailService interface {
 	SendEmail(message sharedModel.EmailMessage) error
 }
 
+type ISmsService interface {
+	SendSms(message sharedModel.SmsMessage) error
+}
+
+// IPushService sends to FCM/APNs device tokens
+type IPushService interface {
+	SendPush(message sharedModel.PushMessage) error
+}
+
+// IEmailRenderer renders the localized email templates, named <audience>.<order event> like the notification events
+type IEmailRenderer interface {
+	Render(locale, name string, data any) (*sharedModel.EmailContent, error)
+}
+
+// IInboxService keeps the notifications in the in-app inbox of their recipients
+type IInboxService interface {
+	Create(ctx context.Context, notifications ...ordermodel.InboxNotification) error
+}
+
 // Service
 type OrderNotificationService struct {
 	orderRepo      IOrderNotificationRepo
 	userRepo       IUserNotificationRepo
 	restaurantRepo IRestaurantNotificationRepo
 	emailSvc       IEmailService
+	emailRenderer  IEmailRenderer
+	smsSvc         ISmsService
+	pushSvc        IPushService
+	inboxSvc       IInboxService
 	enabled        bool
 }
 
-// TODO: System can notify via email, sms (TBD) and push notification(TBD)
+// System notifies via email, sms, push notification and the in-app inbox. A nil sms, push or inbox service disables the channel.
 func NewOrderNotificationService(
 	orderRepo IOrderNotificationRepo,
 	userRepo IUserNotificationRepo,
 	restaurantRepo IRestaurantNotificationRepo,
 	emailSvc IEmailService,
+	emailRenderer IEmailRenderer,
+	smsSvc ISmsService,
+	pushSvc IPushService,
+	inboxSvc IInboxService,
 ) *OrderNotificationService {
 	return &OrderNotificationService{
 		orderRepo:      orderRepo,
 		userRepo:       userRepo,
 		restaurantRepo: restaurantRepo,
 		emailSvc:       emailSvc,
+		emailRenderer:  emailRenderer,
+		smsSvc:         smsSvc,
+		pushSvc:        pushSvc,
+		inboxSvc:       inboxSvc,
 		enabled:        true, // Can be configured via environment variables
 	}
 }
@@ -298,7 +351,7 @@ func (s *OrderNotificationService) NotifyOrderCancelled(ctx context.Context, ord
 func (s *OrderNotificationService) shouldNotifyShipper(newState string) bool {
 	// Notify shipper for states where they are involved
 	switch newState {
-	case "preparing", "on_the_way", "delivered", "cancel":
+	case StatePreparing, StateReadyForPickup, StateOnTheWay, StateDelivered, StateCancelled, StateRestaurantRejected:
 		return true
 	default:
 		return false
@@ -306,19 +359,122 @@ func (s *OrderNotificationService) shouldNotifyShipper(newState string) bool {
 }
 
 // notifyRestaurantStateChange sends notification to restaurant about order state change
-// sendEmailNotification sends an email notification
-func (s *OrderNotificationService) sendEmailNotification(_ context.Context, to, subject, body string) error {
+// sendEmailNotification sends the email in HTML with its plain text alternative, from the configured sender
+func (s *OrderNotificationService) sendEmailNotification(_ context.Context, to string, content *sharedModel.EmailContent) error {
 	if s.emailSvc == nil {
 		return fmt.Errorf("email service not configured")
 	}
+	if to == "" {
+		return nil
+	}
 	var msg sharedModel.EmailMessage
-	msg.From = "sender@gmail.com"
 	msg.To = []string{to}
-	msg.Subject = subject
-	msg.Body = body
+	msg.Subject = content.Subject
+	msg.Body = content.HTML
+	msg.TextBody = content.Text
+	msg.IsHTML = true
 	return s.emailSvc.SendEmail(msg)
 }
 
+// sendNotification renders the email of the event in the user's language and keeps the notification in the user's inbox,
+// then sends the email and the short text by SMS and push on the channels the user enabled for the event and can be reached on.
+// Every channel is tried, the errors are returned together.
+func (s *OrderNotificationService) sendNotification(ctx context.Context, user ordermodel.User, event, orderID string, data orderEmailData, text string) error {
+	var errs []error
+
+	prefMap, err := s.userRepo.FindNotificationPreferences(ctx, []uuid.UUID{user.Id})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get notification preference: %w", err))
+	}
+	pref, hasPref := prefMap[user.Id]
+	if err == nil && !hasPref {
+		errs = append(errs, fmt.Errorf("notification preference of user %s not found", user.Id))
+	}
+
+	// Without preference, the email is rendered in the default language and only the inbox is written
+	content, err := s.emailRenderer.Render(pref.Locale, event, data)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to render email: %w", err))
+		return errors.Join(errs...)
+	}
+
+	if err := s.sendInboxNotification(ctx, user.Id, event, orderID, content.Subject, text); err != nil {
+		errs = append(errs, fmt.Errorf("inbox: %w", err))
+	}
+
+	if !hasPref {
+		return errors.Join(errs...)
+	}
+
+	now := time.Now()
+
+	if pref.Allows(event, string(ChannelEmail), now) {
+		if err := s.sendEmailNotification(ctx, user.Email, content); err != nil {
+			errs = append(errs, fmt.Errorf("email: %w", err))
+		}
+	}
+
+	if pref.Allows(event, string(ChannelSMS), now) {
+		if err := s.sendSmsNotification(ctx, user.Phone, text); err != nil {
+			errs = append(errs, fmt.Errorf("sms: %w", err))
+		}
+	}
+
+	if pref.Allows(event, string(ChannelPush), now) {
+		if err := s.sendPushNotification(ctx, user.Id, orderID, content.Subject, text); err != nil {
+			errs = append(errs, fmt.Errorf("push: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendInboxNotification adds the notification to the user's in-app inbox, the order id is the deep link of the app
+func (s *OrderNotificationService) sendInboxNotification(ctx context.Context, userID uuid.UUID, event, orderID, title, text string) error {
+	if s.inboxSvc == nil || userID == uuid.Nil {
+		return nil
+	}
+
+	return s.inboxSvc.Create(ctx, ordermodel.InboxNotification{
+		UserId: userID,
+		Type:   event,
+		Title:  title,
+		Body:   text,
+		Data:   map[string]string{"orderId": orderID},
+	})
+}
+
+// sendSmsNotification sends an SMS if the channel is configured and the user has a phone number
+func (s *OrderNotificationService) sendSmsNotification(_ context.Context, phone, text string) error {
+	if s.smsSvc == nil || phone == "" {
+		return nil
+	}
+	return s.smsSvc.SendSms(sharedModel.SmsMessage{To: phone, Body: text})
+}
+
+// sendPushNotification sends a push notification to every registered device of the user
+func (s *OrderNotificationService) sendPushNotification(ctx context.Context, userID uuid.UUID, orderID, title, text string) error {
+	if s.pushSvc == nil {
+		return nil
+	}
+
+	tokenMap, err := s.userRepo.FindDeviceTokens(ctx, []uuid.UUID{userID})
+	if err != nil {
+		return fmt.Errorf("failed to get device tokens: %w", err)
+	}
+	tokens := tokenMap[userID]
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	return s.pushSvc.SendPush(sharedModel.PushMessage{
+		Tokens: tokens,
+		Title:  title,
+		Body:   text,
+		Data:   map[string]string{"orderId": orderID},
+	})
+}
+
 // logNotification logs notification for debugging/monitoring
 func (s *OrderNotificationService) logNotification(notificationType string, data map[string]interface{}) error {
 	log.Printf("Notification [%s]: %+v", notificationType, data)
@@ -339,10 +495,16 @@ func (s *OrderNotificationService) IsEnabled() bool {
 // getStateDisplayName returns a user-friendly display name for order states
 func (s *OrderNotificationService) getStateDisplayName(state string) string {
 	switch state {
-	case StateWaitingForShipper:
-		return "waiting for shipper"
+	case StatePendingRestaurant:
+		return "waiting for the restaurant"
+	case StateRestaurantAccepted:
+		return "accepted by the restaurant"
+	case StateRestaurantRejected:
+		return "rejected by the restaurant"
 	case StatePreparing:
 		return "being prepared"
+	case StateReadyForPickup:
+		return "ready for pickup"
 	case StateOnTheWay:
 		return "on the way"
 	case StateDelivered:
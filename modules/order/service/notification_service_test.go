@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	shareComponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	sharedModel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+type fakeUserNotificationRepo struct {
+	users  map[uuid.UUID]ordermodel.User
+	tokens map[uuid.UUID][]string
+	prefs  map[uuid.UUID]ordermodel.NotificationPreference // Every channel of every event when nil
+}
+
+func (r *fakeUserNotificationRepo) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]ordermodel.User, error) {
+	return r.users, nil
+}
+
+func (r *fakeUserNotificationRepo) FindDeviceTokens(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID][]string, error) {
+	return r.tokens, nil
+}
+
+func (r *fakeUserNotificationRepo) FindNotificationPreferences(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]ordermodel.NotificationPreference, error) {
+	if r.prefs != nil {
+		return r.prefs, nil
+	}
+
+	prefs := make(map[uuid.UUID]ordermodel.NotificationPreference, len(userIds))
+	for _, userId := range userIds {
+		prefs[userId] = ordermodel.NotificationPreference{
+			UserId:   userId,
+			Channels: map[string][]string{NotifyCustomerShipperAssigned: {"email", "sms", "push"}},
+		}
+	}
+	return prefs, nil
+}
+
+type fakeChannels struct {
+	smsErr error
+	emails []sharedModel.EmailMessage
+	sms    []sharedModel.SmsMessage
+	pushes []sharedModel.PushMessage
+	inbox  []ordermodel.InboxNotification
+}
+
+func (c *fakeChannels) SendEmail(message sharedModel.EmailMessage) error {
+	c.emails = append(c.emails, message)
+	return nil
+}
+
+func (c *fakeChannels) SendSms(message sharedModel.SmsMessage) error {
+	if c.smsErr != nil {
+		return c.smsErr
+	}
+	c.sms = append(c.sms, message)
+	return nil
+}
+
+func (c *fakeChannels) SendPush(message sharedModel.PushMessage) error {
+	c.pushes = append(c.pushes, message)
+	return nil
+}
+
+func (c *fakeChannels) Create(ctx context.Context, notifications ...ordermodel.InboxNotification) error {
+	c.inbox = append(c.inbox, notifications...)
+	return nil
+}
+
+func TestOrderNotificationService_notifyCustomerShipperAssignment(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.New()
+	renderer := shareComponent.MustNewEmailTemplateRenderer("en")
+
+	t.Run("TC 1: customer is notified on every channel", func(t *testing.T) {
+		userRepo := &fakeUserNotificationRepo{
+			users:  map[uuid.UUID]ordermodel.User{userId: {Id: userId, Email: "a@b.c", Phone: "+84901234567"}},
+			tokens: map[uuid.UUID][]string{userId: {"token-1", "token-2"}},
+		}
+		channels := &fakeChannels{}
+		svc := NewOrderNotificationService(nil, userRepo, nil, channels, renderer, channels, channels, channels)
+
+		if err := svc.notifyCustomerShipperAssignment(ctx, userId.String(), "order-1", "shipper-1"); err != nil {
+			t.Fatalf("notifyCustomerShipperAssignment() error = %v", err)
+		}
+		if len(channels.emails) != 1 || channels.emails[0].To[0] != "a@b.c" || !channels.emails[0].IsHTML || channels.emails[0].TextBody == "" {
+			t.Errorf("emails = %+v", channels.emails)
+		}
+		if len(channels.sms) != 1 || channels.sms[0].To != "+84901234567" || !strings.Contains(channels.sms[0].Body, "order-1") {
+			t.Errorf("sms = %+v", channels.sms)
+		}
+		if len(channels.pushes) != 1 || len(channels.pushes[0].Tokens) != 2 || channels.pushes[0].Data["orderId"] != "order-1" {
+			t.Errorf("pushes = %+v", channels.pushes)
+		}
+		if len(channels.inbox) != 1 || channels.inbox[0].UserId != userId || channels.inbox[0].Type != NotifyCustomerShipperAssigned || channels.inbox[0].Data["orderId"] != "order-1" {
+			t.Errorf("inbox = %+v", channels.inbox)
+		}
+	})
+
+	t.Run("TC 2: channels the customer cannot be reached on are skipped", func(t *testing.T) {
+		userRepo := &fakeUserNotificationRepo{
+			users: map[uuid.UUID]ordermodel.User{userId: {Id: userId, Email: "a@b.c"}},
+		}
+		channels := &fakeChannels{}
+		svc := NewOrderNotificationService(nil, userRepo, nil, channels, renderer, channels, channels, channels)
+
+		if err := svc.notifyCustomerShipperAssignment(ctx, userId.String(), "order-1", "shipper-1"); err != nil {
+			t.Fatalf("notifyCustomerShipperAssignment() error = %v", err)
+		}
+		if len(channels.emails) != 1 || len(channels.sms) != 0 || len(channels.pushes) != 0 {
+			t.Errorf("emails = %d, sms = %d, pushes = %d, want 1, 0, 0", len(channels.emails), len(channels.sms), len(channels.pushes))
+		}
+	})
+
+	t.Run("TC 3: a failed channel does not stop the others", func(t *testing.T) {
+		userRepo := &fakeUserNotificationRepo{
+			users:  map[uuid.UUID]ordermodel.User{userId: {Id: userId, Email: "a@b.c", Phone: "+84901234567"}},
+			tokens: map[uuid.UUID][]string{userId: {"token-1"}},
+		}
+		smsErr := errors.New("sms provider unavailable")
+		channels := &fakeChannels{smsErr: smsErr}
+		svc := NewOrderNotificationService(nil, userRepo, nil, channels, renderer, channels, channels, channels)
+
+		err := svc.notifyCustomerShipperAssignment(ctx, userId.String(), "order-1", "shipper-1")
+		if !errors.Is(err, smsErr) {
+			t.Errorf("notifyCustomerShipperAssignment() error = %v, want %v", err, smsErr)
+		}
+		if len(channels.emails) != 1 || len(channels.pushes) != 1 {
+			t.Errorf("emails = %d, pushes = %d, want 1, 1", len(channels.emails), len(channels.pushes))
+		}
+	})
+	t.Run("TC 4: only the channels enabled in the preference are used, the inbox always is", func(t *testing.T) {
+		userRepo := &fakeUserNotificationRepo{
+			users:  map[uuid.UUID]ordermodel.User{userId: {Id: userId, Email: "a@b.c", Phone: "+84901234567"}},
+			tokens: map[uuid.UUID][]string{userId: {"token-1"}},
+			prefs: map[uuid.UUID]ordermodel.NotificationPreference{userId: {
+				UserId:   userId,
+				Channels: map[string][]string{NotifyCustomerShipperAssigned: {"push"}},
+			}},
+		}
+		channels := &fakeChannels{}
+		svc := NewOrderNotificationService(nil, userRepo, nil, channels, renderer, channels, channels, channels)
+
+		if err := svc.notifyCustomerShipperAssignment(ctx, userId.String(), "order-1", "shipper-1"); err != nil {
+			t.Fatalf("notifyCustomerShipperAssignment() error = %v", err)
+		}
+		if len(channels.emails) != 0 || len(channels.sms) != 0 || len(channels.pushes) != 1 || len(channels.inbox) != 1 {
+			t.Errorf("emails = %d, sms = %d, pushes = %d, inbox = %d, want 0, 0, 1, 1", len(channels.emails), len(channels.sms), len(channels.pushes), len(channels.inbox))
+		}
+	})
+
+	t.Run("TC 5: the email is written in the customer's locale", func(t *testing.T) {
+		userRepo := &fakeUserNotificationRepo{
+			users: map[uuid.UUID]ordermodel.User{userId: {Id: userId, Email: "a@b.c"}},
+			prefs: map[uuid.UUID]ordermodel.NotificationPreference{userId: {
+				UserId:   userId,
+				Channels: map[string][]string{NotifyCustomerShipperAssigned: {"email"}},
+				Locale:   "vi",
+			}},
+		}
+		channels := &fakeChannels{}
+		svc := NewOrderNotificationService(nil, userRepo, nil, channels, renderer, channels, channels, channels)
+
+		if err := svc.notifyCustomerShipperAssignment(ctx, userId.String(), "order-1", "shipper-1"); err != nil {
+			t.Fatalf("notifyCustomerShipperAssignment() error = %v", err)
+		}
+		if len(channels.emails) != 1 || channels.emails[0].Subject != "Đã có tài xế - Đơn hàng order-1" {
+			t.Fatalf("emails = %+v", channels.emails)
+		}
+		if !strings.Contains(channels.emails[0].Body, `lang="vi"`) || !strings.Contains(channels.emails[0].TextBody, "Đã có tài xế nhận đơn") {
+			t.Errorf("email body = %s, text = %s", channels.emails[0].Body, channels.emails[0].TextBody)
+		}
+		if len(channels.inbox) != 1 || channels.inbox[0].Title != channels.emails[0].Subject {
+			t.Errorf("inbox = %+v", channels.inbox)
+		}
+	})
+}
+
+func TestNotificationPreference_Allows(t *testing.T) {
+	pref := ordermodel.NotificationPreference{
+		Channels:        map[string][]string{NotifyRestaurantOrderCreated: {"email", "sms", "push"}},
+		QuietHoursStart: "22:00",
+		QuietHoursEnd:   "07:00",
+		Timezone:        "Asia/Ho_Chi_Minh", // UTC+7
+	}
+
+	tests := []struct {
+		name    string
+		event   string
+		channel string
+		now     time.Time
+		want    bool
+	}{
+		{"TC 1: enabled channel outside quiet hours", NotifyRestaurantOrderCreated, "sms", time.Date(2025, 7, 1, 5, 0, 0, 0, time.UTC), true},
+		{"TC 2: sms muted in quiet hours before midnight", NotifyRestaurantOrderCreated, "sms", time.Date(2025, 7, 1, 15, 30, 0, 0, time.UTC), false},
+		{"TC 3: push muted in quiet hours after midnight", NotifyRestaurantOrderCreated, "push", time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC), false},
+		{"TC 4: email is never muted", NotifyRestaurantOrderCreated, "email", time.Date(2025, 7, 1, 15, 30, 0, 0, time.UTC), true},
+		{"TC 5: quiet hours end is excluded", NotifyRestaurantOrderCreated, "sms", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), true},
+		{"TC 6: event without channel", NotifyRestaurantOrderStateChanged, "email", time.Date(2025, 7, 1, 5, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pref.Allows(tt.event, tt.channel, tt.now); got != tt.want {
+				t.Errorf("Allows(%s, %s, %s) = %v, want %v", tt.event, tt.channel, tt.now, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+const (
+	// outboxClaimLease is how long a claimed event is hidden from other relays
+	outboxClaimLease = 30 * time.Second
+	// outboxBaseBackoff is the delay before the first retry, it doubles on every attempt
+	outboxBaseBackoff = time.Second
+	// outboxStatsInterval is how often the relay logs the outbox backlog
+	outboxStatsInterval = time.Minute
+)
+
+type IEvtPublisher interface {
+	Publish(ctx context.Context, topic string, evt *datatype.AppEvent) error
+}
+
+type IOutboxRepo interface {
+	ClaimPendingOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]ordermodel.OutboxEvent, error)
+	UpdateOutboxEvent(ctx context.Context, evt *ordermodel.OutboxEvent) error
+	GetOutboxStats(ctx context.Context) (*ordermodel.OutboxStats, error)
+}
+
+// OutboxMetrics is a snapshot of the outbox backlog and of the relay counters
+type OutboxMetrics struct {
+	Pending       int64   `json:"pending"`
+	Failed        int64   `json:"failed"`
+	LagSeconds    float64 `json:"lagSeconds"` // Age of the oldest pending event
+	Published     int64   `json:"published"`
+	PublishErrors int64   `json:"publishErrors"`
+	GaveUp        int64   `json:"gaveUp"`
+}
+
+// OutboxRelay publishes the events saved in the outbox through the message broker.
+// Delivery is at least once: an event is published again if it cannot be marked as sent.
+type OutboxRelay struct {
+	repo      IOutboxRepo
+	publisher IEvtPublisher
+	cfg       datatype.OutboxConfig
+
+	published     atomic.Int64
+	publishErrors atomic.Int64
+	gaveUp        atomic.Int64
+}
+
+func NewOutboxRelay(repo IOutboxRepo, publisher IEvtPublisher, cfg datatype.OutboxConfig) *OutboxRelay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+
+	return &OutboxRelay{repo: repo, publisher: publisher, cfg: cfg}
+}
+
+// Run publishes pending events until ctx is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	pollTicker := time.NewTicker(r.cfg.PollInterval)
+	defer pollTicker.Stop()
+	statsTicker := time.NewTicker(outboxStatsInterval)
+	defer statsTicker.Stop()
+
+	for {
+		// Keep going while batches are full, the backlog is drained before waiting again
+		for ctx.Err() == nil {
+			count, err := r.RelayBatch(ctx)
+			if err != nil {
+				log.Printf("Outbox relay: failed to claim events: %v", err)
+				break
+			}
+			if count < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-statsTicker.C:
+			r.logStats(ctx)
+		case <-pollTicker.C:
+		}
+	}
+}
+
+// RelayBatch publishes one batch of due events and returns how many were handled
+func (r *OutboxRelay) RelayBatch(ctx context.Context) (int, error) {
+	events, err := r.repo.ClaimPendingOutboxEvents(ctx, r.cfg.BatchSize, outboxClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range events {
+		r.relay(ctx, &events[i])
+	}
+
+	return len(events), nil
+}
+
+func (r *OutboxRelay) relay(ctx context.Context, evt *ordermodel.OutboxEvent) {
+	appEvt, publishErr := outboxAppEvent(evt)
+	if publishErr == nil {
+		publishErr = r.publisher.Publish(ctx, evt.Topic, appEvt)
+	}
+
+	now := time.Now().UTC()
+	evt.Attempts++
+	evt.UpdatedAt = now
+
+	if publishErr == nil {
+		r.published.Add(1)
+		evt.Status = ordermodel.OutboxStatusSent
+		evt.SentAt = &now
+		evt.LastError = nil
+	} else {
+		r.publishErrors.Add(1)
+		lastError := publishErr.Error()
+		evt.LastError = &lastError
+
+		if evt.Attempts >= r.cfg.MaxAttempts {
+			r.gaveUp.Add(1)
+			evt.Status = ordermodel.OutboxStatusFailed
+			log.Printf("Outbox relay: giving up event %s (%s) after %d attempts: %v", evt.ID, evt.Topic, evt.Attempts, publishErr)
+		} else {
+			evt.NextAttemptAt = now.Add(r.backoff(evt.Attempts))
+			log.Printf("Outbox relay: failed to publish event %s (%s), attempt %d: %v", evt.ID, evt.Topic, evt.Attempts, publishErr)
+		}
+	}
+
+	if err := r.repo.UpdateOutboxEvent(ctx, evt); err != nil {
+		// The claim lease expires and the event is handled again
+		log.Printf("Outbox relay: failed to update event %s: %v", evt.ID, err)
+	}
+}
+
+// outboxAppEvent rebuilds the event saved in the outbox with its original id and trace context
+func outboxAppEvent(evt *ordermodel.OutboxEvent) (*datatype.AppEvent, error) {
+	appEvt, err := datatype.DecodeEvt(evt.Payload)
+	if errors.Is(err, datatype.ErrInvalidEvt) {
+		// Saved before the events had an envelope, the payload is the data only
+		return datatype.NewAppEvent(
+			datatype.WithID(evt.ID),
+			datatype.WithTopic(evt.Topic),
+			datatype.WithData(json.RawMessage(evt.Payload)),
+			datatype.WithOccurredAt(evt.CreatedAt),
+		), nil
+	}
+	return appEvt, err
+}
+
+// backoff doubles the delay on every attempt, up to MaxBackoff
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := outboxBaseBackoff
+	for i := 1; i < attempts && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.MaxBackoff {
+		delay = r.cfg.MaxBackoff
+	}
+	return delay
+}
+
+// Metrics returns the outbox backlog and the counters of this relay
+func (r *OutboxRelay) Metrics(ctx context.Context) (*OutboxMetrics, error) {
+	stats, err := r.repo.GetOutboxStats(ctx)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return &OutboxMetrics{
+		Pending:       stats.Pending,
+		Failed:        stats.Failed,
+		LagSeconds:    stats.Lag(time.Now().UTC()).Seconds(),
+		Published:     r.published.Load(),
+		PublishErrors: r.publishErrors.Load(),
+		GaveUp:        r.gaveUp.Load(),
+	}, nil
+}
+
+func (r *OutboxRelay) logStats(ctx context.Context) {
+	metrics, err := r.Metrics(ctx)
+	if err != nil {
+		log.Printf("Outbox relay: failed to read stats: %v", err)
+		return
+	}
+
+	log.Printf("Outbox relay: pending=%d failed=%d lag=%.0fs published=%d publishErrors=%d",
+		metrics.Pending, metrics.Failed, metrics.LagSeconds, metrics.Published, metrics.PublishErrors)
+}
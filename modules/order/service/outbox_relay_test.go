@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeOutboxRepo struct {
+	events  map[string]*ordermodel.OutboxEvent
+	updated []ordermodel.OutboxEvent
+}
+
+func (r *fakeOutboxRepo) ClaimPendingOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]ordermodel.OutboxEvent, error) {
+	now := time.Now().UTC()
+	var due []ordermodel.OutboxEvent
+	for _, evt := range r.events {
+		if evt.Status == ordermodel.OutboxStatusPending && !evt.NextAttemptAt.After(now) && len(due) < limit {
+			evt.NextAttemptAt = now.Add(lease)
+			due = append(due, *evt)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeOutboxRepo) FindOutboxEventById(ctx context.Context, id string) (*ordermodel.OutboxEvent, error) {
+	evt, ok := r.events[id]
+	if !ok {
+		return nil, ordermodel.ErrOutboxEventNotFound
+	}
+	stored := *evt
+	return &stored, nil
+}
+
+func (r *fakeOutboxRepo) UpdateOutboxEvent(ctx context.Context, evt *ordermodel.OutboxEvent) error {
+	stored := *evt
+	r.events[evt.ID] = &stored
+	r.updated = append(r.updated, stored)
+	return nil
+}
+
+func (r *fakeOutboxRepo) GetOutboxStats(ctx context.Context) (*ordermodel.OutboxStats, error) {
+	var stats ordermodel.OutboxStats
+	for _, evt := range r.events {
+		switch evt.Status {
+		case ordermodel.OutboxStatusPending:
+			stats.Pending++
+			if stats.OldestPendingAt == nil || evt.CreatedAt.Before(*stats.OldestPendingAt) {
+				createdAt := evt.CreatedAt
+				stats.OldestPendingAt = &createdAt
+			}
+		case ordermodel.OutboxStatusFailed:
+			stats.Failed++
+		}
+	}
+	return &stats, nil
+}
+
+type fakeEvtPublisher struct {
+	err       error
+	published []*datatype.AppEvent
+}
+
+func (p *fakeEvtPublisher) Publish(ctx context.Context, topic string, evt *datatype.AppEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, evt)
+	return nil
+}
+
+func newFakeOutboxRepo(t *testing.T) (*fakeOutboxRepo, *ordermodel.OutboxEvent) {
+	evt, err := ordermodel.NewOutboxEvent(context.Background(), "order-1", datatype.OrderCreatedEvt{OrderID: "order-1"})
+	if err != nil {
+		t.Fatalf("NewOutboxEvent() error = %v", err)
+	}
+	return &fakeOutboxRepo{events: map[string]*ordermodel.OutboxEvent{evt.ID: evt}}, evt
+}
+
+func TestOutboxRelay_RelayBatch(t *testing.T) {
+	ctx := context.Background()
+	cfg := datatype.OutboxConfig{BatchSize: 10, MaxAttempts: 2, MaxBackoff: time.Minute}
+
+	t.Run("TC 1: published event is marked sent", func(t *testing.T) {
+		repo, evt := newFakeOutboxRepo(t)
+		publisher := &fakeEvtPublisher{}
+		relay := NewOutboxRelay(repo, publisher, cfg)
+
+		count, err := relay.RelayBatch(ctx)
+		if err != nil || count != 1 {
+			t.Fatalf("RelayBatch() = %d, %v, want 1, nil", count, err)
+		}
+		if len(publisher.published) != 1 {
+			t.Fatalf("published %d events, want 1", len(publisher.published))
+		}
+		published := publisher.published[0]
+		if data, ok := published.Data.(*datatype.OrderCreatedEvt); !ok || data.OrderID != "order-1" || published.ID != evt.ID {
+			t.Errorf("published = %+v, want the saved event", published)
+		}
+		if got := repo.events[evt.ID]; got.Status != ordermodel.OutboxStatusSent || got.SentAt == nil || got.Attempts != 1 {
+			t.Errorf("event = %+v, want sent after 1 attempt", got)
+		}
+
+		// Nothing is published twice
+		if count, _ := relay.RelayBatch(ctx); count != 0 {
+			t.Errorf("second RelayBatch() = %d, want 0", count)
+		}
+	})
+
+	t.Run("TC 2: payload saved without envelope is published as is", func(t *testing.T) {
+		repo, evt := newFakeOutboxRepo(t)
+		evt.Payload = []byte(`{"orderId":"order-1"}`)
+		publisher := &fakeEvtPublisher{}
+		relay := NewOutboxRelay(repo, publisher, cfg)
+
+		if count, err := relay.RelayBatch(ctx); err != nil || count != 1 {
+			t.Fatalf("RelayBatch() = %d, %v, want 1, nil", count, err)
+		}
+		published := publisher.published[0]
+		if data, ok := published.Data.(json.RawMessage); !ok || string(data) != `{"orderId":"order-1"}` || published.Topic != datatype.EvtNotifyOrderCreate {
+			t.Errorf("published = %+v, want the saved payload", published)
+		}
+	})
+
+	t.Run("TC 3: failed publish is retried later then given up", func(t *testing.T) {
+		repo, evt := newFakeOutboxRepo(t)
+		publisher := &fakeEvtPublisher{err: errors.New("nats: connection closed")}
+		relay := NewOutboxRelay(repo, publisher, cfg)
+
+		if _, err := relay.RelayBatch(ctx); err != nil {
+			t.Fatalf("RelayBatch() error = %v", err)
+		}
+		got := repo.events[evt.ID]
+		if got.Status != ordermodel.OutboxStatusPending || got.Attempts != 1 || got.LastError == nil {
+			t.Fatalf("event = %+v, want pending with 1 attempt", got)
+		}
+		if !got.NextAttemptAt.After(time.Now()) {
+			t.Errorf("next attempt = %s, want a backoff", got.NextAttemptAt)
+		}
+
+		// Not due yet
+		if count, _ := relay.RelayBatch(ctx); count != 0 {
+			t.Errorf("RelayBatch() during backoff = %d, want 0", count)
+		}
+
+		got.NextAttemptAt = time.Now().UTC()
+		if _, err := relay.RelayBatch(ctx); err != nil {
+			t.Fatalf("RelayBatch() error = %v", err)
+		}
+		if got := repo.events[evt.ID]; got.Status != ordermodel.OutboxStatusFailed || got.Attempts != 2 {
+			t.Errorf("event = %+v, want failed after 2 attempts", got)
+		}
+
+		metrics, err := relay.Metrics(ctx)
+		if err != nil {
+			t.Fatalf("Metrics() error = %v", err)
+		}
+		if metrics.Failed != 1 || metrics.Pending != 0 || metrics.PublishErrors != 2 || metrics.GaveUp != 1 {
+			t.Errorf("Metrics() = %+v", metrics)
+		}
+
+		// An admin requeues the event once the broker is back
+		requeueHdl := NewRequeueOutboxEventCommandHandler(repo)
+		if _, err := requeueHdl.Execute(ctx, evt.ID); err != nil {
+			t.Fatalf("Requeue Execute() error = %v", err)
+		}
+		publisher.err = nil
+		if count, err := relay.RelayBatch(ctx); err != nil || count != 1 {
+			t.Fatalf("RelayBatch() after requeue = %d, %v, want 1, nil", count, err)
+		}
+		if got := repo.events[evt.ID]; got.Status != ordermodel.OutboxStatusSent || got.Attempts != 1 {
+			t.Errorf("event = %+v, want sent after 1 attempt", got)
+		}
+
+		// Only the failed events are requeued
+		var appErr *datatype.DefaultError
+		if _, err := requeueHdl.Execute(ctx, evt.ID); !errors.As(err, &appErr) || appErr.StatusCode() != http.StatusBadRequest {
+			t.Errorf("Requeue Execute() of a sent event error = %v, want status %d", err, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("TC 4: lag is the age of the oldest pending event in seconds", func(t *testing.T) {
+		repo, evt := newFakeOutboxRepo(t)
+		evt.CreatedAt = time.Now().UTC().Add(-90 * time.Second)
+		relay := NewOutboxRelay(repo, &fakeEvtPublisher{}, cfg)
+
+		metrics, err := relay.Metrics(ctx)
+		if err != nil {
+			t.Fatalf("Metrics() error = %v", err)
+		}
+		if metrics.LagSeconds < 90 || metrics.LagSeconds > 95 {
+			t.Errorf("LagSeconds = %.1f, want about 90", metrics.LagSeconds)
+		}
+	})
+}
+
+func TestOutboxRelay_backoff(t *testing.T) {
+	relay := NewOutboxRelay(nil, nil, datatype.OutboxConfig{MaxBackoff: 10 * time.Second})
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 50, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := relay.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
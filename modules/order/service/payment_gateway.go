@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+)
+
+// GatewayAuthorizeRequest is a request to hold an amount on a card
+type GatewayAuthorizeRequest struct {
+	OrderID string
+	Amount  float64
+	Card    *ordermodel.Card
+}
+
+// GatewayResult is the answer of a payment gateway
+type GatewayResult struct {
+	Reference string
+}
+
+// PaymentGateway is implemented by each payment provider (Stripe, PayPal, fake...).
+// Declines return ordermodel.ErrPaymentDeclined, timeouts ordermodel.ErrPaymentGatewayTimeout.
+type PaymentGateway interface {
+	Authorize(ctx context.Context, req GatewayAuthorizeRequest) (*GatewayResult, error)
+	Capture(ctx context.Context, reference string, amount float64) (*GatewayResult, error)
+	Void(ctx context.Context, reference string) (*GatewayResult, error)
+	Refund(ctx context.Context, reference string, amount float64) (*GatewayResult, error)
+}
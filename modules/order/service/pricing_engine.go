@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"go.opentelemetry.io/otel"
+)
+
+// PriceTolerance is the maximum difference accepted between the client total and the computed total
+const PriceTolerance = 1.0
+
+// Repository interfaces
+type IPricingFoodRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]ordermodel.Food, error)
+}
+
+// IDeliveryQuoter computes the delivery fee and ETA of an order
+type IDeliveryQuoter interface {
+	QuoteDelivery(ctx context.Context, restaurantID uuid.UUID, address *ordermodel.Address) (*ordermodel.DeliveryQuote, error)
+}
+
+// Service
+type PricingEngine struct {
+	foodRepo       IPricingFoodRepo
+	deliveryQuoter IDeliveryQuoter
+}
+
+func NewPricingEngine(foodRepo IPricingFoodRepo, deliveryQuoter IDeliveryQuoter) *PricingEngine {
+	return &PricingEngine{
+		foodRepo:       foodRepo,
+		deliveryQuoter: deliveryQuoter,
+	}
+}
+
+// PriceOrder computes the price breakdown of an order from authoritative food prices.
+// The order details of data are overwritten with the server-side values.
+func (e *PricingEngine) PriceOrder(ctx context.Context, data *OrderCreateDto) (*ordermodel.PriceBreakdown, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "price-order")
+	defer span.End()
+
+	if len(data.OrderDetails) == 0 {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrOrderItemsRequired.Error())
+	}
+
+	restaurantID, err := uuid.Parse(data.RestaurantID)
+	if err != nil {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrInvalidRestaurantIdFormat.Error())
+	}
+
+	foodIDs := make([]uuid.UUID, 0, len(data.OrderDetails))
+	for _, detail := range data.OrderDetails {
+		if detail.FoodOrigin == nil {
+			return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrFoodIdRequired.Error())
+		}
+		if detail.Quantity <= 0 {
+			return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrQuantityInvalid.Error())
+		}
+		foodID, err := uuid.Parse(detail.FoodOrigin.Id)
+		if err != nil {
+			return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrInvalidFoodIdFormat.Error())
+		}
+		foodIDs = append(foodIDs, foodID)
+	}
+
+	foodMap, err := e.foodRepo.FindByIds(ctx, foodIDs)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	breakdown := &ordermodel.PriceBreakdown{}
+	for i := range data.OrderDetails {
+		detail := &data.OrderDetails[i]
+
+		// The food service only finds the active foods, an unknown one may be inactive or deleted
+		food, ok := foodMap[foodIDs[i]]
+		if !ok {
+			return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrFoodNotFound.Error())
+		}
+		if food.Status != string(datatype.StatusActive) {
+			return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrFoodNotAvailable.Error())
+		}
+		if food.RestaurantId != restaurantID {
+			return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrFoodRestaurantMismatch.Error())
+		}
+
+		// No promotion is supported yet, so the line discount is always 0
+		subtotal := roundPrice(food.Price * float64(detail.Quantity))
+		discount := 0.0
+		line := ordermodel.PriceLine{
+			FoodID:    food.Id.String(),
+			Name:      food.Name,
+			UnitPrice: food.Price,
+			Quantity:  detail.Quantity,
+			Subtotal:  subtotal,
+			Discount:  discount,
+			Total:     roundPrice(subtotal - discount),
+		}
+		breakdown.Lines = append(breakdown.Lines, line)
+		breakdown.Subtotal += line.Subtotal
+		breakdown.Discount += line.Discount
+
+		// Overwrite client values with the authoritative ones
+		detail.FoodOrigin = &FoodOriginDto{
+			Id:          food.Id.String(),
+			Name:        food.Name,
+			Description: food.Description,
+			Image:       food.Images,
+		}
+		detail.Price = food.Price
+		detail.Discount = discount
+	}
+
+	if e.deliveryQuoter != nil {
+		quote, err := e.deliveryQuoter.QuoteDelivery(ctx, restaurantID, data.DeliveryAddress)
+		if err != nil {
+			return nil, err
+		}
+		breakdown.DeliveryFee = roundPrice(quote.DeliveryFee)
+		breakdown.Delivery = quote
+	}
+
+	breakdown.Subtotal = roundPrice(breakdown.Subtotal)
+	breakdown.Discount = roundPrice(breakdown.Discount)
+	breakdown.Total = roundPrice(breakdown.Subtotal - breakdown.Discount + breakdown.DeliveryFee)
+
+	if breakdown.Total <= 0 {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrTotalPriceInvalid.Error())
+	}
+
+	// The client total is optional, but must agree with ours when it is sent
+	if data.TotalPrice > 0 && math.Abs(data.TotalPrice-breakdown.Total) > PriceTolerance {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrTotalPriceMismatch.Error())
+	}
+
+	return breakdown, nil
+}
+
+func roundPrice(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakePricingFoodRepo struct {
+	foods map[uuid.UUID]ordermodel.Food
+}
+
+func (r *fakePricingFoodRepo) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]ordermodel.Food, error) {
+	return r.foods, nil
+}
+
+type fakeDeliveryQuoter struct {
+	fee float64
+}
+
+func (q *fakeDeliveryQuoter) QuoteDelivery(ctx context.Context, restaurantID uuid.UUID, address *ordermodel.Address) (*ordermodel.DeliveryQuote, error) {
+	return &ordermodel.DeliveryQuote{DeliveryFee: q.fee}, nil
+}
+
+func TestPricingEngine_PriceOrder(t *testing.T) {
+	restaurantId := uuid.MustParse("019615db-9adb-7eff-ba03-45017274084c")
+	foodId := uuid.MustParse("019615db-9adb-7eff-ba03-45017274084d")
+	inactiveFoodId, otherRestaurantFoodId, deletedFoodId := uuid.New(), uuid.New(), uuid.New()
+	repo := &fakePricingFoodRepo{foods: map[uuid.UUID]ordermodel.Food{
+		foodId:                {Id: foodId, Name: "Pho", Price: 45000, RestaurantId: restaurantId, Status: string(datatype.StatusActive)},
+		inactiveFoodId:        {Id: inactiveFoodId, Name: "Bun cha", Price: 50000, RestaurantId: restaurantId, Status: string(datatype.StatusInactive)},
+		otherRestaurantFoodId: {Id: otherRestaurantFoodId, Name: "Banh mi", Price: 1, RestaurantId: uuid.New(), Status: string(datatype.StatusActive)},
+	}}
+
+	tests := []struct {
+		name       string
+		foodId     uuid.UUID
+		totalPrice float64
+		price      float64
+		quantity   int
+		want       float64
+		wantErr    bool
+	}{
+		{name: "TC 1: client total is ignored when empty", totalPrice: 0, price: 1, quantity: 2, want: 105000},
+		{name: "TC 2: client total within tolerance", totalPrice: 105000.5, price: 45000, quantity: 2, want: 105000},
+		{name: "TC 3: client total mismatch", totalPrice: 1, price: 1, quantity: 2, wantErr: true},
+		{name: "TC 4: invalid quantity", totalPrice: 0, price: 45000, quantity: 0, wantErr: true},
+		{name: "TC 5: inactive food", foodId: inactiveFoodId, price: 50000, quantity: 1, wantErr: true},
+		{name: "TC 6: food of another restaurant", foodId: otherRestaurantFoodId, price: 1, quantity: 1, wantErr: true},
+		{name: "TC 7: deleted food", foodId: deletedFoodId, price: 1, quantity: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewPricingEngine(repo, &fakeDeliveryQuoter{fee: 15000})
+			orderedFoodId := foodId
+			if tt.foodId != uuid.Nil {
+				orderedFoodId = tt.foodId
+			}
+			data := &OrderCreateDto{
+				TotalPrice:   tt.totalPrice,
+				RestaurantID: restaurantId.String(),
+				OrderDetails: []OrderDetailCreateDto{
+					{FoodOrigin: &FoodOriginDto{Id: orderedFoodId.String()}, Price: tt.price, Quantity: tt.quantity, Discount: 100},
+				},
+			}
+			got, err := e.PriceOrder(context.Background(), data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PricingEngine.PriceOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				var appErr *datatype.DefaultError
+				if !errors.As(err, &appErr) || appErr.StatusCode() != http.StatusBadRequest {
+					t.Errorf("PricingEngine.PriceOrder() error = %v, want a bad request", err)
+				}
+				return
+			}
+			if got.Total != tt.want {
+				t.Errorf("PricingEngine.PriceOrder() total = %v, want %v", got.Total, tt.want)
+			}
+			if data.OrderDetails[0].Price != 45000 || data.OrderDetails[0].Discount != 0 {
+				t.Errorf("PricingEngine.PriceOrder() did not overwrite client price")
+			}
+		})
+	}
+}
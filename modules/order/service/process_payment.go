@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
@@ -15,7 +18,7 @@ type PaymentCard struct {
 	Method         string    `json:"method"`
 	Provider       string    `json:"provider"`
 	CardholderName string    `json:"cardholderName"`
-	CardNumber     string    `json:"cardNumber"`
+	Last4          string    `json:"last4"`
 	CardType       string    `json:"cardType"`
 	ExpiryMonth    string    `json:"expiryMonth"`
 	ExpiryYear     string    `json:"expiryYear"`
@@ -39,21 +42,35 @@ type PaymentResult struct {
 	ErrorMessage  string `json:"errorMessage,omitempty"`
 }
 
+// GatewayTimeout bounds every call to a payment gateway
+const GatewayTimeout = 15 * time.Second
+
 // Repository interfaces
 type IPaymentCardRepo interface {
 	FindById(ctx context.Context, id uuid.UUID) (*ordermodel.Card, error)
 }
 
+type IPaymentTransactionRepo interface {
+	InsertPaymentTransaction(ctx context.Context, txn *ordermodel.PaymentTransaction) error
+	FindPaymentTransactions(ctx context.Context, orderID string) ([]ordermodel.PaymentTransaction, error)
+}
+
 // Service
 type PaymentProcessingService struct {
 	cardRepo IPaymentCardRepo
+	txnRepo  IPaymentTransactionRepo
+	gateways map[string]PaymentGateway // by card provider
 }
 
 func NewPaymentProcessingService(
 	cardRepo IPaymentCardRepo,
+	txnRepo IPaymentTransactionRepo,
+	gateways map[string]PaymentGateway,
 ) *PaymentProcessingService {
 	return &PaymentProcessingService{
 		cardRepo: cardRepo,
+		txnRepo:  txnRepo,
+		gateways: gateways,
 	}
 }
 
@@ -121,19 +138,21 @@ func (s *PaymentProcessingService) ProcessPayment(ctx context.Context, req *Paym
 }
 
 // processCashPayment handles cash payment
-func (s *PaymentProcessingService) processCashPayment(_ context.Context, req *PaymentRequest) (*PaymentResult, error) {
+func (s *PaymentProcessingService) processCashPayment(ctx context.Context, req *PaymentRequest) (*PaymentResult, error) {
 	// For cash payments, we just mark as pending
 	// The payment will be completed when the order is delivered
+	transactionID := req.PaymentMethod + "_" + req.OrderID
+	s.recordTransaction(ctx, req, "", ordermodel.TransactionTypeCash, req.Amount, &GatewayResult{Reference: transactionID}, nil)
 
-	// Default implementation - simulate successful payment
 	return &PaymentResult{
 		Success:       true,
-		TransactionID: req.PaymentMethod + "_" + req.OrderID,
+		TransactionID: transactionID,
 	}, nil
 }
 
-// processCardPayment handles card payment processing
-func (s *PaymentProcessingService) processCardPayment(_ context.Context, req *PaymentRequest) (*PaymentResult, error) {
+// processCardPayment authorizes then captures the amount on the card provider.
+// A failed capture voids the authorization so no money is held.
+func (s *PaymentProcessingService) processCardPayment(ctx context.Context, req *PaymentRequest) (*PaymentResult, error) {
 	if req.CardID == nil {
 		return nil, datatype.ErrBadRequest.WithWrap(ordermodel.ErrCardIdRequired).WithDebug(ordermodel.ErrCardIdRequired.Error())
 	}
@@ -143,18 +162,121 @@ func (s *PaymentProcessingService) processCardPayment(_ context.Context, req *Pa
 		return nil, datatype.ErrBadRequest.WithError("invalid card ID format")
 	}
 
-	// TODO: (TBD)
-	// In a real implementation, this would integrate with Stripe, PayPal, etc.
 	// Step 1: Get card info
+	card, err := s.cardRepo.FindById(ctx, cardID)
+	if err != nil {
+		return nil, datatype.ErrNotFound.WithWrap(err).WithDebug("card not found")
+	}
+
+	gateway, ok := s.gateways[card.Provider]
+	if !ok {
+		return nil, datatype.ErrBadRequest.WithWrap(ordermodel.ErrPaymentProviderNotSupport).WithDebug("provider: " + card.Provider)
+	}
+
 	// Step 2: Process payment through gateway
+	authCtx, cancel := context.WithTimeout(ctx, GatewayTimeout)
+	auth, err := gateway.Authorize(authCtx, GatewayAuthorizeRequest{OrderID: req.OrderID, Amount: req.Amount, Card: card})
+	cancel()
+	s.recordTransaction(ctx, req, card.Provider, ordermodel.TransactionTypeAuthorize, req.Amount, auth, err)
+	if err != nil {
+		return &PaymentResult{Success: false, ErrorMessage: err.Error()}, nil
+	}
+
+	captureCtx, cancel := context.WithTimeout(ctx, GatewayTimeout)
+	capture, err := gateway.Capture(captureCtx, auth.Reference, req.Amount)
+	cancel()
+	s.recordTransaction(ctx, req, card.Provider, ordermodel.TransactionTypeCapture, req.Amount, capture, err)
+	if err != nil {
+		voidCtx, cancel := context.WithTimeout(ctx, GatewayTimeout)
+		void, voidErr := gateway.Void(voidCtx, auth.Reference)
+		cancel()
+		s.recordTransaction(ctx, req, card.Provider, ordermodel.TransactionTypeVoid, req.Amount, void, voidErr)
+		return &PaymentResult{Success: false, ErrorMessage: err.Error()}, nil
+	}
 
-	// Default implementation - simulate successful payment
 	return &PaymentResult{
 		Success:       true,
-		TransactionID: req.PaymentMethod + "_" + req.OrderID + "_" + cardID.String(),
+		TransactionID: capture.Reference,
 	}, nil
 }
 
+// RefundPayment refunds an amount of the captured card payment of an order.
+// It returns the provider reference of the refund.
+func (s *PaymentProcessingService) RefundPayment(ctx context.Context, orderID string, amount float64) (string, error) {
+	txns, err := s.txnRepo.FindPaymentTransactions(ctx, orderID)
+	if err != nil {
+		return "", err
+	}
+
+	var capture *ordermodel.PaymentTransaction
+	for i := range txns {
+		if txns[i].Type == ordermodel.TransactionTypeCapture && txns[i].Status == ordermodel.TransactionStatusSucceeded {
+			capture = &txns[i]
+		}
+	}
+	if capture == nil || capture.ProviderRef == nil {
+		return "", ordermodel.ErrPaymentReferenceNotFound
+	}
+
+	gateway, ok := s.gateways[capture.Provider]
+	if !ok {
+		return "", ordermodel.ErrPaymentProviderNotSupport
+	}
+
+	refundCtx, cancel := context.WithTimeout(ctx, GatewayTimeout)
+	refund, err := gateway.Refund(refundCtx, *capture.ProviderRef, amount)
+	cancel()
+
+	req := &PaymentRequest{OrderID: orderID, UserID: capture.UserID, Amount: amount, CardID: capture.CardId}
+	s.recordTransaction(ctx, req, capture.Provider, ordermodel.TransactionTypeRefund, amount, refund, err)
+	if err != nil {
+		return "", err
+	}
+
+	return refund.Reference, nil
+}
+
+// recordTransaction stores a gateway call. A failure to store it must not hide the payment outcome.
+func (s *PaymentProcessingService) recordTransaction(ctx context.Context, req *PaymentRequest, provider string, txnType string, amount float64, result *GatewayResult, gatewayErr error) {
+	if s.txnRepo == nil {
+		return
+	}
+
+	now := time.Now()
+	txn := &ordermodel.PaymentTransaction{
+		ID:        uuid.New().String(),
+		OrderID:   req.OrderID,
+		UserID:    req.UserID,
+		CardId:    req.CardID,
+		Provider:  provider,
+		Type:      txnType,
+		Amount:    amount,
+		Status:    ordermodel.TransactionStatusSucceeded,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	switch {
+	case gatewayErr != nil:
+		errMsg := gatewayErr.Error()
+		txn.ErrorMessage = &errMsg
+		txn.Status = ordermodel.TransactionStatusFailed
+		if errors.Is(gatewayErr, ordermodel.ErrPaymentGatewayTimeout) || errors.Is(gatewayErr, context.DeadlineExceeded) {
+			txn.Status = ordermodel.TransactionStatusTimeout
+		}
+	case txnType == ordermodel.TransactionTypeCash:
+		txn.Status = ordermodel.TransactionStatusPending
+	}
+
+	if result != nil {
+		txn.ProviderRef = &result.Reference
+	}
+
+	if err := s.txnRepo.InsertPaymentTransaction(ctx, txn); err != nil {
+		log.Printf("Failed to record %s transaction of order %s: %v", txnType, req.OrderID, err)
+	}
+}
+
 // GetPaymentStatus determines the payment status based on payment method and result
 // func (s *PaymentProcessingService) GetPaymentStatus(paymentMethod string, result *PaymentResult) string {
 // 	if !result.Success {
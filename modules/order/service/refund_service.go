@@ -2,69 +2,390 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedModel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
 )
 
-// RefundService handles refund processing for cancelled orders
+// MaxRefundRetries is the number of times a failed refund can be retried
+const MaxRefundRetries = 3
+
+// Define DTOs & validate
+type RefundCreateDto struct {
+	OrderID   string   `json:"-"`
+	Amount    *float64 `json:"amount"` // Leave empty for a full refund
+	Reason    string   `json:"reason"`
+	CreatedBy string   `json:"-"` // Get from token
+
+	RefundID string `json:"-"`
+}
+
+func (r *RefundCreateDto) Validate() error {
+	if r.OrderID == "" {
+		return ordermodel.ErrOrderIdRequired
+	}
+
+	if r.Amount != nil && *r.Amount <= 0 {
+		return ordermodel.ErrRefundAmountInvalid
+	}
+
+	return nil
+}
+
+type RefundListReq struct {
+	OrderID *string `json:"orderId" form:"orderId"`
+	Status  *string `json:"status" form:"status"`
+	sharedModel.PagingDto
+}
+
+type RefundListRes struct {
+	Data   []ordermodel.Refund   `json:"data"`
+	Paging sharedModel.PagingDto `json:"paging"`
+}
+
+// Initialize service
+type IRefundRepo interface {
+	InsertRefund(ctx context.Context, refund *ordermodel.Refund) error
+	ClaimRefund(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, fromState string, refund *ordermodel.Refund) error
+	UpdateRefund(ctx context.Context, refund *ordermodel.Refund) error
+	FindRefundById(ctx context.Context, id string) (*ordermodel.Refund, error)
+	FindRefundsByOrderId(ctx context.Context, orderID string) ([]ordermodel.Refund, error)
+	ListRefunds(ctx context.Context, req RefundListReq) ([]ordermodel.Refund, int64, error)
+	FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error)
+	UpdatePaymentStatus(ctx context.Context, tracking *ordermodel.OrderTracking) error
+}
+
+type IRefundPaymentService interface {
+	RefundPayment(ctx context.Context, orderID string, amount float64) (string, error)
+}
+
+// RefundService records refunds in the refund ledger and processes them
 type RefundService struct {
-	// In a real implementation, this would have dependencies like:
-	// - Payment gateway clients (Stripe, PayPal, etc.)
-	// - Database for refund tracking
-	// - Notification services
+	repo           IRefundRepo
+	paymentService IRefundPaymentService
 }
 
 // NewRefundService creates a new refund service
-func NewRefundService() *RefundService {
-	return &RefundService{}
+func NewRefundService(repo IRefundRepo, paymentService IRefundPaymentService) *RefundService {
+	return &RefundService{repo: repo, paymentService: paymentService}
 }
 
-// ProcessRefund processes a refund for a cancelled order
-func (s *RefundService) ProcessRefund(ctx context.Context, orderID string, amount float64, paymentMethod string, cardID *string) error {
-	log.Printf("Processing refund for order %s: amount=%.2f, method=%s", orderID, amount, paymentMethod)
+// Execute creates a full or partial refund for an order (admin)
+func (s *RefundService) Execute(ctx context.Context, data *RefundCreateDto) error {
+	if err := data.Validate(); err != nil {
+		return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	order, tracking, _, err := s.repo.FindById(ctx, data.OrderID)
+	if err != nil {
+		if err == ordermodel.ErrOrderNotFound {
+			return datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	amount := order.TotalPrice
+	if data.Amount != nil {
+		amount = *data.Amount
+	}
+
+	refund, err := s.CreateRefund(ctx, order, tracking, amount, data.Reason, data.CreatedBy)
+	if err != nil {
+		return err
+	}
 
-	switch paymentMethod {
+	// set data to response
+	data.RefundID = refund.ID
+
+	return nil
+}
+
+// CreateRefund records a refund for a paid order and processes it. The refund and the refunding payment status
+// are claimed with the state of tracking before the payment provider is called, the outcome is saved afterwards.
+func (s *RefundService) CreateRefund(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, amount float64, reason string, createdBy string) (*ordermodel.Refund, error) {
+	if amount <= 0 {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrRefundAmountInvalid.Error())
+	}
+
+	// Nothing was captured from a pending or failed payment
+	if tracking.PaymentStatus == PaymentStatusPending || tracking.PaymentStatus == PaymentStatusFailed {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrOrderNotPaid.Error())
+	}
+
+	now := time.Now()
+	refund := &ordermodel.Refund{
+		ID:            uuid.New().String(),
+		OrderID:       order.ID,
+		Amount:        roundPrice(amount),
+		Reason:        reason,
+		PaymentMethod: tracking.PaymentMethod,
+		CardId:        tracking.CardId,
+		Status:        ordermodel.RefundStatusRequested,
+		CreatedBy:     &createdBy,
+		UpdatedBy:     &createdBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.claimRefund(ctx, order, tracking, refund, createdBy); err != nil {
+		return nil, err
+	}
+
+	// A failed refund stays in the ledger so it can be retried
+	if err := s.processRefund(ctx, refund); err != nil {
+		return nil, err
+	}
+
+	if err := s.savePaymentStatus(ctx, order, tracking, createdBy); err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// RefundRemaining refunds what is left to refund of a paid order. Nothing is refunded when the ledger
+// already holds the whole amount, so that cancelling an order again does not refund it twice.
+// The payment status of tracking is saved with the outcome of the refund.
+func (s *RefundService) RefundRemaining(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, reason string, createdBy string) (*ordermodel.Refund, error) {
+	refunds, err := s.repo.FindRefundsByOrderId(ctx, order.ID)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	remaining := roundPrice(order.TotalPrice - refundedAmount(refunds))
+	if remaining <= 0 {
+		return nil, s.savePaymentStatus(ctx, order, tracking, createdBy)
+	}
+	return s.CreateRefund(ctx, order, tracking, remaining, reason, createdBy)
+}
+
+// RefundUnsavedOrder gives back the payment captured for an order which could not be saved.
+// The order does not exist, so the refund is recorded in the ledger only and can be retried when it fails.
+func (s *RefundService) RefundUnsavedOrder(ctx context.Context, orderID string, amount float64, paymentMethod string, cardId *string, createdBy string) (*ordermodel.Refund, error) {
+	now := time.Now()
+	refund := &ordermodel.Refund{
+		ID:            uuid.New().String(),
+		OrderID:       orderID,
+		Amount:        roundPrice(amount),
+		Reason:        ordermodel.RefundReasonOrderNotSaved,
+		PaymentMethod: paymentMethod,
+		CardId:        cardId,
+		Status:        ordermodel.RefundStatusRequested,
+		CreatedBy:     &createdBy,
+		UpdatedBy:     &createdBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.repo.InsertRefund(ctx, refund); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if err := s.processRefund(ctx, refund); err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// RetryRefund processes a failed refund again (admin)
+func (s *RefundService) RetryRefund(ctx context.Context, refundID string, updatedBy string) (*ordermodel.Refund, error) {
+	refund, err := s.repo.FindRefundById(ctx, refundID)
+	if err != nil {
+		if err == ordermodel.ErrRefundNotFound {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if refund.Status != ordermodel.RefundStatusFailed {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrRefundNotRetryable.Error())
+	}
+
+	if refund.Retries >= MaxRefundRetries {
+		return nil, datatype.ErrBadRequest.WithError(ordermodel.ErrRefundRetryExhausted.Error())
+	}
+
+	// The order of a refund made by RefundUnsavedOrder does not exist
+	order, tracking, _, err := s.repo.FindById(ctx, refund.OrderID)
+	if err != nil && err != ordermodel.ErrOrderNotFound {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	// A failed refund gave its amount back, it is claimed again before the payment provider is called
+	refund.Retries++
+	refund.Status = ordermodel.RefundStatusRequested
+	refund.UpdatedBy = &updatedBy
+	refund.UpdatedAt = time.Now()
+	if order != nil {
+		if err := s.claimRefund(ctx, order, tracking, refund, updatedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.processRefund(ctx, refund); err != nil {
+		return nil, err
+	}
+
+	if order != nil {
+		if err := s.savePaymentStatus(ctx, order, tracking, updatedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	return refund, nil
+}
+
+// ListRefunds lists the refund ledger (admin)
+func (s *RefundService) ListRefunds(ctx context.Context, req RefundListReq) (*RefundListRes, error) {
+	req.PagingDto.Process()
+
+	refunds, total, err := s.repo.ListRefunds(ctx, req)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	req.PagingDto.Total = total
+	return &RefundListRes{Data: refunds, Paging: req.PagingDto}, nil
+}
+
+// processRefund moves a refund through processing to succeeded or failed
+func (s *RefundService) processRefund(ctx context.Context, refund *ordermodel.Refund) error {
+	log.Printf("Processing refund %s for order %s: amount=%.2f, method=%s", refund.ID, refund.OrderID, refund.Amount, refund.PaymentMethod)
+
+	refund.Status = ordermodel.RefundStatusProcessing
+	refund.UpdatedAt = time.Now()
+	if err := s.repo.UpdateRefund(ctx, refund); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	var providerRef string
+	var err error
+	switch refund.PaymentMethod {
 	case MethodCash:
-		return s.processCashRefund(ctx, orderID, amount)
+		providerRef, err = s.processCashRefund(ctx, refund)
 	case MethodCreditCard, MethodDebitCard:
-		return s.processCardRefund(ctx, orderID, amount, cardID)
+		providerRef, err = s.processCardRefund(ctx, refund)
 	default:
-		return fmt.Errorf("unsupported payment method for refund: %s", paymentMethod)
+		err = fmt.Errorf("unsupported payment method for refund: %s", refund.PaymentMethod)
+	}
+
+	if err != nil {
+		log.Printf("Refund %s failed: %v", refund.ID, err)
+		errMsg := err.Error()
+		refund.Status = ordermodel.RefundStatusFailed
+		refund.LastError = &errMsg
+	} else {
+		refund.Status = ordermodel.RefundStatusSucceeded
+		refund.LastError = nil
+		if providerRef != "" {
+			refund.ProviderRef = &providerRef
+		}
 	}
+
+	refund.UpdatedAt = time.Now()
+	if err := s.repo.UpdateRefund(ctx, refund); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return nil
 }
 
 // processCashRefund handles cash payment refunds
-func (s *RefundService) processCashRefund(ctx context.Context, orderID string, amount float64) error {
-	// For cash payments, typically no refund is needed if the order is cancelled
-	// before delivery, as no payment was actually collected
-	log.Printf("Cash refund for order %s: No action needed (amount: %.2f)", orderID, amount)
-	return nil
+func (s *RefundService) processCashRefund(ctx context.Context, refund *ordermodel.Refund) (string, error) {
+	// Cash is handed back by the restaurant or the shipper, there is no provider to call
+	log.Printf("Cash refund for order %s: settled manually (amount: %.2f)", refund.OrderID, refund.Amount)
+	return "", nil
 }
 
 // processCardRefund handles card payment refunds
-func (s *RefundService) processCardRefund(ctx context.Context, orderID string, amount float64, cardID *string) error {
-	// TODO: In a real implementation, this would:
-	// 1. Call the payment gateway API to process the refund
-	// 2. Store refund transaction details in database
-	// 3. Send confirmation notifications
-	// 4. Handle partial refunds if needed
-	// 5. Handle refund failures and retry logic
+func (s *RefundService) processCardRefund(ctx context.Context, refund *ordermodel.Refund) (string, error) {
+	if refund.CardId == nil {
+		return "", fmt.Errorf("card ID is required for card refunds")
+	}
+
+	providerRef, err := s.paymentService.RefundPayment(ctx, refund.OrderID, refund.Amount)
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("Card refund processed successfully for order %s", refund.OrderID)
+	return providerRef, nil
+}
 
-	log.Printf("Processing card refund for order %s: amount=%.2f, cardID=%v", orderID, amount, cardID)
+// claimRefund records the refund and the refunding payment status of tracking, read in its current state
+func (s *RefundService) claimRefund(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, refund *ordermodel.Refund, updatedBy string) error {
+	tracking.PaymentStatus = PaymentStatusRefunding
+	tracking.UpdatedBy = &updatedBy
+	tracking.UpdatedAt = time.Now()
+	if err := s.repo.ClaimRefund(ctx, order, tracking, tracking.State, refund); err != nil {
+		if errors.Is(err, ordermodel.ErrRefundAmountExceeded) {
+			return datatype.ErrBadRequest.WithError(ordermodel.ErrRefundAmountExceeded.Error())
+		}
+		return updateOrderError(err)
+	}
+	return nil
+}
 
-	// Simulate refund processing
-	// In production, this would be actual API calls to Stripe, PayPal, etc.
-	if cardID == nil {
-		return fmt.Errorf("card ID is required for card refunds")
+// savePaymentStatus derives the payment status of the order from the ledger and saves it
+func (s *RefundService) savePaymentStatus(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, updatedBy string) error {
+	refunds, err := s.repo.FindRefundsByOrderId(ctx, order.ID)
+	if err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
 
-	// Simulate successful refund
-	log.Printf("Card refund processed successfully for order %s", orderID)
+	tracking.PaymentStatus = refundPaymentStatus(order, refunds)
+	tracking.UpdatedBy = &updatedBy
+	tracking.UpdatedAt = time.Now()
+	if err := s.repo.UpdatePaymentStatus(ctx, tracking); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
 	return nil
 }
 
-// GetRefundStatus gets the status of a refund (for future implementation)
-func (s *RefundService) GetRefundStatus(ctx context.Context, orderID string) (string, error) {
-	// TODO: Implement refund status tracking
-	return "processed", nil
+// refundedAmount sums the pending and succeeded refunds of the ledger, a failed refund holds its amount again once retried
+func refundedAmount(refunds []ordermodel.Refund) float64 {
+	var refunded float64
+	for _, r := range refunds {
+		if slices.Contains(ordermodel.RefundHeldStatuses, r.Status) {
+			refunded += r.Amount
+		}
+	}
+	return refunded
+}
+
+// isRefundable reports whether some of the payment may be left to refund
+func isRefundable(paymentStatus string) bool {
+	return paymentStatus == PaymentStatusPaid ||
+		paymentStatus == PaymentStatusPartiallyRefunded ||
+		paymentStatus == PaymentStatusRefunding
+}
+
+// refundPaymentStatus derives the payment status of an order from its refunds
+func refundPaymentStatus(order *ordermodel.Order, refunds []ordermodel.Refund) string {
+	var succeeded float64
+	pending := false
+	for _, r := range refunds {
+		if r.Status == ordermodel.RefundStatusSucceeded {
+			succeeded += r.Amount
+		} else {
+			pending = true
+		}
+	}
+
+	switch {
+	case pending:
+		return PaymentStatusRefunding
+	case roundPrice(succeeded) >= order.TotalPrice:
+		return PaymentStatusRefunded
+	case succeeded > 0:
+		return PaymentStatusPartiallyRefunded
+	default:
+		return PaymentStatusPaid
+	}
 }
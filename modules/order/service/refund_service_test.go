@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeRefundRepo struct {
+	fakeOrderStateRepo
+	refunds []ordermodel.Refund
+}
+
+func (r *fakeRefundRepo) InsertRefund(ctx context.Context, refund *ordermodel.Refund) error {
+	r.refunds = append(r.refunds, *refund)
+	return nil
+}
+
+func (r *fakeRefundRepo) ClaimRefund(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, fromState string, refund *ordermodel.Refund) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	for i := range r.refunds {
+		if r.refunds[i].ID == refund.ID {
+			r.refunds[i] = *refund
+			return nil
+		}
+	}
+	r.refunds = append(r.refunds, *refund)
+	return nil
+}
+
+func (r *fakeRefundRepo) UpdatePaymentStatus(ctx context.Context, tracking *ordermodel.OrderTracking) error {
+	r.updated = true
+	return nil
+}
+
+func (r *fakeRefundRepo) UpdateRefund(ctx context.Context, refund *ordermodel.Refund) error {
+	for i := range r.refunds {
+		if r.refunds[i].ID == refund.ID {
+			r.refunds[i] = *refund
+		}
+	}
+	return nil
+}
+
+func (r *fakeRefundRepo) FindRefundById(ctx context.Context, id string) (*ordermodel.Refund, error) {
+	for _, refund := range r.refunds {
+		if refund.ID == id {
+			return &refund, nil
+		}
+	}
+	return nil, ordermodel.ErrRefundNotFound
+}
+
+func (r *fakeRefundRepo) FindRefundsByOrderId(ctx context.Context, orderID string) ([]ordermodel.Refund, error) {
+	return append([]ordermodel.Refund(nil), r.refunds...), nil
+}
+
+func (r *fakeRefundRepo) ListRefunds(ctx context.Context, req RefundListReq) ([]ordermodel.Refund, int64, error) {
+	return r.refunds, int64(len(r.refunds)), nil
+}
+
+type fakeRefundPaymentService struct {
+	calls int
+	err   error
+}
+
+func (s *fakeRefundPaymentService) RefundPayment(ctx context.Context, orderID string, amount float64) (string, error) {
+	s.calls++
+	if s.err != nil {
+		return "", s.err
+	}
+	return "re_" + orderID, nil
+}
+
+func TestRefundService_RefundRemaining(t *testing.T) {
+	ctx := context.Background()
+	cardId := "card-1"
+	order := &ordermodel.Order{ID: "order-1", TotalPrice: 100}
+	tracking := &ordermodel.OrderTracking{OrderID: "order-1", PaymentMethod: MethodCreditCard, CardId: &cardId, PaymentStatus: PaymentStatusPaid}
+	repo := &fakeRefundRepo{}
+	svc := NewRefundService(repo, &fakeRefundPaymentService{})
+
+	// TC 1: an admin refunded a part before the cancellation
+	if _, err := svc.CreateRefund(ctx, order, tracking, 30, "Missing item", "admin"); err != nil {
+		t.Fatalf("CreateRefund() error = %v", err)
+	}
+	if tracking.PaymentStatus != PaymentStatusPartiallyRefunded {
+		t.Fatalf("payment status = %s, want %s", tracking.PaymentStatus, PaymentStatusPartiallyRefunded)
+	}
+
+	// TC 2: the cancellation refunds the rest only
+	refund, err := svc.RefundRemaining(ctx, order, tracking, "Cancelled", "admin")
+	if err != nil {
+		t.Fatalf("RefundRemaining() error = %v", err)
+	}
+	if refund == nil || refund.Amount != 70 || tracking.PaymentStatus != PaymentStatusRefunded {
+		t.Fatalf("RefundRemaining() = %+v, payment status %s, want 70 refunded", refund, tracking.PaymentStatus)
+	}
+
+	// TC 3: a retried cancellation refunds nothing more
+	refund, err = svc.RefundRemaining(ctx, order, tracking, "Cancelled", "admin")
+	if err != nil || refund != nil {
+		t.Fatalf("RefundRemaining() = %+v, %v, want no refund", refund, err)
+	}
+	if len(repo.refunds) != 2 || tracking.PaymentStatus != PaymentStatusRefunded {
+		t.Errorf("%d refunds, payment status %s, want 2 refunds and %s", len(repo.refunds), tracking.PaymentStatus, PaymentStatusRefunded)
+	}
+}
+
+func TestRefundService_CreateRefund(t *testing.T) {
+	ctx := context.Background()
+	cardId := "card-1"
+
+	// TC 1: the provider is not called when the order changed before the refund is claimed
+	order := &ordermodel.Order{ID: "order-1", TotalPrice: 100}
+	tracking := &ordermodel.OrderTracking{OrderID: "order-1", PaymentMethod: MethodCreditCard, CardId: &cardId, PaymentStatus: PaymentStatusPaid}
+	repo := &fakeRefundRepo{fakeOrderStateRepo: fakeOrderStateRepo{updateErr: ordermodel.ErrOrderStateChanged}}
+	payment := &fakeRefundPaymentService{}
+	_, err := NewRefundService(repo, payment).CreateRefund(ctx, order, tracking, 30, "Missing item", "admin")
+	var appErr *datatype.DefaultError
+	if !errors.As(err, &appErr) || appErr.StatusCode() != http.StatusConflict {
+		t.Fatalf("CreateRefund() error = %v, want status %d", err, http.StatusConflict)
+	}
+	if payment.calls != 0 || len(repo.refunds) != 0 {
+		t.Errorf("provider called %d times with %d refunds, want none", payment.calls, len(repo.refunds))
+	}
+
+	// TC 2: a failed refund gives its amount back, the rest of the order can still be refunded
+	repo = &fakeRefundRepo{}
+	payment = &fakeRefundPaymentService{err: errors.New("card declined")}
+	svc := NewRefundService(repo, payment)
+	if _, err := svc.CreateRefund(ctx, order, tracking, 100, "Cancelled", "admin"); err != nil {
+		t.Fatalf("CreateRefund() error = %v", err)
+	}
+	if repo.refunds[0].Status != ordermodel.RefundStatusFailed || tracking.PaymentStatus != PaymentStatusRefunding || !repo.updated {
+		t.Fatalf("refund %s, payment status %s, want %s and %s saved", repo.refunds[0].Status, tracking.PaymentStatus, ordermodel.RefundStatusFailed, PaymentStatusRefunding)
+	}
+	payment.err = nil
+	refund, err := svc.RefundRemaining(ctx, order, tracking, "Cancelled", "admin")
+	if err != nil {
+		t.Fatalf("RefundRemaining() error = %v", err)
+	}
+	if refund == nil || refund.Amount != 100 {
+		t.Errorf("RefundRemaining() = %+v, want 100 refunded", refund)
+	}
+}
+
+func TestRefundService_RefundUnsavedOrder(t *testing.T) {
+	ctx := context.Background()
+	cardId := "card-1"
+	repo := &fakeRefundRepo{}
+	payment := &fakeRefundPaymentService{err: errors.New("provider unavailable")}
+	svc := NewRefundService(repo, payment)
+
+	// TC 1: a failed refund of an unsaved order stays in the ledger
+	refund, err := svc.RefundUnsavedOrder(ctx, "order-1", 50, MethodCreditCard, &cardId, "user-1")
+	if err != nil {
+		t.Fatalf("RefundUnsavedOrder() error = %v", err)
+	}
+	if len(repo.refunds) != 1 || repo.refunds[0].Status != ordermodel.RefundStatusFailed {
+		t.Fatalf("ledger = %+v, want one failed refund", repo.refunds)
+	}
+
+	// TC 2: it can be retried although the order does not exist
+	payment.err = nil
+	refund, err = svc.RetryRefund(ctx, refund.ID, "admin")
+	if err != nil {
+		t.Fatalf("RetryRefund() error = %v", err)
+	}
+	if refund.Status != ordermodel.RefundStatusSucceeded || payment.calls != 2 {
+		t.Errorf("RetryRefund() status %s after %d calls, want %s after 2", refund.Status, payment.calls, ordermodel.RefundStatusSucceeded)
+	}
+}
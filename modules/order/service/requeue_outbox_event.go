@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Initilize service
+type IRequeueOutboxEventRepo interface {
+	FindOutboxEventById(ctx context.Context, id string) (*ordermodel.OutboxEvent, error)
+	UpdateOutboxEvent(ctx context.Context, evt *ordermodel.OutboxEvent) error
+}
+
+type RequeueOutboxEventCommandHandler struct {
+	repo IRequeueOutboxEventRepo
+}
+
+func NewRequeueOutboxEventCommandHandler(repo IRequeueOutboxEventRepo) *RequeueOutboxEventCommandHandler {
+	return &RequeueOutboxEventCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute queues an event the relay gave up again with a fresh number of attempts, the last error is kept until it is sent.
+// The later events of its order, held back meanwhile, follow once it is published.
+func (hdl *RequeueOutboxEventCommandHandler) Execute(ctx context.Context, id string) (*ordermodel.OutboxEvent, error) {
+	evt, err := hdl.repo.FindOutboxEventById(ctx, id)
+	if err != nil {
+		if errors.Is(err, ordermodel.ErrOutboxEventNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if evt.Status != ordermodel.OutboxStatusFailed {
+		return nil, datatype.ErrBadRequest.WithWrap(ordermodel.ErrOutboxEventNotFailed).WithDebug("event is " + evt.Status)
+	}
+
+	now := time.Now().UTC()
+	evt.Status = ordermodel.OutboxStatusPending
+	evt.Attempts = 0
+	evt.NextAttemptAt = now
+	evt.UpdatedAt = now
+
+	if err := hdl.repo.UpdateOutboxEvent(ctx, evt); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return evt, nil
+}
@@ -21,20 +21,21 @@ func (s *OrderNotificationService) notifyRestaurantStateChange(ctx context.Conte
 	}
 	userId := restaurantMap[restaurantIDUuid].OwnerId
 
-	// Get restaurant email
+	// Get restaurant owner contact
 	userMap, err := s.userRepo.FindByIds(ctx, []uuid.UUID{userId})
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	email := userMap[userId].Email
+	user := userMap[userId]
 
 	// Create notification message
-	subject, body := s.createRestaurantStateChangeMessage(orderID, oldState, newState)
+	data := orderEmailData{OrderID: orderID, OldState: oldState, NewState: newState}
+	text := s.createRestaurantStateChangeText(orderID, oldState, newState)
 
-	// Send email notification
-	if err := s.sendEmailNotification(ctx, email, subject, body); err != nil {
-		return fmt.Errorf("failed to send email to restaurant: %w", err)
+	// Send email, SMS and push notifications
+	if err := s.sendNotification(ctx, user, NotifyRestaurantOrderStateChanged, orderID, data, text); err != nil {
+		return fmt.Errorf("failed to notify restaurant: %w", err)
 	}
 
 	return nil
@@ -50,20 +51,21 @@ func (s *OrderNotificationService) notifyRestaurantShipperAssignment(ctx context
 	}
 	userId := restaurantMap[restaurantIDUuid].OwnerId
 
-	// Get restaurant owner email
+	// Get restaurant owner contact
 	userMap, err := s.userRepo.FindByIds(ctx, []uuid.UUID{userId})
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	email := userMap[userId].Email
+	user := userMap[userId]
 
 	// Create notification message
-	subject, body := s.createRestaurantShipperAssignmentMessage(orderID, shipperID)
+	data := orderEmailData{OrderID: orderID, ShipperID: shipperID}
+	text := s.createRestaurantShipperAssignmentText(orderID, shipperID)
 
-	// Send email notification
-	if err := s.sendEmailNotification(ctx, email, subject, body); err != nil {
-		return fmt.Errorf("failed to send email to restaurant: %w", err)
+	// Send email, SMS and push notifications
+	if err := s.sendNotification(ctx, user, NotifyRestaurantShipperAssigned, orderID, data, text); err != nil {
+		return fmt.Errorf("failed to notify restaurant: %w", err)
 	}
 
 	return nil
@@ -79,20 +81,21 @@ func (s *OrderNotificationService) notifyRestaurantPaymentStatusChange(ctx conte
 	}
 	userId := restaurantMap[restaurantIDUuid].OwnerId
 
-	// Get restaurant owner email
+	// Get restaurant owner contact
 	userMap, err := s.userRepo.FindByIds(ctx, []uuid.UUID{userId})
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	email := userMap[userId].Email
+	user := userMap[userId]
 
 	// Create notification message
-	subject, body := s.createRestaurantPaymentStatusChangeMessage(orderID, paymentStatus)
+	data := orderEmailData{OrderID: orderID, PaymentStatus: paymentStatus}
+	text := s.createRestaurantPaymentStatusChangeText(orderID, paymentStatus)
 
-	// Send email notification
-	if err := s.sendEmailNotification(ctx, email, subject, body); err != nil {
-		return fmt.Errorf("failed to send email to restaurant: %w", err)
+	// Send email, SMS and push notifications
+	if err := s.sendNotification(ctx, user, NotifyRestaurantPaymentStatusChanged, orderID, data, text); err != nil {
+		return fmt.Errorf("failed to notify restaurant: %w", err)
 	}
 
 	return nil
@@ -108,20 +111,21 @@ func (s *OrderNotificationService) notifyRestaurantOrderCreated(ctx context.Cont
 	}
 	ownerUserId := restaurantMap[restaurantIDUuid].OwnerId
 
-	// Get restaurant owner email
+	// Get restaurant owner contact
 	userMap, err := s.userRepo.FindByIds(ctx, []uuid.UUID{ownerUserId})
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	email := userMap[ownerUserId].Email
+	user := userMap[ownerUserId]
 
 	// Create notification message
-	subject, body := s.createRestaurantOrderCreatedMessage(orderID, userID, order, tracking, orderDetails)
+	data := newOrderEmailData(orderID, order, tracking, orderDetails)
+	text := s.createRestaurantOrderCreatedText(orderID, userID, order, tracking, orderDetails)
 
-	// Send email notification
-	if err := s.sendEmailNotification(ctx, email, subject, body); err != nil {
-		return fmt.Errorf("failed to send email to restaurant: %w", err)
+	// Send email, SMS and push notifications
+	if err := s.sendNotification(ctx, user, NotifyRestaurantOrderCreated, orderID, data, text); err != nil {
+		return fmt.Errorf("failed to notify restaurant: %w", err)
 	}
 
 	return nil
@@ -137,20 +141,22 @@ func (s *OrderNotificationService) notifyRestaurantOrderCancelled(ctx context.Co
 	}
 	ownerUserId := restaurantMap[restaurantIDUuid].OwnerId
 
-	// Get restaurant owner email
+	// Get restaurant owner contact
 	userMap, err := s.userRepo.FindByIds(ctx, []uuid.UUID{ownerUserId})
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	email := userMap[ownerUserId].Email
+	user := userMap[ownerUserId]
 
 	// Create notification message
-	subject, body := s.createRestaurantOrderCancelledMessage(orderID, reason, order, tracking, orderDetails)
+	data := newOrderEmailData(orderID, order, tracking, orderDetails)
+	data.Reason = reason
+	text := s.createRestaurantOrderCancelledText(orderID, reason, order, tracking, orderDetails)
 
-	// Send email notification
-	if err := s.sendEmailNotification(ctx, email, subject, body); err != nil {
-		return fmt.Errorf("failed to send email to restaurant: %w", err)
+	// Send email, SMS and push notifications
+	if err := s.sendNotification(ctx, user, NotifyRestaurantOrderCancelled, orderID, data, text); err != nil {
+		return fmt.Errorf("failed to notify restaurant: %w", err)
 	}
 
 	return nil
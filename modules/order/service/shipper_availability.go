@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type IShipperProfileRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]ordermodel.Shipper, error)
+}
+
+type IShipperWorkloadRepo interface {
+	CountActiveByShipperId(ctx context.Context, shipperId string) (int, error)
+}
+
+// ShipperAvailabilityChecker refuses the shippers who are offline, off shift or already at capacity
+type ShipperAvailabilityChecker struct {
+	shipperRepo  IShipperProfileRepo
+	workloadRepo IShipperWorkloadRepo
+}
+
+func NewShipperAvailabilityChecker(shipperRepo IShipperProfileRepo, workloadRepo IShipperWorkloadRepo) *ShipperAvailabilityChecker {
+	return &ShipperAvailabilityChecker{shipperRepo: shipperRepo, workloadRepo: workloadRepo}
+}
+
+// CheckAvailable returns the maximum number of orders of the shipper, or a conflict error when the shipper
+// cannot be assigned one more order. The count is only an early refusal: the repository counts again, with
+// the workload of the shipper locked, when the order is saved with the limit in Order.ShipperLimit.
+func (c *ShipperAvailabilityChecker) CheckAvailable(ctx context.Context, shipperId string) (int, error) {
+	id, err := uuid.Parse(shipperId)
+	if err != nil {
+		return 0, datatype.ErrBadRequest.WithWrap(ordermodel.ErrShipperRequired).WithDebug(ordermodel.ErrShipperRequired.Error())
+	}
+
+	shippers, err := c.shipperRepo.FindByIds(ctx, []uuid.UUID{id})
+	if err != nil {
+		return 0, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	shipper, ok := shippers[id]
+	if !ok || !shipper.Available {
+		return 0, datatype.ErrConflict.WithWrap(ordermodel.ErrShipperUnavailable).WithDebug(ordermodel.ErrShipperUnavailable.Error())
+	}
+
+	active, err := c.workloadRepo.CountActiveByShipperId(ctx, shipperId)
+	if err != nil {
+		return 0, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	if active >= shipper.MaxConcurrentOrders {
+		return 0, datatype.ErrConflict.WithWrap(ordermodel.ErrShipperAtCapacity).WithDebug(ordermodel.ErrShipperAtCapacity.Error())
+	}
+	return shipper.MaxConcurrentOrders, nil
+}
@@ -7,119 +7,45 @@ import (
 	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
 )
 
-// Shipper message creation methods
+// Shipper short text creation methods, sent by SMS and push notification
 
-// createShipperStateChangeMessage creates email subject and body for shipper notifications
-func (s *OrderNotificationService) createShipperStateChangeMessage(orderID, _ /* oldState */, newState string) (string, string) {
-	subject := fmt.Sprintf("Order %s: %s", orderID, s.getStateDisplayName(newState))
-
-	var body string
+// createShipperStateChangeText creates the short text of shipper state change notifications
+func (s *OrderNotificationService) createShipperStateChangeText(orderID, _ /* oldState */, newState string) string {
 	switch newState {
-	case "preparing":
-		body = fmt.Sprintf(`Order %s: Being prepared
-
-Be ready for pickup notification.
-
-Food Delivery Platform`, orderID)
-
-	case "on_the_way":
-		body = fmt.Sprintf(`Order %s: On the way 🚗
-
-Ensure safe delivery.
-
-Food Delivery Platform`, orderID)
-
-	case "delivered":
-		body = fmt.Sprintf(`Order %s: Delivered ✅
-
-Thank you for your service!
-
-Food Delivery Platform`, orderID)
-
-	case "cancel":
-		body = fmt.Sprintf(`Order %s: Cancelled
-
-You are no longer assigned.
-
-Food Delivery Platform`, orderID)
-
+	case StatePreparing:
+		return fmt.Sprintf("Order %s: being prepared, be ready for pickup.", orderID)
+	case StateReadyForPickup:
+		return fmt.Sprintf("Order %s: ready for pickup.", orderID)
+	case StateOnTheWay:
+		return fmt.Sprintf("Order %s: on the way, drive safely.", orderID)
+	case StateDelivered:
+		return fmt.Sprintf("Order %s: delivered. Thank you!", orderID)
+	case StateCancelled, StateRestaurantRejected:
+		return fmt.Sprintf("Order %s: cancelled, you are no longer assigned.", orderID)
 	default:
-		body = fmt.Sprintf(`Order %s: %s
-
-Food Delivery Platform`, orderID, s.getStateDisplayName(newState))
+		return fmt.Sprintf("Order %s: %s.", orderID, s.getStateDisplayName(newState))
 	}
-
-	return subject, body
 }
 
-// createShipperAssignmentMessage creates email subject and body for shipper assignment notifications
-func (s *OrderNotificationService) createShipperAssignmentMessage(orderID, _ /* restaurantID */ string) (string, string) {
-	subject := fmt.Sprintf("New Assignment - Order %s", orderID)
-
-	body := fmt.Sprintf(`Order %s assigned to you
-
-Prepare for pickup when ready.
-Check app for details.
-
-Food Delivery Platform`, orderID)
-
-	return subject, body
+// createShipperAssignmentText creates the short text of shipper assignment notifications
+func (s *OrderNotificationService) createShipperAssignmentText(orderID, _ /* restaurantID */ string) string {
+	return fmt.Sprintf("Order %s assigned to you. Check the app for details.", orderID)
 }
 
-// createShipperPaymentStatusChangeMessage creates email subject and body for shipper payment status notifications
-func (s *OrderNotificationService) createShipperPaymentStatusChangeMessage(orderID, paymentStatus string) (string, string) {
-	subject := fmt.Sprintf("Payment %s - Order %s", strings.ToUpper(paymentStatus), orderID)
-
-	var body string
-	switch paymentStatus {
-	case PaymentStatusPaid:
-		body = fmt.Sprintf(`Order %s: Payment confirmed ✓
-
-Order ready for processing.
-
-Food Delivery Platform`, orderID)
-
-	default:
-		body = fmt.Sprintf(`Order %s: Payment %s
-
-Wait for confirmation.
-
-Food Delivery Platform`, orderID, strings.ToUpper(paymentStatus))
+// createShipperPaymentStatusChangeText creates the short text of shipper payment status notifications
+func (s *OrderNotificationService) createShipperPaymentStatusChangeText(orderID, paymentStatus string) string {
+	if paymentStatus == PaymentStatusPaid {
+		return fmt.Sprintf("Order %s: payment confirmed.", orderID)
 	}
-
-	return subject, body
+	return fmt.Sprintf("Order %s: payment %s, wait for confirmation.", orderID, strings.ToUpper(paymentStatus))
 }
 
-// createShipperOrderCreatedMessage creates email subject and body for shipper order creation notifications
-func (s *OrderNotificationService) createShipperOrderCreatedMessage(orderID, _ /* restaurantID */ string, order *ordermodel.Order, tracking *ordermodel.OrderTracking) (string, string) {
-	subject := fmt.Sprintf("New Order #%s", orderID)
-
-	body := fmt.Sprintf(`Order #%s assigned
-
-Value: $%.2f
-Delivery: %d min
-Address: %s
-
-Wait for pickup notification.
-
-Food Delivery Platform`, orderID, order.TotalPrice, tracking.EstimatedTime, string(tracking.DeliveryAddress))
-
-	return subject, body
+// createShipperOrderCreatedText creates the short text of shipper order creation notifications
+func (s *OrderNotificationService) createShipperOrderCreatedText(orderID, _ /* restaurantID */ string, order *ordermodel.Order, tracking *ordermodel.OrderTracking) string {
+	return fmt.Sprintf("Order %s assigned: $%.2f, delivery in %d min.", orderID, order.TotalPrice, tracking.EstimatedTime)
 }
 
-// createShipperOrderCancelledMessage creates email subject and body for shipper order cancellation notifications
-func (s *OrderNotificationService) createShipperOrderCancelledMessage(orderID, reason string, order *ordermodel.Order, _ /* tracking */ *ordermodel.OrderTracking) (string, string) {
-	subject := fmt.Sprintf("Order Cancelled #%s", orderID)
-
-	body := fmt.Sprintf(`Order #%s cancelled
-
-Reason: %s
-Value: $%.2f
-
-You are no longer assigned.
-Check app for new orders.
-
-Food Delivery Platform`, orderID, reason, order.TotalPrice)
-
-	return subject, body
+// createShipperOrderCancelledText creates the short text of shipper order cancellation notifications
+func (s *OrderNotificationService) createShipperOrderCancelledText(orderID, reason string, _ /* order */ *ordermodel.Order, _ /* tracking */ *ordermodel.OrderTracking) string {
+	return fmt.Sprintf("Order %s cancelled: %s. You are no longer assigned.", orderID, reason)
 }
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/rpc-client"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// trackKeepAlive is how often an unchanged tracking is sent again, so that idle streams are not closed by proxies
+const trackKeepAlive = 30 * time.Second
+
+// Define DTOs & validate
+type OrderTrackRes struct {
+	OrderID         string                           `json:"orderId"`
+	State           string                           `json:"state"`
+	ShipperID       *string                          `json:"shipperId,omitempty"`
+	ShipperLocation *ordermodel.ShipperLocationTrail `json:"shipperLocation,omitempty"` // While the shipper delivers the order
+	EtaMinutes      *int                             `json:"etaMinutes,omitempty"`      // Until the delivery, none once cancelled
+	Finished        bool                             `json:"finished"`                  // Delivered or cancelled, the last tracking sent
+	UpdatedAt       time.Time                        `json:"updatedAt"`
+}
+
+// trackRoute is the trip of the order from its restaurant to the delivery address
+type trackRoute struct {
+	known                        bool // Both ends are located, otherwise the quoted ETA is used
+	restaurantLat, restaurantLng float64
+	deliveryLat, deliveryLng     float64
+}
+
+// Initialize service
+type IOrderTrackRepo interface {
+	FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error)
+}
+
+type IOrderTrackRestaurantRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO, error)
+}
+
+// IOrderTrackLocationRepo returns the last locations of a shipper, nil when none was reported lately
+type IOrderTrackLocationRepo interface {
+	FindByShipperId(ctx context.Context, shipperId uuid.UUID) (*ordermodel.ShipperLocationTrail, error)
+}
+
+type TrackOrderQueryHandler struct {
+	repo             IOrderTrackRepo
+	restaurantRepo   IOrderTrackRestaurantRepo
+	locationRepo     IOrderTrackLocationRepo
+	distanceProvider IDistanceProvider
+	interval         time.Duration
+}
+
+func NewTrackOrderQueryHandler(
+	repo IOrderTrackRepo,
+	restaurantRepo IOrderTrackRestaurantRepo,
+	locationRepo IOrderTrackLocationRepo,
+	distanceProvider IDistanceProvider,
+	interval time.Duration,
+) *TrackOrderQueryHandler {
+	if interval <= 0 {
+		interval = 3 * time.Second
+	}
+	return &TrackOrderQueryHandler{
+		repo:             repo,
+		restaurantRepo:   restaurantRepo,
+		locationRepo:     locationRepo,
+		distanceProvider: distanceProvider,
+		interval:         interval,
+	}
+}
+
+// Implement
+// Stream sends the tracking of the order to emit, then again whenever it changes,
+// until the order is delivered or cancelled or ctx is done
+func (hdl *TrackOrderQueryHandler) Stream(ctx context.Context, orderId string, emit func(res *OrderTrackRes) error) error {
+	if orderId == "" {
+		return datatype.ErrBadRequest.WithWrap(ordermodel.ErrOrderIdRequired).WithDebug(ordermodel.ErrOrderIdRequired.Error())
+	}
+
+	order, tracking, err := hdl.findOrder(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	route, err := hdl.findRoute(ctx, tracking)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(hdl.interval)
+	defer ticker.Stop()
+
+	var lastSent []byte
+	var lastSentAt time.Time
+	for {
+		res, err := hdl.track(ctx, order, tracking, route)
+		if err != nil {
+			return err
+		}
+
+		data, _ := json.Marshal(res)
+		if !bytes.Equal(data, lastSent) || time.Since(lastSentAt) >= trackKeepAlive {
+			if err := emit(res); err != nil {
+				return err
+			}
+			lastSent, lastSentAt = data, time.Now()
+		}
+		if res.Finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if order, tracking, err = hdl.findOrder(ctx, orderId); err != nil {
+			return err
+		}
+	}
+}
+
+func (hdl *TrackOrderQueryHandler) findOrder(ctx context.Context, orderId string) (*ordermodel.Order, *ordermodel.OrderTracking, error) {
+	order, tracking, _, err := hdl.repo.FindById(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, ordermodel.ErrOrderNotFound) {
+			return nil, nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return order, tracking, nil
+}
+
+// findRoute locates the restaurant and the delivery address, which do not change during the delivery
+func (hdl *TrackOrderQueryHandler) findRoute(ctx context.Context, tracking *ordermodel.OrderTracking) (*trackRoute, error) {
+	var address ordermodel.Address
+	json.Unmarshal(tracking.DeliveryAddress, &address)
+
+	restaurantId, err := uuid.Parse(tracking.RestaurantID)
+	if err != nil || address.Lat == nil || address.Lng == nil {
+		return &trackRoute{}, nil
+	}
+
+	restaurants, err := hdl.restaurantRepo.FindByIds(ctx, []uuid.UUID{restaurantId})
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	restaurant, ok := restaurants[restaurantId]
+	if !ok || (restaurant.Lat == 0 && restaurant.Lng == 0) {
+		return &trackRoute{}, nil
+	}
+
+	return &trackRoute{
+		known:         true,
+		restaurantLat: restaurant.Lat,
+		restaurantLng: restaurant.Lng,
+		deliveryLat:   *address.Lat,
+		deliveryLng:   *address.Lng,
+	}, nil
+}
+
+func (hdl *TrackOrderQueryHandler) track(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, route *trackRoute) (*OrderTrackRes, error) {
+	res := &OrderTrackRes{
+		OrderID:   order.ID,
+		State:     tracking.State,
+		ShipperID: order.ShipperID,
+		Finished:  tracking.State == StateDelivered || isCancelledState(tracking.State),
+		UpdatedAt: tracking.UpdatedAt,
+	}
+
+	if order.ShipperID != nil && !res.Finished {
+		if shipperId, err := uuid.Parse(*order.ShipperID); err == nil {
+			// The tracking goes on without the shipper location when the dispatch service does not answer
+			if res.ShipperLocation, err = hdl.locationRepo.FindByShipperId(ctx, shipperId); err != nil {
+				log.Printf("Failed to find the location of shipper %s: %v", shipperId, err)
+			}
+		}
+	}
+
+	eta, err := hdl.estimateEta(ctx, tracking, route, res.ShipperLocation, time.Now())
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	res.EtaMinutes = eta
+	return res, nil
+}
+
+// estimateEta returns the minutes left until the delivery, nil when the order will not be delivered.
+// Before the pickup the shipper rides to the restaurant while the food is prepared.
+func (hdl *TrackOrderQueryHandler) estimateEta(ctx context.Context, tracking *ordermodel.OrderTracking, route *trackRoute, location *ordermodel.ShipperLocationTrail, now time.Time) (*int, error) {
+	var eta int
+	switch {
+	case tracking.State == StateDelivered:
+		return &eta, nil
+	case isCancelledState(tracking.State):
+		return nil, nil
+	case !route.known:
+		eta = max(tracking.EstimatedTime-int(now.Sub(tracking.CreatedAt).Minutes()), 0)
+		return &eta, nil
+	}
+
+	if tracking.State == StateOnTheWay {
+		fromLat, fromLng := route.restaurantLat, route.restaurantLng
+		if location != nil {
+			fromLat, fromLng = location.Lat, location.Lng
+		}
+		distance, err := hdl.distanceProvider.Distance(ctx, fromLat, fromLng, route.deliveryLat, route.deliveryLng)
+		if err != nil {
+			return nil, err
+		}
+		eta = travelMinutes(distance)
+		return &eta, nil
+	}
+
+	wait := preparationLeft(tracking, now)
+	if location != nil {
+		distance, err := hdl.distanceProvider.Distance(ctx, location.Lat, location.Lng, route.restaurantLat, route.restaurantLng)
+		if err != nil {
+			return nil, err
+		}
+		wait = max(wait, travelMinutes(distance))
+	}
+
+	distance, err := hdl.distanceProvider.Distance(ctx, route.restaurantLat, route.restaurantLng, route.deliveryLat, route.deliveryLng)
+	if err != nil {
+		return nil, err
+	}
+	eta = wait + travelMinutes(distance)
+	return &eta, nil
+}
+
+// preparationLeft returns the minutes left before the food is ready, counted from the last update once preparing
+func preparationLeft(tracking *ordermodel.OrderTracking, now time.Time) int {
+	switch tracking.State {
+	case StateReadyForPickup:
+		return 0
+	case StatePreparing:
+		return max(PreparationTimeMinutes-int(now.Sub(tracking.UpdatedAt).Minutes()), 0)
+	default:
+		return PreparationTimeMinutes
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+)
+
+// fakeOrderTrackRepo returns the order in the next state at each call, then stays in the last one
+type fakeOrderTrackRepo struct {
+	order    *ordermodel.Order
+	tracking *ordermodel.OrderTracking
+	states   []string
+}
+
+func (r *fakeOrderTrackRepo) FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error) {
+	if r.order.ID != id {
+		return nil, nil, nil, ordermodel.ErrOrderNotFound
+	}
+
+	tracking := *r.tracking
+	tracking.State = r.states[0]
+	if len(r.states) > 1 {
+		r.states = r.states[1:]
+	}
+	return r.order, &tracking, nil, nil
+}
+
+type fakeOrderTrackLocationRepo map[uuid.UUID]*ordermodel.ShipperLocationTrail
+
+func (r fakeOrderTrackLocationRepo) FindByShipperId(ctx context.Context, shipperId uuid.UUID) (*ordermodel.ShipperLocationTrail, error) {
+	return r[shipperId], nil
+}
+
+func TestTrackOrderQueryHandler_Stream(t *testing.T) {
+	ctx := context.Background()
+	restaurantId, ownerId, shipperId := uuid.New(), uuid.New(), uuid.New()
+	shipper := shipperId.String()
+	deliveryLat, deliveryLng := 10.80, 106.70
+	address, _ := json.Marshal(ordermodel.Address{Addr: "1 Le Loi", Lat: &deliveryLat, Lng: &deliveryLng})
+
+	restaurantRepo := &fakeOrderStateRestaurantRepo{restaurantId: restaurantId, ownerId: ownerId, lat: 10.77, lng: 106.70}
+	locationRepo := fakeOrderTrackLocationRepo{shipperId: {ShipperLocation: ordermodel.ShipperLocation{ShipperId: shipperId, Lat: 10.79, Lng: 106.70}}}
+
+	newHandler := func(states ...string) *TrackOrderQueryHandler {
+		repo := &fakeOrderTrackRepo{
+			order:    &ordermodel.Order{ID: "order-1", ShipperID: &shipper},
+			tracking: &ordermodel.OrderTracking{OrderID: "order-1", RestaurantID: restaurantId.String(), DeliveryAddress: address, UpdatedAt: time.Now()},
+			states:   states,
+		}
+		return NewTrackOrderQueryHandler(repo, restaurantRepo, locationRepo, NewHaversineDistanceProvider(), time.Millisecond)
+	}
+	travel := func(fromLat, fromLng, toLat, toLng float64) int {
+		return travelMinutes(sharecomponent.Haversine(fromLat, fromLng, toLat, toLng))
+	}
+
+	t.Run("TC 1: changes are streamed until the delivery", func(t *testing.T) {
+		var sent []OrderTrackRes
+		err := newHandler(StateReadyForPickup, StateReadyForPickup, StateOnTheWay, StateDelivered).Stream(ctx, "order-1", func(res *OrderTrackRes) error {
+			sent = append(sent, *res)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+		if len(sent) != 3 {
+			t.Fatalf("sent %d trackings, want 3 without the unchanged one", len(sent))
+		}
+
+		pickup, onTheWay, delivered := sent[0], sent[1], sent[2]
+		wantPickup := travel(10.79, 106.70, 10.77, 106.70) + travel(10.77, 106.70, deliveryLat, deliveryLng)
+		if pickup.ShipperLocation == nil || pickup.EtaMinutes == nil || *pickup.EtaMinutes != wantPickup {
+			t.Errorf("ready for pickup tracking = %+v, want ETA %d", pickup, wantPickup)
+		}
+		wantOnTheWay := travel(10.79, 106.70, deliveryLat, deliveryLng)
+		if onTheWay.EtaMinutes == nil || *onTheWay.EtaMinutes != wantOnTheWay {
+			t.Errorf("on the way ETA = %v, want %d", onTheWay.EtaMinutes, wantOnTheWay)
+		}
+		if !delivered.Finished || delivered.ShipperLocation != nil || *delivered.EtaMinutes != 0 {
+			t.Errorf("delivered tracking = %+v", delivered)
+		}
+	})
+
+	t.Run("TC 2: food preparation delays the pickup of a close shipper", func(t *testing.T) {
+		var sent []OrderTrackRes
+		err := newHandler(StatePreparing, StateCancelled).Stream(ctx, "order-1", func(res *OrderTrackRes) error {
+			sent = append(sent, *res)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+
+		want := PreparationTimeMinutes + travel(10.77, 106.70, deliveryLat, deliveryLng)
+		if len(sent) != 2 || *sent[0].EtaMinutes != want {
+			t.Fatalf("sent = %+v, want a preparing ETA of %d", sent, want)
+		}
+		if !sent[1].Finished || sent[1].EtaMinutes != nil {
+			t.Errorf("cancelled tracking = %+v, want finished without ETA", sent[1])
+		}
+	})
+
+	t.Run("TC 3: stream stops when the watcher leaves", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		err := newHandler(StateOnTheWay).Stream(ctx, "order-1", func(res *OrderTrackRes) error {
+			cancel()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+	})
+
+	t.Run("TC 4: unknown order", func(t *testing.T) {
+		err := newHandler(StateOnTheWay).Stream(ctx, "order-2", func(res *OrderTrackRes) error {
+			t.Error("tracking sent for an unknown order")
+			return nil
+		})
+		if err == nil {
+			t.Fatal("Stream() error = nil")
+		}
+	})
+}
+
+func TestOrderWatcherChecker(t *testing.T) {
+	ctx := context.Background()
+	customerId, shipperId, ownerId, restaurantId := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	shipper := shipperId.String()
+
+	repo := &fakeOrderStateRepo{
+		order:    &ordermodel.Order{ID: "order-1", UserID: customerId.String(), ShipperID: &shipper},
+		tracking: &ordermodel.OrderTracking{OrderID: "order-1", RestaurantID: restaurantId.String()},
+	}
+	checker := NewOrderWatcherChecker(repo, &fakeOrderStateRestaurantRepo{restaurantId: restaurantId, ownerId: ownerId})
+
+	for name, tc := range map[string]struct {
+		userId uuid.UUID
+		want   bool
+	}{
+		"customer":         {customerId, true},
+		"shipper":          {shipperId, true},
+		"restaurant owner": {ownerId, true},
+		"stranger":         {uuid.New(), false},
+	} {
+		got, err := checker.IsOwner(ctx, "order-1", tc.userId)
+		if err != nil || got != tc.want {
+			t.Errorf("IsOwner(%s) = %v, %v, want %v", name, got, err, tc.want)
+		}
+	}
+}
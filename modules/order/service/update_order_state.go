@@ -2,27 +2,35 @@ package service
 
 import (
 	"context"
-	"log"
+	"errors"
 	"time"
 
+	"github.com/google/uuid"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/rpc-client"
 	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
-	"github.com/ntttrang/go-food-delivery-backend-service/shared"
 	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 )
 
 // OrderState constants
 const (
-	StateWaitingForShipper = "waiting_for_shipper"
-	StatePreparing         = "preparing"
-	StateOnTheWay          = "on_the_way"
-	StateDelivered         = "delivered"
-	StateCancelled         = "cancel"
+	StatePendingRestaurant  = "pending_restaurant" // Placed, waiting for the restaurant to accept it
+	StateRestaurantAccepted = "restaurant_accepted"
+	StateRestaurantRejected = "restaurant_rejected"
+	StatePreparing          = "preparing"
+	StateReadyForPickup     = "ready_for_pickup"
+	StateOnTheWay           = "on_the_way"
+	StateDelivered          = "delivered"
+	StateCancelled          = "cancel"
 )
 
 // PaymentStatus constants
 const (
-	PaymentStatusPending = "pending"
-	PaymentStatusPaid    = "paid"
+	PaymentStatusPending           = "pending"
+	PaymentStatusPaid              = "paid"
+	PaymentStatusFailed            = "failed"
+	PaymentStatusRefunding         = "refunding"
+	PaymentStatusRefunded          = "refunded"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
 )
 
 // StateTransitionRequest represents a request to change order state
@@ -32,13 +40,14 @@ type StateTransitionRequest struct {
 	ShipperID          *string `json:"shipperId,omitempty"`
 	PaymentStatus      *string `json:"paymentStatus,omitempty"`
 	UpdatedBy          string  `json:"-"`                            // User ID who is making the update - Get from Requester context
-	CancellationReason *string `json:"cancellationReason,omitempty"` // Required when cancelling
+	UpdatedByRole      string  `json:"-"`                            // Role of the user, kept in the state history
+	CancellationReason *string `json:"cancellationReason,omitempty"` // Required when cancelling or rejecting
 }
 
 // Repository interface
 type IOrderStateRepo interface {
 	FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error)
-	Update(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking) error
+	Update(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, fromState string, events ...*ordermodel.OutboxEvent) error
 }
 
 // Notification interface for future implementation
@@ -49,49 +58,148 @@ type IOrderNotificationService interface {
 	NotifyOrderCancelled(ctx context.Context, orderID string, reason string) error
 }
 
+// Restaurant interface, to find the owner of the restaurant
+type IOrderStateRestaurantRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO, error)
+}
+
+// Refund interface
+type IOrderRefundService interface {
+	RefundRemaining(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, reason string, createdBy string) (*ordermodel.Refund, error)
+}
+
+// Inventory interface
+type IOrderInventoryService interface {
+	CommitInventory(ctx context.Context, orderID string) error
+	RestoreInventory(ctx context.Context, orderID string) error
+}
+
+// Shipper interface, refuses the shippers who are offline or at capacity
+type IShipperAvailabilityChecker interface {
+	CheckAvailable(ctx context.Context, shipperId string) (int, error)
+}
+
 // Service
 type OrderStateManagementService struct {
 	repo                IOrderStateRepo
+	restaurantRepo      IOrderStateRestaurantRepo
+	stateMachine        *ordermodel.StateMachine
 	notificationService IOrderNotificationService
-	evtPublisher        IEvtPublisher
+	inventoryService    IOrderInventoryService
+	refundService       IOrderRefundService
+	availabilityChecker IShipperAvailabilityChecker
 }
 
 func NewOrderStateManagementService(
 	repo IOrderStateRepo,
+	restaurantRepo IOrderStateRestaurantRepo,
+	stateMachine *ordermodel.StateMachine,
 	notificationService IOrderNotificationService,
-	evtPublisher IEvtPublisher,
+	inventoryService IOrderInventoryService,
+	refundService IOrderRefundService,
+	availabilityChecker IShipperAvailabilityChecker,
 ) *OrderStateManagementService {
 	return &OrderStateManagementService{
 		repo:                repo,
+		restaurantRepo:      restaurantRepo,
+		stateMachine:        stateMachine,
 		notificationService: notificationService,
-		// refundService and inventoryService are optional for now
-		evtPublisher: evtPublisher,
+		inventoryService:    inventoryService,
+		refundService:       refundService,
+		availabilityChecker: availabilityChecker,
 	}
 }
 
-// validateStateTransition validates if the state transition is allowed
-func (s *OrderStateManagementService) validateStateTransition(currentState, newState string) error {
-	validTransitions := map[string][]string{
-		StateWaitingForShipper: {StatePreparing, StateCancelled},
-		StatePreparing:         {StateOnTheWay, StateCancelled},
-		StateOnTheWay:          {StateDelivered, StateCancelled},
-		StateDelivered:         {}, // Terminal state
-		StateCancelled:         {}, // Terminal state
+// validateStateTransition validates if the state machine allows the transition to the requester
+func (s *OrderStateManagementService) validateStateTransition(ctx context.Context, req *StateTransitionRequest, order *ordermodel.Order, tracking *ordermodel.OrderTracking) error {
+	transition, ok := s.stateMachine.Transition(tracking.State, req.NewState)
+	if !ok {
+		if s.stateMachine.IsTerminal(tracking.State) {
+			return datatype.ErrBadRequest.WithWrap(ordermodel.ErrInvalidOrderState).WithDebug("order is already " + tracking.State)
+		}
+		return datatype.ErrBadRequest.WithWrap(ordermodel.ErrInvalidOrderState).WithDebug("invalid state transition from " + tracking.State + " to " + req.NewState)
+	}
+
+	allowed, err := s.actsAsAny(ctx, req, order, tracking, s.stateMachine.AllowedActors(transition))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return datatype.ErrForbidden.WithWrap(ordermodel.ErrStateTransitionForbidden).WithDebug("requester cannot move the order from " + tracking.State + " to " + req.NewState)
+	}
+
+	if transition.RequiresReason && (req.CancellationReason == nil || *req.CancellationReason == "") {
+		return datatype.ErrBadRequest.WithError("cancellation reason is required when moving an order to " + req.NewState)
+	}
+	if req.ShipperID != nil && !transition.AssignsShipper {
+		return datatype.ErrBadRequest.WithError("shipper cannot be assigned when moving an order to " + req.NewState)
 	}
 
-	allowedStates, exists := validTransitions[currentState]
-	if !exists {
-		return datatype.ErrBadRequest.WithWrap(ordermodel.ErrInvalidOrderState).WithDebug("invalid current state: " + currentState)
+	return validatePaymentStatus(req, tracking)
+}
+
+// validatePaymentStatus lets admins settle the payment by hand. A cancellation and the refunds
+// decide the payment status themselves, it cannot be set then.
+func validatePaymentStatus(req *StateTransitionRequest, tracking *ordermodel.OrderTracking) error {
+	if req.PaymentStatus == nil {
+		return nil
+	}
+	if req.UpdatedByRole != string(datatype.RoleAdmin) {
+		return datatype.ErrForbidden.WithWrap(ordermodel.ErrPaymentStatusForbidden).WithDebug(ordermodel.ErrPaymentStatusForbidden.Error())
+	}
+	if *req.PaymentStatus != PaymentStatusPending && *req.PaymentStatus != PaymentStatusPaid {
+		return datatype.ErrBadRequest.WithError("invalid payment status")
 	}
+	if isCancelledState(req.NewState) || (tracking.PaymentStatus != PaymentStatusPending && tracking.PaymentStatus != PaymentStatusPaid) {
+		return datatype.ErrBadRequest.WithWrap(ordermodel.ErrPaymentStatusNotSettable).WithDebug("order payment is " + tracking.PaymentStatus)
+	}
+	return nil
+}
 
-	// Check if newState is in allowedStates
-	for _, allowedState := range allowedStates {
-		if allowedState == newState {
-			return nil
+// actsAsAny reports whether the requester is one of the actors on the order
+func (s *OrderStateManagementService) actsAsAny(ctx context.Context, req *StateTransitionRequest, order *ordermodel.Order, tracking *ordermodel.OrderTracking, actors []string) (bool, error) {
+	for _, actor := range actors {
+		switch actor {
+		case ordermodel.ActorAdmin:
+			if req.UpdatedByRole == string(datatype.RoleAdmin) {
+				return true, nil
+			}
+		case ordermodel.ActorCustomer:
+			if order.UserID == req.UpdatedBy {
+				return true, nil
+			}
+		case ordermodel.ActorShipper:
+			if order.ShipperID != nil && *order.ShipperID == req.UpdatedBy {
+				return true, nil
+			}
+		case ordermodel.ActorRestaurantOwner:
+			isOwner, err := s.isRestaurantOwner(ctx, tracking.RestaurantID, req.UpdatedBy)
+			if err != nil {
+				return false, err
+			}
+			if isOwner {
+				return true, nil
+			}
 		}
 	}
+	return false, nil
+}
+
+func (s *OrderStateManagementService) isRestaurantOwner(ctx context.Context, restaurantID, userID string) (bool, error) {
+	if s.restaurantRepo == nil {
+		return false, nil
+	}
+	id, err := uuid.Parse(restaurantID)
+	if err != nil {
+		return false, nil
+	}
 
-	return datatype.ErrBadRequest.WithWrap(ordermodel.ErrInvalidOrderState).WithDebug("invalid state transition from " + currentState + " to " + newState)
+	restaurants, err := s.restaurantRepo.FindByIds(ctx, []uuid.UUID{id})
+	if err != nil {
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	restaurant, ok := restaurants[id]
+	return ok && restaurant.OwnerId.String() == userID, nil
 }
 
 // Execute handles order state transitions
@@ -106,10 +214,19 @@ func (s *OrderStateManagementService) Execute(ctx context.Context, req *StateTra
 	}
 
 	// Validate state transition
-	if err := s.validateStateTransition(tracking.State, req.NewState); err != nil {
+	if err := s.validateStateTransition(ctx, req, order, tracking); err != nil {
 		return err
 	}
 
+	// A newly assigned shipper must be online, on shift and below their limit of orders
+	if req.ShipperID != nil && (order.ShipperID == nil || *order.ShipperID != *req.ShipperID) {
+		limit, err := s.availabilityChecker.CheckAvailable(ctx, *req.ShipperID)
+		if err != nil {
+			return err
+		}
+		order.ShipperLimit = limit
+	}
+
 	// Store old state for notification
 	oldState := tracking.State
 
@@ -117,22 +234,23 @@ func (s *OrderStateManagementService) Execute(ctx context.Context, req *StateTra
 	tracking.State = req.NewState
 	tracking.UpdatedAt = time.Now()
 
+	// Assign shipper, the state machine only allows it on the transitions before the pickup
+	if req.ShipperID != nil {
+		order.ShipperID = req.ShipperID
+		order.UpdatedAt = time.Now()
+	}
+
 	// Handle specific state transitions
 	switch req.NewState {
-	case StatePreparing:
-		// When order moves to preparing, it should have a shipper assigned
-		if req.ShipperID != nil {
-			order.ShipperID = req.ShipperID
-			order.UpdatedAt = time.Now()
-		}
-
 	case StateOnTheWay:
 		// Ensure shipper is assigned
 		if order.ShipperID == nil {
 			return datatype.ErrBadRequest.WithWrap(ordermodel.ErrShipperRequired).WithDebug("shipper must be assigned before order can be on the way")
 		}
-		// Set estimated delivery time (example: 30 minutes from now)
-		tracking.EstimatedTime = 30
+		// Keep the ETA quoted at checkout, fall back to the default for older orders
+		if tracking.EstimatedTime == 0 {
+			tracking.EstimatedTime = DefaultEstimatedTime
+		}
 
 	case StateDelivered:
 		// Set actual delivery time
@@ -142,20 +260,9 @@ func (s *OrderStateManagementService) Execute(ctx context.Context, req *StateTra
 			tracking.PaymentStatus = PaymentStatusPaid
 		}
 
-	case StateCancelled:
-		// Validate cancellation reason is provided
-		if req.CancellationReason == nil || *req.CancellationReason == "" {
-			return datatype.ErrBadRequest.WithError("cancellation reason is required when cancelling an order")
-		}
-
-		// Handle refund for paid orders
-		if tracking.PaymentStatus == PaymentStatusPaid {
-			// Step 1: Process refund based on payment method ( TBD)
-			// Step 2: Update payment status to indicate refund is being processed
-			tracking.PaymentStatus = PaymentStatusPending
-		}
-
-		// Step 3: Restore inventory if inventory service is available (TBD)
+	case StateCancelled, StateRestaurantRejected:
+		// A rejected order is cancelled by the restaurant, both require a reason in the state machine.
+		// The stock and the payment are settled by settleOrder once the cancellation is saved.
 
 		// Clear shipper assignment if order was assigned
 		if order.ShipperID != nil {
@@ -163,112 +270,149 @@ func (s *OrderStateManagementService) Execute(ctx context.Context, req *StateTra
 			order.UpdatedAt = time.Now()
 		}
 
-		// Set cancellation timestamp (using DeliveryTime field to track cancellation time)
-		tracking.CancelReason = *req.CancellationReason
-		tracking.DeliveryTime = int(time.Since(tracking.CreatedAt).Minutes())
+		// The cancellation time is kept in the state history
+		tracking.CancelReason = req.reason()
 	}
 
-	// Update payment status if provided
+	// Update payment status if provided, checked by validatePaymentStatus
 	if req.PaymentStatus != nil {
-		if *req.PaymentStatus != PaymentStatusPending && *req.PaymentStatus != PaymentStatusPaid {
-			return datatype.ErrBadRequest.WithError("invalid payment status")
-		}
 		tracking.PaymentStatus = *req.PaymentStatus
 	}
 	order.UpdatedBy = &req.UpdatedBy
 	tracking.UpdatedBy = &req.UpdatedBy
+	tracking.StateChange = newStateChange(req)
 
-	// Save changes
-	if err := s.repo.Update(ctx, order, tracking); err != nil {
+	// Events are saved with the order and published by the outbox relay
+	events, err := newStateTransitionEvents(ctx, req, order, tracking.RestaurantID, oldState)
+	if err != nil {
 		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
 
-	// Send notifications
-	// Change state
-	if req.NewState != "" {
-		orderStateChangeMsg := map[string]interface{}{
-			"orderId":  req.OrderID,
-			"oldState": oldState,
-			"newState": req.NewState,
+	// Save changes
+	if err := s.repo.Update(ctx, order, tracking, oldState, events...); err != nil {
+		return updateOrderError(err)
+	}
+
+	return s.settleOrder(ctx, req, order, tracking)
+}
+
+// settleOrder consumes the stock of a delivered order, releases the stock of a cancelled one and refunds it.
+// It runs once the new state is saved, so that a failed save never releases the stock of a live order.
+// The food service and the refund ledger ignore what is already settled, a failed step can be run again.
+func (s *OrderStateManagementService) settleOrder(ctx context.Context, req *StateTransitionRequest, order *ordermodel.Order, tracking *ordermodel.OrderTracking) error {
+	switch {
+	case req.NewState == StateDelivered:
+		// Stock reserved at checkout is now consumed
+		if s.inventoryService != nil {
+			if err := s.inventoryService.CommitInventory(ctx, req.OrderID); err != nil {
+				return err
+			}
 		}
-		go func() {
-			log.Println("Publish msg: ORDER STATE CHANGE")
-			defer shared.Recover()
 
-			evt := datatype.NewAppEvent(
-				datatype.WithTopic(datatype.EvtNotifyOrderStateChange),
-				datatype.WithData(orderStateChangeMsg),
-			)
+	case isCancelledState(req.NewState):
+		// Restore inventory if inventory service is available
+		if s.inventoryService != nil {
+			if err := s.inventoryService.RestoreInventory(ctx, req.OrderID); err != nil {
+				return err
+			}
+		}
 
-			if err := s.evtPublisher.Publish(ctx, evt.Topic, evt); err != nil {
-				log.Println("Failed to publish event", err)
+		// Handle refund for paid card orders: what is left to refund is recorded in the ledger
+		// and the payment status follows its outcome
+		if isRefundable(tracking.PaymentStatus) && tracking.PaymentMethod != MethodCash && s.refundService != nil {
+			if _, err := s.refundService.RefundRemaining(ctx, order, tracking, tracking.CancelReason, req.UpdatedBy); err != nil {
+				return err
 			}
-		}()
+		}
 	}
+	return nil
+}
 
-	// Notify cancellation with reason
-	if req.NewState == StateCancelled && req.CancellationReason != nil {
-		orderCancelMsg := map[string]interface{}{
-			"orderId":      req.OrderID,
-			"cancelReason": *req.CancellationReason,
-		}
-		go func() {
-			log.Println("Publish msg: CANCEL ORDER")
-			defer shared.Recover()
+// newStateChange describes the transition for the state history
+func newStateChange(req *StateTransitionRequest) *ordermodel.OrderStateChange {
+	change := &ordermodel.OrderStateChange{
+		ActorRole: req.UpdatedByRole,
+		Metadata:  map[string]any{},
+	}
+	if req.CancellationReason != nil {
+		change.Reason = *req.CancellationReason
+	}
+	if req.ShipperID != nil {
+		change.Metadata["shipperId"] = *req.ShipperID
+	}
+	if req.PaymentStatus != nil {
+		change.Metadata["paymentStatus"] = *req.PaymentStatus
+	}
+	return change
+}
 
-			evt := datatype.NewAppEvent(
-				datatype.WithTopic(datatype.EvtNotifyOrderCancel),
-				datatype.WithData(orderCancelMsg),
-			)
+// newStateTransitionEvents builds the notifications of a state transition
+func newStateTransitionEvents(ctx context.Context, req *StateTransitionRequest, order *ordermodel.Order, restaurantId, oldState string) ([]*ordermodel.OutboxEvent, error) {
+	var payloads []datatype.EvtPayload
 
-			if err := s.evtPublisher.Publish(ctx, evt.Topic, evt); err != nil {
-				log.Println("Failed to publish event", err)
-			}
-		}()
+	// Change state
+	if req.NewState != "" {
+		payloads = append(payloads, datatype.OrderStateChangedEvt{
+			OrderID:      req.OrderID,
+			RestaurantID: restaurantId,
+			OldState:     oldState,
+			NewState:     req.NewState,
+		})
+	}
+
+	// Notify cancellation with reason
+	if isCancelledState(req.NewState) {
+		payloads = append(payloads, datatype.OrderCancelledEvt{
+			OrderID:      req.OrderID,
+			CancelReason: req.reason(),
+		})
 	}
 
 	// Notify shipper assignment
 	if req.ShipperID != nil && order.ShipperID != nil {
-		assignShipperMsg := map[string]interface{}{
-			"orderId":   req.OrderID,
-			"shipperId": *order.ShipperID,
-		}
-		go func() {
-			log.Println("Publish msg: ASSIGN SHIPPER")
-			defer shared.Recover()
-
-			evt := datatype.NewAppEvent(
-				datatype.WithTopic(datatype.EvtNotifyShipperAssign),
-				datatype.WithData(assignShipperMsg),
-			)
-
-			if err := s.evtPublisher.Publish(ctx, evt.Topic, evt); err != nil {
-				log.Println("Failed to publish event", err)
-			}
-		}()
+		payloads = append(payloads, datatype.ShipperAssignedEvt{
+			OrderID:   req.OrderID,
+			ShipperID: *order.ShipperID,
+		})
 	}
 
 	// Notify payment status change
 	if req.PaymentStatus != nil {
-		paymentStatusChangeMsg := map[string]interface{}{
-			"orderId":       req.OrderID,
-			"paymentStatus": req.PaymentStatus,
+		payloads = append(payloads, datatype.PaymentStatusChangedEvt{
+			OrderID:       req.OrderID,
+			PaymentStatus: *req.PaymentStatus,
+		})
+	}
+
+	events := make([]*ordermodel.OutboxEvent, 0, len(payloads))
+	for _, payload := range payloads {
+		evt, err := ordermodel.NewOutboxEvent(ctx, req.OrderID, payload)
+		if err != nil {
+			return nil, err
 		}
-		go func() {
-			log.Println("Publish msg: CHANGE PAYMENT STATUS")
-			defer shared.Recover()
+		events = append(events, evt)
+	}
 
-			evt := datatype.NewAppEvent(
-				datatype.WithTopic(datatype.EvtNotifyPaymentStatusChange),
-				datatype.WithData(paymentStatusChangeMsg),
-			)
+	return events, nil
+}
 
-			if err := s.evtPublisher.Publish(ctx, evt.Topic, evt); err != nil {
-				log.Println("Failed to publish event", err)
-			}
-		}()
+// updateOrderError maps a failed save, the order may have been changed by another request meanwhile
+func updateOrderError(err error) error {
+	if errors.Is(err, ordermodel.ErrOrderStateChanged) || errors.Is(err, ordermodel.ErrShipperAtCapacity) {
+		return datatype.ErrConflict.WithWrap(err).WithDebug(err.Error())
+	}
+	return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+}
 
+// reason returns the cancellation reason, empty when none is given
+func (r *StateTransitionRequest) reason() string {
+	if r.CancellationReason == nil {
+		return ""
 	}
+	return *r.CancellationReason
+}
 
-	return nil
+// isCancelledState reports whether the order is cancelled in the state, by any party
+func isCancelledState(state string) bool {
+	return state == StateCancelled || state == StateRestaurantRejected
 }
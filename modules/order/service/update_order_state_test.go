@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/order/infras/repository/rpc-client"
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeOrderStateRepo struct {
+	order     *ordermodel.Order
+	tracking  *ordermodel.OrderTracking
+	updated   bool
+	updateErr error
+}
+
+func (r *fakeOrderStateRepo) FindById(ctx context.Context, id string) (*ordermodel.Order, *ordermodel.OrderTracking, []ordermodel.OrderDetail, error) {
+	if r.order == nil || r.order.ID != id {
+		return nil, nil, nil, ordermodel.ErrOrderNotFound
+	}
+	return r.order, r.tracking, nil, nil
+}
+
+func (r *fakeOrderStateRepo) Update(ctx context.Context, order *ordermodel.Order, tracking *ordermodel.OrderTracking, fromState string, events ...*ordermodel.OutboxEvent) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	r.updated = true
+	return nil
+}
+
+type fakeOrderInventoryService struct {
+	committed, restored []string
+}
+
+func (s *fakeOrderInventoryService) CommitInventory(ctx context.Context, orderID string) error {
+	s.committed = append(s.committed, orderID)
+	return nil
+}
+
+func (s *fakeOrderInventoryService) RestoreInventory(ctx context.Context, orderID string) error {
+	s.restored = append(s.restored, orderID)
+	return nil
+}
+
+type fakeOrderStateRestaurantRepo struct {
+	restaurantId uuid.UUID
+	ownerId      uuid.UUID
+	lat, lng     float64
+}
+
+func (r *fakeOrderStateRestaurantRepo) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO, error) {
+	return map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO{
+		r.restaurantId: {Id: r.restaurantId, OwnerId: r.ownerId, Lat: r.lat, Lng: r.lng},
+	}, nil
+}
+
+type fakeShipperProfileRepo map[uuid.UUID]ordermodel.Shipper
+
+func (r fakeShipperProfileRepo) FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]ordermodel.Shipper, error) {
+	shippers := make(map[uuid.UUID]ordermodel.Shipper)
+	for _, id := range ids {
+		if shipper, ok := r[id]; ok {
+			shippers[id] = shipper
+		}
+	}
+	return shippers, nil
+}
+
+type fakeShipperWorkloadRepo map[string]int
+
+func (r fakeShipperWorkloadRepo) CountActiveByShipperId(ctx context.Context, shipperId string) (int, error) {
+	return r[shipperId], nil
+}
+
+func TestLoadStateMachine(t *testing.T) {
+	sm, err := ordermodel.LoadStateMachine("")
+	if err != nil {
+		t.Fatalf("LoadStateMachine() error = %v", err)
+	}
+
+	for _, state := range []string{StateDelivered, StateCancelled, StateRestaurantRejected} {
+		if !sm.IsTerminal(state) {
+			t.Errorf("IsTerminal(%s) = false, want true", state)
+		}
+	}
+	if _, ok := sm.Transition(StatePendingRestaurant, StateDelivered); ok {
+		t.Errorf("Transition(%s, %s) is allowed", StatePendingRestaurant, StateDelivered)
+	}
+
+	broken := &ordermodel.StateMachine{Transitions: []ordermodel.StateTransition{{From: StatePreparing, To: StateCancelled, Actors: []string{"cook"}, RequiresReason: true}}}
+	if err := broken.Validate(); err == nil {
+		t.Error("Validate() of an unknown actor error = nil")
+	}
+
+	noReason := &ordermodel.StateMachine{Transitions: []ordermodel.StateTransition{{From: StatePreparing, To: StateCancelled, Actors: []string{ordermodel.ActorAdmin}}}}
+	if err := noReason.Validate(); err == nil {
+		t.Error("Validate() of a cancellation without a reason error = nil")
+	}
+}
+
+func TestOrderStateManagementService_Execute(t *testing.T) {
+	ctx := context.Background()
+	customerId, shipperId, ownerId, restaurantId := uuid.New(), uuid.New().String(), uuid.New(), uuid.New()
+	busyShipperId, offlineShipperId := uuid.New(), uuid.New()
+	busyShipper, offlineShipper := busyShipperId.String(), offlineShipperId.String()
+	reason := "Out of ingredients"
+	paid := PaymentStatusPaid
+
+	availabilityChecker := NewShipperAvailabilityChecker(
+		fakeShipperProfileRepo{
+			uuid.MustParse(shipperId): {Id: uuid.MustParse(shipperId), Available: true, MaxConcurrentOrders: 2},
+			busyShipperId:             {Id: busyShipperId, Available: true, MaxConcurrentOrders: 2},
+			offlineShipperId:          {Id: offlineShipperId, MaxConcurrentOrders: 2},
+		},
+		fakeShipperWorkloadRepo{shipperId: 1, busyShipper: 2},
+	)
+
+	newService := func(state string, assignedShipper *string) (*OrderStateManagementService, *fakeOrderStateRepo) {
+		repo := &fakeOrderStateRepo{
+			order:    &ordermodel.Order{ID: "order-1", UserID: customerId.String(), ShipperID: assignedShipper},
+			tracking: &ordermodel.OrderTracking{OrderID: "order-1", RestaurantID: restaurantId.String(), State: state, PaymentMethod: MethodCash, PaymentStatus: PaymentStatusPending},
+		}
+		restaurantRepo := &fakeOrderStateRestaurantRepo{restaurantId: restaurantId, ownerId: ownerId}
+		return NewOrderStateManagementService(repo, restaurantRepo, ordermodel.MustLoadStateMachine(""), nil, nil, nil, availabilityChecker), repo
+	}
+
+	tests := []struct {
+		name            string
+		state           string
+		assignedShipper *string
+		req             StateTransitionRequest
+		wantStatus      int
+	}{
+		{
+			name:  "TC 1: restaurant owner accepts and assigns a shipper",
+			state: StatePendingRestaurant,
+			req:   StateTransitionRequest{NewState: StateRestaurantAccepted, ShipperID: &shipperId, UpdatedBy: ownerId.String(), UpdatedByRole: string(datatype.RoleUser)},
+		},
+		{
+			name:       "TC 2: customer cannot accept their own order",
+			state:      StatePendingRestaurant,
+			req:        StateTransitionRequest{NewState: StateRestaurantAccepted, UpdatedBy: customerId.String(), UpdatedByRole: string(datatype.RoleUser)},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "TC 3: restaurant owner rejects without a reason",
+			state:      StatePendingRestaurant,
+			req:        StateTransitionRequest{NewState: StateRestaurantRejected, UpdatedBy: ownerId.String(), UpdatedByRole: string(datatype.RoleUser)},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "TC 4: customer cancels before preparation",
+			state: StateRestaurantAccepted,
+			req:   StateTransitionRequest{NewState: StateCancelled, CancellationReason: &reason, UpdatedBy: customerId.String(), UpdatedByRole: string(datatype.RoleUser)},
+		},
+		{
+			name:       "TC 5: customer cannot cancel once preparing",
+			state:      StatePreparing,
+			req:        StateTransitionRequest{NewState: StateCancelled, CancellationReason: &reason, UpdatedBy: customerId.String(), UpdatedByRole: string(datatype.RoleUser)},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:            "TC 6: assigned shipper picks up",
+			state:           StateReadyForPickup,
+			assignedShipper: &shipperId,
+			req:             StateTransitionRequest{NewState: StateOnTheWay, UpdatedBy: shipperId, UpdatedByRole: string(datatype.RoleShipper)},
+		},
+		{
+			name:            "TC 7: another shipper cannot deliver",
+			state:           StateOnTheWay,
+			assignedShipper: &shipperId,
+			req:             StateTransitionRequest{NewState: StateDelivered, UpdatedBy: uuid.New().String(), UpdatedByRole: string(datatype.RoleShipper)},
+			wantStatus:      http.StatusForbidden,
+		},
+		{
+			name:            "TC 8: admin overrides a cancellation on the way",
+			state:           StateOnTheWay,
+			assignedShipper: &shipperId,
+			req:             StateTransitionRequest{NewState: StateCancelled, CancellationReason: &reason, UpdatedBy: uuid.New().String(), UpdatedByRole: string(datatype.RoleAdmin)},
+		},
+		{
+			name:       "TC 9: admin cannot skip the lifecycle",
+			state:      StatePendingRestaurant,
+			req:        StateTransitionRequest{NewState: StateDelivered, UpdatedBy: uuid.New().String(), UpdatedByRole: string(datatype.RoleAdmin)},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "TC 10: shipper cannot be assigned on the way",
+			state:      StateReadyForPickup,
+			req:        StateTransitionRequest{NewState: StateOnTheWay, ShipperID: &shipperId, UpdatedBy: uuid.New().String(), UpdatedByRole: string(datatype.RoleAdmin)},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "TC 11: shipper at capacity cannot be assigned",
+			state:      StatePendingRestaurant,
+			req:        StateTransitionRequest{NewState: StateRestaurantAccepted, ShipperID: &busyShipper, UpdatedBy: ownerId.String(), UpdatedByRole: string(datatype.RoleUser)},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "TC 12: offline shipper cannot be assigned",
+			state:      StatePendingRestaurant,
+			req:        StateTransitionRequest{NewState: StateRestaurantAccepted, ShipperID: &offlineShipper, UpdatedBy: ownerId.String(), UpdatedByRole: string(datatype.RoleUser)},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "TC 13: customer cannot set the payment status",
+			state:      StateRestaurantAccepted,
+			req:        StateTransitionRequest{NewState: StateCancelled, CancellationReason: &reason, PaymentStatus: &paid, UpdatedBy: customerId.String(), UpdatedByRole: string(datatype.RoleUser)},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:            "TC 14: admin cannot set the payment status of a cancellation",
+			state:           StateOnTheWay,
+			assignedShipper: &shipperId,
+			req:             StateTransitionRequest{NewState: StateCancelled, CancellationReason: &reason, PaymentStatus: &paid, UpdatedBy: uuid.New().String(), UpdatedByRole: string(datatype.RoleAdmin)},
+			wantStatus:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newService(tt.state, tt.assignedShipper)
+			req := tt.req
+			req.OrderID = "order-1"
+
+			err := svc.Execute(ctx, &req)
+			if tt.wantStatus != 0 {
+				var appErr *datatype.DefaultError
+				if !errors.As(err, &appErr) || appErr.StatusCode() != tt.wantStatus {
+					t.Fatalf("Execute() error = %v, want status %d", err, tt.wantStatus)
+				}
+				if repo.updated || repo.tracking.State != tt.state {
+					t.Errorf("order updated to %s after a refused transition", repo.tracking.State)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !repo.updated || repo.tracking.State != tt.req.NewState {
+				t.Errorf("state = %s, want %s", repo.tracking.State, tt.req.NewState)
+			}
+		})
+	}
+}
+
+func TestOrderStateManagementService_Execute_shipperLimit(t *testing.T) {
+	ctx := context.Background()
+	ownerId, restaurantId, shipperId := uuid.New(), uuid.New(), uuid.New()
+	shipper := shipperId.String()
+
+	tests := []struct {
+		name       string
+		updateErr  error
+		wantStatus int
+	}{
+		{name: "TC 1: the limit of the shipper is enforced by the repository"},
+		{name: "TC 2: another order took the last place of the shipper meanwhile", updateErr: ordermodel.ErrShipperAtCapacity, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOrderStateRepo{
+				order:     &ordermodel.Order{ID: "order-1", UserID: uuid.New().String()},
+				tracking:  &ordermodel.OrderTracking{OrderID: "order-1", RestaurantID: restaurantId.String(), State: StatePendingRestaurant, PaymentMethod: MethodCash, PaymentStatus: PaymentStatusPending},
+				updateErr: tt.updateErr,
+			}
+			availabilityChecker := NewShipperAvailabilityChecker(
+				fakeShipperProfileRepo{shipperId: {Id: shipperId, Available: true, MaxConcurrentOrders: 2}},
+				fakeShipperWorkloadRepo{shipper: 1},
+			)
+			restaurantRepo := &fakeOrderStateRestaurantRepo{restaurantId: restaurantId, ownerId: ownerId}
+			svc := NewOrderStateManagementService(repo, restaurantRepo, ordermodel.MustLoadStateMachine(""), nil, nil, nil, availabilityChecker)
+
+			err := svc.Execute(ctx, &StateTransitionRequest{OrderID: "order-1", NewState: StateRestaurantAccepted, ShipperID: &shipper, UpdatedBy: ownerId.String(), UpdatedByRole: string(datatype.RoleUser)})
+			if tt.wantStatus != 0 {
+				var appErr *datatype.DefaultError
+				if !errors.As(err, &appErr) || appErr.StatusCode() != tt.wantStatus {
+					t.Fatalf("Execute() error = %v, want status %d", err, tt.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if repo.order.ShipperLimit != 2 {
+				t.Errorf("shipper limit = %d, want 2", repo.order.ShipperLimit)
+			}
+		})
+	}
+}
+
+func TestOrderStateManagementService_Execute_settleOrder(t *testing.T) {
+	ctx := context.Background()
+	customerId := uuid.New()
+	reason := "Ordered by mistake"
+
+	tests := []struct {
+		name         string
+		updateErr    error
+		wantRestored int
+		wantStatus   int
+	}{
+		{name: "TC 1: stock of a cancelled order is released once saved", wantRestored: 1},
+		{name: "TC 2: stock is kept when the cancellation is not saved", updateErr: ordermodel.ErrOrderStateChanged, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOrderStateRepo{
+				order:     &ordermodel.Order{ID: "order-1", UserID: customerId.String()},
+				tracking:  &ordermodel.OrderTracking{OrderID: "order-1", State: StateRestaurantAccepted, PaymentMethod: MethodCash, PaymentStatus: PaymentStatusPending},
+				updateErr: tt.updateErr,
+			}
+			inventory := &fakeOrderInventoryService{}
+			svc := NewOrderStateManagementService(repo, &fakeOrderStateRestaurantRepo{}, ordermodel.MustLoadStateMachine(""), nil, inventory, nil, nil)
+
+			err := svc.Execute(ctx, &StateTransitionRequest{OrderID: "order-1", NewState: StateCancelled, CancellationReason: &reason, UpdatedBy: customerId.String(), UpdatedByRole: string(datatype.RoleUser)})
+			if tt.wantStatus != 0 {
+				var appErr *datatype.DefaultError
+				if !errors.As(err, &appErr) || appErr.StatusCode() != tt.wantStatus {
+					t.Fatalf("Execute() error = %v, want status %d", err, tt.wantStatus)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(inventory.restored) != tt.wantRestored {
+				t.Errorf("stock released %d times, want %d", len(inventory.restored), tt.wantRestored)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	ordermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/order/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"github.com/pkg/errors"
+)
+
+// IWebhookPublisher queues the order events for the webhooks of the restaurant partners
+type IWebhookPublisher interface {
+	Publish(ctx context.Context, evt ordermodel.WebhookEvent) error
+}
+
+// orderWebhookData is the data of the order webhooks, the order as it is when the event is handled
+type orderWebhookData struct {
+	OrderId         string             `json:"orderId"`
+	State           string             `json:"state"`
+	OldState        string             `json:"oldState,omitempty"`
+	NewState        string             `json:"newState,omitempty"`
+	CancelReason    string             `json:"cancelReason,omitempty"`
+	TotalPrice      float64            `json:"totalPrice"`
+	PaymentStatus   string             `json:"paymentStatus"`
+	PaymentMethod   string             `json:"paymentMethod"`
+	EstimatedTime   int                `json:"estimatedTime"` // minutes
+	DeliveryAddress json.RawMessage    `json:"deliveryAddress,omitempty"`
+	Items           []orderWebhookItem `json:"items"`
+}
+
+type orderWebhookItem struct {
+	Food     json.RawMessage `json:"food,omitempty"`
+	Quantity int             `json:"quantity"`
+	Price    float64         `json:"price"`
+	Discount float64         `json:"discount"`
+}
+
+// OrderWebhookService forwards the order events to the webhook module,
+// which delivers them to the endpoints registered by the restaurant
+type OrderWebhookService struct {
+	orderRepo IOrderNotificationRepo
+	publisher IWebhookPublisher
+}
+
+func NewOrderWebhookService(orderRepo IOrderNotificationRepo, publisher IWebhookPublisher) *OrderWebhookService {
+	return &OrderWebhookService{orderRepo: orderRepo, publisher: publisher}
+}
+
+func (s *OrderWebhookService) OrderCreated(ctx context.Context, evt *datatype.AppEvent, data *datatype.OrderCreatedEvt) error {
+	return s.publish(ctx, evt, ordermodel.WebhookOrderCreated, data.OrderID, func(d *orderWebhookData) {})
+}
+
+func (s *OrderWebhookService) OrderStateChanged(ctx context.Context, evt *datatype.AppEvent, data *datatype.OrderStateChangedEvt) error {
+	return s.publish(ctx, evt, ordermodel.WebhookOrderStateChanged, data.OrderID, func(d *orderWebhookData) {
+		d.OldState = data.OldState
+		d.NewState = data.NewState
+	})
+}
+
+func (s *OrderWebhookService) OrderCancelled(ctx context.Context, evt *datatype.AppEvent, data *datatype.OrderCancelledEvt) error {
+	return s.publish(ctx, evt, ordermodel.WebhookOrderCancelled, data.OrderID, func(d *orderWebhookData) {
+		d.CancelReason = data.CancelReason
+	})
+}
+
+func (s *OrderWebhookService) publish(ctx context.Context, evt *datatype.AppEvent, eventType, orderId string, fill func(d *orderWebhookData)) error {
+	order, tracking, details, err := s.orderRepo.FindById(ctx, orderId)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get order %s", orderId)
+	}
+	if order == nil || tracking == nil {
+		return errors.Wrap(ordermodel.ErrOrderNotFound, orderId)
+	}
+
+	data := newOrderWebhookData(order, tracking, details)
+	fill(&data)
+
+	occurredAt := evt.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now().UTC()
+	}
+
+	return s.publisher.Publish(ctx, ordermodel.WebhookEvent{
+		Id:           evt.ID,
+		Type:         eventType,
+		RestaurantId: tracking.RestaurantID,
+		OccurredAt:   occurredAt,
+		Data:         data,
+	})
+}
+
+func newOrderWebhookData(order *ordermodel.Order, tracking *ordermodel.OrderTracking, details []ordermodel.OrderDetail) orderWebhookData {
+	data := orderWebhookData{
+		OrderId:       order.ID,
+		State:         tracking.State,
+		TotalPrice:    order.TotalPrice,
+		PaymentStatus: tracking.PaymentStatus,
+		PaymentMethod: tracking.PaymentMethod,
+		EstimatedTime: tracking.EstimatedTime,
+		Items:         make([]orderWebhookItem, 0, len(details)),
+	}
+	if len(tracking.DeliveryAddress) > 0 {
+		data.DeliveryAddress = json.RawMessage(tracking.DeliveryAddress)
+	}
+	for _, detail := range details {
+		data.Items = append(data.Items, orderWebhookItem{
+			Food:     json.RawMessage(detail.FoodOrigin),
+			Quantity: detail.Quantity,
+			Price:    detail.Price,
+			Discount: detail.Discount,
+		})
+	}
+	return data
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
 	"github.com/ntttrang/go-food-delivery-backend-service/modules/payment/model"
 	service "github.com/ntttrang/go-food-delivery-backend-service/modules/payment/service"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
 )
 
 type ICreateCommandHandler interface {
@@ -36,7 +38,8 @@ type CardController struct {
 	getCardsByUserIDHandler IGetByUserIdQueryHandler
 	updateCardStatusHandler IUpdateStatusCommandHandler
 
-	repo ICardRepository
+	repo         ICardRepository
+	ownerChecker middleware.IOwnershipChecker
 }
 
 // NewCardController creates a new card controller
@@ -46,6 +49,7 @@ func NewCardController(
 	getCardsByUserIDHandler IGetByUserIdQueryHandler,
 	updateCardStatusHandler IUpdateStatusCommandHandler,
 	repo ICardRepository,
+	ownerChecker middleware.IOwnershipChecker,
 ) *CardController {
 	return &CardController{
 		createCardHandler:       createCardHandler,
@@ -53,15 +57,16 @@ func NewCardController(
 		getCardsByUserIDHandler: getCardsByUserIDHandler,
 		updateCardStatusHandler: updateCardStatusHandler,
 		repo:                    repo,
+		ownerChecker:            ownerChecker,
 	}
 }
 
-func (c *CardController) SetupRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+func (c *CardController) SetupRoutes(router *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
 	cards := router.Group("/cards")
 	{
-		cards.POST("", authMiddleware, c.CreateCard)
-		cards.GET("/:id", c.GetCardByID)
-		cards.PATCH("/:id", c.UpdateCardStatus)
-		cards.GET("/user/:userId", c.GetCardsByUserID)
+		cards.POST("", mldProvider.Auth(), c.CreateCard)
+		cards.GET("/:id", mldProvider.RequireOwner(middleware.FromParam("id"), c.ownerChecker), c.GetCardByID)
+		cards.PATCH("/:id", mldProvider.RequireOwner(middleware.FromParam("id"), c.ownerChecker), c.UpdateCardStatus)
+		cards.GET("/user/:userId", mldProvider.RequireOwner(middleware.FromParam("userId"), middleware.SelfChecker), c.GetCardsByUserID)
 	}
 }
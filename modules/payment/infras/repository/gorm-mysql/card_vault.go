@@ -0,0 +1,110 @@
+package gormmysql
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/payment/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// CardVaultRepo encrypts card numbers with AES-256-GCM and stores them behind a token
+type CardVaultRepo struct {
+	dbCtx       shareinfras.IDbContext
+	keyProvider sharecomponent.IKeyProvider
+}
+
+func NewCardVaultRepo(dbCtx shareinfras.IDbContext, keyProvider sharecomponent.IKeyProvider) *CardVaultRepo {
+	return &CardVaultRepo{dbCtx: dbCtx, keyProvider: keyProvider}
+}
+
+// Tokenize stores the encrypted card number and returns its token and fingerprint.
+// The fingerprint identifies the same card across users without revealing the number.
+func (r *CardVaultRepo) Tokenize(ctx context.Context, cardNumber string) (string, string, error) {
+	keyId, key, err := r.keyProvider.CurrentKey(ctx)
+	if err != nil {
+		return "", "", model.ErrVaultKeyNotConfigured
+	}
+	fingerprintKey, err := r.keyProvider.FingerprintKey(ctx)
+	if err != nil {
+		return "", "", model.ErrVaultKeyNotConfigured
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", "", errors.WithStack(err)
+	}
+
+	now := time.Now()
+	entry := model.CardVaultEntry{
+		Token:      "tok_" + uuid.New().String(),
+		KeyId:      keyId,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, []byte(cardNumber), nil),
+		CreatedAt:  &now,
+	}
+
+	db := r.dbCtx.GetMainConnection()
+	if err := db.WithContext(ctx).Create(&entry).Error; err != nil {
+		return "", "", errors.WithStack(err)
+	}
+
+	// Not the encryption key, which is rotated: the same card must keep its fingerprint
+	mac := hmac.New(sha256.New, fingerprintKey)
+	mac.Write([]byte(cardNumber))
+
+	return entry.Token, hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Detokenize returns the card number of a token, for payment providers only
+func (r *CardVaultRepo) Detokenize(ctx context.Context, token string) (string, error) {
+	db := r.dbCtx.GetMainConnection()
+
+	var entry model.CardVaultEntry
+	if err := db.WithContext(ctx).Where("token = ?", token).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", model.ErrVaultTokenNotFound
+		}
+		return "", errors.WithStack(err)
+	}
+
+	key, err := r.keyProvider.GetKey(ctx, entry.KeyId)
+	if err != nil {
+		return "", model.ErrVaultKeyNotConfigured
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	plain, err := gcm.Open(nil, entry.Nonce, entry.Ciphertext, nil)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
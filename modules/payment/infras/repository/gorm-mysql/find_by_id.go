@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/ntttrang/go-food-delivery-backend-service/modules/payment/model"
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 )
 
 func (r *CardRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.Card, error) {
@@ -13,6 +14,9 @@ func (r *CardRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.Card, err
 
 	var card model.Card
 	if err := db.WithContext(ctx).Where("id = ?", id).First(&card).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCardNotFound
+		}
 		return nil, errors.WithStack(err)
 	}
 
@@ -38,19 +38,25 @@ const (
 	CardStatusDeleted  CardStatus = "DELETED"
 )
 
+// Card only stores a vault token of the card number, never the number itself nor the CVV
 type Card struct {
 	ID             uuid.UUID `gorm:"column:id" json:"id"`
 	Method         string    `gorm:"column:method" json:"method"`
 	Provider       string    `gorm:"column:provider" json:"provider"`
 	CardholderName string    `gorm:"column:cardholder_name" json:"cardholderName"`
-	CardNumber     string    `gorm:"column:card_number" json:"cardNumber"`
-	CardType       string    `gorm:"column:card_type" json:"cardType"`
+	VaultToken     string    `gorm:"column:vault_token" json:"-"`
+	Last4          string    `gorm:"column:last4" json:"last4"`
+	Fingerprint    string    `gorm:"column:fingerprint" json:"fingerprint"`
+	CardType       string    `gorm:"column:card_type" json:"cardType"` // brand, detected from the BIN
 	ExpiryMonth    string    `gorm:"column:expiry_month" json:"expiryMonth"`
 	ExpiryYear     string    `gorm:"column:expiry_year" json:"expiryYear"`
-	CVV            string    `gorm:"column:cvv" json:"cvv"`
 	UserID         uuid.UUID `gorm:"column:user_id" json:"userId"`
 	Status         string    `gorm:"column:status" json:"status"`
 	sharedmodel.DateDto
+
+	// Sensitive input, only kept in memory until the card is tokenized
+	CardNumber string `gorm:"-" json:"-"`
+	CVV        string `gorm:"-" json:"-"`
 }
 
 func (Card) TableName() string {
@@ -81,18 +87,19 @@ func (c *Card) Validate() error {
 		return ErrInvalidCardholderName
 	}
 
-	// Validate CardNumber (basic validation)
+	// Validate CardNumber
 	c.CardNumber = strings.ReplaceAll(c.CardNumber, " ", "")
 	if !regexp.MustCompile(`^\d{13,19}$`).MatchString(c.CardNumber) {
 		return ErrInvalidCardNumber
 	}
+	if !LuhnValid(c.CardNumber) {
+		return ErrInvalidCardNumber
+	}
 
-	// Validate CardType
+	// Detect CardType from the BIN
+	c.CardType = DetectCardType(c.CardNumber)
 	if c.CardType == "" {
-		return ErrCardTypeRequired
-	}
-	if c.CardType != CardTypeVisa && c.CardType != CardTypeMastercard && c.CardType != CardTypeJCB {
-		return ErrInvalidPaymentMethod
+		return ErrCardTypeUnsupported
 	}
 
 	// Validate ExpiryMonth
@@ -0,0 +1,53 @@
+package model
+
+import "strconv"
+
+// LuhnValid checks the card number checksum
+func LuhnValid(number string) bool {
+	if number == "" {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// DetectCardType returns the card brand from the BIN (first digits), or empty if not supported
+func DetectCardType(number string) string {
+	prefix := func(n int) int {
+		if len(number) < n {
+			return -1
+		}
+		v, err := strconv.Atoi(number[:n])
+		if err != nil {
+			return -1
+		}
+		return v
+	}
+
+	switch {
+	case prefix(1) == 4:
+		return CardTypeVisa
+	case prefix(2) >= 51 && prefix(2) <= 55, prefix(4) >= 2221 && prefix(4) <= 2720:
+		return CardTypeMastercard
+	case prefix(4) >= 3528 && prefix(4) <= 3589:
+		return CardTypeJCB
+	default:
+		return ""
+	}
+}
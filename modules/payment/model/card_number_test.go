@@ -0,0 +1,29 @@
+package model
+
+import "testing"
+
+func TestDetectCardType(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		valid  bool
+		want   string
+	}{
+		{name: "TC 1: visa", number: "4242424242424242", valid: true, want: CardTypeVisa},
+		{name: "TC 2: mastercard 5x", number: "5555555555554444", valid: true, want: CardTypeMastercard},
+		{name: "TC 3: mastercard 2x", number: "2223003122003222", valid: true, want: CardTypeMastercard},
+		{name: "TC 4: jcb", number: "3566002020360505", valid: true, want: CardTypeJCB},
+		{name: "TC 5: amex not supported", number: "378282246310005", valid: true, want: ""},
+		{name: "TC 6: bad checksum", number: "4242424242424241", valid: false, want: CardTypeVisa},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LuhnValid(tt.number); got != tt.valid {
+				t.Errorf("LuhnValid() = %v, want %v", got, tt.valid)
+			}
+			if got := DetectCardType(tt.number); got != tt.want {
+				t.Errorf("DetectCardType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// CardVaultEntry holds an encrypted card number behind a token
+type CardVaultEntry struct {
+	Token      string     `gorm:"column:token"`
+	KeyId      string     `gorm:"column:key_id"`
+	Nonce      []byte     `gorm:"column:nonce"`
+	Ciphertext []byte     `gorm:"column:ciphertext"`
+	CreatedAt  *time.Time `gorm:"column:created_at"`
+}
+
+func (CardVaultEntry) TableName() string {
+	return "card_vault"
+}
@@ -19,4 +19,7 @@ var (
 	ErrMethodRequired        = errors.New("payment method is required")
 	ErrProviderRequired      = errors.New("payment provider is required")
 	ErrCardTypeRequired      = errors.New("card type is required")
+	ErrCardTypeUnsupported   = errors.New("card brand is not supported")
+	ErrVaultKeyNotConfigured = errors.New("card vault key is not configured")
+	ErrVaultTokenNotFound    = errors.New("card vault token not found")
 )
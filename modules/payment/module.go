@@ -5,6 +5,7 @@ import (
 	httpgin "github.com/ntttrang/go-food-delivery-backend-service/modules/payment/infras/controller/http-gin"
 	gormmysql "github.com/ntttrang/go-food-delivery-backend-service/modules/payment/infras/repository/gorm-mysql"
 	"github.com/ntttrang/go-food-delivery-backend-service/modules/payment/service"
+	shareComponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
 	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
 )
 
@@ -13,9 +14,14 @@ func SetupPaymentModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 
 	// Setup repositories
 	cardRepo := gormmysql.NewCardRepo(dbCtx)
+	keyProvider, err := shareComponent.NewStaticKeyProvider(appCtx.GetConfig().VaultConfig)
+	if err != nil {
+		panic(err)
+	}
+	cardVaultRepo := gormmysql.NewCardVaultRepo(dbCtx, keyProvider)
 
 	// Setup card handlers
-	createCardHandler := service.NewCreateCardCommandHandler(cardRepo)
+	createCardHandler := service.NewCreateCardCommandHandler(cardRepo, cardVaultRepo)
 	getCardByIDHandler := service.NewGetCardByIDQueryHandler(cardRepo)
 	getCardsByUserIDHandler := service.NewGetCardsByUserIDQueryHandler(cardRepo)
 	updateCardStatusHandler := service.NewUpdateCardStatusCommandHandler(cardRepo)
@@ -28,11 +34,12 @@ func SetupPaymentModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 		updateCardStatusHandler,
 
 		cardRepo,
+		service.NewCardOwnershipChecker(cardRepo),
 	)
 
 	// RPC routes
 	g.POST("/rpc/payments/find-by-id", cardController.RPCGetById)
 
 	// Setup routes
-	cardController.SetupRoutes(g, appCtx.MiddlewareProvider().Auth())
+	cardController.SetupRoutes(g, appCtx.MiddlewareProvider())
 }
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/payment/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type ICardOwnerRepo interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Card, error)
+}
+
+// CardOwnershipChecker allows only the card holder to access a card
+type CardOwnershipChecker struct {
+	repo ICardOwnerRepo
+}
+
+func NewCardOwnershipChecker(repo ICardOwnerRepo) *CardOwnershipChecker {
+	return &CardOwnershipChecker{repo: repo}
+}
+
+func (c *CardOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	id, err := uuid.Parse(resourceId)
+	if err != nil {
+		return false, datatype.ErrBadRequest.WithError(err.Error())
+	}
+
+	card, err := c.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, model.ErrCardNotFound) {
+			return false, datatype.ErrNotFound.WithDebug(model.ErrCardNotFound.Error())
+		}
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return card.UserID == userId, nil
+}
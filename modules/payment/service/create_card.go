@@ -14,7 +14,6 @@ type CreateCardReq struct {
 	Provider       string    `json:"provider"`
 	CardholderName string    `json:"cardholderName"`
 	CardNumber     string    `json:"cardNumber"`
-	CardType       string    `json:"cardType"`
 	Cvv            string    `json:"cvv"`
 	ExpiryMonth    string    `json:"expiryMonth"`
 	ExpiryYear     string    `json:"expiryYear"`
@@ -29,12 +28,17 @@ type ICreateCardRepo interface {
 	Create(ctx context.Context, card *model.Card) error
 }
 
+type ICardVault interface {
+	Tokenize(ctx context.Context, cardNumber string) (token string, fingerprint string, err error)
+}
+
 type CreateCardCommandHandler struct {
-	repo ICreateCardRepo
+	repo  ICreateCardRepo
+	vault ICardVault
 }
 
-func NewCreateCardCommandHandler(repo ICreateCardRepo) *CreateCardCommandHandler {
-	return &CreateCardCommandHandler{repo: repo}
+func NewCreateCardCommandHandler(repo ICreateCardRepo, vault ICardVault) *CreateCardCommandHandler {
+	return &CreateCardCommandHandler{repo: repo, vault: vault}
 }
 
 func (h *CreateCardCommandHandler) Execute(ctx context.Context, req *CreateCardReq) (*CreateCardRes, error) {
@@ -43,7 +47,6 @@ func (h *CreateCardCommandHandler) Execute(ctx context.Context, req *CreateCardR
 		Provider:       req.Provider,
 		CardholderName: strings.TrimSpace(req.CardholderName),
 		CardNumber:     req.CardNumber,
-		CardType:       req.CardType,
 		CVV:            req.Cvv,
 		ExpiryMonth:    req.ExpiryMonth,
 		ExpiryYear:     req.ExpiryYear,
@@ -53,8 +56,19 @@ func (h *CreateCardCommandHandler) Execute(ctx context.Context, req *CreateCardR
 
 	// Validate the card
 	if err := card.Validate(); err != nil {
-		return nil, err
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	// Tokenize the card number, the CVV is only used for validation and never stored
+	token, fingerprint, err := h.vault.Tokenize(ctx, card.CardNumber)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
+	card.VaultToken = token
+	card.Fingerprint = fingerprint
+	card.Last4 = card.CardNumber[len(card.CardNumber)-4:]
+	card.CardNumber = ""
+	card.CVV = ""
 
 	// Generate a new UUID for the card
 	card.ID, _ = uuid.NewV7()
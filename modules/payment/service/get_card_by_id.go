@@ -30,7 +30,5 @@ func (h *GetCardByIDQueryHandler) Execute(ctx context.Context, id uuid.UUID) (*m
 		return nil, datatype.ErrNotFound.WithDebug("card not found")
 	}
 
-	card.CardNumber = "**** **** **** " + card.CardNumber[len(card.CardNumber)-4:]
-	card.CVV = "***"
 	return card, nil
 }
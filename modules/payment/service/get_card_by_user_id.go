@@ -28,8 +28,6 @@ func (h *GetCardsByUserIDQueryHandler) Execute(ctx context.Context, userID uuid.
 	var activeCards []model.Card
 	for _, card := range cards {
 		if card.Status == string(model.CardStatusActive) {
-			card.CardNumber = "**** **** **** " + card.CardNumber[len(card.CardNumber)-4:]
-			card.CVV = "***"
 			activeCards = append(activeCards, card)
 		}
 	}
@@ -2,14 +2,14 @@ package httpgin
 
 import (
 	"context"
-	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
 	model "github.com/ntttrang/go-food-delivery-backend-service/modules/restaurant/model"
 	restaurantservice "github.com/ntttrang/go-food-delivery-backend-service/modules/restaurant/service"
-	sharedrpc "github.com/ntttrang/go-food-delivery-backend-service/shared/infras/rpc"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
 )
 
 type ICreateCommandHandler interface {
@@ -105,6 +105,9 @@ type RestaurantHttpController struct {
 	syncRestaurantIndexCommandHandler ISyncRestaurantIndexCommandHandler
 
 	rpcRepo RpcRestaurantRepo
+
+	restaurantOwnerChecker middleware.IOwnershipChecker
+	commentOwnerChecker    middleware.IOwnershipChecker
 }
 
 func NewRestaurantHttpController(
@@ -125,6 +128,8 @@ func NewRestaurantHttpController(
 	syncRestaurantByIdCommandHandler ISyncRestaurantByIdCommandHandler,
 	syncRestaurantIndexCommandHandler ISyncRestaurantIndexCommandHandler,
 	rpcRepo RpcRestaurantRepo,
+	restaurantOwnerChecker middleware.IOwnershipChecker,
+	commentOwnerChecker middleware.IOwnershipChecker,
 ) *RestaurantHttpController {
 	return &RestaurantHttpController{
 		createCmdHdl:      createCmdHdl,
@@ -149,37 +154,43 @@ func NewRestaurantHttpController(
 		syncRestaurantIndexCommandHandler: syncRestaurantIndexCommandHandler,
 
 		rpcRepo: rpcRepo,
+
+		restaurantOwnerChecker: restaurantOwnerChecker,
+		commentOwnerChecker:    commentOwnerChecker,
 	}
 }
 
-func (ctrl *RestaurantHttpController) SetupRoutes(g *gin.RouterGroup) {
-	introspectRpcClient := sharedrpc.NewIntrospectRpcClient(os.Getenv("USER_SERVICE_URL"))
+func (ctrl *RestaurantHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	auth := mldProvider.Auth()
+	isRestaurantOwner := mldProvider.RequireOwner(middleware.FromParam("id"), ctrl.restaurantOwnerChecker)
+	isMenuOwner := mldProvider.RequireOwner(middleware.FromJSONBody("restaurantId"), ctrl.restaurantOwnerChecker)
+
 	// Restaurant
-	g.POST("", middleware.Auth(introspectRpcClient), ctrl.CreateRestaurantAPI)
+	g.POST("", auth, ctrl.CreateRestaurantAPI)
 	g.GET("", ctrl.ListRestaurantsAPI)         // Query params
 	g.GET("/:id", ctrl.GetRestaurantDetailAPI) // Path Variables
-	g.PATCH("/:id", ctrl.UpdateRestaurantByIdAPI)
-	g.DELETE("/:id", ctrl.DeleteRestaurantByIdAPI)
+	g.PATCH("/:id", isRestaurantOwner, ctrl.UpdateRestaurantByIdAPI)
+	g.DELETE("/:id", isRestaurantOwner, ctrl.DeleteRestaurantByIdAPI)
 
 	// Favorites Restaurant
-	g.POST("/favorites", middleware.Auth(introspectRpcClient), ctrl.UpdateFavoritesRestaurantAPI)
-	g.GET("/favorites", middleware.Auth(introspectRpcClient), ctrl.ListFavoriteRestaurantsAPI)
+	g.POST("/favorites", auth, ctrl.UpdateFavoritesRestaurantAPI)
+	g.GET("/favorites", auth, ctrl.ListFavoriteRestaurantsAPI)
 
 	// Restaurant Comments
-	g.POST("/comments", middleware.Auth(introspectRpcClient), ctrl.CreateRestaurantCommentAPI)
+	g.POST("/comments", auth, ctrl.CreateRestaurantCommentAPI)
 	g.GET("/comments", ctrl.ListRestaurantCommentAPI)
-	g.DELETE("/comments/:id", ctrl.DeleteRestaurantCommentAPI)
+	g.DELETE("/comments/:id", mldProvider.RequireOwner(middleware.FromParam("id"), ctrl.commentOwnerChecker), ctrl.DeleteRestaurantCommentAPI)
 
-	// Menu item (restaurant-food)
-	g.POST("/menu-item", ctrl.CreateMenuItemAPI)
+	// Menu item (restaurant-food), only the restaurant owner can change the menu
+	g.POST("/menu-item", isMenuOwner, ctrl.CreateMenuItemAPI)
 	g.GET("/menu-item/:restaurantId", ctrl.ListMenuItemAPI)
-	g.DELETE("/menu-item", ctrl.DeleteMenuItemAPI)
+	g.DELETE("/menu-item", isMenuOwner, ctrl.DeleteMenuItemAPI)
 
 	// Search endpoints
 	g.POST("/search", ctrl.SearchRestaurantsAPI)
 
 	// Admin endpoints for Elasticsearch index management
-	adminGroup := g.Group("/admin")
+	adminGroup := g.Group("/admin", mldProvider.RequireRole(datatype.RoleAdmin))
 	adminGroup.POST("/sync-index", ctrl.SyncRestaurantIndexAPI)
 	adminGroup.POST("/sync-index/:id", ctrl.SyncRestaurantByIdAPI)
 }
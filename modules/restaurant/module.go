@@ -69,11 +69,13 @@ func SetupRestaurantModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 		createMenuItemCmdHdl, listMenuItemCmdHdl, deleteMenuItemCmdHdl,
 		searchRestaurantQueryHandler, syncRestaurantByIdCmdHdl, syncRestaurantIndexCmdHdl,
 		restaurantRepo,
+		restaurantService.NewRestaurantOwnershipChecker(restaurantRepo),
+		restaurantService.NewCommentOwnershipChecker(restaurantRatingRepo),
 	)
 
 	// RPC
 	g.POST("/rpc/restaurants/find-by-ids", resCtl.RPCGetByIds)
 
 	restaurants := g.Group("/restaurants")
-	resCtl.SetupRoutes(restaurants)
+	resCtl.SetupRoutes(restaurants, appCtx.MiddlewareProvider())
 }
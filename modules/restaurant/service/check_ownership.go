@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	restaurantmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/restaurant/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type IRestaurantOwnerRepo interface {
+	FindById(ctx context.Context, id uuid.UUID) (*restaurantmodel.Restaurant, error)
+}
+
+// RestaurantOwnershipChecker allows only the restaurant's owner to manage it
+type RestaurantOwnershipChecker struct {
+	repo IRestaurantOwnerRepo
+}
+
+func NewRestaurantOwnershipChecker(repo IRestaurantOwnerRepo) *RestaurantOwnershipChecker {
+	return &RestaurantOwnershipChecker{repo: repo}
+}
+
+func (c *RestaurantOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	id, err := uuid.Parse(resourceId)
+	if err != nil {
+		return false, datatype.ErrBadRequest.WithError(restaurantmodel.ErrRestaurantIdRequired.Error())
+	}
+
+	restaurant, err := c.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, restaurantmodel.ErrRestaurantNotFound) {
+			return false, datatype.ErrNotFound.WithDebug(restaurantmodel.ErrRestaurantNotFound.Error())
+		}
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return restaurant.OwnerId == userId, nil
+}
+
+type ICommentOwnerRepo interface {
+	FindById(ctx context.Context, id uuid.UUID) (*restaurantmodel.RestaurantRating, error)
+}
+
+// CommentOwnershipChecker allows only the author of a comment to manage it
+type CommentOwnershipChecker struct {
+	repo ICommentOwnerRepo
+}
+
+func NewCommentOwnershipChecker(repo ICommentOwnerRepo) *CommentOwnershipChecker {
+	return &CommentOwnershipChecker{repo: repo}
+}
+
+func (c *CommentOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	id, err := uuid.Parse(resourceId)
+	if err != nil {
+		return false, datatype.ErrBadRequest.WithError(err.Error())
+	}
+
+	comment, err := c.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, restaurantmodel.ErrRestaurantRatingNotFound) {
+			return false, datatype.ErrNotFound.WithDebug(restaurantmodel.ErrRestaurantRatingNotFound.Error())
+		}
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return comment.UserID == userId, nil
+}
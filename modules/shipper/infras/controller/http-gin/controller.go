@@ -0,0 +1,73 @@
+package httpgin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	shippermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+type IUpsertProfileCommandHandler interface {
+	Execute(ctx context.Context, req *service.UpsertProfileReq) (*shippermodel.Shipper, error)
+}
+
+type IUpdateStatusCommandHandler interface {
+	Execute(ctx context.Context, req *service.UpdateStatusReq) (*shippermodel.Shipper, error)
+}
+
+type IUpdateCapacityCommandHandler interface {
+	Execute(ctx context.Context, req *service.UpdateCapacityReq) (*shippermodel.Shipper, error)
+}
+
+type IGetShipperQueryHandler interface {
+	Execute(ctx context.Context, id uuid.UUID) (*service.ShipperRes, error)
+	ExecuteByIds(ctx context.Context, ids []uuid.UUID) ([]service.ShipperRes, error)
+}
+
+type ShipperHttpController struct {
+	upsertProfileCmdHdl  IUpsertProfileCommandHandler
+	updateStatusCmdHdl   IUpdateStatusCommandHandler
+	updateCapacityCmdHdl IUpdateCapacityCommandHandler
+	getShipperQryHdl     IGetShipperQueryHandler
+}
+
+func NewShipperHttpController(
+	upsertProfileCmdHdl IUpsertProfileCommandHandler,
+	updateStatusCmdHdl IUpdateStatusCommandHandler,
+	updateCapacityCmdHdl IUpdateCapacityCommandHandler,
+	getShipperQryHdl IGetShipperQueryHandler,
+) *ShipperHttpController {
+	return &ShipperHttpController{
+		upsertProfileCmdHdl:  upsertProfileCmdHdl,
+		updateStatusCmdHdl:   updateStatusCmdHdl,
+		updateCapacityCmdHdl: updateCapacityCmdHdl,
+		getShipperQryHdl:     getShipperQryHdl,
+	}
+}
+
+func (ctrl *ShipperHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	// RPC
+	g.POST("/rpc/shippers/find-by-ids", ctrl.RPCGetByIds)
+
+	shippers := g.Group("/shippers", mldProvider.Auth())
+
+	// Shippers manage their profile and go online to be assigned orders
+	me := shippers.Group("/me", mldProvider.RequireRole(datatype.RoleShipper))
+	{
+		me.GET("", ctrl.GetMyProfileAPI)
+		me.PUT("", ctrl.UpsertProfileAPI)
+		me.POST("/online", ctrl.GoOnlineAPI)
+		me.POST("/offline", ctrl.GoOfflineAPI)
+	}
+
+	// Admins review the shippers and set their workload limit
+	admin := shippers.Group("", mldProvider.RequireRole(datatype.RoleAdmin))
+	{
+		admin.GET("/:id", ctrl.GetShipperAPI)
+		admin.PATCH("/:id/capacity", ctrl.UpdateCapacityAPI)
+	}
+}
@@ -0,0 +1,72 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *ShipperHttpController) GetMyProfileAPI(c *gin.Context) {
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	shipper, err := ctrl.getShipperQryHdl.Execute(c.Request.Context(), requester.Subject())
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shipper})
+}
+
+// UpsertProfileAPI creates the profile of the requesting shipper or updates its vehicle, city and shifts
+func (ctrl *ShipperHttpController) UpsertProfileAPI(c *gin.Context) {
+	var req service.UpsertProfileReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+	req.ShipperId = requester.Subject()
+
+	shipper, err := ctrl.upsertProfileCmdHdl.Execute(c.Request.Context(), &req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shipper})
+}
+
+func (ctrl *ShipperHttpController) GetShipperAPI(c *gin.Context) {
+	shipper, err := ctrl.getShipperQryHdl.Execute(c.Request.Context(), mustParseId(c, "id"))
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shipper})
+}
+
+// UpdateCapacityAPI sets how many orders the shipper may deliver at once
+func (ctrl *ShipperHttpController) UpdateCapacityAPI(c *gin.Context) {
+	var req service.UpdateCapacityReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+	req.ShipperId = mustParseId(c, "id")
+
+	shipper, err := ctrl.updateCapacityCmdHdl.Execute(c.Request.Context(), &req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shipper})
+}
+
+func mustParseId(c *gin.Context, param string) uuid.UUID {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+	return id
+}
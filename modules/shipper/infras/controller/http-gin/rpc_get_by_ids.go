@@ -0,0 +1,30 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RPCGetByIdsRequestDTO struct {
+	Ids []uuid.UUID `json:"ids"`
+}
+
+// RPCGetByIds returns the shippers with their availability, used to assign orders
+func (ctrl *ShipperHttpController) RPCGetByIds(c *gin.Context) {
+	var req RPCGetByIdsRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shippers, err := ctrl.getShipperQryHdl.ExecuteByIds(c.Request.Context(), req.Ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shippers})
+}
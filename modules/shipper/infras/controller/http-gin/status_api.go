@@ -0,0 +1,34 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	shippermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// GoOnlineAPI makes the requesting shipper available for new orders, during their shifts
+func (ctrl *ShipperHttpController) GoOnlineAPI(c *gin.Context) {
+	ctrl.updateStatus(c, shippermodel.StatusOnline)
+}
+
+// GoOfflineAPI stops assigning new orders to the requesting shipper
+func (ctrl *ShipperHttpController) GoOfflineAPI(c *gin.Context) {
+	ctrl.updateStatus(c, shippermodel.StatusOffline)
+}
+
+func (ctrl *ShipperHttpController) updateStatus(c *gin.Context, status string) {
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	shipper, err := ctrl.updateStatusCmdHdl.Execute(c.Request.Context(), &service.UpdateStatusReq{
+		ShipperId: requester.Subject(),
+		Status:    status,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shipper})
+}
@@ -0,0 +1,11 @@
+package shippergormmysql
+
+import shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+
+type ShipperRepo struct {
+	dbCtx shareinfras.IDbContext
+}
+
+func NewShipperRepo(dbCtx shareinfras.IDbContext) *ShipperRepo {
+	return &ShipperRepo{dbCtx: dbCtx}
+}
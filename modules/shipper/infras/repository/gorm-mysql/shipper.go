@@ -0,0 +1,49 @@
+package shippergormmysql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	shippermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func (r *ShipperRepo) InsertShipper(ctx context.Context, shipper *shippermodel.Shipper) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Create(shipper).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *ShipperRepo) UpdateShipper(ctx context.Context, shipper *shippermodel.Shipper) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Save(shipper).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *ShipperRepo) FindShipperById(ctx context.Context, id uuid.UUID) (*shippermodel.Shipper, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var shipper shippermodel.Shipper
+	if err := db.Where("id = ?", id).First(&shipper).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shippermodel.ErrShipperNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &shipper, nil
+}
+
+func (r *ShipperRepo) FindShippersByIds(ctx context.Context, ids []uuid.UUID) ([]shippermodel.Shipper, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var shippers []shippermodel.Shipper
+	if err := db.Where("id IN ?", ids).Find(&shippers).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return shippers, nil
+}
@@ -0,0 +1,16 @@
+package shippermodel
+
+import "errors"
+
+var (
+	ErrShipperIdRequired      = errors.New("shipper id is required")
+	ErrShipperNotFound        = errors.New("shipper profile not found")
+	ErrVehicleTypeInvalid     = errors.New("vehicle type must be bicycle, motorbike or car")
+	ErrCityIdRequired         = errors.New("city id is required")
+	ErrShiftInvalid           = errors.New("shift must be on a weekday from 0 (Sunday) to 6, from HH:MM to a later HH:MM")
+	ErrShiftOverlap           = errors.New("shifts of the same weekday must not overlap")
+	ErrMaxOrdersInvalid       = errors.New("maximum concurrent orders must be between 1 and 10")
+	ErrStatusInvalid          = errors.New("status must be online or offline")
+	ErrShipperOffShift        = errors.New("shipper cannot go online outside of their shifts")
+	ErrShipperProfileRequired = errors.New("shipper must complete their profile before going online")
+)
@@ -0,0 +1,97 @@
+package shippermodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusOnline  = "online"  // Offered orders while on shift
+	StatusOffline = "offline" // Not offered any order
+)
+
+const (
+	VehicleBicycle   = "bicycle"
+	VehicleMotorbike = "motorbike"
+	VehicleCar       = "car"
+)
+
+var VehicleTypes = []string{VehicleBicycle, VehicleMotorbike, VehicleCar}
+
+// MaxOrdersLimit bounds the concurrent orders an admin may allow to a shipper
+const MaxOrdersLimit = 10
+
+// Shipper is the profile of a user with the shipper role, who delivers orders in a city
+type Shipper struct {
+	Id                  uuid.UUID      `gorm:"column:id;primaryKey" json:"id"` // Id of the user
+	Status              string         `gorm:"column:status" json:"status"`
+	VehicleType         string         `gorm:"column:vehicle_type" json:"vehicleType"`
+	CityId              int            `gorm:"column:city_id" json:"cityId"`
+	Shifts              []ShipperShift `gorm:"column:shifts;serializer:json" json:"shifts"` // Always on shift when empty
+	MaxConcurrentOrders int            `gorm:"column:max_concurrent_orders" json:"maxConcurrentOrders"`
+	StatusChangedAt     *time.Time     `gorm:"column:status_changed_at" json:"statusChangedAt"`
+	CreatedAt           time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt           time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+func (Shipper) TableName() string {
+	return "shippers"
+}
+
+// OnShift reports whether the time is in one of the shifts of the shipper
+func (s *Shipper) OnShift(at time.Time) bool {
+	if len(s.Shifts) == 0 {
+		return true
+	}
+	for _, shift := range s.Shifts {
+		if shift.Contains(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// Available reports whether the shipper may be assigned new orders, workload aside
+func (s *Shipper) Available(at time.Time) bool {
+	return s.Status == StatusOnline && s.OnShift(at)
+}
+
+// ShipperShift is a weekly working period, in the time zone of the shifts
+type ShipperShift struct {
+	Weekday time.Weekday `json:"weekday"`
+	Start   string       `json:"start"` // HH:MM
+	End     string       `json:"end"`   // HH:MM, after Start. A shift over midnight is split in two.
+}
+
+// Minutes returns the start and end of the shift in minutes since midnight, false when they are not valid
+func (s ShipperShift) Minutes() (int, int, bool) {
+	start, err := time.Parse("15:04", s.Start)
+	if err != nil {
+		return 0, 0, false
+	}
+	end, err := time.Parse("15:04", s.End)
+	if err != nil && s.End != "24:00" {
+		return 0, 0, false
+	}
+
+	startMinutes := start.Hour()*60 + start.Minute()
+	endMinutes := 24 * 60
+	if s.End != "24:00" {
+		endMinutes = end.Hour()*60 + end.Minute()
+	}
+	if s.Weekday < time.Sunday || s.Weekday > time.Saturday || endMinutes <= startMinutes {
+		return 0, 0, false
+	}
+	return startMinutes, endMinutes, true
+}
+
+// Contains reports whether the time, in the time zone of the shifts, is in the shift
+func (s ShipperShift) Contains(at time.Time) bool {
+	start, end, ok := s.Minutes()
+	if !ok || at.Weekday() != s.Weekday {
+		return false
+	}
+	minutes := at.Hour()*60 + at.Minute()
+	return minutes >= start && minutes < end
+}
@@ -0,0 +1,39 @@
+package shippermodule
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	httpgin "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/infras/controller/http-gin"
+	gormmysql "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/infras/repository/gorm-mysql"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/service"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+func SetupShipperModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
+	config := appCtx.GetConfig().ShipperConfig
+	shipperRepo := gormmysql.NewShipperRepo(appCtx.DbContext())
+
+	// Shifts are in the time zone of the cities served, the app cannot run with a wrong one
+	timezone, err := time.LoadLocation(config.ShiftTimezone)
+	if err != nil {
+		panic(err)
+	}
+
+	// Setup handlers
+	upsertProfileCmdHdl := service.NewUpsertProfileCommandHandler(shipperRepo, config.DefaultMaxOrders)
+	updateStatusCmdHdl := service.NewUpdateStatusCommandHandler(shipperRepo, timezone)
+	updateCapacityCmdHdl := service.NewUpdateCapacityCommandHandler(shipperRepo)
+	getShipperQryHdl := service.NewGetShipperQueryHandler(shipperRepo, timezone)
+
+	// Setup controllers
+	shipperCtrl := httpgin.NewShipperHttpController(
+		upsertProfileCmdHdl,
+		updateStatusCmdHdl,
+		updateCapacityCmdHdl,
+		getShipperQryHdl,
+	)
+
+	// Setup routes
+	shipperCtrl.SetupRoutes(g, appCtx.MiddlewareProvider())
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	shippermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type ShipperRes struct {
+	shippermodel.Shipper
+	OnShift   bool `json:"onShift"`
+	Available bool `json:"available"` // Online and on shift, may be assigned new orders within maxConcurrentOrders
+}
+
+// Initilize service
+type IGetShipperRepo interface {
+	FindShipperById(ctx context.Context, id uuid.UUID) (*shippermodel.Shipper, error)
+	FindShippersByIds(ctx context.Context, ids []uuid.UUID) ([]shippermodel.Shipper, error)
+}
+
+type GetShipperQueryHandler struct {
+	repo     IGetShipperRepo
+	timezone *time.Location
+}
+
+func NewGetShipperQueryHandler(repo IGetShipperRepo, timezone *time.Location) *GetShipperQueryHandler {
+	return &GetShipperQueryHandler{repo: repo, timezone: timezone}
+}
+
+// Implement
+func (hdl *GetShipperQueryHandler) Execute(ctx context.Context, id uuid.UUID) (*ShipperRes, error) {
+	shipper, err := hdl.repo.FindShipperById(ctx, id)
+	if err != nil {
+		if errors.Is(err, shippermodel.ErrShipperNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	res := hdl.newShipperRes(*shipper, time.Now())
+	return &res, nil
+}
+
+// ExecuteByIds returns the shippers with their availability, used to assign orders.
+// Users without a shipper profile are left out.
+func (hdl *GetShipperQueryHandler) ExecuteByIds(ctx context.Context, ids []uuid.UUID) ([]ShipperRes, error) {
+	shippers, err := hdl.repo.FindShippersByIds(ctx, ids)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	now := time.Now()
+	res := make([]ShipperRes, len(shippers))
+	for i, shipper := range shippers {
+		res[i] = hdl.newShipperRes(shipper, now)
+	}
+	return res, nil
+}
+
+func (hdl *GetShipperQueryHandler) newShipperRes(shipper shippermodel.Shipper, now time.Time) ShipperRes {
+	at := now.In(hdl.timezone)
+	return ShipperRes{
+		Shipper:   shipper,
+		OnShift:   shipper.OnShift(at),
+		Available: shipper.Available(at),
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	shippermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type UpdateCapacityReq struct {
+	ShipperId           uuid.UUID `json:"-"`
+	MaxConcurrentOrders int       `json:"maxConcurrentOrders"`
+}
+
+func (r *UpdateCapacityReq) Validate() error {
+	if r.ShipperId == uuid.Nil {
+		return shippermodel.ErrShipperIdRequired
+	}
+	if r.MaxConcurrentOrders < 1 || r.MaxConcurrentOrders > shippermodel.MaxOrdersLimit {
+		return shippermodel.ErrMaxOrdersInvalid
+	}
+	return nil
+}
+
+// Initilize service
+type IUpdateCapacityRepo interface {
+	FindShipperById(ctx context.Context, id uuid.UUID) (*shippermodel.Shipper, error)
+	UpdateShipper(ctx context.Context, shipper *shippermodel.Shipper) error
+}
+
+type UpdateCapacityCommandHandler struct {
+	repo IUpdateCapacityRepo
+}
+
+func NewUpdateCapacityCommandHandler(repo IUpdateCapacityRepo) *UpdateCapacityCommandHandler {
+	return &UpdateCapacityCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute sets how many orders the shipper may deliver at once, e.g. more with a car
+func (hdl *UpdateCapacityCommandHandler) Execute(ctx context.Context, req *UpdateCapacityReq) (*shippermodel.Shipper, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	shipper, err := hdl.repo.FindShipperById(ctx, req.ShipperId)
+	if err != nil {
+		if errors.Is(err, shippermodel.ErrShipperNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	shipper.MaxConcurrentOrders = req.MaxConcurrentOrders
+	shipper.UpdatedAt = time.Now().UTC()
+	if err := hdl.repo.UpdateShipper(ctx, shipper); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return shipper, nil
+}
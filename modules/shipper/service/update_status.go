@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	shippermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type UpdateStatusReq struct {
+	ShipperId uuid.UUID `json:"-"`
+	Status    string    `json:"-"`
+}
+
+func (r *UpdateStatusReq) Validate() error {
+	if r.ShipperId == uuid.Nil {
+		return shippermodel.ErrShipperIdRequired
+	}
+	if r.Status != shippermodel.StatusOnline && r.Status != shippermodel.StatusOffline {
+		return shippermodel.ErrStatusInvalid
+	}
+	return nil
+}
+
+// Initilize service
+type IUpdateStatusRepo interface {
+	FindShipperById(ctx context.Context, id uuid.UUID) (*shippermodel.Shipper, error)
+	UpdateShipper(ctx context.Context, shipper *shippermodel.Shipper) error
+}
+
+type UpdateStatusCommandHandler struct {
+	repo     IUpdateStatusRepo
+	timezone *time.Location
+}
+
+func NewUpdateStatusCommandHandler(repo IUpdateStatusRepo, timezone *time.Location) *UpdateStatusCommandHandler {
+	return &UpdateStatusCommandHandler{repo: repo, timezone: timezone}
+}
+
+// Implement
+// Execute puts the shipper online, only during one of their shifts, or offline.
+// Orders already assigned stay with an offline shipper, who is not assigned new ones.
+func (hdl *UpdateStatusCommandHandler) Execute(ctx context.Context, req *UpdateStatusReq) (*shippermodel.Shipper, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	shipper, err := hdl.repo.FindShipperById(ctx, req.ShipperId)
+	if err != nil {
+		if errors.Is(err, shippermodel.ErrShipperNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(shippermodel.ErrShipperProfileRequired).WithDebug(shippermodel.ErrShipperProfileRequired.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	now := time.Now()
+	if req.Status == shippermodel.StatusOnline && !shipper.OnShift(now.In(hdl.timezone)) {
+		return nil, datatype.ErrBadRequest.WithWrap(shippermodel.ErrShipperOffShift).WithDebug(shippermodel.ErrShipperOffShift.Error())
+	}
+	if shipper.Status == req.Status {
+		return shipper, nil
+	}
+
+	shipper.Status = req.Status
+	shipper.StatusChangedAt = &now
+	shipper.UpdatedAt = now
+	if err := hdl.repo.UpdateShipper(ctx, shipper); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return shipper, nil
+}
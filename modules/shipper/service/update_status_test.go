@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	shippermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeShipperRepo struct {
+	shippers map[uuid.UUID]*shippermodel.Shipper
+}
+
+func (r *fakeShipperRepo) FindShipperById(ctx context.Context, id uuid.UUID) (*shippermodel.Shipper, error) {
+	shipper, ok := r.shippers[id]
+	if !ok {
+		return nil, shippermodel.ErrShipperNotFound
+	}
+	found := *shipper
+	return &found, nil
+}
+
+func (r *fakeShipperRepo) UpdateShipper(ctx context.Context, shipper *shippermodel.Shipper) error {
+	stored := *shipper
+	r.shippers[shipper.Id] = &stored
+	return nil
+}
+
+func TestValidateShifts(t *testing.T) {
+	tests := []struct {
+		name    string
+		shifts  []shippermodel.ShipperShift
+		wantErr error
+	}{
+		{
+			name:   "TC 1: split shifts and a shift until midnight",
+			shifts: []shippermodel.ShipperShift{{Weekday: time.Monday, Start: "07:00", End: "11:00"}, {Weekday: time.Monday, Start: "17:00", End: "24:00"}},
+		},
+		{
+			name:    "TC 2: shift ending before it starts",
+			shifts:  []shippermodel.ShipperShift{{Weekday: time.Monday, Start: "22:00", End: "02:00"}},
+			wantErr: shippermodel.ErrShiftInvalid,
+		},
+		{
+			name:    "TC 3: overlapping shifts of a weekday",
+			shifts:  []shippermodel.ShipperShift{{Weekday: time.Friday, Start: "10:00", End: "14:00"}, {Weekday: time.Friday, Start: "08:00", End: "10:30"}},
+			wantErr: shippermodel.ErrShiftOverlap,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateShifts(tt.shifts); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateShifts() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusCommandHandler_Execute(t *testing.T) {
+	ctx := context.Background()
+	today := time.Now().UTC().Weekday()
+	onShiftId, offShiftId := uuid.New(), uuid.New()
+
+	repo := &fakeShipperRepo{shippers: map[uuid.UUID]*shippermodel.Shipper{
+		onShiftId:  {Id: onShiftId, Status: shippermodel.StatusOffline, Shifts: []shippermodel.ShipperShift{{Weekday: today, Start: "00:00", End: "24:00"}}},
+		offShiftId: {Id: offShiftId, Status: shippermodel.StatusOffline, Shifts: []shippermodel.ShipperShift{{Weekday: (today + 1) % 7, Start: "00:00", End: "24:00"}}},
+	}}
+	hdl := NewUpdateStatusCommandHandler(repo, time.UTC)
+
+	tests := []struct {
+		name       string
+		req        UpdateStatusReq
+		wantStatus int
+	}{
+		{name: "TC 1: shipper goes online during their shift", req: UpdateStatusReq{ShipperId: onShiftId, Status: shippermodel.StatusOnline}},
+		{name: "TC 2: shipper cannot go online off shift", req: UpdateStatusReq{ShipperId: offShiftId, Status: shippermodel.StatusOnline}, wantStatus: http.StatusBadRequest},
+		{name: "TC 3: shipper goes offline at any time", req: UpdateStatusReq{ShipperId: offShiftId, Status: shippermodel.StatusOffline}},
+		{name: "TC 4: user without a shipper profile", req: UpdateStatusReq{ShipperId: uuid.New(), Status: shippermodel.StatusOnline}, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			shipper, err := hdl.Execute(ctx, &req)
+			if tt.wantStatus != 0 {
+				var appErr *datatype.DefaultError
+				if !errors.As(err, &appErr) || appErr.StatusCode() != tt.wantStatus {
+					t.Fatalf("Execute() error = %v, want status %d", err, tt.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if shipper.Status != tt.req.Status || repo.shippers[tt.req.ShipperId].Status != tt.req.Status {
+				t.Errorf("status = %s, want %s", repo.shippers[tt.req.ShipperId].Status, tt.req.Status)
+			}
+		})
+	}
+}
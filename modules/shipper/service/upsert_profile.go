@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	shippermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/shipper/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type UpsertProfileReq struct {
+	ShipperId   uuid.UUID                   `json:"-"`
+	VehicleType string                      `json:"vehicleType"`
+	CityId      int                         `json:"cityId"`
+	Shifts      []shippermodel.ShipperShift `json:"shifts"` // Always on shift when empty
+}
+
+func (r *UpsertProfileReq) Validate() error {
+	if r.ShipperId == uuid.Nil {
+		return shippermodel.ErrShipperIdRequired
+	}
+
+	r.VehicleType = strings.ToLower(strings.TrimSpace(r.VehicleType))
+	if !slices.Contains(shippermodel.VehicleTypes, r.VehicleType) {
+		return shippermodel.ErrVehicleTypeInvalid
+	}
+	if r.CityId <= 0 {
+		return shippermodel.ErrCityIdRequired
+	}
+	return validateShifts(r.Shifts)
+}
+
+// validateShifts checks each shift, and that the shifts of a weekday do not overlap
+func validateShifts(shifts []shippermodel.ShipperShift) error {
+	type period struct{ start, end int }
+	byWeekday := make(map[time.Weekday][]period)
+
+	for _, shift := range shifts {
+		start, end, ok := shift.Minutes()
+		if !ok {
+			return shippermodel.ErrShiftInvalid
+		}
+		byWeekday[shift.Weekday] = append(byWeekday[shift.Weekday], period{start, end})
+	}
+
+	for _, periods := range byWeekday {
+		sort.Slice(periods, func(i, j int) bool { return periods[i].start < periods[j].start })
+		for i := 1; i < len(periods); i++ {
+			if periods[i].start < periods[i-1].end {
+				return shippermodel.ErrShiftOverlap
+			}
+		}
+	}
+	return nil
+}
+
+// Initilize service
+type IUpsertProfileRepo interface {
+	FindShipperById(ctx context.Context, id uuid.UUID) (*shippermodel.Shipper, error)
+	InsertShipper(ctx context.Context, shipper *shippermodel.Shipper) error
+	UpdateShipper(ctx context.Context, shipper *shippermodel.Shipper) error
+}
+
+type UpsertProfileCommandHandler struct {
+	repo             IUpsertProfileRepo
+	defaultMaxOrders int
+}
+
+func NewUpsertProfileCommandHandler(repo IUpsertProfileRepo, defaultMaxOrders int) *UpsertProfileCommandHandler {
+	if defaultMaxOrders <= 0 {
+		defaultMaxOrders = 1
+	}
+	return &UpsertProfileCommandHandler{repo: repo, defaultMaxOrders: defaultMaxOrders}
+}
+
+// Implement
+// Execute creates the profile of the shipper, offline, or updates their vehicle, city and shifts
+func (hdl *UpsertProfileCommandHandler) Execute(ctx context.Context, req *UpsertProfileReq) (*shippermodel.Shipper, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	now := time.Now().UTC()
+	shipper, err := hdl.repo.FindShipperById(ctx, req.ShipperId)
+	if err != nil && !errors.Is(err, shippermodel.ErrShipperNotFound) {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if shipper == nil {
+		shipper = &shippermodel.Shipper{
+			Id:                  req.ShipperId,
+			Status:              shippermodel.StatusOffline,
+			VehicleType:         req.VehicleType,
+			CityId:              req.CityId,
+			Shifts:              req.Shifts,
+			MaxConcurrentOrders: hdl.defaultMaxOrders,
+			CreatedAt:           now,
+			UpdatedAt:           now,
+		}
+		if err := hdl.repo.InsertShipper(ctx, shipper); err != nil {
+			return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+		}
+		return shipper, nil
+	}
+
+	shipper.VehicleType = req.VehicleType
+	shipper.CityId = req.CityId
+	shipper.Shifts = req.Shifts
+	shipper.UpdatedAt = now
+	if err := hdl.repo.UpdateShipper(ctx, shipper); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return shipper, nil
+}
@@ -13,6 +13,7 @@ func (ctrl *UserHttpController) AuthenticateAPI(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		panic(datatype.ErrBadRequest.WithError(err.Error()))
 	}
+	req.IPAddress = c.ClientIP()
 
 	authRes, err := ctrl.authCmdHdl.Execute(c.Request.Context(), req)
 	if err != nil {
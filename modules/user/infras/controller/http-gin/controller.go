@@ -5,8 +5,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
 	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
 	service "github.com/ntttrang/go-food-delivery-backend-service/modules/user/service"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 )
 
 type IRegisterUserCommandHandler interface {
@@ -16,6 +19,19 @@ type IRegisterUserCommandHandler interface {
 type IAuthenticateCommandHandler interface {
 	Execute(ctx context.Context, req service.AuthenticateReq) (*service.AuthenticateRes, error)
 }
+type IRefreshTokenCommandHandler interface {
+	Execute(ctx context.Context, req service.RefreshTokenReq) (*service.AuthenticateRes, error)
+}
+
+type ILogoutCommandHandler interface {
+	Execute(ctx context.Context, req service.LogoutReq) error
+	ExecuteAll(ctx context.Context, req service.LogoutAllReq) error
+}
+
+type IJwksProvider interface {
+	JWKS() sharecomponent.JWKSet
+}
+
 type IntrospectCommandHandler interface {
 	Execute(ctx context.Context, req service.IntrospectReq) (*service.IntrospectRes, error)
 }
@@ -53,6 +69,21 @@ type IRepoRPCUser interface {
 	FindByIds(ctx context.Context, ids []uuid.UUID) ([]usermodel.User, error)
 }
 
+type IRepoRPCDeviceToken interface {
+	FindByUserIds(ctx context.Context, userIds []uuid.UUID) ([]usermodel.DeviceToken, error)
+}
+
+type IDeviceTokenCommandHandler interface {
+	Register(ctx context.Context, req *service.RegisterDeviceTokenReq) error
+	Unregister(ctx context.Context, req service.UnregisterDeviceTokenReq) error
+}
+
+type INotificationPreferenceHandler interface {
+	FindByUserIds(ctx context.Context, userIds []uuid.UUID) ([]usermodel.NotificationPreference, error)
+	Get(ctx context.Context, userId uuid.UUID) (*usermodel.NotificationPreference, error)
+	Update(ctx context.Context, req *service.UpdateNotificationPreferenceReq) (*usermodel.NotificationPreference, error)
+}
+
 type IListAddrQueryHandler interface {
 	Execute(ctx context.Context, req service.UserAddrListReq) (service.UserAddrListRes, error)
 }
@@ -66,6 +97,9 @@ type UserHttpController struct {
 	signUpGgCmdHdl     ISignUpGoogleCommandHandler
 	authCmdHdl         IAuthenticateCommandHandler
 	introspectCmdHdl   IntrospectCommandHandler
+	refreshCmdHdl      IRefreshTokenCommandHandler
+	logoutCmdHdl       ILogoutCommandHandler
+	jwksProvider       IJwksProvider
 	generateCode       IGenerateCode
 	verifyCode         IVerifyCode
 
@@ -74,22 +108,31 @@ type UserHttpController struct {
 	createCmdHdl      ICreateCommandHandler
 	updateCmdHdl      IUpdateCommandHandler
 
-	rpcUser IRepoRPCUser
+	rpcUser        IRepoRPCUser
+	rpcDeviceToken IRepoRPCDeviceToken
 
 	listAddrQueryHdl IListAddrQueryHandler
 	createAddrCmdHdl ICreateAddrCommandHandler
+
+	deviceTokenCmdHdl IDeviceTokenCommandHandler
+	notifPrefHdl      INotificationPreferenceHandler
 }
 
 func NewUserHttpController(registerUserCmdHdl IRegisterUserCommandHandler, signUpGgCmdHdl ISignUpGoogleCommandHandler, authCmdHdl IAuthenticateCommandHandler, introspectCmdHdl IntrospectCommandHandler,
+	refreshCmdHdl IRefreshTokenCommandHandler, logoutCmdHdl ILogoutCommandHandler, jwksProvider IJwksProvider,
 	generateCode IGenerateCode, verifyCode IVerifyCode,
 	listQueryHdl IListQueryHandler, getDetailQueryHdl IGetDetailQueryHandler, createCmdHdl ICreateCommandHandler, updateCmdHdl IUpdateCommandHandler,
-	rpcUser IRepoRPCUser,
-	listAddrQueryHdl IListAddrQueryHandler, createAddrCmdHdl ICreateAddrCommandHandler) *UserHttpController {
+	rpcUser IRepoRPCUser, rpcDeviceToken IRepoRPCDeviceToken,
+	listAddrQueryHdl IListAddrQueryHandler, createAddrCmdHdl ICreateAddrCommandHandler,
+	deviceTokenCmdHdl IDeviceTokenCommandHandler, notifPrefHdl INotificationPreferenceHandler) *UserHttpController {
 	return &UserHttpController{
 		registerUserCmdHdl: registerUserCmdHdl,
 		signUpGgCmdHdl:     signUpGgCmdHdl,
 		authCmdHdl:         authCmdHdl,
 		introspectCmdHdl:   introspectCmdHdl,
+		refreshCmdHdl:      refreshCmdHdl,
+		logoutCmdHdl:       logoutCmdHdl,
+		jwksProvider:       jwksProvider,
 		generateCode:       generateCode,
 		verifyCode:         verifyCode,
 		listQueryHdl:       listQueryHdl,
@@ -97,12 +140,15 @@ func NewUserHttpController(registerUserCmdHdl IRegisterUserCommandHandler, signU
 		createCmdHdl:       createCmdHdl,
 		updateCmdHdl:       updateCmdHdl,
 		rpcUser:            rpcUser,
+		rpcDeviceToken:     rpcDeviceToken,
 		listAddrQueryHdl:   listAddrQueryHdl,
 		createAddrCmdHdl:   createAddrCmdHdl,
+		deviceTokenCmdHdl:  deviceTokenCmdHdl,
+		notifPrefHdl:       notifPrefHdl,
 	}
 }
 
-func (ctrl *UserHttpController) SetupRoutes(g *gin.RouterGroup, authMld gin.HandlerFunc) {
+func (ctrl *UserHttpController) SetupRoutes(g *gin.RouterGroup, authMld gin.HandlerFunc, internalMld gin.HandlerFunc) {
 	// Signup by email
 	g.POST("/register", ctrl.RegisterAPI)
 	// Sign up with Google Account
@@ -110,21 +156,36 @@ func (ctrl *UserHttpController) SetupRoutes(g *gin.RouterGroup, authMld gin.Hand
 	g.GET("/google/callback", ctrl.CallbackAPI)
 
 	g.POST("/authenticate", ctrl.AuthenticateAPI) // Login
+	g.POST("/refresh", ctrl.RefreshTokenAPI)
+	g.POST("/logout", authMld, ctrl.LogoutAPI)
+	g.POST("/logout-all", authMld, ctrl.LogoutAllAPI) // Log out all my devices
 	g.GET("/profile", authMld, ctrl.GetProfileAPI)
 	g.POST("/rpc/users/introspect-token", ctrl.IntrospectTokenRpcAPI) // RPC
+	g.GET("/.well-known/jwks.json", ctrl.JwksAPI)                     // Public keys to validate tokens locally
 	g.GET("/generate-code", authMld, ctrl.GenerateCodeAPI)
 	g.GET("/verify/:code", authMld, ctrl.VerifyCodeAPI)
 	//g.GET("/reset-password", ctrl.ResetPasswordAPI)
 
 	// RPC
 	g.POST("/rpc/users/find-by-ids", ctrl.RPCGetByIds)
+	g.POST("/rpc/users/device-tokens/find-by-user-ids", internalMld, ctrl.RPCFindDeviceTokens)
+	g.POST("/rpc/users/notification-preferences/find-by-user-ids", internalMld, ctrl.RPCFindNotificationPreferences)
 
 	// User info group API
 	users := g.Group("/users")
-	users.POST("", authMld, ctrl.CreateUserAPI)
-	users.GET("", ctrl.ListUsersAPI)
+	users.POST("", authMld, middleware.RequireRole(datatype.RoleAdmin), ctrl.CreateUserAPI)
+	users.GET("", authMld, middleware.RequireRole(datatype.RoleAdmin), ctrl.ListUsersAPI)
 	users.GET("/:id", ctrl.GetUserDetailAPI)
-	users.PATCH("/:id", authMld, ctrl.UpdateUseAPI)
+	users.PATCH("/:id", authMld, middleware.RequireOwner(middleware.FromParam("id"), middleware.SelfChecker), ctrl.UpdateUseAPI) // Self or admin
+	users.POST("/:id/logout-all", authMld, ctrl.LogoutUserAllAPI)                                                                // Self or admin
+
+	// Push notification tokens of my devices
+	users.POST("/device-tokens", authMld, ctrl.RegisterDeviceTokenAPI)
+	users.DELETE("/device-tokens/:token", authMld, ctrl.UnregisterDeviceTokenAPI)
+
+	// Which events notify me on which channel, and my quiet hours
+	users.GET("/notification-preferences", authMld, ctrl.GetNotificationPreferenceAPI)
+	users.PUT("/notification-preferences", authMld, ctrl.UpdateNotificationPreferenceAPI)
 
 	// Address
 	users.POST("/address", authMld, ctrl.CreateUserAddrAPI)
@@ -0,0 +1,61 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	service "github.com/ntttrang/go-food-delivery-backend-service/modules/user/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *UserHttpController) RegisterDeviceTokenAPI(c *gin.Context) {
+	var req service.RegisterDeviceTokenReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	req.UserId = c.MustGet(datatype.KeyRequester).(datatype.Requester).Subject()
+
+	if err := ctrl.deviceTokenCmdHdl.Register(c.Request.Context(), &req); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
+
+func (ctrl *UserHttpController) UnregisterDeviceTokenAPI(c *gin.Context) {
+	req := service.UnregisterDeviceTokenReq{
+		Token:  c.Param("token"),
+		UserId: c.MustGet(datatype.KeyRequester).(datatype.Requester).Subject(),
+	}
+
+	if err := ctrl.deviceTokenCmdHdl.Unregister(c.Request.Context(), req); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
+
+type RPCFindDeviceTokensRequestDTO struct {
+	UserIds []uuid.UUID `json:"userIds"`
+}
+
+// RPCFindDeviceTokens returns the device tokens of the users, used to send push notifications
+func (ctl *UserHttpController) RPCFindDeviceTokens(c *gin.Context) {
+	var dto RPCFindDeviceTokensRequestDTO
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := ctl.rpcDeviceToken.FindByUserIds(c.Request.Context(), dto.UserIds)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tokens})
+}
@@ -0,0 +1,13 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JwksAPI publishes the public keys in the standard JWKS format (no data envelope)
+func (ctrl *UserHttpController) JwksAPI(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, ctrl.jwksProvider.JWKS())
+}
@@ -0,0 +1,53 @@
+package httpgin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	service "github.com/ntttrang/go-food-delivery-backend-service/modules/user/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *UserHttpController) LogoutAPI(c *gin.Context) {
+	var req service.LogoutReq
+	// Body is optional
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	req.Requester = c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	if err := ctrl.logoutCmdHdl.Execute(c.Request.Context(), req); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
+
+func (ctrl *UserHttpController) LogoutAllAPI(c *gin.Context) {
+	requester := c.MustGet(datatype.KeyRequester).(datatype.Requester)
+
+	req := service.LogoutAllReq{UserId: requester.Subject(), Requester: requester}
+	if err := ctrl.logoutCmdHdl.ExecuteAll(c.Request.Context(), req); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
+
+func (ctrl *UserHttpController) LogoutUserAllAPI(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	req := service.LogoutAllReq{UserId: id, Requester: c.MustGet(datatype.KeyRequester).(datatype.Requester)}
+	if err := ctrl.logoutCmdHdl.ExecuteAll(c.Request.Context(), req); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
@@ -0,0 +1,60 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	service "github.com/ntttrang/go-food-delivery-backend-service/modules/user/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *UserHttpController) GetNotificationPreferenceAPI(c *gin.Context) {
+	userId := c.MustGet(datatype.KeyRequester).(datatype.Requester).Subject()
+
+	pref, err := ctrl.notifPrefHdl.Get(c.Request.Context(), userId)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": pref})
+}
+
+func (ctrl *UserHttpController) UpdateNotificationPreferenceAPI(c *gin.Context) {
+	var req service.UpdateNotificationPreferenceReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	req.UserId = c.MustGet(datatype.KeyRequester).(datatype.Requester).Subject()
+
+	pref, err := ctrl.notifPrefHdl.Update(c.Request.Context(), &req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": pref})
+}
+
+type RPCFindNotificationPreferencesRequestDTO struct {
+	UserIds []uuid.UUID `json:"userIds"`
+}
+
+// RPCFindNotificationPreferences returns the preference of each user, defaults included, used before sending notifications
+func (ctl *UserHttpController) RPCFindNotificationPreferences(c *gin.Context) {
+	var dto RPCFindNotificationPreferencesRequestDTO
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefs, err := ctl.notifPrefHdl.FindByUserIds(c.Request.Context(), dto.UserIds)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": prefs})
+}
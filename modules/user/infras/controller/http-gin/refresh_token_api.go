@@ -0,0 +1,23 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	service "github.com/ntttrang/go-food-delivery-backend-service/modules/user/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *UserHttpController) RefreshTokenAPI(c *gin.Context) {
+	var req service.RefreshTokenReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+
+	authRes, err := ctrl.refreshCmdHdl.Execute(c.Request.Context(), req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, datatype.ResponseSuccess(authRes))
+}
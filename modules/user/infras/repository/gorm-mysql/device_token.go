@@ -0,0 +1,39 @@
+package usergormmysql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm/clause"
+)
+
+// Upsert saves the token, a token registered again moves to the new user (e.g. another account on the same device)
+func (r *DeviceTokenRepo) Upsert(ctx context.Context, token *usermodel.DeviceToken) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "updated_at"}),
+	}).Create(token).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *DeviceTokenRepo) Delete(ctx context.Context, userId uuid.UUID, token string) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Where("user_id = ? AND token = ?", userId, token).Delete(&usermodel.DeviceToken{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *DeviceTokenRepo) FindByUserIds(ctx context.Context, userIds []uuid.UUID) ([]usermodel.DeviceToken, error) {
+	var tokens []usermodel.DeviceToken
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Where("user_id IN (?)", userIds).Order("updated_at DESC").Find(&tokens).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return tokens, nil
+}
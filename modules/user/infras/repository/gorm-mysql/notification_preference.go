@@ -0,0 +1,31 @@
+package usergormmysql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm/clause"
+)
+
+// FindByUserIds returns the saved preferences, users who never changed theirs have none
+func (r *NotificationPreferenceRepo) FindByUserIds(ctx context.Context, userIds []uuid.UUID) ([]usermodel.NotificationPreference, error) {
+	var prefs []usermodel.NotificationPreference
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Where("user_id IN (?)", userIds).Find(&prefs).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return prefs, nil
+}
+
+func (r *NotificationPreferenceRepo) Upsert(ctx context.Context, pref *usermodel.NotificationPreference) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"channels", "quiet_hours_start", "quiet_hours_end", "timezone", "locale", "updated_at"}),
+	}).Create(pref).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
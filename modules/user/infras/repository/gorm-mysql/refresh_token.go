@@ -0,0 +1,84 @@
+package usergormmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func (r *RefreshTokenRepo) Insert(ctx context.Context, token *usermodel.RefreshToken) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.Create(token).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepo) FindByHash(ctx context.Context, tokenHash string) (*usermodel.RefreshToken, error) {
+	var token usermodel.RefreshToken
+	db := r.dbCtx.GetMainConnection()
+	if err := db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, usermodel.ErrRefreshTokenNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &token, nil
+}
+
+// Rotate revokes the old token and inserts its replacement in one transaction.
+// The revoke only succeeds if the old token is still active, so a token cannot be rotated twice.
+func (r *RefreshTokenRepo) Rotate(ctx context.Context, oldId uuid.UUID, newToken *usermodel.RefreshToken) error {
+	db := r.dbCtx.GetMainConnection().Begin()
+	if db.Error != nil {
+		return errors.WithStack(db.Error)
+	}
+
+	now := time.Now().UTC()
+	result := db.Model(&usermodel.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", oldId).
+		Updates(map[string]interface{}{"revoked_at": now, "replaced_by": newToken.Id})
+	if result.Error != nil {
+		db.Rollback()
+		return errors.WithStack(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		db.Rollback()
+		return usermodel.ErrRefreshTokenReused
+	}
+
+	if err := db.Create(newToken).Error; err != nil {
+		db.Rollback()
+		return errors.WithStack(err)
+	}
+
+	if err := db.Commit().Error; err != nil {
+		db.Rollback()
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepo) Revoke(ctx context.Context, id uuid.UUID) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.Model(&usermodel.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now().UTC()).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepo) RevokeAllByUserId(ctx context.Context, userId uuid.UUID) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.Model(&usermodel.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", time.Now().UTC()).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
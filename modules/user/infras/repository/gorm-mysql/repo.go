@@ -19,3 +19,27 @@ type UserAddressRepo struct {
 func NewUserAddressRepo(dbCtx shareinfras.IDbContext) *UserAddressRepo {
 	return &UserAddressRepo{dbCtx: dbCtx}
 }
+
+type RefreshTokenRepo struct {
+	dbCtx shareinfras.IDbContext
+}
+
+func NewRefreshTokenRepo(dbCtx shareinfras.IDbContext) *RefreshTokenRepo {
+	return &RefreshTokenRepo{dbCtx: dbCtx}
+}
+
+type DeviceTokenRepo struct {
+	dbCtx shareinfras.IDbContext
+}
+
+func NewDeviceTokenRepo(dbCtx shareinfras.IDbContext) *DeviceTokenRepo {
+	return &DeviceTokenRepo{dbCtx: dbCtx}
+}
+
+type NotificationPreferenceRepo struct {
+	dbCtx shareinfras.IDbContext
+}
+
+func NewNotificationPreferenceRepo(dbCtx shareinfras.IDbContext) *NotificationPreferenceRepo {
+	return &NotificationPreferenceRepo{dbCtx: dbCtx}
+}
@@ -0,0 +1,28 @@
+package usermodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Device platforms
+const (
+	DevicePlatformAndroid = "android" // FCM
+	DevicePlatformIOS     = "ios"     // APNs
+	DevicePlatformWeb     = "web"
+)
+
+// DeviceToken is the push notification token of a user's device, a token belongs to one user at a time
+type DeviceToken struct {
+	Id        uuid.UUID  `gorm:"column:id" json:"id"`
+	UserId    uuid.UUID  `gorm:"column:user_id" json:"userId"`
+	Token     string     `gorm:"column:token" json:"token"`
+	Platform  string     `gorm:"column:platform" json:"platform"`
+	CreatedAt *time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt *time.Time `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+func (DeviceToken) TableName() string {
+	return "device_tokens"
+}
@@ -3,18 +3,32 @@ package usermodel
 import "errors"
 
 var (
-	ErrEmailRequired       = errors.New("email is required")
-	ErrEmailInvalid        = errors.New("email is invalid")
-	ErrPasswordInvalid     = errors.New("password must be greater than 6 characters")
-	ErrPasswordRequired    = errors.New("password is required")
-	ErrFirstNameRequired   = errors.New("first name is required")
-	ErrLastNameRequired    = errors.New("last name is required")
-	ErrUserDeletedOrBanned = errors.New("user is deleted or banned")
-	ErrUserNotFound        = errors.New("user not found")
-	ErrIdRequired          = errors.New("id is required")
-	ErrInvalidPhoneNumber  = errors.New("phone number invalid")
-	ErrAddrRequired        = errors.New("addr is required")
-	ErrDuplicated          = errors.New("addr is duplicated")
-	ErrUserAddrNotFound    = errors.New("user address not found")
-	ErrPermission          = errors.New("you can only update your own profile unless you're an admin")
+	ErrEmailRequired         = errors.New("email is required")
+	ErrEmailInvalid          = errors.New("email is invalid")
+	ErrPasswordInvalid       = errors.New("password must be greater than 6 characters")
+	ErrPasswordRequired      = errors.New("password is required")
+	ErrFirstNameRequired     = errors.New("first name is required")
+	ErrLastNameRequired      = errors.New("last name is required")
+	ErrUserDeletedOrBanned   = errors.New("user is deleted or banned")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrIdRequired            = errors.New("id is required")
+	ErrInvalidPhoneNumber    = errors.New("phone number invalid")
+	ErrAddrRequired          = errors.New("addr is required")
+	ErrDuplicated            = errors.New("addr is duplicated")
+	ErrUserAddrNotFound      = errors.New("user address not found")
+	ErrInvalidCredentials    = errors.New("email or password is incorrect")
+	ErrLoginLocked           = errors.New("too many failed login attempts, please try again later")
+	ErrRefreshTokenRequired  = errors.New("refresh token is required")
+	ErrRefreshTokenInvalid   = errors.New("refresh token is invalid or expired")
+	ErrRefreshTokenReused    = errors.New("refresh token was already used, all sessions are revoked")
+	ErrRefreshTokenNotFound  = errors.New("refresh token not found")
+	ErrTokenRevoked          = errors.New("token has been revoked")
+	ErrPermission            = errors.New("you can only update your own profile unless you're an admin")
+	ErrDeviceTokenRequired   = errors.New("device token is required")
+	ErrDevicePlatformInvalid = errors.New("device platform must be android, ios or web")
+	ErrNotifEventInvalid     = errors.New("notification event is invalid")
+	ErrNotifChannelInvalid   = errors.New("notification channel must be email, sms or push")
+	ErrQuietHoursInvalid     = errors.New("quiet hours must be both empty or both HH:MM and differ")
+	ErrTimezoneInvalid       = errors.New("timezone is invalid")
+	ErrLocaleInvalid         = errors.New("locale must be en or vi")
 )
@@ -0,0 +1,131 @@
+package usermodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification channels
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelSms   = "sms"
+	NotificationChannelPush  = "push"
+)
+
+// Notification events, named <audience>.<order event>.
+// A customer is notified about their orders, a restaurant owner about the orders of their restaurant
+// and a shipper about the orders assigned to them.
+const (
+	NotifyCustomerOrderCreated         = "customer.order_created"
+	NotifyCustomerOrderStateChanged    = "customer.order_state_changed"
+	NotifyCustomerShipperAssigned      = "customer.shipper_assigned"
+	NotifyCustomerPaymentStatusChanged = "customer.payment_status_changed"
+	NotifyCustomerOrderCancelled       = "customer.order_cancelled"
+
+	NotifyRestaurantOrderCreated         = "restaurant.order_created"
+	NotifyRestaurantOrderStateChanged    = "restaurant.order_state_changed"
+	NotifyRestaurantShipperAssigned      = "restaurant.shipper_assigned"
+	NotifyRestaurantPaymentStatusChanged = "restaurant.payment_status_changed"
+	NotifyRestaurantOrderCancelled       = "restaurant.order_cancelled"
+
+	NotifyShipperOrderCreated         = "shipper.order_created"
+	NotifyShipperOrderStateChanged    = "shipper.order_state_changed"
+	NotifyShipperAssigned             = "shipper.shipper_assigned"
+	NotifyShipperPaymentStatusChanged = "shipper.payment_status_changed"
+	NotifyShipperOrderCancelled       = "shipper.order_cancelled"
+)
+
+const DefaultNotificationTimezone = "UTC"
+
+var NotificationChannels = []string{NotificationChannelEmail, NotificationChannelSms, NotificationChannelPush}
+
+// defaultNotificationChannels are the channels of each event until the user changes them.
+// Restaurants only get the alerts they act on: new orders and cancellations.
+var defaultNotificationChannels = map[string][]string{
+	NotifyCustomerOrderCreated:         NotificationChannels,
+	NotifyCustomerOrderStateChanged:    NotificationChannels,
+	NotifyCustomerShipperAssigned:      NotificationChannels,
+	NotifyCustomerPaymentStatusChanged: NotificationChannels,
+	NotifyCustomerOrderCancelled:       NotificationChannels,
+
+	NotifyRestaurantOrderCreated:         NotificationChannels,
+	NotifyRestaurantOrderStateChanged:    {},
+	NotifyRestaurantShipperAssigned:      {},
+	NotifyRestaurantPaymentStatusChanged: {},
+	NotifyRestaurantOrderCancelled:       NotificationChannels,
+
+	NotifyShipperOrderCreated:         NotificationChannels,
+	NotifyShipperOrderStateChanged:    NotificationChannels,
+	NotifyShipperAssigned:             NotificationChannels,
+	NotifyShipperPaymentStatusChanged: NotificationChannels,
+	NotifyShipperOrderCancelled:       NotificationChannels,
+}
+
+// NotificationPreference tells which channels notify the user of each event.
+// SMS and push are muted during the quiet hours, given as HH:MM in the user's timezone; an empty range means none.
+// Emails are written in the user's locale, the platform default when empty.
+type NotificationPreference struct {
+	UserId          uuid.UUID           `gorm:"column:user_id;primaryKey" json:"userId"`
+	Channels        map[string][]string `gorm:"column:channels;serializer:json" json:"channels"` // Event -> enabled channels
+	QuietHoursStart string              `gorm:"column:quiet_hours_start" json:"quietHoursStart"`
+	QuietHoursEnd   string              `gorm:"column:quiet_hours_end" json:"quietHoursEnd"`
+	Timezone        string              `gorm:"column:timezone" json:"timezone"`
+	Locale          string              `gorm:"column:locale" json:"locale"`
+	CreatedAt       *time.Time          `gorm:"column:created_at" json:"createdAt,omitempty"`
+	UpdatedAt       *time.Time          `gorm:"column:updated_at" json:"updatedAt,omitempty"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// NewDefaultNotificationPreference returns the preference of a user who never changed it
+func NewDefaultNotificationPreference(userId uuid.UUID) NotificationPreference {
+	return NotificationPreference{
+		UserId:   userId,
+		Channels: DefaultNotificationChannels(),
+		Timezone: DefaultNotificationTimezone,
+	}
+}
+
+// DefaultNotificationChannels returns a copy of the default channels of every event
+func DefaultNotificationChannels() map[string][]string {
+	channels := make(map[string][]string, len(defaultNotificationChannels))
+	for event, eventChannels := range defaultNotificationChannels {
+		channels[event] = append([]string{}, eventChannels...)
+	}
+	return channels
+}
+
+// IsNotificationEvent tells if the event can be configured
+func IsNotificationEvent(event string) bool {
+	_, ok := defaultNotificationChannels[event]
+	return ok
+}
+
+// IsNotificationChannel tells if the channel exists
+func IsNotificationChannel(channel string) bool {
+	for _, c := range NotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDefaults fills the events the user did not configure with their default channels
+func (p NotificationPreference) WithDefaults() NotificationPreference {
+	channels := DefaultNotificationChannels()
+	for event, eventChannels := range p.Channels {
+		if IsNotificationEvent(event) {
+			channels[event] = eventChannels
+		}
+	}
+	p.Channels = channels
+
+	if p.Timezone == "" {
+		p.Timezone = DefaultNotificationTimezone
+	}
+	return p
+}
@@ -0,0 +1,30 @@
+package usermodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is stored hashed, the raw value is only given to the client once
+type RefreshToken struct {
+	Id         uuid.UUID  `gorm:"column:id" json:"id"`
+	UserId     uuid.UUID  `gorm:"column:user_id" json:"userId"`
+	TokenHash  string     `gorm:"column:token_hash" json:"-"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at" json:"expiresAt"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at" json:"revokedAt"`
+	ReplacedBy *uuid.UUID `gorm:"column:replaced_by" json:"replacedBy"`
+	CreatedAt  *time.Time `gorm:"column:created_at" json:"createdAt"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
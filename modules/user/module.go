@@ -9,7 +9,14 @@ import (
 	repo "github.com/ntttrang/go-food-delivery-backend-service/modules/user/infras/repository/gorm-mysql"
 	userService "github.com/ntttrang/go-food-delivery-backend-service/modules/user/service"
 	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+	sharerpc "github.com/ntttrang/go-food-delivery-backend-service/shared/infras/rpc"
+)
+
+const (
+	accessTokenExpIn  = 60 * 15        // 15 minutes
+	refreshTokenExpIn = 3600 * 24 * 30 // 30 days
 )
 
 func SetupUserModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
@@ -19,18 +26,25 @@ func SetupUserModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 	// repo
 	userRepo := repo.NewUserRepo(dbCtx)
 	userAddrRepo := repo.NewUserAddressRepo(dbCtx)
-	jwtComp := sharecomponent.NewJwtComp(os.Getenv("JWT_SECRET_KEY"), 3600*24*7)
+	refreshTokenRepo := repo.NewRefreshTokenRepo(dbCtx)
+	deviceTokenRepo := repo.NewDeviceTokenRepo(dbCtx)
+	notificationPrefRepo := repo.NewNotificationPreferenceRepo(dbCtx)
+	jwtComp := newJwtComp(appCtx.GetConfig().AuthConfig)
+	redisCache := sharecomponent.NewRedisAdapter(appCtx.GetConfig().RedisConfig)
+	tokenIssuer := userService.NewTokenIssuer(jwtComp, refreshTokenRepo, refreshTokenExpIn)
 	ggOAuth := sharecomponent.NewGoogleOauth(appCtx.GetConfig().GoogleConfig)
 	// service
 	registerCmdHdl := userService.NewRegisterUserCommandHandler(userRepo)
-	signUpGgCmdHdl := userService.NewSignUpGoogleCommandHandler(userRepo, jwtComp, ggOAuth)
-	authCmdHdl := userService.NewAuthenticateCommandHandler(userRepo, jwtComp)
-	introspectCmdHdl := userService.NewIntrospectCommandHandler(jwtComp, userRepo)
+	signUpGgCmdHdl := userService.NewSignUpGoogleCommandHandler(userRepo, tokenIssuer, ggOAuth)
+	authCmdHdl := userService.NewAuthenticateCommandHandler(userRepo, tokenIssuer, redisCache)
+	refreshCmdHdl := userService.NewRefreshTokenCommandHandler(refreshTokenRepo, userRepo, tokenIssuer, redisCache, accessTokenExpIn)
+	logoutCmdHdl := userService.NewLogoutCommandHandler(refreshTokenRepo, redisCache, accessTokenExpIn)
+	introspectCmdHdl := userService.NewIntrospectCommandHandler(jwtComp, userRepo, redisCache)
 	introspectCmdHdlWrapper := userService.NewIntrospectCmdHdlWrapper(introspectCmdHdl)
 
-	redisCache := sharecomponent.NewRedisAdapter(appCtx.GetConfig().RedisConfig)
-	email := sharecomponent.NewEmailService(appCtx.GetConfig().EmailConfig)
-	generateCode := userService.NewGenerateCode(userRepo, redisCache, email)
+	email := sharerpc.NewEmailOutboxRpcClient(appCtx.GetConfig().NotificationServiceURL, appCtx.GetConfig().AuthConfig.InternalToken)
+	emailRenderer := sharecomponent.MustNewEmailTemplateRenderer(appCtx.GetConfig().EmailConfig.DefaultLocale)
+	generateCode := userService.NewGenerateCode(userRepo, redisCache, email, emailRenderer, notificationPrefRepo)
 	verifyCode := userService.NewVerifyCode(userRepo, redisCache)
 
 	listQueryHdl := userService.NewListQueryHandler(userRepo)
@@ -41,15 +55,33 @@ func SetupUserModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
 	listAddrQueryHdl := userService.NewListAddrQueryHandler(userAddrRepo)
 	createAddrCmdHdl := userService.NewCreateUserAddrCommandHandler(userAddrRepo)
 
+	deviceTokenCmdHdl := userService.NewDeviceTokenCommandHandler(deviceTokenRepo)
+	notificationPrefHdl := userService.NewNotificationPreferenceHandler(notificationPrefRepo)
+
 	// controller
 	userCtrl := userHttpgin.NewUserHttpController(
 		registerCmdHdl, signUpGgCmdHdl, authCmdHdl, introspectCmdHdl,
+		refreshCmdHdl, logoutCmdHdl, jwtComp,
 		generateCode, verifyCode,
 		listQueryHdl, getDetailQueryHdl, createCmdHdl, updateCmdHdl,
-		userRepo, // user RPC
+		userRepo, deviceTokenRepo, // user RPC
 		listAddrQueryHdl, createAddrCmdHdl,
+		deviceTokenCmdHdl, notificationPrefHdl,
 	)
 
 	// Setup router
-	userCtrl.SetupRoutes(g, middleware.Auth(introspectCmdHdlWrapper))
+	userCtrl.SetupRoutes(g, middleware.Auth(introspectCmdHdlWrapper), appCtx.MiddlewareProvider().RequireInternal())
+}
+
+// newJwtComp signs with the configured RS256/EdDSA keys, or falls back to HS256 with JWT_SECRET_KEY
+func newJwtComp(cfg datatype.AuthConfig) *sharecomponent.JwtComp {
+	if cfg.SigningKeys == "" {
+		return sharecomponent.NewJwtComp(os.Getenv("JWT_SECRET_KEY"), accessTokenExpIn)
+	}
+
+	keySet, err := sharecomponent.LoadJwtKeySet(cfg.SigningKeys)
+	if err != nil {
+		panic(err)
+	}
+	return sharecomponent.NewJwtCompWithKeys(keySet, accessTokenExpIn)
 }
@@ -3,17 +3,33 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
 	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 	sharemodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	MaxFailedLoginPerAccount = 5
+	MaxFailedLoginPerIP      = 20
+	LoginLockoutDuration     = 15 * time.Minute
+)
+
+// dummyPasswordHash is compared when the email does not exist,
+// so unknown emails take as long as wrong passwords
+const dummyPasswordHash = "$2a$10$Mu43YbzdaDu4mtjO5AyJEekdSUbwJhwqUolAc5BrETgHafZAB3qFa"
+
 // Define DTOs & validate
 type AuthenticateReq struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+
+	IPAddress string `json:"-"` // Get from request
 }
 
 func (r *AuthenticateReq) Validate() error {
@@ -40,8 +56,10 @@ func (r *AuthenticateReq) Validate() error {
 }
 
 type AuthenticateRes struct {
-	Token string `json:"token"`
-	ExpIn int    `json:"expIn"`
+	Token        string `json:"token"`
+	ExpIn        int    `json:"expIn"`
+	RefreshToken string `json:"refreshToken"`
+	RefreshExpIn int    `json:"refreshExpIn"`
 }
 
 // Initilize service
@@ -50,19 +68,27 @@ type IAuthenticateRepo interface {
 }
 
 type ITokenIssuer interface {
-	IssueToken(ctx context.Context, userId string) (string, error)
-	ExpIn() int
+	IssueToken(ctx context.Context, user *usermodel.User) (*AuthenticateRes, error)
+}
+
+// ILoginAttemptCache counts failed logins, counters expire after the lockout duration
+type ILoginAttemptCache interface {
+	Get(ctx context.Context, key string, dest interface{}) error
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	Delete(ctx context.Context, key string) error
 }
 
 type AuthenticateCommandHandler struct {
-	authRepo    IAuthenticateRepo
-	tokenIssuer ITokenIssuer
+	authRepo     IAuthenticateRepo
+	tokenIssuer  ITokenIssuer
+	attemptCache ILoginAttemptCache
 }
 
-func NewAuthenticateCommandHandler(authRepo IAuthenticateRepo, tokenIssuer ITokenIssuer) *AuthenticateCommandHandler {
+func NewAuthenticateCommandHandler(authRepo IAuthenticateRepo, tokenIssuer ITokenIssuer, attemptCache ILoginAttemptCache) *AuthenticateCommandHandler {
 	return &AuthenticateCommandHandler{
-		authRepo:    authRepo,
-		tokenIssuer: tokenIssuer,
+		authRepo:     authRepo,
+		tokenIssuer:  tokenIssuer,
+		attemptCache: attemptCache,
 	}
 }
 
@@ -72,25 +98,86 @@ func (hdl *AuthenticateCommandHandler) Execute(ctx context.Context, req Authenti
 		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
 	}
 
-	user, err := hdl.authRepo.FindByEmail(ctx, req.Email)
-	if err != nil {
-		if errors.Is(err, usermodel.ErrUserNotFound) {
-			return nil, datatype.ErrNotFound.WithDebug(usermodel.ErrUserNotFound.Error())
+	accountKey := loginAttemptAccountKey(req.Email)
+	ipKey := loginAttemptIPKey(req.IPAddress)
+
+	// Reject early while the account or the IP is locked
+	if err := hdl.checkLockout(ctx, accountKey, MaxFailedLoginPerAccount); err != nil {
+		return nil, err
+	}
+	if req.IPAddress != "" {
+		if err := hdl.checkLockout(ctx, ipKey, MaxFailedLoginPerIP); err != nil {
+			return nil, err
 		}
+	}
+
+	user, err := hdl.authRepo.FindByEmail(ctx, req.Email)
+	if err != nil && !errors.Is(err, usermodel.ErrUserNotFound) {
 		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
 
-	if user != nil {
-		if user.Status == datatype.StatusDeleted || user.Status == datatype.StatusBanned {
-			return nil, datatype.ErrDeleted.WithError(usermodel.ErrUserDeletedOrBanned.Error())
+	// Same response for unknown email and wrong password
+	if !verifyPassword(user, req.Password) {
+		if err := hdl.recordFailure(ctx, accountKey, ipKey, req.IPAddress != ""); err != nil {
+			return nil, err
 		}
+		return nil, datatype.ErrUnauthorized.WithWrap(usermodel.ErrInvalidCredentials).WithError(usermodel.ErrInvalidCredentials.Error())
+	}
+
+	if user.Status == datatype.StatusDeleted || user.Status == datatype.StatusBanned {
+		return nil, datatype.ErrDeleted.WithError(usermodel.ErrUserDeletedOrBanned.Error())
 	}
 
-	// JWT
-	token, err := hdl.tokenIssuer.IssueToken(ctx, user.Id.String())
-	if err != nil {
+	// Successful login resets the account counter, the IP counter keeps running
+	if err := hdl.attemptCache.Delete(ctx, accountKey); err != nil {
 		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
 
-	return &AuthenticateRes{Token: token, ExpIn: hdl.tokenIssuer.ExpIn()}, nil
+	// JWT & refresh token
+	return hdl.tokenIssuer.IssueToken(ctx, user)
+}
+
+func (hdl *AuthenticateCommandHandler) checkLockout(ctx context.Context, key string, max int64) error {
+	var failed int64
+	if err := hdl.attemptCache.Get(ctx, key, &failed); err != nil && !errors.Is(err, sharecomponent.ErrCacheMiss) {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if failed >= max {
+		return datatype.ErrTooManyRequests.WithWrap(usermodel.ErrLoginLocked).WithError(usermodel.ErrLoginLocked.Error())
+	}
+	return nil
+}
+
+func (hdl *AuthenticateCommandHandler) recordFailure(ctx context.Context, accountKey, ipKey string, withIP bool) error {
+	if _, err := hdl.attemptCache.Incr(ctx, accountKey, LoginLockoutDuration); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if withIP {
+		if _, err := hdl.attemptCache.Incr(ctx, ipKey, LoginLockoutDuration); err != nil {
+			return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+		}
+	}
+	return nil
+}
+
+// verifyPassword compares the salted password with the stored bcrypt hash.
+// A dummy hash is compared when the user is missing to keep the response time constant.
+func verifyPassword(user *usermodel.User, password string) bool {
+	if user == nil || user.Password == "" {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+		return false
+	}
+
+	saltPass := fmt.Sprintf("%s.%s", user.Salt, password)
+	return bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(saltPass)) == nil
+}
+
+func loginAttemptAccountKey(email string) string {
+	return "login:failed:account:" + strings.ToLower(email)
+}
+
+func loginAttemptIPKey(ip string) string {
+	return "login:failed:ip:" + ip
 }
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type fakeAuthRepo struct {
+	users map[string]*usermodel.User
+}
+
+func (r *fakeAuthRepo) FindByEmail(ctx context.Context, email string) (*usermodel.User, error) {
+	if u, ok := r.users[email]; ok {
+		return u, nil
+	}
+	return nil, usermodel.ErrUserNotFound
+}
+
+type fakeTokenIssuer struct{}
+
+func (fakeTokenIssuer) IssueToken(ctx context.Context, user *usermodel.User) (*AuthenticateRes, error) {
+	return &AuthenticateRes{Token: "token-" + user.Id.String(), ExpIn: 900, RefreshToken: "refresh-" + user.Id.String()}, nil
+}
+
+type fakeAttemptCache struct {
+	counters map[string]int64
+}
+
+func (c *fakeAttemptCache) Get(ctx context.Context, key string, dest interface{}) error {
+	v, ok := c.counters[key]
+	if !ok {
+		return sharecomponent.ErrCacheMiss
+	}
+	*dest.(*int64) = v
+	return nil
+}
+
+func (c *fakeAttemptCache) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	c.counters[key]++
+	return c.counters[key], nil
+}
+
+func (c *fakeAttemptCache) Delete(ctx context.Context, key string) error {
+	delete(c.counters, key)
+	return nil
+}
+
+func newTestAuthHandler(t *testing.T) (*AuthenticateCommandHandler, *fakeAttemptCache) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("salt123.secret-pass"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &fakeAuthRepo{users: map[string]*usermodel.User{
+		"alice@example.com": {Id: uuid.New(), Email: "alice@example.com", Password: string(hash), Salt: "salt123", Status: datatype.StatusActive},
+	}}
+	cache := &fakeAttemptCache{counters: map[string]int64{}}
+	return NewAuthenticateCommandHandler(repo, fakeTokenIssuer{}, cache), cache
+}
+
+func TestAuthenticateCommandHandler_Execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      AuthenticateReq
+		wantCode int
+	}{
+		{name: "TC 1: valid credentials", req: AuthenticateReq{Email: "alice@example.com", Password: "secret-pass", IPAddress: "10.0.0.1"}, wantCode: 0},
+		{name: "TC 2: wrong password", req: AuthenticateReq{Email: "alice@example.com", Password: "wrong-pass", IPAddress: "10.0.0.1"}, wantCode: 401},
+		{name: "TC 3: unknown email gets the same response", req: AuthenticateReq{Email: "bob@example.com", Password: "secret-pass", IPAddress: "10.0.0.1"}, wantCode: 401},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, _ := newTestAuthHandler(t)
+			res, err := hdl.Execute(context.Background(), tt.req)
+			if tt.wantCode == 0 {
+				if err != nil || res == nil || res.Token == "" {
+					t.Errorf("AuthenticateCommandHandler.Execute() = %v, %v, want token", res, err)
+				}
+				return
+			}
+			var appErr *datatype.DefaultError
+			if !errors.As(err, &appErr) || appErr.StatusCode() != tt.wantCode {
+				t.Errorf("AuthenticateCommandHandler.Execute() error = %v, want code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAuthenticateCommandHandler_Lockout(t *testing.T) {
+	hdl, cache := newTestAuthHandler(t)
+	ctx := context.Background()
+
+	for i := 0; i < MaxFailedLoginPerAccount; i++ {
+		_, _ = hdl.Execute(ctx, AuthenticateReq{Email: "alice@example.com", Password: "wrong-pass", IPAddress: "10.0.0.1"})
+	}
+
+	// Locked even with the right password
+	_, err := hdl.Execute(ctx, AuthenticateReq{Email: "alice@example.com", Password: "secret-pass", IPAddress: "10.0.0.2"})
+	if !errors.Is(err, usermodel.ErrLoginLocked) {
+		t.Fatalf("expected account lockout, got %v", err)
+	}
+
+	// IP lockout applies to any account
+	cache.counters = map[string]int64{loginAttemptIPKey("10.0.0.3"): MaxFailedLoginPerIP}
+	_, err = hdl.Execute(ctx, AuthenticateReq{Email: "alice@example.com", Password: "secret-pass", IPAddress: "10.0.0.3"})
+	if !errors.Is(err, usermodel.ErrLoginLocked) {
+		t.Fatalf("expected ip lockout, got %v", err)
+	}
+}
@@ -89,26 +89,11 @@ func (s *CreateCommandHandler) Execute(ctx context.Context, req *CreateUserReq)
 		return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
 	}
 
-	// Authorization check
+	// Authorization check, the permission is checked by the RequireRole middleware
 	if req.Requester == nil {
 		return datatype.ErrUnauthorized.WithDebug("requester information required")
 	}
 
-	// Check permissions:
-	// 1. Users can update their own profile
-	// 2. Admins can update any user's profile
-	isOwnProfile := req.Requester.Subject() == req.Id
-
-	// Check if requester is admin
-	isAdmin := false
-	if user, ok := req.Requester.(*IntrospectRes); ok {
-		isAdmin = user.Role == datatype.RoleAdmin
-	}
-
-	if !isOwnProfile && !isAdmin {
-		return datatype.ErrForbidden.WithDebug(usermodel.ErrPermission.Error())
-	}
-
 	user := req.ConvertToUser()
 	user.Id, _ = uuid.NewV7()
 	salt, _ := sharemodel.RandomStr(16)
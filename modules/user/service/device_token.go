@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type RegisterDeviceTokenReq struct {
+	Token    string `json:"token"`
+	Platform string `json:"platform"`
+
+	UserId uuid.UUID `json:"-"`
+}
+
+func (r *RegisterDeviceTokenReq) Validate() error {
+	r.Token = strings.TrimSpace(r.Token)
+	if r.Token == "" {
+		return usermodel.ErrDeviceTokenRequired
+	}
+
+	r.Platform = strings.ToLower(strings.TrimSpace(r.Platform))
+	switch r.Platform {
+	case usermodel.DevicePlatformAndroid, usermodel.DevicePlatformIOS, usermodel.DevicePlatformWeb:
+	default:
+		return usermodel.ErrDevicePlatformInvalid
+	}
+	return nil
+}
+
+type UnregisterDeviceTokenReq struct {
+	Token string `json:"-"`
+
+	UserId uuid.UUID `json:"-"`
+}
+
+// Initilize service
+type IDeviceTokenRepo interface {
+	Upsert(ctx context.Context, token *usermodel.DeviceToken) error
+	Delete(ctx context.Context, userId uuid.UUID, token string) error
+}
+
+type DeviceTokenCommandHandler struct {
+	deviceTokenRepo IDeviceTokenRepo
+}
+
+func NewDeviceTokenCommandHandler(deviceTokenRepo IDeviceTokenRepo) *DeviceTokenCommandHandler {
+	return &DeviceTokenCommandHandler{deviceTokenRepo: deviceTokenRepo}
+}
+
+// Implement
+// Register saves the push notification token of the requester's device
+func (hdl *DeviceTokenCommandHandler) Register(ctx context.Context, req *RegisterDeviceTokenReq) error {
+	if err := req.Validate(); err != nil {
+		return datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	now := time.Now().UTC()
+	token := usermodel.DeviceToken{
+		Id:        uuid.New(),
+		UserId:    req.UserId,
+		Token:     req.Token,
+		Platform:  req.Platform,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+	if err := hdl.deviceTokenRepo.Upsert(ctx, &token); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return nil
+}
+
+// Unregister stops the push notifications of a device, e.g. on logout
+func (hdl *DeviceTokenCommandHandler) Unregister(ctx context.Context, req UnregisterDeviceTokenReq) error {
+	req.Token = strings.TrimSpace(req.Token)
+	if req.Token == "" {
+		return datatype.ErrBadRequest.WithWrap(usermodel.ErrDeviceTokenRequired).WithDebug(usermodel.ErrDeviceTokenRequired.Error())
+	}
+
+	if err := hdl.deviceTokenRepo.Delete(ctx, req.UserId, req.Token); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return nil
+}
@@ -37,20 +37,30 @@ type IEmail interface {
 	SendEmail(message sharemodel.EmailMessage) error
 }
 
+type IEmailRenderer interface {
+	Render(locale, name string, data any) (*sharemodel.EmailContent, error)
+}
+
 type GenerateCode struct {
-	userRepo   IUserRepo
-	redisCache IRedisCache
-	emailHdl   IEmail
+	userRepo      IUserRepo
+	redisCache    IRedisCache
+	emailHdl      IEmail
+	emailRenderer IEmailRenderer
+	prefRepo      INotificationPreferenceRepo
 }
 
-func NewGenerateCode(userRepo IUserRepo, redisCache IRedisCache, emailHdl IEmail) *GenerateCode {
+func NewGenerateCode(userRepo IUserRepo, redisCache IRedisCache, emailHdl IEmail, emailRenderer IEmailRenderer, prefRepo INotificationPreferenceRepo) *GenerateCode {
 	return &GenerateCode{
-		userRepo:   userRepo,
-		redisCache: redisCache,
-		emailHdl:   emailHdl,
+		userRepo:      userRepo,
+		redisCache:    redisCache,
+		emailHdl:      emailHdl,
+		emailRenderer: emailRenderer,
+		prefRepo:      prefRepo,
 	}
 }
 
+const verificationCodeTTL = time.Hour
+
 // Implement
 func (g *GenerateCode) Execute(ctx context.Context, userId uuid.UUID) (string, error) {
 
@@ -62,10 +72,10 @@ func (g *GenerateCode) Execute(ctx context.Context, userId uuid.UUID) (string, e
 		return "", datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
 	}
 
-	var emailAddr string
-	if user != nil {
-		emailAddr = user.Email
+	if user == nil || user.Email == "" {
+		return "", datatype.ErrBadRequest.WithDebug(usermodel.ErrEmailRequired.Error())
 	}
+	emailAddr := user.Email
 
 	// Generate code
 	seed := time.Now().UnixNano()
@@ -74,17 +84,36 @@ func (g *GenerateCode) Execute(ctx context.Context, userId uuid.UUID) (string, e
 	verifyCode := fmt.Sprintf("%06d", random.Intn(1000000)) // Generates a 6-digit code
 
 	// Store in redis
-	err = g.redisCache.Set(ctx, emailAddr, verifyCode, time.Hour*1)
+	err = g.redisCache.Set(ctx, emailAddr, verifyCode, verificationCodeTTL)
 	if err != nil {
 		return "", err
 	}
 
-	// Send email
+	// Send email in the user's language, the default one when the user never chose it
+	var locale string
+	prefs, err := g.prefRepo.FindByUserIds(ctx, []uuid.UUID{userId})
+	if err != nil {
+		return "", datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	if len(prefs) > 0 {
+		locale = prefs[0].Locale
+	}
+
+	content, err := g.emailRenderer.Render(locale, "account.verification_code", map[string]any{
+		"FirstName":        user.FirstName,
+		"Code":             verifyCode,
+		"ExpiresInMinutes": int(verificationCodeTTL.Minutes()),
+	})
+	if err != nil {
+		return "", datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
 	var msg sharemodel.EmailMessage
-	msg.From = "sender@gmail.com"
-	msg.To = []string{"minhtrang.2106@gmail.com"}
-	msg.Subject = "[FD-Testing-v2] Your Verification Code"
-	msg.Body = fmt.Sprintf("Your verification code is: %s", verifyCode)
+	msg.To = []string{emailAddr}
+	msg.Subject = content.Subject
+	msg.Body = content.HTML
+	msg.TextBody = content.Text
+	msg.IsHTML = true
 	err = g.emailHdl.SendEmail(msg)
 	if err != nil {
 		return "", err
@@ -2,36 +2,71 @@ package service
 
 import (
 	"context"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	sharemodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
 )
 
+type fakeEmail struct {
+	messages []sharemodel.EmailMessage
+}
+
+func (e *fakeEmail) SendEmail(message sharemodel.EmailMessage) error {
+	e.messages = append(e.messages, message)
+	return nil
+}
+
 func TestGenerateCode_Generate(t *testing.T) {
 	userId := uuid.MustParse("019615db-9adb-7eff-ba03-45017274084c")
+	user := &usermodel.User{Id: userId, Email: "trang@example.com", FirstName: "Trang"}
+	renderer := sharecomponent.MustNewEmailTemplateRenderer("en")
+
 	tests := []struct {
-		name    string
-		g       *GenerateCode
-		want    string
-		wantErr bool
+		name        string
+		prefs       map[uuid.UUID]usermodel.NotificationPreference
+		wantSubject string
 	}{
 		{
-			name:    "TC 1",
-			g:       &GenerateCode{},
-			want:    "a",
-			wantErr: false,
+			name:        "TC 1: email in the default locale",
+			prefs:       map[uuid.UUID]usermodel.NotificationPreference{},
+			wantSubject: "Your verification code",
+		},
+		{
+			name:        "TC 2: email in the user's locale",
+			prefs:       map[uuid.UUID]usermodel.NotificationPreference{userId: {UserId: userId, Locale: "vi"}},
+			wantSubject: "Mã xác thực của bạn",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			g := &GenerateCode{}
+			cache := &fakeRevocationCache{values: map[string][]byte{}}
+			email := &fakeEmail{}
+			g := NewGenerateCode(&fakeUserByIdRepo{user: user}, cache, email, renderer, &fakeNotificationPreferenceRepo{prefs: tt.prefs})
+
 			got, err := g.Execute(context.Background(), userId)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GenerateCode.Generate() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			if err != nil {
+				t.Fatalf("GenerateCode.Generate() error = %v", err)
+			}
+			if !regexp.MustCompile(`^\d{6}$`).MatchString(got) {
+				t.Errorf("GenerateCode.Generate() = %v, want a 6-digit code", got)
+			}
+			if _, ok := cache.values[user.Email]; !ok {
+				t.Errorf("code is not cached for %s", user.Email)
+			}
+			if len(email.messages) != 1 {
+				t.Fatalf("emails = %d, want 1", len(email.messages))
+			}
+			msg := email.messages[0]
+			if len(msg.To) != 1 || msg.To[0] != user.Email || msg.Subject != tt.wantSubject {
+				t.Errorf("email to %v with subject %s, want %s with %s", msg.To, msg.Subject, user.Email, tt.wantSubject)
 			}
-			if got != tt.want {
-				t.Errorf("GenerateCode.Generate() = %v, want %v", got, tt.want)
+			if !msg.IsHTML || !strings.Contains(msg.Body, got) || !strings.Contains(msg.TextBody, got) {
+				t.Errorf("email body does not contain the code %s", got)
 			}
 		})
 	}
@@ -32,6 +32,9 @@ type IntrospectRes struct {
 	Type      datatype.UserType   `json:"type"`
 	Status    datatype.UserStatus `json:"status"`
 	sharemodel.DateDto
+
+	TokenId        string `json:"jti,omitempty"`
+	TokenExpiresAt int64  `json:"exp,omitempty"`
 }
 
 func (ir *IntrospectRes) GetRole() string {
@@ -48,14 +51,16 @@ type IIntrospectRepo interface {
 }
 
 type IntrospectCommandHandler struct {
-	jwtComp *sharecomponent.JwtComp
-	repo    IIntrospectRepo
+	jwtComp         *sharecomponent.JwtComp
+	repo            IIntrospectRepo
+	revocationCache ITokenRevocationCache
 }
 
-func NewIntrospectCommandHandler(jwtComp *sharecomponent.JwtComp, repo IIntrospectRepo) *IntrospectCommandHandler {
+func NewIntrospectCommandHandler(jwtComp *sharecomponent.JwtComp, repo IIntrospectRepo, revocationCache ITokenRevocationCache) *IntrospectCommandHandler {
 	return &IntrospectCommandHandler{
-		jwtComp: jwtComp,
-		repo:    repo,
+		jwtComp:         jwtComp,
+		repo:            repo,
+		revocationCache: revocationCache,
 	}
 }
 
@@ -65,12 +70,21 @@ func (hdl *IntrospectCommandHandler) Execute(ctx context.Context, req Introspect
 		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
 	}
 
-	userId, err := hdl.jwtComp.Validate(req.Token)
+	claims, err := hdl.jwtComp.Validate(req.Token)
+	if err != nil {
+		return nil, datatype.ErrUnauthorized.WithWrap(err)
+	}
+
+	userId, err := uuid.Parse(claims.Subject)
 	if err != nil {
 		return nil, datatype.ErrUnauthorized.WithWrap(err)
 	}
 
-	user, err := hdl.repo.FindById(ctx, uuid.MustParse(userId))
+	if err := hdl.checkRevoked(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	user, err := hdl.repo.FindById(ctx, userId)
 	if err != nil {
 		return nil, datatype.ErrUnauthorized.WithWrap(err)
 	}
@@ -90,11 +104,39 @@ func (hdl *IntrospectCommandHandler) Execute(ctx context.Context, req Introspect
 			CreatedAt: user.CreatedAt,
 			UpdatedAt: user.UpdatedAt,
 		},
+		TokenId:        claims.ID,
+		TokenExpiresAt: claims.ExpiresAt.Unix(),
 	}
 
 	return &res, nil
 }
 
+// checkRevoked rejects tokens revoked by logout, and tokens issued before a "log out all devices"
+func (hdl *IntrospectCommandHandler) checkRevoked(ctx context.Context, claims *sharecomponent.TokenClaims) error {
+	if claims.ID != "" {
+		var revoked bool
+		err := hdl.revocationCache.Get(ctx, revokedTokenKey(claims.ID), &revoked)
+		if err != nil && !errors.Is(err, sharecomponent.ErrCacheMiss) {
+			return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+		}
+		if revoked {
+			return datatype.ErrUnauthorized.WithWrap(usermodel.ErrTokenRevoked).WithDebug(usermodel.ErrTokenRevoked.Error())
+		}
+	}
+
+	// In milliseconds, a login right after "log out all devices" is not caught in the same second
+	var revokedBefore int64
+	err := hdl.revocationCache.Get(ctx, revokedBeforeKey(claims.Subject), &revokedBefore)
+	if err != nil && !errors.Is(err, sharecomponent.ErrCacheMiss) {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	if revokedBefore > 0 && claims.IssuedAt.UnixMilli() <= revokedBefore {
+		return datatype.ErrUnauthorized.WithWrap(usermodel.ErrTokenRevoked).WithDebug(usermodel.ErrTokenRevoked.Error())
+	}
+
+	return nil
+}
+
 // Implement of ITokenIntrospector
 type IntrospectCmdHdlWrapper struct {
 	hdl *IntrospectCommandHandler
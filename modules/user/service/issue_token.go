@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharemodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// Initilize service
+type IAccessTokenIssuer interface {
+	IssueToken(ctx context.Context, userId string, role string) (string, error)
+	ExpIn() int
+}
+
+type IRefreshTokenRepo interface {
+	Insert(ctx context.Context, token *usermodel.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*usermodel.RefreshToken, error)
+	Rotate(ctx context.Context, oldId uuid.UUID, newToken *usermodel.RefreshToken) error
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeAllByUserId(ctx context.Context, userId uuid.UUID) error
+}
+
+// TokenIssuer issues a short-lived access token with a refresh token stored server-side
+type TokenIssuer struct {
+	accessTokenIssuer IAccessTokenIssuer
+	refreshTokenRepo  IRefreshTokenRepo
+	refreshExpIn      int
+}
+
+func NewTokenIssuer(accessTokenIssuer IAccessTokenIssuer, refreshTokenRepo IRefreshTokenRepo, refreshExpIn int) *TokenIssuer {
+	return &TokenIssuer{
+		accessTokenIssuer: accessTokenIssuer,
+		refreshTokenRepo:  refreshTokenRepo,
+		refreshExpIn:      refreshExpIn,
+	}
+}
+
+// IssueToken starts a new session for the user
+func (t *TokenIssuer) IssueToken(ctx context.Context, user *usermodel.User) (*AuthenticateRes, error) {
+	rawRefreshToken, refreshToken, err := t.newRefreshToken(user.Id)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if err := t.refreshTokenRepo.Insert(ctx, refreshToken); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return t.issueAccessToken(ctx, user, rawRefreshToken)
+}
+
+// RotateToken replaces a refresh token with a new one, the old one can no longer be used
+func (t *TokenIssuer) RotateToken(ctx context.Context, old *usermodel.RefreshToken, user *usermodel.User) (*AuthenticateRes, error) {
+	rawRefreshToken, refreshToken, err := t.newRefreshToken(user.Id)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if err := t.refreshTokenRepo.Rotate(ctx, old.Id, refreshToken); err != nil {
+		return nil, err
+	}
+
+	return t.issueAccessToken(ctx, user, rawRefreshToken)
+}
+
+func (t *TokenIssuer) issueAccessToken(ctx context.Context, user *usermodel.User, rawRefreshToken string) (*AuthenticateRes, error) {
+	token, err := t.accessTokenIssuer.IssueToken(ctx, user.Id.String(), string(user.Role))
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return &AuthenticateRes{
+		Token:        token,
+		ExpIn:        t.accessTokenIssuer.ExpIn(),
+		RefreshToken: rawRefreshToken,
+		RefreshExpIn: t.refreshExpIn,
+	}, nil
+}
+
+func (t *TokenIssuer) newRefreshToken(userId uuid.UUID) (string, *usermodel.RefreshToken, error) {
+	raw, err := sharemodel.RandomStr(32)
+	if err != nil {
+		return "", nil, err
+	}
+
+	id, _ := uuid.NewV7()
+	now := time.Now().UTC()
+	return raw, &usermodel.RefreshToken{
+		Id:        id,
+		UserId:    userId,
+		TokenHash: HashRefreshToken(raw),
+		ExpiresAt: now.Add(time.Second * time.Duration(t.refreshExpIn)),
+		CreatedAt: &now,
+	}, nil
+}
+
+// HashRefreshToken is the value stored in DB, refresh tokens are random so sha256 is enough
+func HashRefreshToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type LogoutReq struct {
+	RefreshToken string `json:"refreshToken"` // Optional, revoked with the access token
+
+	Requester datatype.Requester `json:"-"`
+}
+
+type LogoutAllReq struct {
+	UserId uuid.UUID `json:"-"`
+
+	Requester datatype.Requester `json:"-"`
+}
+
+// Initilize service
+// ITokenRevocationCache keeps the revoked access tokens until they expire
+type ITokenRevocationCache interface {
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Get(ctx context.Context, key string, dest interface{}) error
+}
+
+type LogoutCommandHandler struct {
+	refreshTokenRepo IRefreshTokenRepo
+	revocationCache  ITokenRevocationCache
+	accessExpIn      int
+}
+
+func NewLogoutCommandHandler(refreshTokenRepo IRefreshTokenRepo, revocationCache ITokenRevocationCache, accessExpIn int) *LogoutCommandHandler {
+	return &LogoutCommandHandler{
+		refreshTokenRepo: refreshTokenRepo,
+		revocationCache:  revocationCache,
+		accessExpIn:      accessExpIn,
+	}
+}
+
+// Implement
+// Execute logs out the current device: the access token in use and its refresh token
+func (hdl *LogoutCommandHandler) Execute(ctx context.Context, req LogoutReq) error {
+	if req.Requester == nil {
+		return datatype.ErrUnauthorized.WithDebug("requester information required")
+	}
+
+	if introspect, ok := req.Requester.(*IntrospectRes); ok && introspect.TokenId != "" {
+		ttl := time.Until(time.Unix(introspect.TokenExpiresAt, 0))
+		if ttl > 0 {
+			if err := hdl.revocationCache.Set(ctx, revokedTokenKey(introspect.TokenId), true, ttl); err != nil {
+				return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+			}
+		}
+	}
+
+	rawRefreshToken := strings.TrimSpace(req.RefreshToken)
+	if rawRefreshToken == "" {
+		return nil
+	}
+
+	refreshToken, err := hdl.refreshTokenRepo.FindByHash(ctx, HashRefreshToken(rawRefreshToken))
+	if err != nil {
+		if errors.Is(err, usermodel.ErrRefreshTokenNotFound) {
+			return nil
+		}
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if refreshToken.UserId != req.Requester.Subject() {
+		return datatype.ErrForbidden.WithDebug("refresh token belongs to another user")
+	}
+
+	if err := hdl.refreshTokenRepo.Revoke(ctx, refreshToken.Id); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return nil
+}
+
+// ExecuteAll logs out all devices of a user. Users can only do it for themselves, admins for anyone.
+func (hdl *LogoutCommandHandler) ExecuteAll(ctx context.Context, req LogoutAllReq) error {
+	if req.Requester == nil {
+		return datatype.ErrUnauthorized.WithDebug("requester information required")
+	}
+
+	isOwnAccount := req.Requester.Subject() == req.UserId
+	isAdmin := req.Requester.GetRole() == string(datatype.RoleAdmin)
+	if !isOwnAccount && !isAdmin {
+		return datatype.ErrForbidden.WithDebug(usermodel.ErrPermission.Error())
+	}
+
+	return revokeAllSessions(ctx, hdl.refreshTokenRepo, hdl.revocationCache, req.UserId, hdl.accessExpIn)
+}
+
+// revokeAllSessions revokes the refresh tokens in DB and every access token issued until now
+func revokeAllSessions(ctx context.Context, repo IRefreshTokenRepo, cache ITokenRevocationCache, userId uuid.UUID, accessExpIn int) error {
+	if err := repo.RevokeAllByUserId(ctx, userId); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	// Access tokens are stateless, reject the ones issued before now until the last of them expires
+	ttl := time.Second * time.Duration(accessExpIn)
+	if err := cache.Set(ctx, revokedBeforeKey(userId.String()), time.Now().UnixMilli(), ttl); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return nil
+}
+
+func revokedTokenKey(jti string) string {
+	return "token:revoked:" + jti
+}
+
+func revokedBeforeKey(userId string) string {
+	return "token:revoked-before-ms:" + userId
+}
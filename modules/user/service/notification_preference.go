@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // Timezones of the quiet hours, also in images without zoneinfo
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+// UpdateNotificationPreferenceReq changes the given events only, nil quiet hours, timezone and locale are kept
+type UpdateNotificationPreferenceReq struct {
+	Channels        map[string][]string `json:"channels"`
+	QuietHoursStart *string             `json:"quietHoursStart"`
+	QuietHoursEnd   *string             `json:"quietHoursEnd"`
+	Timezone        *string             `json:"timezone"`
+	Locale          *string             `json:"locale"` // Empty resets to the platform default
+
+	UserId uuid.UUID `json:"-"`
+}
+
+func (r *UpdateNotificationPreferenceReq) Validate() error {
+	for event, channels := range r.Channels {
+		if !usermodel.IsNotificationEvent(event) {
+			return usermodel.ErrNotifEventInvalid
+		}
+
+		// Normalize and drop duplicates
+		seen := make(map[string]bool, len(channels))
+		normalized := make([]string, 0, len(channels))
+		for _, channel := range channels {
+			channel = strings.ToLower(strings.TrimSpace(channel))
+			if !usermodel.IsNotificationChannel(channel) {
+				return usermodel.ErrNotifChannelInvalid
+			}
+			if !seen[channel] {
+				seen[channel] = true
+				normalized = append(normalized, channel)
+			}
+		}
+		r.Channels[event] = normalized
+	}
+
+	if (r.QuietHoursStart == nil) != (r.QuietHoursEnd == nil) {
+		return usermodel.ErrQuietHoursInvalid
+	}
+	if r.QuietHoursStart != nil {
+		start, end := strings.TrimSpace(*r.QuietHoursStart), strings.TrimSpace(*r.QuietHoursEnd)
+		if !validQuietHours(start, end) {
+			return usermodel.ErrQuietHoursInvalid
+		}
+		r.QuietHoursStart, r.QuietHoursEnd = &start, &end
+	}
+
+	if r.Timezone != nil {
+		timezone := strings.TrimSpace(*r.Timezone)
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+			return usermodel.ErrTimezoneInvalid
+		}
+		r.Timezone = &timezone
+	}
+
+	if r.Locale != nil {
+		locale := strings.ToLower(strings.TrimSpace(*r.Locale))
+		if locale != "" && !slices.Contains(datatype.SupportedLocales, locale) {
+			return usermodel.ErrLocaleInvalid
+		}
+		r.Locale = &locale
+	}
+	return nil
+}
+
+// validQuietHours accepts no quiet hours, or a HH:MM range which may span midnight
+func validQuietHours(start, end string) bool {
+	if start == "" && end == "" {
+		return true
+	}
+	if _, err := time.Parse("15:04", start); err != nil {
+		return false
+	}
+	if _, err := time.Parse("15:04", end); err != nil {
+		return false
+	}
+	return start != end
+}
+
+// Initilize service
+type INotificationPreferenceRepo interface {
+	FindByUserIds(ctx context.Context, userIds []uuid.UUID) ([]usermodel.NotificationPreference, error)
+	Upsert(ctx context.Context, pref *usermodel.NotificationPreference) error
+}
+
+type NotificationPreferenceHandler struct {
+	prefRepo INotificationPreferenceRepo
+}
+
+func NewNotificationPreferenceHandler(prefRepo INotificationPreferenceRepo) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{prefRepo: prefRepo}
+}
+
+// Implement
+// FindByUserIds returns the preference of every user, with the defaults of the events they did not configure
+func (hdl *NotificationPreferenceHandler) FindByUserIds(ctx context.Context, userIds []uuid.UUID) ([]usermodel.NotificationPreference, error) {
+	saved, err := hdl.prefRepo.FindByUserIds(ctx, userIds)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	savedMap := make(map[uuid.UUID]usermodel.NotificationPreference, len(saved))
+	for _, pref := range saved {
+		savedMap[pref.UserId] = pref
+	}
+
+	prefs := make([]usermodel.NotificationPreference, 0, len(userIds))
+	for _, userId := range userIds {
+		pref, ok := savedMap[userId]
+		if !ok {
+			pref = usermodel.NewDefaultNotificationPreference(userId)
+		}
+		prefs = append(prefs, pref.WithDefaults())
+	}
+	return prefs, nil
+}
+
+// Get returns the requester's preference
+func (hdl *NotificationPreferenceHandler) Get(ctx context.Context, userId uuid.UUID) (*usermodel.NotificationPreference, error) {
+	prefs, err := hdl.FindByUserIds(ctx, []uuid.UUID{userId})
+	if err != nil {
+		return nil, err
+	}
+	return &prefs[0], nil
+}
+
+// Update changes the requester's preference and returns it
+func (hdl *NotificationPreferenceHandler) Update(ctx context.Context, req *UpdateNotificationPreferenceReq) (*usermodel.NotificationPreference, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	pref, err := hdl.Get(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	for event, channels := range req.Channels {
+		pref.Channels[event] = channels
+	}
+	if req.QuietHoursStart != nil {
+		pref.QuietHoursStart = *req.QuietHoursStart
+		pref.QuietHoursEnd = *req.QuietHoursEnd
+	}
+	if req.Timezone != nil {
+		pref.Timezone = *req.Timezone
+	}
+	if req.Locale != nil {
+		pref.Locale = *req.Locale
+	}
+
+	now := time.Now().UTC()
+	if pref.CreatedAt == nil {
+		pref.CreatedAt = &now
+	}
+	pref.UpdatedAt = &now
+
+	if err := hdl.prefRepo.Upsert(ctx, pref); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return pref, nil
+}
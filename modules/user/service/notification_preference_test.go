@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+)
+
+type fakeNotificationPreferenceRepo struct {
+	prefs map[uuid.UUID]usermodel.NotificationPreference
+}
+
+func (r *fakeNotificationPreferenceRepo) FindByUserIds(ctx context.Context, userIds []uuid.UUID) ([]usermodel.NotificationPreference, error) {
+	var prefs []usermodel.NotificationPreference
+	for _, userId := range userIds {
+		if pref, ok := r.prefs[userId]; ok {
+			prefs = append(prefs, pref)
+		}
+	}
+	return prefs, nil
+}
+
+func (r *fakeNotificationPreferenceRepo) Upsert(ctx context.Context, pref *usermodel.NotificationPreference) error {
+	r.prefs[pref.UserId] = *pref
+	return nil
+}
+
+func TestNotificationPreferenceHandler_Update(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.New()
+	str := func(s string) *string { return &s }
+
+	t.Run("TC 1: defaults until the user changes them", func(t *testing.T) {
+		hdl := NewNotificationPreferenceHandler(&fakeNotificationPreferenceRepo{prefs: map[uuid.UUID]usermodel.NotificationPreference{}})
+
+		pref, err := hdl.Get(ctx, userId)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if len(pref.Channels[usermodel.NotifyRestaurantOrderCreated]) != 3 || len(pref.Channels[usermodel.NotifyRestaurantOrderStateChanged]) != 0 {
+			t.Errorf("restaurant channels = %v, want only new order and cancellation alerts", pref.Channels)
+		}
+		if pref.Timezone != usermodel.DefaultNotificationTimezone {
+			t.Errorf("Timezone = %s, want %s", pref.Timezone, usermodel.DefaultNotificationTimezone)
+		}
+	})
+
+	t.Run("TC 2: update changes the given events only", func(t *testing.T) {
+		repo := &fakeNotificationPreferenceRepo{prefs: map[uuid.UUID]usermodel.NotificationPreference{}}
+		hdl := NewNotificationPreferenceHandler(repo)
+
+		_, err := hdl.Update(ctx, &UpdateNotificationPreferenceReq{
+			UserId:          userId,
+			Channels:        map[string][]string{usermodel.NotifyCustomerOrderStateChanged: {"PUSH", "push"}},
+			QuietHoursStart: str("22:00"),
+			QuietHoursEnd:   str("07:00"),
+			Timezone:        str("Asia/Ho_Chi_Minh"),
+		})
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		saved := repo.prefs[userId]
+		if got := saved.Channels[usermodel.NotifyCustomerOrderStateChanged]; len(got) != 1 || got[0] != usermodel.NotificationChannelPush {
+			t.Errorf("changed event channels = %v, want [push]", got)
+		}
+		if got := saved.Channels[usermodel.NotifyCustomerOrderCreated]; len(got) != 3 {
+			t.Errorf("other event channels = %v, want the defaults", got)
+		}
+		if saved.QuietHoursStart != "22:00" || saved.QuietHoursEnd != "07:00" || saved.Timezone != "Asia/Ho_Chi_Minh" {
+			t.Errorf("quiet hours = %s-%s %s", saved.QuietHoursStart, saved.QuietHoursEnd, saved.Timezone)
+		}
+	})
+
+	t.Run("TC 3: invalid requests", func(t *testing.T) {
+		hdl := NewNotificationPreferenceHandler(&fakeNotificationPreferenceRepo{prefs: map[uuid.UUID]usermodel.NotificationPreference{}})
+
+		tests := []struct {
+			name string
+			req  UpdateNotificationPreferenceReq
+			want error
+		}{
+			{"unknown event", UpdateNotificationPreferenceReq{Channels: map[string][]string{"order_created": {"email"}}}, usermodel.ErrNotifEventInvalid},
+			{"unknown channel", UpdateNotificationPreferenceReq{Channels: map[string][]string{usermodel.NotifyCustomerOrderCreated: {"fax"}}}, usermodel.ErrNotifChannelInvalid},
+			{"quiet hours without end", UpdateNotificationPreferenceReq{QuietHoursStart: str("22:00")}, usermodel.ErrQuietHoursInvalid},
+			{"quiet hours not HH:MM", UpdateNotificationPreferenceReq{QuietHoursStart: str("10pm"), QuietHoursEnd: str("07:00")}, usermodel.ErrQuietHoursInvalid},
+			{"unknown timezone", UpdateNotificationPreferenceReq{Timezone: str("Mars/Olympus")}, usermodel.ErrTimezoneInvalid},
+		}
+		for _, tt := range tests {
+			tt.req.UserId = userId
+			if _, err := hdl.Update(ctx, &tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("%s: Update() error = %v, want %v", tt.name, err, tt.want)
+			}
+		}
+	})
+}
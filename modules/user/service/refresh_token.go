@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Define DTOs & validate
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (r *RefreshTokenReq) Validate() error {
+	r.RefreshToken = strings.TrimSpace(r.RefreshToken)
+
+	if r.RefreshToken == "" {
+		return usermodel.ErrRefreshTokenRequired
+	}
+
+	return nil
+}
+
+// Initilize service
+type IRefreshUserRepo interface {
+	FindById(ctx context.Context, id uuid.UUID) (*usermodel.User, error)
+}
+
+type IRefreshTokenRotator interface {
+	RotateToken(ctx context.Context, old *usermodel.RefreshToken, user *usermodel.User) (*AuthenticateRes, error)
+}
+
+type RefreshTokenCommandHandler struct {
+	refreshTokenRepo IRefreshTokenRepo
+	userRepo         IRefreshUserRepo
+	tokenRotator     IRefreshTokenRotator
+	revocationCache  ITokenRevocationCache
+	accessExpIn      int
+}
+
+func NewRefreshTokenCommandHandler(refreshTokenRepo IRefreshTokenRepo, userRepo IRefreshUserRepo, tokenRotator IRefreshTokenRotator,
+	revocationCache ITokenRevocationCache, accessExpIn int) *RefreshTokenCommandHandler {
+	return &RefreshTokenCommandHandler{
+		refreshTokenRepo: refreshTokenRepo,
+		userRepo:         userRepo,
+		tokenRotator:     tokenRotator,
+		revocationCache:  revocationCache,
+		accessExpIn:      accessExpIn,
+	}
+}
+
+// Implement
+func (hdl *RefreshTokenCommandHandler) Execute(ctx context.Context, req RefreshTokenReq) (*AuthenticateRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	refreshToken, err := hdl.refreshTokenRepo.FindByHash(ctx, HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, usermodel.ErrRefreshTokenNotFound) {
+			return nil, datatype.ErrUnauthorized.WithWrap(usermodel.ErrRefreshTokenInvalid).WithError(usermodel.ErrRefreshTokenInvalid.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	// A revoked token being used again means it leaked, end every session of the user
+	if refreshToken.IsRevoked() {
+		return nil, hdl.handleReuse(ctx, refreshToken.UserId)
+	}
+
+	if refreshToken.IsExpired(time.Now().UTC()) {
+		return nil, datatype.ErrUnauthorized.WithWrap(usermodel.ErrRefreshTokenInvalid).WithError(usermodel.ErrRefreshTokenInvalid.Error())
+	}
+
+	user, err := hdl.userRepo.FindById(ctx, refreshToken.UserId)
+	if err != nil {
+		return nil, datatype.ErrUnauthorized.WithWrap(err).WithDebug(err.Error())
+	}
+	if user.Status == datatype.StatusDeleted || user.Status == datatype.StatusBanned {
+		return nil, datatype.ErrUnauthorized.WithDebug(usermodel.ErrUserDeletedOrBanned.Error())
+	}
+
+	res, err := hdl.tokenRotator.RotateToken(ctx, refreshToken, user)
+	if err != nil {
+		// Another request rotated the same token first
+		if errors.Is(err, usermodel.ErrRefreshTokenReused) {
+			return nil, hdl.handleReuse(ctx, refreshToken.UserId)
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return res, nil
+}
+
+func (hdl *RefreshTokenCommandHandler) handleReuse(ctx context.Context, userId uuid.UUID) error {
+	if err := revokeAllSessions(ctx, hdl.refreshTokenRepo, hdl.revocationCache, userId, hdl.accessExpIn); err != nil {
+		return err
+	}
+	return datatype.ErrUnauthorized.WithWrap(usermodel.ErrRefreshTokenReused).WithError(usermodel.ErrRefreshTokenReused.Error())
+}
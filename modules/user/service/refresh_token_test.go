@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	usermodel "github.com/ntttrang/go-food-delivery-backend-service/modules/user/model"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeRefreshTokenRepo struct {
+	tokens map[uuid.UUID]*usermodel.RefreshToken
+}
+
+func (r *fakeRefreshTokenRepo) Insert(ctx context.Context, token *usermodel.RefreshToken) error {
+	r.tokens[token.Id] = token
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) FindByHash(ctx context.Context, tokenHash string) (*usermodel.RefreshToken, error) {
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, usermodel.ErrRefreshTokenNotFound
+}
+
+func (r *fakeRefreshTokenRepo) Rotate(ctx context.Context, oldId uuid.UUID, newToken *usermodel.RefreshToken) error {
+	old := r.tokens[oldId]
+	if old.RevokedAt != nil {
+		return usermodel.ErrRefreshTokenReused
+	}
+	now := time.Now()
+	old.RevokedAt = &now
+	old.ReplacedBy = &newToken.Id
+	r.tokens[newToken.Id] = newToken
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) Revoke(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	r.tokens[id].RevokedAt = &now
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) RevokeAllByUserId(ctx context.Context, userId uuid.UUID) error {
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.UserId == userId && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeRevocationCache struct {
+	values map[string][]byte
+}
+
+func (c *fakeRevocationCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.values[key] = data
+	return nil
+}
+
+func (c *fakeRevocationCache) Get(ctx context.Context, key string, dest interface{}) error {
+	data, ok := c.values[key]
+	if !ok {
+		return sharecomponent.ErrCacheMiss
+	}
+	return json.Unmarshal(data, dest)
+}
+
+type fakeUserByIdRepo struct {
+	user *usermodel.User
+}
+
+func (r *fakeUserByIdRepo) FindById(ctx context.Context, id uuid.UUID) (*usermodel.User, error) {
+	if r.user.Id != id {
+		return nil, usermodel.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+func TestRefreshTokenCommandHandler_Execute(t *testing.T) {
+	ctx := context.Background()
+	user := &usermodel.User{Id: uuid.New(), Status: datatype.StatusActive}
+	repo := &fakeRefreshTokenRepo{tokens: map[uuid.UUID]*usermodel.RefreshToken{}}
+	cache := &fakeRevocationCache{values: map[string][]byte{}}
+	jwtComp := sharecomponent.NewJwtComp("secret", 900)
+	issuer := NewTokenIssuer(jwtComp, repo, 3600)
+	hdl := NewRefreshTokenCommandHandler(repo, &fakeUserByIdRepo{user: user}, issuer, cache, 900)
+	introspect := NewIntrospectCommandHandler(jwtComp, &fakeUserByIdRepo{user: user}, cache)
+
+	login, err := issuer.IssueToken(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotation gives a new refresh token
+	rotated, err := hdl.Execute(ctx, RefreshTokenReq{RefreshToken: login.RefreshToken})
+	if err != nil {
+		t.Fatalf("refresh error = %v", err)
+	}
+	if rotated.RefreshToken == login.RefreshToken || rotated.Token == "" {
+		t.Fatalf("expected a new token pair, got %+v", rotated)
+	}
+
+	// Reusing the old refresh token revokes every session
+	_, err = hdl.Execute(ctx, RefreshTokenReq{RefreshToken: login.RefreshToken})
+	if !errors.Is(err, usermodel.ErrRefreshTokenReused) {
+		t.Fatalf("expected reuse detection, got %v", err)
+	}
+	if _, err := hdl.Execute(ctx, RefreshTokenReq{RefreshToken: rotated.RefreshToken}); err == nil {
+		t.Fatal("expected the rotated refresh token to be revoked")
+	}
+	if _, err := introspect.Execute(ctx, IntrospectReq{Token: rotated.Token}); !errors.Is(err, usermodel.ErrTokenRevoked) {
+		t.Fatalf("expected the access token to be revoked, got %v", err)
+	}
+}
+
+func TestLogoutCommandHandler_Execute(t *testing.T) {
+	ctx := context.Background()
+	user := &usermodel.User{Id: uuid.New(), Status: datatype.StatusActive}
+	repo := &fakeRefreshTokenRepo{tokens: map[uuid.UUID]*usermodel.RefreshToken{}}
+	cache := &fakeRevocationCache{values: map[string][]byte{}}
+	jwtComp := sharecomponent.NewJwtComp("secret", 900)
+	issuer := NewTokenIssuer(jwtComp, repo, 3600)
+	introspect := NewIntrospectCommandHandler(jwtComp, &fakeUserByIdRepo{user: user}, cache)
+	hdl := NewLogoutCommandHandler(repo, cache, 900)
+
+	first, _ := issuer.IssueToken(ctx, user)
+	second, _ := issuer.IssueToken(ctx, user)
+
+	requester, err := introspect.Execute(ctx, IntrospectReq{Token: first.Token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hdl.Execute(ctx, LogoutReq{RefreshToken: first.RefreshToken, Requester: requester}); err != nil {
+		t.Fatalf("logout error = %v", err)
+	}
+
+	// Only the logged out device is revoked
+	if _, err := introspect.Execute(ctx, IntrospectReq{Token: first.Token}); !errors.Is(err, usermodel.ErrTokenRevoked) {
+		t.Fatalf("expected revoked token, got %v", err)
+	}
+	if _, err := introspect.Execute(ctx, IntrospectReq{Token: second.Token}); err != nil {
+		t.Fatalf("expected other device to stay logged in, got %v", err)
+	}
+
+	// Another user cannot log out all devices of this user
+	other := &IntrospectRes{Id: uuid.New(), Role: datatype.RoleUser}
+	if err := hdl.ExecuteAll(ctx, LogoutAllReq{UserId: user.Id, Requester: other}); err == nil {
+		t.Fatal("expected forbidden")
+	}
+
+	admin := &IntrospectRes{Id: uuid.New(), Role: datatype.RoleAdmin}
+	if err := hdl.ExecuteAll(ctx, LogoutAllReq{UserId: user.Id, Requester: admin}); err != nil {
+		t.Fatalf("logout all error = %v", err)
+	}
+	if _, err := introspect.Execute(ctx, IntrospectReq{Token: second.Token}); !errors.Is(err, usermodel.ErrTokenRevoked) {
+		t.Fatalf("expected all tokens revoked, got %v", err)
+	}
+
+	// A login right after, within the same second, is not revoked
+	time.Sleep(2 * time.Millisecond)
+	third, _ := issuer.IssueToken(ctx, user)
+	if _, err := introspect.Execute(ctx, IntrospectReq{Token: third.Token}); err != nil {
+		t.Fatalf("expected the new login to be accepted, got %v", err)
+	}
+}
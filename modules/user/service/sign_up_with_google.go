@@ -19,8 +19,7 @@ type ISignupGoogleRepo interface {
 }
 
 type IGoogleTokenIssuer interface {
-	IssueToken(ctx context.Context, userId string) (string, error)
-	ExpIn() int
+	IssueToken(ctx context.Context, user *usermodel.User) (*AuthenticateRes, error)
 }
 
 type IGoogleOAuth interface {
@@ -101,11 +100,6 @@ func (hdl *SignUpGoogleCommandHandler) AuthenticateByGoogle(ctx context.Context,
 		}
 	}
 
-	// JWT
-	token, err := hdl.tokenIssuer.IssueToken(ctx, user.Id.String())
-	if err != nil {
-		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
-	}
-
-	return &AuthenticateRes{Token: token, ExpIn: hdl.tokenIssuer.ExpIn()}, nil
+	// JWT & refresh token
+	return hdl.tokenIssuer.IssueToken(ctx, user)
 }
@@ -0,0 +1,88 @@
+package httpgin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/service"
+	sharedinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+type ICreateEndpointCommandHandler interface {
+	Execute(ctx context.Context, req *service.CreateEndpointReq) (*webhookmodel.WebhookEndpoint, error)
+}
+
+type IListEndpointQueryHandler interface {
+	Execute(ctx context.Context, restaurantId uuid.UUID) ([]webhookmodel.WebhookEndpoint, error)
+}
+
+type IDeleteEndpointCommandHandler interface {
+	Execute(ctx context.Context, restaurantId, id uuid.UUID) error
+}
+
+type ISendTestEventCommandHandler interface {
+	Execute(ctx context.Context, restaurantId, endpointId uuid.UUID) (*webhookmodel.WebhookDelivery, error)
+}
+
+type IPublishEventCommandHandler interface {
+	Execute(ctx context.Context, req *service.PublishEventReq) (int, error)
+}
+
+type IListDeliveryQueryHandler interface {
+	Execute(ctx context.Context, req service.DeliveryListReq) (service.DeliveryListRes, error)
+	ExecuteDetail(ctx context.Context, restaurantId, id uuid.UUID) (*service.DeliveryDetailRes, error)
+}
+
+type WebhookHttpController struct {
+	createEndpointCmdHdl ICreateEndpointCommandHandler
+	listEndpointQryHdl   IListEndpointQueryHandler
+	deleteEndpointCmdHdl IDeleteEndpointCommandHandler
+	sendTestEventCmdHdl  ISendTestEventCommandHandler
+	publishEventCmdHdl   IPublishEventCommandHandler
+	listDeliveryQryHdl   IListDeliveryQueryHandler
+
+	restaurantOwnerChecker middleware.IOwnershipChecker
+}
+
+func NewWebhookHttpController(
+	createEndpointCmdHdl ICreateEndpointCommandHandler,
+	listEndpointQryHdl IListEndpointQueryHandler,
+	deleteEndpointCmdHdl IDeleteEndpointCommandHandler,
+	sendTestEventCmdHdl ISendTestEventCommandHandler,
+	publishEventCmdHdl IPublishEventCommandHandler,
+	listDeliveryQryHdl IListDeliveryQueryHandler,
+	restaurantOwnerChecker middleware.IOwnershipChecker,
+) *WebhookHttpController {
+	return &WebhookHttpController{
+		createEndpointCmdHdl: createEndpointCmdHdl,
+		listEndpointQryHdl:   listEndpointQryHdl,
+		deleteEndpointCmdHdl: deleteEndpointCmdHdl,
+		sendTestEventCmdHdl:  sendTestEventCmdHdl,
+		publishEventCmdHdl:   publishEventCmdHdl,
+		listDeliveryQryHdl:   listDeliveryQryHdl,
+
+		restaurantOwnerChecker: restaurantOwnerChecker,
+	}
+}
+
+func (ctrl *WebhookHttpController) SetupRoutes(g *gin.RouterGroup, mldProvider sharedinfras.IMiddlewareProvider) {
+	// RPC, called by the order-webhook consumer
+	g.POST("/rpc/webhooks/events", mldProvider.RequireInternal(), ctrl.RPCPublishEvent)
+
+	// Webhooks of a restaurant, managed by its owner and the admins
+	restaurantWebhooks := g.Group("/webhooks/restaurants/:restaurantId",
+		mldProvider.Auth(),
+		mldProvider.RequireOwner(middleware.FromParam("restaurantId"), ctrl.restaurantOwnerChecker),
+	)
+	{
+		restaurantWebhooks.POST("/endpoints", ctrl.CreateEndpointAPI)
+		restaurantWebhooks.GET("/endpoints", ctrl.ListEndpointsAPI)
+		restaurantWebhooks.DELETE("/endpoints/:id", ctrl.DeleteEndpointAPI)
+		restaurantWebhooks.POST("/endpoints/:id/test", ctrl.SendTestEventAPI)
+		restaurantWebhooks.GET("/deliveries", ctrl.ListDeliveriesAPI)
+		restaurantWebhooks.GET("/deliveries/:id", ctrl.GetDeliveryAPI)
+	}
+}
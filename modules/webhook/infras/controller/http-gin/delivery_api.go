@@ -0,0 +1,36 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *WebhookHttpController) ListDeliveriesAPI(c *gin.Context) {
+	var req service.DeliveryListReq
+	if err := c.ShouldBind(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+	req.RestaurantId = mustParseId(c, "restaurantId")
+
+	req.PagingDto.Process()
+
+	result, err := ctrl.listDeliveryQryHdl.Execute(c.Request.Context(), req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// GetDeliveryAPI returns a delivery with the log of its attempts
+func (ctrl *WebhookHttpController) GetDeliveryAPI(c *gin.Context) {
+	result, err := ctrl.listDeliveryQryHdl.ExecuteDetail(c.Request.Context(), mustParseId(c, "restaurantId"), mustParseId(c, "id"))
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
@@ -0,0 +1,60 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/service"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func (ctrl *WebhookHttpController) CreateEndpointAPI(c *gin.Context) {
+	var req service.CreateEndpointReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+	req.RestaurantId = mustParseId(c, "restaurantId")
+
+	endpoint, err := ctrl.createEndpointCmdHdl.Execute(c.Request.Context(), &req)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": endpoint})
+}
+
+func (ctrl *WebhookHttpController) ListEndpointsAPI(c *gin.Context) {
+	endpoints, err := ctrl.listEndpointQryHdl.Execute(c.Request.Context(), mustParseId(c, "restaurantId"))
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": endpoints})
+}
+
+func (ctrl *WebhookHttpController) DeleteEndpointAPI(c *gin.Context) {
+	if err := ctrl.deleteEndpointCmdHdl.Execute(c.Request.Context(), mustParseId(c, "restaurantId"), mustParseId(c, "id")); err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
+
+// SendTestEventAPI sends a webhook.test event to the endpoint and responds with the outcome
+func (ctrl *WebhookHttpController) SendTestEventAPI(c *gin.Context) {
+	delivery, err := ctrl.sendTestEventCmdHdl.Execute(c.Request.Context(), mustParseId(c, "restaurantId"), mustParseId(c, "id"))
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": delivery})
+}
+
+func mustParseId(c *gin.Context, param string) uuid.UUID {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		panic(datatype.ErrBadRequest.WithError(err.Error()))
+	}
+	return id
+}
@@ -0,0 +1,26 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/service"
+)
+
+// RPCPublishEvent queues an order event for the webhooks of its restaurant, used by the order consumer
+func (ctrl *WebhookHttpController) RPCPublishEvent(c *gin.Context) {
+	var req service.PublishEventReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	queued, err := ctrl.publishEventCmdHdl.Execute(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"queued": queued}})
+}
@@ -0,0 +1,122 @@
+package webhookgormmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/service"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsertDeliveries skips the deliveries already queued, webhook_deliveries is unique on (endpoint_id, event_id)
+func (r *WebhookRepo) InsertDeliveries(ctx context.Context, deliveries []webhookmodel.WebhookDelivery) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// ClaimPendingDeliveries returns the due deliveries and pushes their next attempt by lease,
+// so that another dispatcher does not send them while they are being handled
+func (r *WebhookRepo) ClaimPendingDeliveries(ctx context.Context, limit int, lease time.Duration) ([]webhookmodel.WebhookDelivery, error) {
+	db := r.dbCtx.GetMainConnection()
+	now := time.Now().UTC()
+
+	var deliveries []webhookmodel.WebhookDelivery
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", webhookmodel.DeliveryStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return errors.WithStack(err)
+		}
+
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.Id
+		}
+		if err := tx.Model(&webhookmodel.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepo) UpdateDelivery(ctx context.Context, delivery *webhookmodel.WebhookDelivery) error {
+	db := r.dbCtx.GetMainConnection()
+	if err := db.WithContext(ctx).Save(delivery).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) InsertDeliveryAttempt(ctx context.Context, attempt *webhookmodel.WebhookDeliveryAttempt) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Create(attempt).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) FindDeliveryById(ctx context.Context, id uuid.UUID) (*webhookmodel.WebhookDelivery, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var delivery webhookmodel.WebhookDelivery
+	if err := db.Where("id = ?", id).First(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, webhookmodel.ErrDeliveryNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepo) FindDeliveryAttempts(ctx context.Context, deliveryId uuid.UUID) ([]webhookmodel.WebhookDeliveryAttempt, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var attempts []webhookmodel.WebhookDeliveryAttempt
+	if err := db.Where("delivery_id = ?", deliveryId).Order("attempt ASC").Find(&attempts).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return attempts, nil
+}
+
+func (r *WebhookRepo) FindDeliveries(ctx context.Context, req service.DeliveryListReq) ([]webhookmodel.WebhookDelivery, int64, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx).
+		Table(webhookmodel.WebhookDelivery{}.TableName()).
+		Where("restaurant_id = ?", req.RestaurantId)
+
+	if req.EndpointId != "" {
+		db = db.Where("endpoint_id = ?", req.EndpointId)
+	}
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+	if req.EventType != "" {
+		db = db.Where("event_type = ?", req.EventType)
+	}
+
+	var result []webhookmodel.WebhookDelivery
+	var total int64
+	if err := db.Count(&total).Offset((req.Page - 1) * req.Limit).Limit(req.Limit).Order("created_at DESC").Find(&result).Error; err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	return result, total, nil
+}
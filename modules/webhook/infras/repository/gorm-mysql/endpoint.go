@@ -0,0 +1,64 @@
+package webhookgormmysql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func (r *WebhookRepo) InsertEndpoint(ctx context.Context, endpoint *webhookmodel.WebhookEndpoint) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Create(endpoint).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) FindEndpointById(ctx context.Context, id uuid.UUID) (*webhookmodel.WebhookEndpoint, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var endpoint webhookmodel.WebhookEndpoint
+	if err := db.Where("id = ?", id).First(&endpoint).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, webhookmodel.ErrEndpointNotFound
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &endpoint, nil
+}
+
+func (r *WebhookRepo) FindEndpointsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]webhookmodel.WebhookEndpoint, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var endpoints []webhookmodel.WebhookEndpoint
+	if err := db.Where("id IN ?", ids).Find(&endpoints).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make(map[uuid.UUID]webhookmodel.WebhookEndpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		result[endpoint.Id] = endpoint
+	}
+	return result, nil
+}
+
+func (r *WebhookRepo) FindEndpointsByRestaurantId(ctx context.Context, restaurantId uuid.UUID) ([]webhookmodel.WebhookEndpoint, error) {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+
+	var endpoints []webhookmodel.WebhookEndpoint
+	if err := db.Where("restaurant_id = ?", restaurantId).Order("created_at ASC").Find(&endpoints).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return endpoints, nil
+}
+
+func (r *WebhookRepo) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	db := r.dbCtx.GetMainConnection().WithContext(ctx)
+	if err := db.Where("id = ?", id).Delete(&webhookmodel.WebhookEndpoint{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
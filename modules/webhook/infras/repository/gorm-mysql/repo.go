@@ -0,0 +1,11 @@
+package webhookgormmysql
+
+import shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+
+type WebhookRepo struct {
+	dbCtx shareinfras.IDbContext
+}
+
+func NewWebhookRepo(dbCtx shareinfras.IDbContext) *WebhookRepo {
+	return &WebhookRepo{dbCtx: dbCtx}
+}
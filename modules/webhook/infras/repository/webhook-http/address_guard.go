@@ -0,0 +1,113 @@
+package webhookhttp
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/pkg/errors"
+)
+
+// carrierGradeNat is the shared address space of RFC 6598, not reachable from the internet either
+var carrierGradeNat = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// AddressGuard keeps the webhooks away from the internal network: the urls must be https and every
+// address of their host must be public. The addresses are checked again when dialing, so that a host
+// resolving to a public address at registration cannot later point the webhooks to an internal one.
+type AddressGuard struct {
+	allowInsecure bool
+	resolver      *net.Resolver
+	dialer        *net.Dialer
+}
+
+// NewAddressGuard returns the guard, allowInsecure accepts http urls and private addresses for development
+func NewAddressGuard(allowInsecure bool) *AddressGuard {
+	return &AddressGuard{
+		allowInsecure: allowInsecure,
+		resolver:      net.DefaultResolver,
+		dialer:        &net.Dialer{},
+	}
+}
+
+// ValidateUrl refuses the urls which are not https or whose host has a non public address
+func (g *AddressGuard) ValidateUrl(ctx context.Context, rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Hostname() == "" {
+		return webhookmodel.ErrUrlInvalid
+	}
+	if g.allowInsecure {
+		return nil
+	}
+	if u.Scheme != "https" {
+		return webhookmodel.ErrUrlNotAllowed
+	}
+
+	_, err = g.resolvePublic(ctx, u.Hostname())
+	return err
+}
+
+// DialContext dials one of the public addresses of the host, for the http.Transport of the sender
+func (g *AddressGuard) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if g.allowInsecure {
+		return g.dialer.DialContext(ctx, network, addr)
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	ips, err := g.resolvePublic(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	// The checked address is dialed rather than the host, which could resolve differently meanwhile
+	var dialErr error
+	for _, ip := range ips {
+		conn, err := g.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+	}
+	return nil, errors.WithStack(dialErr)
+}
+
+// resolvePublic returns the addresses of the host, or an error when one of them is not public
+func (g *AddressGuard) resolvePublic(ctx context.Context, host string) ([]net.IP, error) {
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := g.resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, errors.Wrap(webhookmodel.ErrUrlNotAllowed, err.Error())
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, webhookmodel.ErrUrlNotAllowed
+	}
+	for _, ip := range ips {
+		if !isPublicIP(ip) {
+			return nil, errors.Wrap(webhookmodel.ErrUrlNotAllowed, host+" resolves to "+ip.String())
+		}
+	}
+	return ips, nil
+}
+
+// isPublicIP reports whether the address is reachable from the internet
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!carrierGradeNat.Contains(ip)
+}
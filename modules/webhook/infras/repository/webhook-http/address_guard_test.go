@@ -0,0 +1,58 @@
+package webhookhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+)
+
+func TestAddressGuard_ValidateUrl(t *testing.T) {
+	tests := []struct {
+		name          string
+		url           string
+		allowInsecure bool
+		wantErr       error
+	}{
+		{name: "TC 1: https with a public address", url: "https://93.184.216.34/hooks"},
+		{name: "TC 2: http outside development", url: "http://93.184.216.34/hooks", wantErr: webhookmodel.ErrUrlNotAllowed},
+		{name: "TC 3: loopback", url: "https://127.0.0.1:8080/hooks", wantErr: webhookmodel.ErrUrlNotAllowed},
+		{name: "TC 4: private network", url: "https://10.0.0.12/hooks", wantErr: webhookmodel.ErrUrlNotAllowed},
+		{name: "TC 5: cloud metadata service", url: "https://169.254.169.254/latest/meta-data", wantErr: webhookmodel.ErrUrlNotAllowed},
+		{name: "TC 6: IPv6 loopback", url: "https://[::1]/hooks", wantErr: webhookmodel.ErrUrlNotAllowed},
+		{name: "TC 7: IPv4 mapped private address", url: "https://[::ffff:192.168.1.1]/hooks", wantErr: webhookmodel.ErrUrlNotAllowed},
+		{name: "TC 8: loopback in development", url: "http://localhost:8080/hooks", allowInsecure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewAddressGuard(tt.allowInsecure).ValidateUrl(context.Background(), tt.url)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateUrl() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookHttpSender_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The url was accepted in development, the sender refuses to dial the loopback address afterwards
+	sender := NewWebhookHttpSender(time.Second, NewAddressGuard(false))
+	if _, _, err := sender.Send(context.Background(), server.URL, http.Header{}, []byte("{}")); !errors.Is(err, webhookmodel.ErrUrlNotAllowed) {
+		t.Errorf("Send() error = %v, want %v", err, webhookmodel.ErrUrlNotAllowed)
+	}
+
+	sender = NewWebhookHttpSender(time.Second, NewAddressGuard(true))
+	status, _, err := sender.Send(context.Background(), server.URL, http.Header{}, []byte("{}"))
+	if err != nil || status != http.StatusNoContent {
+		t.Errorf("Send() = %d, %v, want %d", status, err, http.StatusNoContent)
+	}
+}
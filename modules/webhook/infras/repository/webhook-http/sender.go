@@ -0,0 +1,57 @@
+package webhookhttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	userAgent = "food-delivery-webhooks/1.0"
+	// responseBodyLimit is the part of the response kept in the attempt log
+	responseBodyLimit = 1024
+)
+
+// WebhookHttpSender POSTs the webhooks. Redirects are not followed, a 3xx response is a failed attempt.
+// The connections go through the address guard and never through a proxy, which would dial for it.
+type WebhookHttpSender struct {
+	client *http.Client
+}
+
+func NewWebhookHttpSender(timeout time.Duration, guard *AddressGuard) *WebhookHttpSender {
+	return &WebhookHttpSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:               nil,
+				DialContext:         guard.DialContext,
+				TLSHandshakeTimeout: timeout,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *WebhookHttpSender) Send(ctx context.Context, url string, header http.Header, body []byte) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", errors.WithStack(err)
+	}
+	req.Header = header.Clone()
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, responseBodyLimit))
+	return resp.StatusCode, string(respBody), nil
+}
@@ -0,0 +1,15 @@
+package webhookmodel
+
+import "errors"
+
+var (
+	ErrEndpointNotFound      = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrRestaurantIdRequired  = errors.New("restaurant id is required")
+	ErrUrlInvalid            = errors.New("url must be an absolute http or https url")
+	ErrUrlNotAllowed         = errors.New("url must be https and reach a public address")
+	ErrEventTypeInvalid      = errors.New("event type must be order.created, order.state_changed or order.cancelled")
+	ErrSecretTooShort        = errors.New("secret must have at least 16 characters")
+	ErrEventIdRequired       = errors.New("event id is required")
+	ErrDeliveryStatusInvalid = errors.New("delivery status must be pending, succeeded or failed")
+)
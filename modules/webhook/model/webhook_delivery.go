@@ -0,0 +1,83 @@
+package webhookmodel
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// Delivery status
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed" // Gave up after too many attempts
+)
+
+var DeliveryStatuses = []string{DeliveryStatusPending, DeliveryStatusSucceeded, DeliveryStatusFailed}
+
+// WebhookDelivery is an event to deliver to an endpoint. The payload is kept as sent,
+// every attempt signs the same bytes.
+type WebhookDelivery struct {
+	Id             uuid.UUID      `gorm:"column:id" json:"id"`
+	EndpointId     uuid.UUID      `gorm:"column:endpoint_id" json:"endpointId"`
+	RestaurantId   uuid.UUID      `gorm:"column:restaurant_id" json:"restaurantId"`
+	EventId        string         `gorm:"column:event_id" json:"eventId"` // Unique per endpoint, partners dedupe on it
+	EventType      string         `gorm:"column:event_type" json:"eventType"`
+	Payload        datatypes.JSON `gorm:"column:payload" json:"payload"`
+	Status         string         `gorm:"column:status" json:"status"`
+	Attempts       int            `gorm:"column:attempts" json:"attempts"`
+	LastStatusCode *int           `gorm:"column:last_status_code" json:"lastStatusCode,omitempty"`
+	LastError      *string        `gorm:"column:last_error" json:"lastError,omitempty"`
+	NextAttemptAt  time.Time      `gorm:"column:next_attempt_at" json:"nextAttemptAt"`
+	DeliveredAt    *time.Time     `gorm:"column:delivered_at" json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// NewWebhookDelivery queues the event for the endpoint, due at nextAttemptAt
+func NewWebhookDelivery(endpoint *WebhookEndpoint, eventId, eventType string, payload []byte, nextAttemptAt time.Time) *WebhookDelivery {
+	now := time.Now().UTC()
+	return &WebhookDelivery{
+		Id:            uuid.New(),
+		EndpointId:    endpoint.Id,
+		RestaurantId:  endpoint.RestaurantId,
+		EventId:       eventId,
+		EventType:     eventType,
+		Payload:       datatypes.JSON(payload),
+		Status:        DeliveryStatusPending,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// WebhookDeliveryAttempt is the log of one POST of a delivery
+type WebhookDeliveryAttempt struct {
+	Id           uuid.UUID `gorm:"column:id" json:"id"`
+	DeliveryId   uuid.UUID `gorm:"column:delivery_id" json:"deliveryId"`
+	Attempt      int       `gorm:"column:attempt" json:"attempt"`
+	StatusCode   *int      `gorm:"column:status_code" json:"statusCode,omitempty"` // Empty when no response was received
+	ResponseBody string    `gorm:"column:response_body" json:"responseBody"`       // Truncated
+	Error        *string   `gorm:"column:error" json:"error,omitempty"`
+	DurationMs   int64     `gorm:"column:duration_ms" json:"durationMs"`
+	CreatedAt    time.Time `gorm:"column:created_at" json:"createdAt"`
+}
+
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
+
+// WebhookPayload is the JSON body POSTed to the endpoints
+type WebhookPayload struct {
+	Id           string          `json:"id"`
+	Type         string          `json:"type"`
+	RestaurantId uuid.UUID       `json:"restaurantId"`
+	OccurredAt   time.Time       `json:"occurredAt"`
+	Data         json.RawMessage `json:"data"`
+}
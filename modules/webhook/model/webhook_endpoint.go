@@ -0,0 +1,40 @@
+package webhookmodel
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Events delivered to the endpoints
+const (
+	EventOrderCreated      = "order.created"
+	EventOrderStateChanged = "order.state_changed"
+	EventOrderCancelled    = "order.cancelled"
+	EventTest              = "webhook.test" // Sent on demand to check an endpoint, never retried
+)
+
+// OrderEvents are the events an endpoint can subscribe to
+var OrderEvents = []string{EventOrderCreated, EventOrderStateChanged, EventOrderCancelled}
+
+// WebhookEndpoint is a URL of a restaurant partner, e.g. its POS, receiving the order events
+type WebhookEndpoint struct {
+	Id           uuid.UUID `gorm:"column:id" json:"id"`
+	RestaurantId uuid.UUID `gorm:"column:restaurant_id" json:"restaurantId"`
+	Url          string    `gorm:"column:url" json:"url"`
+	Description  string    `gorm:"column:description" json:"description"`
+	Secret       string    `gorm:"column:secret" json:"secret,omitempty"` // Only returned when the endpoint is created
+	Events       []string  `gorm:"column:events;serializer:json" json:"events"`
+	CreatedAt    time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt    time.Time `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// Subscribes tells if the event is delivered to the endpoint, the test event always is
+func (e WebhookEndpoint) Subscribes(eventType string) bool {
+	return eventType == EventTest || slices.Contains(e.Events, eventType)
+}
@@ -0,0 +1,53 @@
+package webhookmodule
+
+import (
+	"github.com/gin-gonic/gin"
+	httpgin "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/infras/controller/http-gin"
+	gormmysql "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/infras/repository/gorm-mysql"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/infras/repository/rpc-client"
+	webhookhttp "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/infras/repository/webhook-http"
+	"github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/service"
+	shareinfras "github.com/ntttrang/go-food-delivery-backend-service/shared/infras"
+)
+
+func SetupWebhookModule(appCtx shareinfras.IAppContext, g *gin.RouterGroup) {
+	dbCtx := appCtx.DbContext()
+	config := appCtx.GetConfig()
+
+	// Setup repositories
+	webhookRepo := gormmysql.NewWebhookRepo(dbCtx)
+	restaurantRpcClientRepo := rpcclient.NewRestaurantRPCClient(config.RestaurantServiceURL)
+
+	// Setup handlers
+	dispatcher := NewWebhookDispatcher(appCtx)
+	createEndpointCmdHdl := service.NewCreateEndpointCommandHandler(webhookRepo, webhookhttp.NewAddressGuard(config.WebhookConfig.AllowInsecureUrls))
+	listEndpointQryHdl := service.NewListEndpointQueryHandler(webhookRepo)
+	deleteEndpointCmdHdl := service.NewDeleteEndpointCommandHandler(webhookRepo)
+	sendTestEventCmdHdl := service.NewSendTestEventCommandHandler(webhookRepo, dispatcher)
+	publishEventCmdHdl := service.NewPublishEventCommandHandler(webhookRepo)
+	listDeliveryQryHdl := service.NewListDeliveryQueryHandler(webhookRepo)
+
+	// Setup controllers
+	webhookCtrl := httpgin.NewWebhookHttpController(
+		createEndpointCmdHdl,
+		listEndpointQryHdl,
+		deleteEndpointCmdHdl,
+		sendTestEventCmdHdl,
+		publishEventCmdHdl,
+		listDeliveryQryHdl,
+		service.NewRestaurantOwnershipChecker(restaurantRpcClientRepo),
+	)
+
+	// Setup routes
+	webhookCtrl.SetupRoutes(g, appCtx.MiddlewareProvider())
+}
+
+// NewWebhookDispatcher delivers the queued webhooks, run by the app process or the webhook-dispatcher command
+func NewWebhookDispatcher(appCtx shareinfras.IAppContext) *service.WebhookDispatcher {
+	config := appCtx.GetConfig().WebhookConfig
+	repo := gormmysql.NewWebhookRepo(appCtx.DbContext())
+
+	sender := webhookhttp.NewWebhookHttpSender(config.Timeout, webhookhttp.NewAddressGuard(config.AllowInsecureUrls))
+
+	return service.NewWebhookDispatcher(repo, sender, config)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	rpcclient "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/infras/repository/rpc-client"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type IRPCRestaurantOwnerRepo interface {
+	FindByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]rpcclient.RPCGetByIdsResponseDTO, error)
+}
+
+// RestaurantOwnershipChecker allows only the restaurant's owner to manage its webhooks
+type RestaurantOwnershipChecker struct {
+	restaurantRepo IRPCRestaurantOwnerRepo
+}
+
+func NewRestaurantOwnershipChecker(restaurantRepo IRPCRestaurantOwnerRepo) *RestaurantOwnershipChecker {
+	return &RestaurantOwnershipChecker{restaurantRepo: restaurantRepo}
+}
+
+func (c *RestaurantOwnershipChecker) IsOwner(ctx context.Context, resourceId string, userId uuid.UUID) (bool, error) {
+	id, err := uuid.Parse(resourceId)
+	if err != nil {
+		return false, datatype.ErrBadRequest.WithError(webhookmodel.ErrRestaurantIdRequired.Error())
+	}
+
+	restaurants, err := c.restaurantRepo.FindByIds(ctx, []uuid.UUID{id})
+	if err != nil {
+		return false, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	restaurant, ok := restaurants[id]
+	if !ok {
+		return false, datatype.ErrNotFound.WithDebug("restaurant not found")
+	}
+	return restaurant.OwnerId == userId, nil
+}
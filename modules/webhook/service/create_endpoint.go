@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"github.com/pkg/errors"
+)
+
+const (
+	minSecretLength = 16
+	secretPrefix    = "whsec_"
+)
+
+// Define DTOs & validate
+type CreateEndpointReq struct {
+	RestaurantId uuid.UUID `json:"-"`
+	Url          string    `json:"url"`
+	Description  string    `json:"description"`
+	Secret       string    `json:"secret"` // Generated when empty
+	Events       []string  `json:"events"` // Every order event when empty
+}
+
+func (r *CreateEndpointReq) Validate() error {
+	if r.RestaurantId == uuid.Nil {
+		return webhookmodel.ErrRestaurantIdRequired
+	}
+
+	r.Url = strings.TrimSpace(r.Url)
+	u, err := url.Parse(r.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return webhookmodel.ErrUrlInvalid
+	}
+
+	events := make([]string, 0, len(r.Events))
+	for _, event := range r.Events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !slices.Contains(webhookmodel.OrderEvents, event) {
+			return webhookmodel.ErrEventTypeInvalid
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		events = webhookmodel.OrderEvents
+	}
+	r.Events = events
+
+	r.Secret = strings.TrimSpace(r.Secret)
+	if r.Secret != "" && len(r.Secret) < minSecretLength {
+		return webhookmodel.ErrSecretTooShort
+	}
+
+	r.Description = strings.TrimSpace(r.Description)
+	return nil
+}
+
+// Initilize service
+type ICreateEndpointRepo interface {
+	InsertEndpoint(ctx context.Context, endpoint *webhookmodel.WebhookEndpoint) error
+}
+
+// IUrlValidator refuses the urls reaching the internal network
+type IUrlValidator interface {
+	ValidateUrl(ctx context.Context, rawUrl string) error
+}
+
+type CreateEndpointCommandHandler struct {
+	repo         ICreateEndpointRepo
+	urlValidator IUrlValidator
+}
+
+func NewCreateEndpointCommandHandler(repo ICreateEndpointRepo, urlValidator IUrlValidator) *CreateEndpointCommandHandler {
+	return &CreateEndpointCommandHandler{repo: repo, urlValidator: urlValidator}
+}
+
+// Implement
+// Execute registers the endpoint, the returned secret is not shown again
+func (hdl *CreateEndpointCommandHandler) Execute(ctx context.Context, req *CreateEndpointReq) (*webhookmodel.WebhookEndpoint, error) {
+	if err := req.Validate(); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+	if err := hdl.urlValidator.ValidateUrl(ctx, req.Url); err != nil {
+		return nil, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = generateSecret(); err != nil {
+			return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+		}
+	}
+
+	now := time.Now().UTC()
+	endpoint := &webhookmodel.WebhookEndpoint{
+		Id:           uuid.New(),
+		RestaurantId: req.RestaurantId,
+		Url:          req.Url,
+		Description:  req.Description,
+		Secret:       secret,
+		Events:       req.Events,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := hdl.repo.InsertEndpoint(ctx, endpoint); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return endpoint, nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Initilize service
+type IDeleteEndpointRepo interface {
+	FindEndpointById(ctx context.Context, id uuid.UUID) (*webhookmodel.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+}
+
+type DeleteEndpointCommandHandler struct {
+	repo IDeleteEndpointRepo
+}
+
+func NewDeleteEndpointCommandHandler(repo IDeleteEndpointRepo) *DeleteEndpointCommandHandler {
+	return &DeleteEndpointCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute removes the endpoint, its pending deliveries are given up by the dispatcher
+func (hdl *DeleteEndpointCommandHandler) Execute(ctx context.Context, restaurantId, id uuid.UUID) error {
+	if _, err := findRestaurantEndpoint(ctx, hdl.repo, restaurantId, id); err != nil {
+		return err
+	}
+
+	if err := hdl.repo.DeleteEndpoint(ctx, id); err != nil {
+		return datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return nil
+}
+
+type IFindEndpointRepo interface {
+	FindEndpointById(ctx context.Context, id uuid.UUID) (*webhookmodel.WebhookEndpoint, error)
+}
+
+// findRestaurantEndpoint returns the endpoint when it belongs to the restaurant
+func findRestaurantEndpoint(ctx context.Context, repo IFindEndpointRepo, restaurantId, id uuid.UUID) (*webhookmodel.WebhookEndpoint, error) {
+	endpoint, err := repo.FindEndpointById(ctx, id)
+	if err != nil {
+		if errors.Is(err, webhookmodel.ErrEndpointNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if endpoint.RestaurantId != restaurantId {
+		return nil, datatype.ErrNotFound.WithDebug(webhookmodel.ErrEndpointNotFound.Error())
+	}
+	return endpoint, nil
+}
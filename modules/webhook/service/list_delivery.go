@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharemodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// Define DTOs & validate
+type DeliveryListReq struct {
+	RestaurantId uuid.UUID `json:"-" form:"-"`
+	EndpointId   string    `json:"endpointId" form:"endpointId"` // Every endpoint when empty
+	Status       string    `json:"status" form:"status"`         // Every status when empty
+	EventType    string    `json:"eventType" form:"eventType"`   // Every event when empty
+	sharemodel.PagingDto
+}
+
+func (r *DeliveryListReq) Validate() error {
+	if r.EndpointId != "" {
+		if _, err := uuid.Parse(r.EndpointId); err != nil {
+			return err
+		}
+	}
+	if r.Status != "" && !slices.Contains(webhookmodel.DeliveryStatuses, r.Status) {
+		return webhookmodel.ErrDeliveryStatusInvalid
+	}
+	if r.EventType != "" && r.EventType != webhookmodel.EventTest && !slices.Contains(webhookmodel.OrderEvents, r.EventType) {
+		return webhookmodel.ErrEventTypeInvalid
+	}
+	return nil
+}
+
+type DeliveryListRes struct {
+	Items      []webhookmodel.WebhookDelivery `json:"items"`
+	Pagination sharemodel.PagingDto           `json:"pagination"`
+}
+
+type DeliveryDetailRes struct {
+	webhookmodel.WebhookDelivery
+	History []webhookmodel.WebhookDeliveryAttempt `json:"history"` // Every attempt, oldest first
+}
+
+// Initilize service
+type IListDeliveryRepo interface {
+	FindDeliveries(ctx context.Context, req DeliveryListReq) ([]webhookmodel.WebhookDelivery, int64, error)
+	FindDeliveryById(ctx context.Context, id uuid.UUID) (*webhookmodel.WebhookDelivery, error)
+	FindDeliveryAttempts(ctx context.Context, deliveryId uuid.UUID) ([]webhookmodel.WebhookDeliveryAttempt, error)
+}
+
+type ListDeliveryQueryHandler struct {
+	repo IListDeliveryRepo
+}
+
+func NewListDeliveryQueryHandler(repo IListDeliveryRepo) *ListDeliveryQueryHandler {
+	return &ListDeliveryQueryHandler{repo: repo}
+}
+
+// Implement
+// Execute returns the deliveries of the restaurant, newest first
+func (hdl *ListDeliveryQueryHandler) Execute(ctx context.Context, req DeliveryListReq) (DeliveryListRes, error) {
+	if err := req.Validate(); err != nil {
+		return DeliveryListRes{}, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	deliveries, total, err := hdl.repo.FindDeliveries(ctx, req)
+	if err != nil {
+		return DeliveryListRes{}, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	var resp DeliveryListRes
+	resp.Items = deliveries
+	resp.Pagination = sharemodel.PagingDto{
+		Page:  req.Page,
+		Limit: req.Limit,
+		Total: total,
+	}
+	return resp, nil
+}
+
+// ExecuteDetail returns a delivery of the restaurant with the log of its attempts
+func (hdl *ListDeliveryQueryHandler) ExecuteDetail(ctx context.Context, restaurantId, id uuid.UUID) (*DeliveryDetailRes, error) {
+	delivery, err := hdl.repo.FindDeliveryById(ctx, id)
+	if err != nil {
+		if errors.Is(err, webhookmodel.ErrDeliveryNotFound) {
+			return nil, datatype.ErrNotFound.WithWrap(err).WithDebug(err.Error())
+		}
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	if delivery.RestaurantId != restaurantId {
+		return nil, datatype.ErrNotFound.WithDebug(webhookmodel.ErrDeliveryNotFound.Error())
+	}
+
+	attempts, err := hdl.repo.FindDeliveryAttempts(ctx, id)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return &DeliveryDetailRes{WebhookDelivery: *delivery, History: attempts}, nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Initilize service
+type IListEndpointRepo interface {
+	FindEndpointsByRestaurantId(ctx context.Context, restaurantId uuid.UUID) ([]webhookmodel.WebhookEndpoint, error)
+}
+
+type ListEndpointQueryHandler struct {
+	repo IListEndpointRepo
+}
+
+func NewListEndpointQueryHandler(repo IListEndpointRepo) *ListEndpointQueryHandler {
+	return &ListEndpointQueryHandler{repo: repo}
+}
+
+// Implement
+// Execute returns the endpoints of the restaurant without their secret
+func (hdl *ListEndpointQueryHandler) Execute(ctx context.Context, restaurantId uuid.UUID) ([]webhookmodel.WebhookEndpoint, error) {
+	endpoints, err := hdl.repo.FindEndpointsByRestaurantId(ctx, restaurantId)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	for i := range endpoints {
+		endpoints[i].Secret = ""
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"github.com/pkg/errors"
+)
+
+// Define DTOs & validate
+type PublishEventReq struct {
+	Id           string          `json:"id"` // Id of the source event, a republished event is delivered once
+	Type         string          `json:"type"`
+	RestaurantId uuid.UUID       `json:"restaurantId"`
+	OccurredAt   time.Time       `json:"occurredAt"`
+	Data         json.RawMessage `json:"data"`
+}
+
+func (r *PublishEventReq) Validate() error {
+	r.Id = strings.TrimSpace(r.Id)
+	if r.Id == "" {
+		return webhookmodel.ErrEventIdRequired
+	}
+	if !slices.Contains(webhookmodel.OrderEvents, r.Type) {
+		return webhookmodel.ErrEventTypeInvalid
+	}
+	if r.RestaurantId == uuid.Nil {
+		return webhookmodel.ErrRestaurantIdRequired
+	}
+	if r.OccurredAt.IsZero() {
+		r.OccurredAt = time.Now()
+	}
+	if len(r.Data) == 0 {
+		r.Data = json.RawMessage("{}")
+	}
+	return nil
+}
+
+// Initilize service
+type IPublishEventRepo interface {
+	FindEndpointsByRestaurantId(ctx context.Context, restaurantId uuid.UUID) ([]webhookmodel.WebhookEndpoint, error)
+	InsertDeliveries(ctx context.Context, deliveries []webhookmodel.WebhookDelivery) error
+}
+
+type PublishEventCommandHandler struct {
+	repo IPublishEventRepo
+}
+
+func NewPublishEventCommandHandler(repo IPublishEventRepo) *PublishEventCommandHandler {
+	return &PublishEventCommandHandler{repo: repo}
+}
+
+// Implement
+// Execute queues a delivery of the event for each endpoint of the restaurant subscribing to it,
+// and returns how many were queued
+func (hdl *PublishEventCommandHandler) Execute(ctx context.Context, req *PublishEventReq) (int, error) {
+	if err := req.Validate(); err != nil {
+		return 0, datatype.ErrBadRequest.WithWrap(err).WithDebug(err.Error())
+	}
+
+	endpoints, err := hdl.repo.FindEndpointsByRestaurantId(ctx, req.RestaurantId)
+	if err != nil {
+		return 0, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	payload, err := newPayload(req.Id, req.Type, req.RestaurantId, req.OccurredAt, req.Data)
+	if err != nil {
+		return 0, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	now := time.Now().UTC()
+	var deliveries []webhookmodel.WebhookDelivery
+	for i := range endpoints {
+		if endpoints[i].Subscribes(req.Type) {
+			deliveries = append(deliveries, *webhookmodel.NewWebhookDelivery(&endpoints[i], req.Id, req.Type, payload, now))
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	if err := hdl.repo.InsertDeliveries(ctx, deliveries); err != nil {
+		return 0, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+	return len(deliveries), nil
+}
+
+func newPayload(id, eventType string, restaurantId uuid.UUID, occurredAt time.Time, data json.RawMessage) ([]byte, error) {
+	payload, err := json.Marshal(webhookmodel.WebhookPayload{
+		Id:           id,
+		Type:         eventType,
+		RestaurantId: restaurantId,
+		OccurredAt:   occurredAt.UTC(),
+		Data:         data,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return payload, nil
+}
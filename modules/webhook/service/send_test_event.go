@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// Initilize service
+type ISendTestEventRepo interface {
+	FindEndpointById(ctx context.Context, id uuid.UUID) (*webhookmodel.WebhookEndpoint, error)
+	InsertDeliveries(ctx context.Context, deliveries []webhookmodel.WebhookDelivery) error
+}
+
+type IWebhookDeliverer interface {
+	Deliver(ctx context.Context, delivery *webhookmodel.WebhookDelivery, endpoint *webhookmodel.WebhookEndpoint)
+}
+
+type SendTestEventCommandHandler struct {
+	repo      ISendTestEventRepo
+	deliverer IWebhookDeliverer
+}
+
+func NewSendTestEventCommandHandler(repo ISendTestEventRepo, deliverer IWebhookDeliverer) *SendTestEventCommandHandler {
+	return &SendTestEventCommandHandler{repo: repo, deliverer: deliverer}
+}
+
+// Implement
+// Execute sends a webhook.test event to the endpoint right away and returns the delivery with its outcome.
+// A failed test is not retried.
+func (hdl *SendTestEventCommandHandler) Execute(ctx context.Context, restaurantId, endpointId uuid.UUID) (*webhookmodel.WebhookDelivery, error) {
+	endpoint, err := findRestaurantEndpoint(ctx, hdl.repo, restaurantId, endpointId)
+	if err != nil {
+		return nil, err
+	}
+
+	eventId := uuid.NewString()
+	now := time.Now().UTC()
+	data, _ := json.Marshal(map[string]string{
+		"endpointId": endpoint.Id.String(),
+		"message":    "This is a test event",
+	})
+	payload, err := newPayload(eventId, webhookmodel.EventTest, endpoint.RestaurantId, now, data)
+	if err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	// Not due before the lease, so that the dispatcher does not send it too
+	delivery := webhookmodel.NewWebhookDelivery(endpoint, eventId, webhookmodel.EventTest, payload, now.Add(webhookClaimLease))
+	if err := hdl.repo.InsertDeliveries(ctx, []webhookmodel.WebhookDelivery{*delivery}); err != nil {
+		return nil, datatype.ErrInternalServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	hdl.deliverer.Deliver(ctx, delivery, endpoint)
+	return delivery, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers of a webhook request
+const (
+	HeaderWebhookId        = "X-Webhook-Id" // Event id, the same on every attempt
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp" // Unix seconds of the attempt
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// SignPayload returns the X-Webhook-Signature of a request: sha256= followed by the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the endpoint secret. The timestamp is signed so that partners can reject replays.
+func SignPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature tells if the signature was made with the secret, as partners should check it
+func VerifySignature(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignPayload(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+const (
+	// webhookClaimLease is how long a claimed delivery is hidden from other dispatchers, longer than the endpoint timeout
+	webhookClaimLease = 2 * time.Minute
+	// webhookBaseBackoff is the delay before the first retry, it doubles on every attempt
+	webhookBaseBackoff = 30 * time.Second
+)
+
+// IWebhookSender POSTs a webhook to an endpoint. A response of any status is not an error,
+// the error is only for requests without response.
+type IWebhookSender interface {
+	Send(ctx context.Context, url string, header http.Header, body []byte) (statusCode int, respBody string, err error)
+}
+
+type IWebhookDeliveryRepo interface {
+	ClaimPendingDeliveries(ctx context.Context, limit int, lease time.Duration) ([]webhookmodel.WebhookDelivery, error)
+	FindEndpointsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]webhookmodel.WebhookEndpoint, error)
+	UpdateDelivery(ctx context.Context, delivery *webhookmodel.WebhookDelivery) error
+	InsertDeliveryAttempt(ctx context.Context, attempt *webhookmodel.WebhookDeliveryAttempt) error
+}
+
+// WebhookDispatcher delivers the queued webhooks, retrying the failed ones with an exponential backoff.
+// Delivery is at least once: partners dedupe on the X-Webhook-Id header.
+type WebhookDispatcher struct {
+	repo   IWebhookDeliveryRepo
+	sender IWebhookSender
+	cfg    datatype.WebhookConfig
+}
+
+func NewWebhookDispatcher(repo IWebhookDeliveryRepo, sender IWebhookSender, cfg datatype.WebhookConfig) *WebhookDispatcher {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 20
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+
+	return &WebhookDispatcher{repo: repo, sender: sender, cfg: cfg}
+}
+
+// Run delivers due webhooks until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while batches are full, the backlog is drained before waiting again
+		for ctx.Err() == nil {
+			count, err := d.DispatchBatch(ctx)
+			if err != nil {
+				log.Printf("Webhook dispatcher: failed to claim deliveries: %v", err)
+				break
+			}
+			if count < d.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchBatch delivers one batch of due webhooks and returns how many were handled
+func (d *WebhookDispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	deliveries, err := d.repo.ClaimPendingDeliveries(ctx, d.cfg.BatchSize, webhookClaimLease)
+	if err != nil {
+		return 0, err
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	endpointIds := make([]uuid.UUID, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		endpointIds = append(endpointIds, delivery.EndpointId)
+	}
+	endpoints, err := d.repo.FindEndpointsByIds(ctx, endpointIds)
+	if err != nil {
+		// The claim lease expires and the deliveries are handled again
+		return 0, err
+	}
+
+	for i := range deliveries {
+		endpoint, ok := endpoints[deliveries[i].EndpointId]
+		if !ok {
+			d.giveUp(ctx, &deliveries[i], "endpoint was deleted")
+			continue
+		}
+		d.Deliver(ctx, &deliveries[i], &endpoint)
+	}
+
+	return len(deliveries), nil
+}
+
+// Deliver POSTs the delivery to the endpoint once, logs the attempt and schedules the next one on failure
+func (d *WebhookDispatcher) Deliver(ctx context.Context, delivery *webhookmodel.WebhookDelivery, endpoint *webhookmodel.WebhookEndpoint) {
+	start := time.Now()
+	timestamp := start.Unix()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(HeaderWebhookId, delivery.EventId)
+	header.Set(HeaderWebhookEvent, delivery.EventType)
+	header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	header.Set(HeaderWebhookSignature, SignPayload(endpoint.Secret, timestamp, delivery.Payload))
+
+	statusCode, respBody, sendErr := d.sender.Send(ctx, endpoint.Url, header, delivery.Payload)
+	if sendErr == nil && (statusCode < 200 || statusCode >= 300) {
+		sendErr = fmt.Errorf("endpoint responded %d", statusCode)
+	}
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+
+	attempt := &webhookmodel.WebhookDeliveryAttempt{
+		Id:           uuid.New(),
+		DeliveryId:   delivery.Id,
+		Attempt:      delivery.Attempts,
+		ResponseBody: respBody,
+		DurationMs:   now.Sub(start).Milliseconds(),
+		CreatedAt:    now,
+	}
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+		delivery.LastStatusCode = &statusCode
+	}
+
+	if sendErr == nil {
+		delivery.Status = webhookmodel.DeliveryStatusSucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+	} else {
+		lastError := sendErr.Error()
+		attempt.Error = &lastError
+		delivery.LastError = &lastError
+
+		if delivery.Attempts >= d.maxAttempts(delivery) {
+			delivery.Status = webhookmodel.DeliveryStatusFailed
+			log.Printf("Webhook dispatcher: giving up delivery %s to %s after %d attempts: %v", delivery.Id, endpoint.Url, delivery.Attempts, sendErr)
+		} else {
+			delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+			log.Printf("Webhook dispatcher: failed to deliver %s to %s, attempt %d: %v", delivery.Id, endpoint.Url, delivery.Attempts, sendErr)
+		}
+	}
+
+	if err := d.repo.InsertDeliveryAttempt(ctx, attempt); err != nil {
+		log.Printf("Webhook dispatcher: failed to log attempt %d of delivery %s: %v", attempt.Attempt, delivery.Id, err)
+	}
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		// The claim lease expires and the webhook is delivered again
+		log.Printf("Webhook dispatcher: failed to update delivery %s: %v", delivery.Id, err)
+	}
+}
+
+func (d *WebhookDispatcher) giveUp(ctx context.Context, delivery *webhookmodel.WebhookDelivery, reason string) {
+	delivery.Status = webhookmodel.DeliveryStatusFailed
+	delivery.LastError = &reason
+	delivery.UpdatedAt = time.Now().UTC()
+
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Printf("Webhook dispatcher: failed to update delivery %s: %v", delivery.Id, err)
+	}
+}
+
+// maxAttempts of a delivery, test events are sent once
+func (d *WebhookDispatcher) maxAttempts(delivery *webhookmodel.WebhookDelivery) int {
+	if delivery.EventType == webhookmodel.EventTest {
+		return 1
+	}
+	return d.cfg.MaxAttempts
+}
+
+// backoff doubles the delay on every attempt, up to MaxBackoff
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	delay := webhookBaseBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.MaxBackoff {
+		delay = d.cfg.MaxBackoff
+	}
+	return delay
+}
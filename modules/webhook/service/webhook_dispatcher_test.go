@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/ntttrang/go-food-delivery-backend-service/modules/webhook/model"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+type fakeWebhookRepo struct {
+	endpoints  map[uuid.UUID]webhookmodel.WebhookEndpoint
+	deliveries map[uuid.UUID]*webhookmodel.WebhookDelivery
+	attempts   []webhookmodel.WebhookDeliveryAttempt
+}
+
+func (r *fakeWebhookRepo) FindEndpointsByRestaurantId(ctx context.Context, restaurantId uuid.UUID) ([]webhookmodel.WebhookEndpoint, error) {
+	var endpoints []webhookmodel.WebhookEndpoint
+	for _, endpoint := range r.endpoints {
+		if endpoint.RestaurantId == restaurantId {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+func (r *fakeWebhookRepo) FindEndpointById(ctx context.Context, id uuid.UUID) (*webhookmodel.WebhookEndpoint, error) {
+	endpoint, ok := r.endpoints[id]
+	if !ok {
+		return nil, webhookmodel.ErrEndpointNotFound
+	}
+	return &endpoint, nil
+}
+
+func (r *fakeWebhookRepo) FindEndpointsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]webhookmodel.WebhookEndpoint, error) {
+	return r.endpoints, nil
+}
+
+func (r *fakeWebhookRepo) InsertDeliveries(ctx context.Context, deliveries []webhookmodel.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		duplicate := false
+		for _, stored := range r.deliveries {
+			duplicate = duplicate || (stored.EndpointId == delivery.EndpointId && stored.EventId == delivery.EventId)
+		}
+		if !duplicate {
+			stored := delivery
+			r.deliveries[delivery.Id] = &stored
+		}
+	}
+	return nil
+}
+
+func (r *fakeWebhookRepo) ClaimPendingDeliveries(ctx context.Context, limit int, lease time.Duration) ([]webhookmodel.WebhookDelivery, error) {
+	now := time.Now().UTC()
+	var due []webhookmodel.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == webhookmodel.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) && len(due) < limit {
+			delivery.NextAttemptAt = now.Add(lease)
+			due = append(due, *delivery)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeWebhookRepo) UpdateDelivery(ctx context.Context, delivery *webhookmodel.WebhookDelivery) error {
+	stored := *delivery
+	r.deliveries[delivery.Id] = &stored
+	return nil
+}
+
+func (r *fakeWebhookRepo) InsertDeliveryAttempt(ctx context.Context, attempt *webhookmodel.WebhookDeliveryAttempt) error {
+	r.attempts = append(r.attempts, *attempt)
+	return nil
+}
+
+func (r *fakeWebhookRepo) onlyDelivery(t *testing.T) *webhookmodel.WebhookDelivery {
+	t.Helper()
+	if len(r.deliveries) != 1 {
+		t.Fatalf("stored %d deliveries, want 1", len(r.deliveries))
+	}
+	for _, delivery := range r.deliveries {
+		return delivery
+	}
+	return nil
+}
+
+type fakeWebhookRequest struct {
+	url    string
+	header http.Header
+	body   []byte
+}
+
+type fakeWebhookSender struct {
+	statusCode int
+	err        error
+	requests   []fakeWebhookRequest
+}
+
+func (s *fakeWebhookSender) Send(ctx context.Context, url string, header http.Header, body []byte) (int, string, error) {
+	s.requests = append(s.requests, fakeWebhookRequest{url: url, header: header, body: body})
+	if s.err != nil {
+		return 0, "", s.err
+	}
+	return s.statusCode, "ok", nil
+}
+
+func newFakeWebhookRepo(restaurantId uuid.UUID, events ...string) (*fakeWebhookRepo, webhookmodel.WebhookEndpoint) {
+	endpoint := webhookmodel.WebhookEndpoint{
+		Id:           uuid.New(),
+		RestaurantId: restaurantId,
+		Url:          "https://pos.example.com/hooks",
+		Secret:       "whsec_0123456789abcdef",
+		Events:       events,
+	}
+	return &fakeWebhookRepo{
+		endpoints:  map[uuid.UUID]webhookmodel.WebhookEndpoint{endpoint.Id: endpoint},
+		deliveries: map[uuid.UUID]*webhookmodel.WebhookDelivery{},
+	}, endpoint
+}
+
+func TestWebhookDispatcher_DispatchBatch(t *testing.T) {
+	ctx := context.Background()
+	restaurantId := uuid.New()
+	cfg := datatype.WebhookConfig{BatchSize: 10, MaxAttempts: 2, MaxBackoff: time.Hour}
+	publish := func(t *testing.T, repo *fakeWebhookRepo, eventType string) int {
+		queued, err := NewPublishEventCommandHandler(repo).Execute(ctx, &PublishEventReq{
+			Id:           "evt-1",
+			Type:         eventType,
+			RestaurantId: restaurantId,
+			Data:         json.RawMessage(`{"orderId":"order-1"}`),
+		})
+		if err != nil {
+			t.Fatalf("PublishEventCommandHandler.Execute() error = %v", err)
+		}
+		return queued
+	}
+
+	t.Run("TC 1: subscribed event is delivered once and signed", func(t *testing.T) {
+		repo, endpoint := newFakeWebhookRepo(restaurantId, webhookmodel.EventOrderCreated)
+		sender := &fakeWebhookSender{statusCode: http.StatusOK}
+
+		if queued := publish(t, repo, webhookmodel.EventOrderCancelled); queued != 0 {
+			t.Errorf("queued %d deliveries of an event not subscribed to, want 0", queued)
+		}
+		publish(t, repo, webhookmodel.EventOrderCreated)
+		publish(t, repo, webhookmodel.EventOrderCreated)
+		if len(repo.deliveries) != 1 {
+			t.Fatalf("stored %d deliveries of a republished event, want 1", len(repo.deliveries))
+		}
+
+		count, err := NewWebhookDispatcher(repo, sender, cfg).DispatchBatch(ctx)
+		if err != nil || count != 1 {
+			t.Fatalf("DispatchBatch() = %d, %v, want 1, nil", count, err)
+		}
+
+		req := sender.requests[0]
+		timestamp, _ := strconv.ParseInt(req.header.Get(HeaderWebhookTimestamp), 10, 64)
+		if !VerifySignature(endpoint.Secret, timestamp, req.body, req.header.Get(HeaderWebhookSignature)) {
+			t.Errorf("signature %s does not match the body", req.header.Get(HeaderWebhookSignature))
+		}
+		if req.header.Get(HeaderWebhookId) != "evt-1" || req.header.Get(HeaderWebhookEvent) != webhookmodel.EventOrderCreated {
+			t.Errorf("headers = %v", req.header)
+		}
+
+		var payload webhookmodel.WebhookPayload
+		if err := json.Unmarshal(req.body, &payload); err != nil || payload.Id != "evt-1" || string(payload.Data) != `{"orderId":"order-1"}` {
+			t.Errorf("body = %s, %v", req.body, err)
+		}
+
+		if delivery := repo.onlyDelivery(t); delivery.Status != webhookmodel.DeliveryStatusSucceeded || delivery.Attempts != 1 || *delivery.LastStatusCode != http.StatusOK {
+			t.Errorf("delivery = %+v, want succeeded after 1 attempt", delivery)
+		}
+		if len(repo.attempts) != 1 || *repo.attempts[0].StatusCode != http.StatusOK {
+			t.Errorf("attempts = %+v, want one logged", repo.attempts)
+		}
+	})
+
+	t.Run("TC 2: failed delivery is retried after a backoff, then given up", func(t *testing.T) {
+		repo, _ := newFakeWebhookRepo(restaurantId, webhookmodel.OrderEvents...)
+		sender := &fakeWebhookSender{statusCode: http.StatusInternalServerError}
+		dispatcher := NewWebhookDispatcher(repo, sender, cfg)
+		publish(t, repo, webhookmodel.EventOrderStateChanged)
+
+		if _, err := dispatcher.DispatchBatch(ctx); err != nil {
+			t.Fatalf("DispatchBatch() error = %v", err)
+		}
+		delivery := repo.onlyDelivery(t)
+		if delivery.Status != webhookmodel.DeliveryStatusPending || delivery.LastError == nil || !delivery.NextAttemptAt.After(time.Now().Add(webhookBaseBackoff/2)) {
+			t.Errorf("delivery = %+v, want pending with a backoff", delivery)
+		}
+
+		// Not due yet
+		if count, _ := dispatcher.DispatchBatch(ctx); count != 0 {
+			t.Errorf("DispatchBatch() = %d before the backoff, want 0", count)
+		}
+
+		sender.statusCode, sender.err = 0, errors.New("connection refused")
+		repo.deliveries[delivery.Id].NextAttemptAt = time.Now().UTC()
+		if _, err := dispatcher.DispatchBatch(ctx); err != nil {
+			t.Fatalf("DispatchBatch() error = %v", err)
+		}
+		if stored := repo.deliveries[delivery.Id]; stored.Status != webhookmodel.DeliveryStatusFailed || stored.Attempts != 2 {
+			t.Errorf("delivery = %+v, want failed after 2 attempts", stored)
+		}
+		if len(repo.attempts) != 2 || repo.attempts[1].StatusCode != nil || repo.attempts[1].Error == nil {
+			t.Errorf("attempts = %+v, want the second one without response", repo.attempts)
+		}
+	})
+
+	t.Run("TC 3: test event is sent right away and not retried", func(t *testing.T) {
+		repo, endpoint := newFakeWebhookRepo(restaurantId, webhookmodel.EventOrderCreated)
+		sender := &fakeWebhookSender{statusCode: http.StatusNotFound}
+		dispatcher := NewWebhookDispatcher(repo, sender, cfg)
+
+		if _, err := NewSendTestEventCommandHandler(repo, dispatcher).Execute(ctx, uuid.New(), endpoint.Id); err == nil {
+			t.Error("test event to the endpoint of another restaurant error = nil, want an error")
+		}
+
+		delivery, err := NewSendTestEventCommandHandler(repo, dispatcher).Execute(ctx, restaurantId, endpoint.Id)
+		if err != nil {
+			t.Fatalf("SendTestEventCommandHandler.Execute() error = %v", err)
+		}
+		if delivery.Status != webhookmodel.DeliveryStatusFailed || delivery.Attempts != 1 || len(sender.requests) != 1 {
+			t.Errorf("delivery = %+v, want failed after 1 attempt", delivery)
+		}
+		if sender.requests[0].header.Get(HeaderWebhookEvent) != webhookmodel.EventTest {
+			t.Errorf("event = %s, want %s", sender.requests[0].header.Get(HeaderWebhookEvent), webhookmodel.EventTest)
+		}
+	})
+}
@@ -1,13 +1,28 @@
 package sharecomponent
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
 	"gopkg.in/gomail.v2"
 )
 
+// EmailSender delivers an email right away, see the notification module for the queued outbox
+type EmailSender interface {
+	SendEmail(message sharedmodel.EmailMessage) error
+}
+
+// NewEmailSender returns the transport selected by the config, SMTP by default
+func NewEmailSender(cfg datatype.EmailConfig) EmailSender {
+	if cfg.Transport == datatype.EmailTransportEml {
+		return NewEmlEmailService(cfg)
+	}
+	return NewEmailService(cfg)
+}
+
 type EmailService struct {
 	cfg datatype.EmailConfig
 }
@@ -21,21 +36,62 @@ func NewEmailService(cfg datatype.EmailConfig) *EmailService {
 func (e *EmailService) SendEmail(message sharedmodel.EmailMessage) error {
 	config := e.cfg
 
+	msg, err := newGomailMessage(message, config.From, config.AttachmentDir)
+	if err != nil {
+		return err
+	}
+	dialer := gomail.NewDialer(config.SMTPHost, config.SMTPPort, config.SMTPUsername, config.SMTPPassword)
+
+	// Send the email
+	if err := dialer.DialAndSend(msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// ErrAttachmentNotAllowed is returned for an attachment which is not a file of the attachment directory
+var ErrAttachmentNotAllowed = errors.New("attachment must be a file name of the attachment directory")
+
+// ValidAttachmentName reports whether the attachment is a plain file name, which cannot leave the attachment directory
+func ValidAttachmentName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name && !filepath.IsAbs(name)
+}
+
+// newGomailMessage builds the MIME message, from the default sender when the message has none.
+// The attachments are file names of attachmentDir, no attachment is allowed when it is empty.
+func newGomailMessage(message sharedmodel.EmailMessage, defaultFrom string, attachmentDir string) (*gomail.Message, error) {
 	// Create a new message
 	msg := gomail.NewMessage()
 
+	from := message.From
+	if from == "" {
+		from = defaultFrom
+	}
+
 	// Set email headers
-	msg.SetHeader("From", message.From)
+	msg.SetHeader("From", from)
 	msg.SetHeader("To", message.To...)
 	msg.SetHeader("Subject", message.Subject)
 
-	msg.SetBody("text/html", message.Body)
-	dialer := gomail.NewDialer(config.SMTPHost, config.SMTPPort, config.SMTPUsername, config.SMTPPassword)
+	// An HTML body comes after its plain text alternative, the last part is the preferred one
+	switch {
+	case message.IsHTML && message.TextBody != "":
+		msg.SetBody("text/plain", message.TextBody)
+		msg.AddAlternative("text/html", message.Body)
+	case message.IsHTML:
+		msg.SetBody("text/html", message.Body)
+	default:
+		msg.SetBody("text/plain", message.Body)
+	}
 
-	// Send the email
-	if err := dialer.DialAndSend(msg); err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+	// Files are read when the message is written, a missing file fails the send
+	for _, name := range message.Attachments {
+		if attachmentDir == "" || !ValidAttachmentName(name) {
+			return nil, fmt.Errorf("%w: %q", ErrAttachmentNotAllowed, name)
+		}
+		msg.Attach(filepath.Join(attachmentDir, name))
 	}
 
-	return nil
+	return msg, nil
 }
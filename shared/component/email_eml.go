@@ -0,0 +1,54 @@
+package sharecomponent
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// EmlEmailService writes every email to an .eml file of the sink directory instead of sending it,
+// so that emails can be opened in a mail client during local development and checked by tests
+type EmlEmailService struct {
+	from          string
+	dir           string
+	attachmentDir string
+}
+
+func NewEmlEmailService(cfg datatype.EmailConfig) *EmlEmailService {
+	return &EmlEmailService{from: cfg.From, dir: cfg.SinkDir, attachmentDir: cfg.AttachmentDir}
+}
+
+func (e *EmlEmailService) SendEmail(message sharedmodel.EmailMessage) error {
+	msg, err := newGomailMessage(message, e.from, e.attachmentDir)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(e.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create email sink: %w", err)
+	}
+
+	// Sortable by time, unique across processes
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), uuid.NewString())
+	path := filepath.Join(e.dir, name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create email file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := msg.WriteTo(f); err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	log.Printf("Email sink: %q to %v written to %s", message.Subject, message.To, path)
+	return nil
+}
@@ -0,0 +1,69 @@
+package sharecomponent
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+func TestEmlEmailService_SendEmail(t *testing.T) {
+	dir := t.TempDir()
+	attachmentDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(attachmentDir, "invoice.txt"), []byte("invoice"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewEmailSender(datatype.EmailConfig{Transport: datatype.EmailTransportEml, SinkDir: dir, From: "no-reply@fd.local", AttachmentDir: attachmentDir})
+
+	t.Run("TC 1: HTML with its plain text alternative and an attachment", func(t *testing.T) {
+		err := svc.SendEmail(sharedmodel.EmailMessage{
+			To:          []string{"a@b.c"},
+			Subject:     "Order 1",
+			Body:        "<p>Order 1 delivered</p>",
+			TextBody:    "Order 1 delivered",
+			IsHTML:      true,
+			Attachments: []string{"invoice.txt"},
+		})
+		if err != nil {
+			t.Fatalf("SendEmail() error = %v", err)
+		}
+
+		files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+		if len(files) != 1 {
+			t.Fatalf("eml files = %d, want 1", len(files))
+		}
+		content, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"From: no-reply@fd.local", "To: a@b.c", "text/plain", "text/html", `filename="invoice.txt"`} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("eml does not contain %q", want)
+			}
+		}
+	})
+
+	t.Run("TC 2: missing attachment fails without leaving a file", func(t *testing.T) {
+		before, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+		err := svc.SendEmail(sharedmodel.EmailMessage{To: []string{"a@b.c"}, Subject: "Order 2", Body: "x", Attachments: []string{"missing.pdf"}})
+		if err == nil {
+			t.Error("SendEmail() error = nil, want an error")
+		}
+		after, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+		if len(after) != len(before) {
+			t.Errorf("eml files = %d, want %d", len(after), len(before))
+		}
+	})
+	t.Run("TC 3: attachment outside of the attachment directory is refused", func(t *testing.T) {
+		for _, name := range []string{"/etc/passwd", "../.env", filepath.Join("..", filepath.Base(dir), "x.eml")} {
+			err := svc.SendEmail(sharedmodel.EmailMessage{To: []string{"a@b.c"}, Subject: "Order 3", Body: "x", Attachments: []string{name}})
+			if !errors.Is(err, ErrAttachmentNotAllowed) {
+				t.Errorf("SendEmail(%q) error = %v, want ErrAttachmentNotAllowed", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package sharecomponent
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+	"github.com/pkg/errors"
+)
+
+// Email templates, one directory per locale. A template <name> defines <name>.subject and <name>.text,
+// rendered as plain text, and <name>.html, rendered as HTML in the layout of the locale.
+//
+//go:embed templates/email
+var emailTemplateFS embed.FS
+
+const (
+	emailTemplateDir     = "templates/email"
+	emailTemplateSamples = emailTemplateDir + "/samples.json"
+	emailLayoutHTML      = "layout.html"
+	emailLayoutText      = "layout.text"
+)
+
+var ErrEmailTemplateNotFound = errors.New("email template not found")
+
+var emailTemplateFuncs = map[string]any{
+	"upper": strings.ToUpper,
+	"inc":   func(i int) int { return i + 1 },
+	"money": func(amount float64) string { return fmt.Sprintf("$%.2f", amount) },
+}
+
+type emailTemplateSet struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+type EmailTemplateRenderer struct {
+	defaultLocale string
+	sets          map[string]emailTemplateSet // Locale -> templates
+	samples       map[string]map[string]any   // Template -> sample data of the preview
+}
+
+// NewEmailTemplateRenderer parses the embedded templates of every supported locale
+func NewEmailTemplateRenderer(defaultLocale string) (*EmailTemplateRenderer, error) {
+	return newEmailTemplateRenderer(emailTemplateFS, defaultLocale)
+}
+
+func newEmailTemplateRenderer(fsys fs.FS, defaultLocale string) (*EmailTemplateRenderer, error) {
+	r := &EmailTemplateRenderer{
+		defaultLocale: normalizeLocale(defaultLocale),
+		sets:          make(map[string]emailTemplateSet, len(datatype.SupportedLocales)),
+	}
+	if r.defaultLocale == "" {
+		r.defaultLocale = datatype.LocaleEn
+	}
+
+	for _, locale := range datatype.SupportedLocales {
+		pattern := emailTemplateDir + "/" + locale + "/*.tmpl"
+
+		html, err := htmltemplate.New(locale).Funcs(emailTemplateFuncs).ParseFS(fsys, pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s email templates", locale)
+		}
+		text, err := texttemplate.New(locale).Funcs(emailTemplateFuncs).ParseFS(fsys, pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s email templates", locale)
+		}
+		r.sets[locale] = emailTemplateSet{html: html, text: text}
+	}
+
+	if _, ok := r.sets[r.defaultLocale]; !ok {
+		return nil, errors.Errorf("default email locale %s is not supported", r.defaultLocale)
+	}
+
+	samples, err := fs.ReadFile(fsys, emailTemplateSamples)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := json.Unmarshal(samples, &r.samples); err != nil {
+		return nil, errors.Wrap(err, "failed to parse email template samples")
+	}
+
+	return r, nil
+}
+
+// MustNewEmailTemplateRenderer panics when the embedded templates are broken, which is a bug of the build
+func MustNewEmailTemplateRenderer(defaultLocale string) *EmailTemplateRenderer {
+	r, err := NewEmailTemplateRenderer(defaultLocale)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Render renders a template in the locale, e.g. vi or vi-VN.
+// Unsupported locales and templates missing in the locale fall back to the default locale.
+func (r *EmailTemplateRenderer) Render(locale, name string, data any) (*sharedmodel.EmailContent, error) {
+	set, ok := r.sets[normalizeLocale(locale)]
+	if !ok || set.text.Lookup(name+".subject") == nil {
+		set = r.sets[r.defaultLocale]
+	}
+	if set.text.Lookup(name+".subject") == nil {
+		return nil, errors.Wrap(ErrEmailTemplateNotFound, name)
+	}
+
+	subject, err := executeText(set.text, name+".subject", data)
+	if err != nil {
+		return nil, err
+	}
+
+	textBody, err := executeText(set.text, name+".text", data)
+	if err != nil {
+		return nil, err
+	}
+	text, err := executeText(set.text, emailLayoutText, map[string]any{"Subject": subject, "Content": textBody})
+	if err != nil {
+		return nil, err
+	}
+
+	var htmlBody bytes.Buffer
+	if err := set.html.ExecuteTemplate(&htmlBody, name+".html", data); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var html bytes.Buffer
+	if err := set.html.ExecuteTemplate(&html, emailLayoutHTML, map[string]any{
+		"Subject": subject,
+		"Content": htmltemplate.HTML(htmlBody.String()), // Already escaped
+	}); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &sharedmodel.EmailContent{
+		Subject: strings.Join(strings.Fields(subject), " "),
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text),
+	}, nil
+}
+
+// Preview renders a template with its sample data, overridden by the given data
+func (r *EmailTemplateRenderer) Preview(locale, name string, data map[string]any) (*sharedmodel.EmailContent, error) {
+	previewData := make(map[string]any)
+	for k, v := range r.samples[name] {
+		previewData[k] = v
+	}
+	for k, v := range data {
+		previewData[k] = v
+	}
+	return r.Render(locale, name, previewData)
+}
+
+// Templates returns the names of the templates of the default locale
+func (r *EmailTemplateRenderer) Templates() []string {
+	var names []string
+	for _, t := range r.sets[r.defaultLocale].text.Templates() {
+		if name, ok := strings.CutSuffix(t.Name(), ".subject"); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *EmailTemplateRenderer) Locales() []string {
+	return datatype.SupportedLocales
+}
+
+func (r *EmailTemplateRenderer) DefaultLocale() string {
+	return r.defaultLocale
+}
+
+func executeText(t *texttemplate.Template, name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return buf.String(), nil
+}
+
+// normalizeLocale keeps the language of a locale, e.g. vi-VN -> vi
+func normalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locale = locale[:i]
+	}
+	return locale
+}
@@ -0,0 +1,77 @@
+package sharecomponent
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEmailTemplateRenderer_PreviewEveryTemplate(t *testing.T) {
+	r := MustNewEmailTemplateRenderer("en")
+
+	names := r.Templates()
+	if len(names) != 16 {
+		t.Errorf("Templates() = %d templates, want 16", len(names))
+	}
+
+	for _, locale := range r.Locales() {
+		for _, name := range names {
+			content, err := r.Preview(locale, name, nil)
+			if err != nil {
+				t.Errorf("Preview(%s, %s) error = %v", locale, name, err)
+				continue
+			}
+			if content.Subject == "" || content.Text == "" || !strings.Contains(content.HTML, `lang="`+locale+`"`) {
+				t.Errorf("Preview(%s, %s) = %+v", locale, name, content)
+			}
+			if strings.Contains(content.Text+content.HTML, "<no value>") {
+				t.Errorf("Preview(%s, %s) misses sample data", locale, name)
+			}
+		}
+	}
+}
+
+func TestEmailTemplateRenderer_Render(t *testing.T) {
+	r := MustNewEmailTemplateRenderer("en")
+
+	t.Run("TC 1: HTML is escaped, the plain text is not", func(t *testing.T) {
+		content, err := r.Render("en", "customer.order_cancelled", map[string]any{
+			"OrderID": "order-1", "Reason": "<script>alert(1)</script>", "TotalPrice": 10.0,
+		})
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if strings.Contains(content.HTML, "<script>") || !strings.Contains(content.HTML, "&lt;script&gt;") {
+			t.Errorf("HTML = %s", content.HTML)
+		}
+		if !strings.Contains(content.Text, "<script>alert(1)</script>") {
+			t.Errorf("Text = %s", content.Text)
+		}
+	})
+
+	t.Run("TC 2: region of the locale is ignored", func(t *testing.T) {
+		content, err := r.Render("vi-VN", "customer.shipper_assigned", map[string]any{"OrderID": "order-1"})
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if content.Subject != "Đã có tài xế - Đơn hàng order-1" {
+			t.Errorf("Subject = %s", content.Subject)
+		}
+	})
+
+	t.Run("TC 3: unsupported locale falls back to the default", func(t *testing.T) {
+		content, err := r.Render("fr", "customer.shipper_assigned", map[string]any{"OrderID": "order-1"})
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if content.Subject != "Shipper Assigned - Order order-1" {
+			t.Errorf("Subject = %s", content.Subject)
+		}
+	})
+
+	t.Run("TC 4: unknown template", func(t *testing.T) {
+		if _, err := r.Render("en", "customer.unknown", nil); !errors.Is(err, ErrEmailTemplateNotFound) {
+			t.Errorf("Render() error = %v, want %v", err, ErrEmailTemplateNotFound)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package sharecomponent
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// inMemoryBroker delivers the events to the subscribers of the same process.
+// Events are not persisted: a failed or pending delivery is lost when the process stops.
+type inMemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[int]MsgHandler
+	nextId      int
+	syncMode    bool
+	inflight    sync.WaitGroup
+}
+
+// NewInMemoryBroker returns an in-process broker. In sync mode Publish calls the handlers
+// before returning and returns their errors, which makes tests deterministic.
+func NewInMemoryBroker(syncMode bool) *inMemoryBroker {
+	return &inMemoryBroker{
+		subscribers: make(map[string]map[int]MsgHandler),
+		syncMode:    syncMode,
+	}
+}
+
+func (b *inMemoryBroker) Publish(ctx context.Context, topic string, evt *datatype.AppEvent) error {
+	// Encoded like on the wire, so that the subscribers decode the same envelope as with NATS
+	dataByte, err := datatype.EncodeEvt(ctx, evt)
+	if err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	handlers := make([]MsgHandler, 0, len(b.subscribers[topic]))
+	for _, handler := range b.subscribers[topic] {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	if b.syncMode {
+		var errs []error
+		for _, handler := range handlers {
+			if err := handler(ctx, dataByte); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	// The delivery must not be cancelled with the request which published the event
+	deliveryCtx := context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		b.inflight.Add(1)
+		go func(handler MsgHandler) {
+			defer b.inflight.Done()
+			if err := handler(deliveryCtx, dataByte); err != nil {
+				log.Printf("In-memory broker: failed to handle event %s (%s): %v", evt.ID, topic, err)
+			}
+		}(handler)
+	}
+	return nil
+}
+
+// Subscribe adds a handler of the topic, every subscriber receives each event
+func (b *inMemoryBroker) Subscribe(topic string, handler MsgHandler) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextId
+	b.nextId++
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[int]MsgHandler)
+	}
+	b.subscribers[topic][id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers[topic], id)
+	}
+}
+
+// Wait blocks until the events being delivered are handled
+func (b *inMemoryBroker) Wait() {
+	b.inflight.Wait()
+}
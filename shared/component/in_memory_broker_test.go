@@ -0,0 +1,76 @@
+package sharecomponent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func TestInMemoryBroker_Publish(t *testing.T) {
+	ctx := context.Background()
+	evt := datatype.NewAppEvent(datatype.WithData(datatype.OrderCreatedEvt{OrderID: "order-1"}))
+
+	t.Run("TC 1: every subscriber of the topic receives the event", func(t *testing.T) {
+		broker := NewInMemoryBroker(false)
+
+		var mu sync.Mutex
+		received := map[string]string{}
+		subscribe := func(name, topic string) {
+			broker.Subscribe(topic, func(ctx context.Context, data []byte) error {
+				decoded, err := datatype.DecodeEvt(data)
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				received[name] = decoded.Data.(*datatype.OrderCreatedEvt).OrderID
+				return nil
+			})
+		}
+		subscribe("email", datatype.EvtNotifyOrderCreate)
+		subscribe("push", datatype.EvtNotifyOrderCreate)
+		subscribe("other", datatype.EvtNotifyOrderCancel)
+
+		if err := broker.Publish(ctx, evt.Topic, evt); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		broker.Wait()
+
+		want := map[string]string{"email": "order-1", "push": "order-1"}
+		if len(received) != len(want) || received["email"] != "order-1" || received["push"] != "order-1" {
+			t.Errorf("received = %v, want %v", received, want)
+		}
+	})
+
+	t.Run("TC 2: sync mode returns the handler errors", func(t *testing.T) {
+		broker := NewInMemoryBroker(true)
+		handlerErr := errors.New("smtp: timeout")
+		broker.Subscribe(datatype.EvtNotifyOrderCreate, func(ctx context.Context, data []byte) error {
+			return handlerErr
+		})
+
+		if err := broker.Publish(ctx, evt.Topic, evt); !errors.Is(err, handlerErr) {
+			t.Errorf("Publish() error = %v, want %v", err, handlerErr)
+		}
+	})
+
+	t.Run("TC 3: unsubscribed handler is not called", func(t *testing.T) {
+		broker := NewInMemoryBroker(true)
+		calls := 0
+		unsubscribe := broker.Subscribe(datatype.EvtNotifyOrderCreate, func(ctx context.Context, data []byte) error {
+			calls++
+			return nil
+		})
+
+		_ = broker.Publish(ctx, evt.Topic, evt)
+		unsubscribe()
+		_ = broker.Publish(ctx, evt.Topic, evt)
+
+		if calls != 1 {
+			t.Errorf("calls = %d, want 1", calls)
+		}
+	})
+}
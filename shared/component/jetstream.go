@@ -0,0 +1,301 @@
+package sharecomponent
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"github.com/pkg/errors"
+)
+
+const (
+	OrderEventsStream = "ORDER_EVENTS"
+	DeadLetterStream  = "DEAD_LETTERS"
+
+	deadLetterSubjectPrefix = "dlq."
+	// A replayed dead letter is published on replay.<consumer>.<subject>, only read by that consumer
+	replaySubjectPrefix = "replay."
+
+	// Headers describing why a message was dead lettered
+	HeaderDeadLetterSubject    = "Dead-Letter-Subject"
+	HeaderDeadLetterSequence   = "Dead-Letter-Sequence"
+	HeaderDeadLetterConsumer   = "Dead-Letter-Consumer"
+	HeaderDeadLetterDeliveries = "Dead-Letter-Deliveries"
+	HeaderDeadLetterError      = "Dead-Letter-Error"
+)
+
+// OrderEventSubjects are the subjects persisted in the order events stream, one per event of the catalogue
+var OrderEventSubjects = datatype.EvtTopics()
+
+// ErrPoisonMessage marks a message which can never be handled, e.g. a bad payload.
+// It is dead lettered at once instead of being redelivered.
+var ErrPoisonMessage = errors.New("poison message")
+
+// ErrDeadLetterNotFound is returned when replaying a dead letter which does not exist
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// ErrDeadLetterConsumerMissing is returned when replaying a dead letter which does not name its consumer
+var ErrDeadLetterConsumerMissing = errors.New("dead letter has no consumer")
+
+// MsgHandler handles an encoded event, a returned error means the message is not handled
+type MsgHandler func(ctx context.Context, data []byte) error
+
+type JetStreamConsumerConfig struct {
+	Durable    string
+	AckWait    time.Duration   // a message not acked in time is redelivered, e.g. the consumer crashed
+	MaxDeliver int             // a message failing this many times is dead lettered
+	BackOff    []time.Duration // delay before each redelivery after a failure, the last one is reused
+}
+
+// DeadLetter is a message which could not be handled
+type DeadLetter struct {
+	Sequence   uint64    `json:"sequence"` // Sequence in the dead letter stream
+	Subject    string    `json:"subject"`  // Original subject
+	Consumer   string    `json:"consumer"`
+	Deliveries int       `json:"deliveries"`
+	Error      string    `json:"error"`
+	Data       string    `json:"data"`
+	FailedAt   time.Time `json:"failedAt"`
+}
+
+type jetStreamComp struct {
+	js jetstream.JetStream
+}
+
+func NewJetStreamComp(nc *nats.Conn) (*jetStreamComp, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &jetStreamComp{js: js}, nil
+}
+
+// EnsureStreams creates or updates the order events and dead letter streams
+func (c *jetStreamComp) EnsureStreams(ctx context.Context) error {
+	if _, err := c.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      OrderEventsStream,
+		Subjects:  append(append([]string{}, OrderEventSubjects...), replaySubjectPrefix+">"),
+		Storage:   jetstream.FileStorage,
+		Retention: jetstream.LimitsPolicy,
+		MaxAge:    7 * 24 * time.Hour,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := c.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      DeadLetterStream,
+		Subjects:  []string{deadLetterSubjectPrefix + ">"},
+		Storage:   jetstream.FileStorage,
+		Retention: jetstream.LimitsPolicy,
+		MaxAge:    30 * 24 * time.Hour,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Publish waits until the message is persisted by the stream
+func (c *jetStreamComp) Publish(ctx context.Context, subject string, data []byte, opts ...jetstream.PublishOpt) error {
+	if _, err := c.js.Publish(ctx, subject, data, opts...); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Consume starts a durable pull consumer of the order events stream, each subject is handled by its handler.
+// A message is acked when handled, redelivered with backoff on failure and dead lettered after MaxDeliver attempts.
+func (c *jetStreamComp) Consume(ctx context.Context, cfg JetStreamConsumerConfig, handlers map[string]MsgHandler) (jetstream.ConsumeContext, error) {
+	subjects := make([]string, 0, 2*len(handlers))
+	for subject := range handlers {
+		subjects = append(subjects, subject, replaySubject(cfg.Durable, subject))
+	}
+
+	consumer, err := c.js.CreateOrUpdateConsumer(ctx, OrderEventsStream, jetstream.ConsumerConfig{
+		Durable:        cfg.Durable,
+		FilterSubjects: subjects,
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        cfg.AckWait,
+		// One more delivery than ours so that the last failure is still seen and dead lettered
+		MaxDeliver: cfg.MaxDeliver + 1,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return consumer.Consume(func(msg jetstream.Msg) {
+		c.handleMsg(ctx, cfg, handlers, msg)
+	}, jetstream.ConsumeErrHandler(func(consumeCtx jetstream.ConsumeContext, err error) {
+		log.Printf("JetStream consumer %s: %v", cfg.Durable, err)
+	}))
+}
+
+func (c *jetStreamComp) handleMsg(ctx context.Context, cfg JetStreamConsumerConfig, handlers map[string]MsgHandler, msg jetstream.Msg) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		log.Printf("JetStream consumer %s: invalid message metadata: %v", cfg.Durable, err)
+		_ = msg.Term()
+		return
+	}
+
+	subject := originalSubject(cfg.Durable, msg.Subject())
+	handler, ok := handlers[subject]
+	if !ok {
+		err = fmt.Errorf("%w: no handler for subject %s", ErrPoisonMessage, subject)
+	} else {
+		handleCtx, cancel := context.WithTimeout(ctx, cfg.AckWait)
+		err = handler(handleCtx, msg.Data())
+		cancel()
+	}
+
+	if err == nil {
+		if ackErr := msg.Ack(); ackErr != nil {
+			log.Printf("JetStream consumer %s: failed to ack message %d: %v", cfg.Durable, meta.Sequence.Stream, ackErr)
+		}
+		return
+	}
+
+	deliveries := int(meta.NumDelivered)
+	if !shouldDeadLetter(err, deliveries, cfg.MaxDeliver) {
+		delay := redeliveryDelay(cfg.BackOff, deliveries)
+		log.Printf("JetStream consumer %s: message %d (%s) failed, attempt %d, retry in %s: %v", cfg.Durable, meta.Sequence.Stream, msg.Subject(), deliveries, delay, err)
+		if nakErr := msg.NakWithDelay(delay); nakErr != nil {
+			log.Printf("JetStream consumer %s: failed to nak message %d: %v", cfg.Durable, meta.Sequence.Stream, nakErr)
+		}
+		return
+	}
+
+	log.Printf("JetStream consumer %s: dead lettering message %d (%s) after %d attempts: %v", cfg.Durable, meta.Sequence.Stream, msg.Subject(), deliveries, err)
+	if dlqErr := c.deadLetter(ctx, cfg.Durable, subject, msg, meta, err); dlqErr != nil {
+		// Keep the message, it is redelivered and dead lettered again
+		log.Printf("JetStream consumer %s: failed to dead letter message %d: %v", cfg.Durable, meta.Sequence.Stream, dlqErr)
+		_ = msg.NakWithDelay(redeliveryDelay(cfg.BackOff, deliveries))
+		return
+	}
+	_ = msg.Term()
+}
+
+func (c *jetStreamComp) deadLetter(ctx context.Context, consumer string, subject string, msg jetstream.Msg, meta *jetstream.MsgMetadata, cause error) error {
+	dlqMsg := nats.NewMsg(deadLetterSubjectPrefix + subject)
+	dlqMsg.Data = msg.Data()
+	dlqMsg.Header.Set(HeaderDeadLetterSubject, subject)
+	dlqMsg.Header.Set(HeaderDeadLetterSequence, strconv.FormatUint(meta.Sequence.Stream, 10))
+	dlqMsg.Header.Set(HeaderDeadLetterConsumer, consumer)
+	dlqMsg.Header.Set(HeaderDeadLetterDeliveries, strconv.FormatUint(meta.NumDelivered, 10))
+	dlqMsg.Header.Set(HeaderDeadLetterError, cause.Error())
+
+	if _, err := c.js.PublishMsg(ctx, dlqMsg); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// ListDeadLetters returns the oldest dead letters
+func (c *jetStreamComp) ListDeadLetters(ctx context.Context, limit int) ([]DeadLetter, error) {
+	stream, err := c.js.Stream(ctx, DeadLetterStream)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var deadLetters []DeadLetter
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && seq > 0 && len(deadLetters) < limit; seq++ {
+		msg, err := stream.GetMsg(ctx, seq)
+		if err != nil {
+			if errors.Is(err, jetstream.ErrMsgNotFound) {
+				continue // Replayed or deleted
+			}
+			return nil, errors.WithStack(err)
+		}
+		deadLetters = append(deadLetters, toDeadLetter(msg))
+	}
+
+	return deadLetters, nil
+}
+
+// ReplayDeadLetter publishes a dead letter again for the consumer which failed it, the other consumers
+// of its subject do not see it again. The dead letter is then removed from the dead letter stream.
+func (c *jetStreamComp) ReplayDeadLetter(ctx context.Context, seq uint64) error {
+	stream, err := c.js.Stream(ctx, DeadLetterStream)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	msg, err := stream.GetMsg(ctx, seq)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			return ErrDeadLetterNotFound
+		}
+		return errors.WithStack(err)
+	}
+
+	deadLetter := toDeadLetter(msg)
+	if deadLetter.Consumer == "" {
+		return ErrDeadLetterConsumerMissing
+	}
+	if err := c.Publish(ctx, replaySubject(deadLetter.Consumer, deadLetter.Subject), msg.Data); err != nil {
+		return err
+	}
+
+	if err := stream.DeleteMsg(ctx, seq); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// replaySubject is the subject of a dead letter replayed for a single consumer
+func replaySubject(consumer string, subject string) string {
+	return replaySubjectPrefix + consumer + "." + subject
+}
+
+// originalSubject returns the subject of the event, a message replayed for the consumer included
+func originalSubject(consumer string, subject string) string {
+	return strings.TrimPrefix(subject, replaySubjectPrefix+consumer+".")
+}
+
+func toDeadLetter(msg *jetstream.RawStreamMsg) DeadLetter {
+	subject := msg.Header.Get(HeaderDeadLetterSubject)
+	if subject == "" {
+		subject = strings.TrimPrefix(msg.Subject, deadLetterSubjectPrefix)
+	}
+	deliveries, _ := strconv.Atoi(msg.Header.Get(HeaderDeadLetterDeliveries))
+
+	return DeadLetter{
+		Sequence:   msg.Sequence,
+		Subject:    subject,
+		Consumer:   msg.Header.Get(HeaderDeadLetterConsumer),
+		Deliveries: deliveries,
+		Error:      msg.Header.Get(HeaderDeadLetterError),
+		Data:       string(msg.Data),
+		FailedAt:   msg.Time,
+	}
+}
+
+// shouldDeadLetter tells if a failed message must not be retried anymore
+func shouldDeadLetter(err error, deliveries int, maxDeliver int) bool {
+	return errors.Is(err, ErrPoisonMessage) || deliveries >= maxDeliver
+}
+
+// redeliveryDelay returns the backoff before the next delivery of a message delivered deliveries times
+func redeliveryDelay(backOff []time.Duration, deliveries int) time.Duration {
+	if len(backOff) == 0 {
+		return 0
+	}
+	if deliveries < 1 {
+		deliveries = 1
+	}
+	if deliveries > len(backOff) {
+		return backOff[len(backOff)-1]
+	}
+	return backOff[deliveries-1]
+}
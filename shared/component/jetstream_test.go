@@ -0,0 +1,64 @@
+package sharecomponent
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestJetStream_redeliveryDelay(t *testing.T) {
+	backOff := []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute}
+
+	tests := []struct {
+		deliveries int
+		want       time.Duration
+	}{
+		{deliveries: 1, want: 5 * time.Second},
+		{deliveries: 2, want: 30 * time.Second},
+		{deliveries: 3, want: 2 * time.Minute},
+		{deliveries: 10, want: 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := redeliveryDelay(backOff, tt.deliveries); got != tt.want {
+			t.Errorf("redeliveryDelay(%d) = %s, want %s", tt.deliveries, got, tt.want)
+		}
+	}
+
+	if got := redeliveryDelay(nil, 3); got != 0 {
+		t.Errorf("redeliveryDelay() without backoff = %s, want 0", got)
+	}
+}
+
+func TestJetStream_shouldDeadLetter(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		deliveries int
+		want       bool
+	}{
+		{name: "TC 1: failure is retried", err: errors.New("smtp: timeout"), deliveries: 1, want: false},
+		{name: "TC 2: last attempt is dead lettered", err: errors.New("smtp: timeout"), deliveries: 5, want: true},
+		{name: "TC 3: poison message is dead lettered at once", err: fmt.Errorf("%w: bad json", ErrPoisonMessage), deliveries: 1, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldDeadLetter(tt.err, tt.deliveries, 5); got != tt.want {
+				t.Errorf("shouldDeadLetter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJetStream_replaySubject(t *testing.T) {
+	subject := replaySubject("order-webhook", "order.created")
+	if subject != "replay.order-webhook.order.created" {
+		t.Fatalf("replaySubject() = %s", subject)
+	}
+	if got := originalSubject("order-webhook", subject); got != "order.created" {
+		t.Errorf("originalSubject() of the replay = %s, want order.created", got)
+	}
+	if got := originalSubject("order-webhook", "order.created"); got != "order.created" {
+		t.Errorf("originalSubject() = %s, want order.created", got)
+	}
+}
@@ -2,15 +2,20 @@ package sharecomponent
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
+var ErrTokenInvalid = errors.New("invalid token")
+
+// JwtComp signs access tokens with HS256 (shared secret), or with RS256/EdDSA
+// when signing keys are configured so other services can validate them with the JWKS.
 type JwtComp struct {
 	secretKey string
+	keySet    *JwtKeySet
 	expIn     int
 }
 
@@ -21,19 +26,68 @@ func NewJwtComp(secretKey string, expIn int) *JwtComp {
 	}
 }
 
-func (j *JwtComp) IssueToken(ctx context.Context, userId string) (string, error) {
-	now := time.Now().UTC()
-	claims := jwt.RegisteredClaims{
-		Subject:   userId,
-		ExpiresAt: jwt.NewNumericDate(now.Add(time.Second * time.Duration(j.expIn))),
-		NotBefore: jwt.NewNumericDate(now),
-		IssuedAt:  jwt.NewNumericDate(now),
-		ID:        userId,
+// NewJwtCompWithKeys uses asymmetric keys, the first key of the set signs new tokens
+func NewJwtCompWithKeys(keySet *JwtKeySet, expIn int) *JwtComp {
+	return &JwtComp{
+		keySet: keySet,
+		expIn:  expIn,
+	}
+}
+
+// AppClaims adds the user role so tokens can be authorized without calling the user service
+type AppClaims struct {
+	Role       string `json:"role,omitempty"`
+	IssuedAtMs int64  `json:"iat_ms,omitempty"` // iat keeps whole seconds, the revocations compare the milliseconds
+	jwt.RegisteredClaims
+}
+
+// TokenClaims are the claims of a validated access token
+type TokenClaims struct {
+	Subject   string
+	Role      string
+	ID        string // jti, unique per token
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+func NewTokenClaims(ac *AppClaims) *TokenClaims {
+	claims := &TokenClaims{Subject: ac.Subject, Role: ac.Role, ID: ac.ID}
+	if ac.IssuedAtMs > 0 {
+		claims.IssuedAt = time.UnixMilli(ac.IssuedAtMs).UTC()
+	} else if ac.IssuedAt != nil {
+		claims.IssuedAt = ac.IssuedAt.Time
+	}
+	if ac.ExpiresAt != nil {
+		claims.ExpiresAt = ac.ExpiresAt.Time
 	}
+	return claims
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+func (j *JwtComp) IssueToken(ctx context.Context, userId string, role string) (string, error) {
+	now := time.Now().UTC()
+	claims := AppClaims{
+		Role:       role,
+		IssuedAtMs: now.UnixMilli(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userId,
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Second * time.Duration(j.expIn))),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	}
 
-	tokenString, err := token.SignedString([]byte(j.secretKey))
+	var tokenString string
+	var err error
+	if j.keySet != nil {
+		key := j.keySet.Current()
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.Kid
+		tokenString, err = token.SignedString(key.PrivateKey)
+	} else {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		tokenString, err = token.SignedString([]byte(j.secretKey))
+	}
 	if err != nil {
 		return "", errors.WithStack(err)
 	}
@@ -45,20 +99,33 @@ func (j *JwtComp) ExpIn() int {
 	return j.expIn
 }
 
-func (j *JwtComp) Validate(tokenStr string) (string, error) {
-	var rc jwt.RegisteredClaims
+// JWKS returns the public keys, empty in HS256 mode
+func (j *JwtComp) JWKS() JWKSet {
+	if j.keySet == nil {
+		return JWKSet{Keys: []JWK{}}
+	}
+	return j.keySet.JWKS()
+}
 
-	token, err := jwt.ParseWithClaims(tokenStr, &rc, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secretKey), nil
-	})
+func (j *JwtComp) Validate(tokenStr string) (*TokenClaims, error) {
+	var ac AppClaims
 
+	var token *jwt.Token
+	var err error
+	if j.keySet != nil {
+		token, err = jwt.ParseWithClaims(tokenStr, &ac, j.keySet.Keyfunc, jwt.WithValidMethods(AsymmetricMethods))
+	} else {
+		token, err = jwt.ParseWithClaims(tokenStr, &ac, func(token *jwt.Token) (interface{}, error) {
+			return []byte(j.secretKey), nil
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	}
 	if err != nil {
-		log.Fatal(err)
+		return nil, errors.Wrap(ErrTokenInvalid, err.Error())
 	}
 
 	if !token.Valid {
-		return "", errors.New("invalid token")
+		return nil, ErrTokenInvalid
 	}
 
-	return rc.Subject, nil
+	return NewTokenClaims(&ac), nil
 }
@@ -0,0 +1,191 @@
+package sharecomponent
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// AsymmetricMethods are the algorithms accepted for tokens validated with a JWKS
+var AsymmetricMethods = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+
+var ErrJwtKeyNotFound = errors.New("jwt key not found")
+
+type JwtSigningKey struct {
+	Kid        string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.Signer
+}
+
+// JwtKeySet holds the signing keys. The first key signs, the others are kept
+// in the JWKS so tokens signed before a rotation stay valid until they expire.
+type JwtKeySet struct {
+	keys []JwtSigningKey
+}
+
+// LoadJwtKeySet reads "kid:/path/key.pem,..." PKCS#8 (or PKCS#1 RSA) private keys.
+// RSA keys sign with RS256 and Ed25519 keys with EdDSA.
+func LoadJwtKeySet(signingKeys string) (*JwtKeySet, error) {
+	set := &JwtKeySet{}
+
+	for _, item := range strings.Split(signingKeys, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid jwt signing key %q, expected kid:path", item)
+		}
+
+		data, err := os.ReadFile(parts[1])
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		key, err := parseSigningKey(parts[0], data)
+		if err != nil {
+			return nil, err
+		}
+		set.keys = append(set.keys, *key)
+	}
+
+	if len(set.keys) == 0 {
+		return nil, ErrJwtKeyNotFound
+	}
+	return set, nil
+}
+
+func NewJwtKeySet(keys ...JwtSigningKey) *JwtKeySet {
+	return &JwtKeySet{keys: keys}
+}
+
+func parseSigningKey(kid string, data []byte) (*JwtSigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("jwt key %s is not PEM encoded", kid)
+	}
+
+	var privateKey interface{}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		rsaKey, rsaErr := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if rsaErr != nil {
+			return nil, errors.Wrapf(err, "parse jwt key %s", kid)
+		}
+		privateKey = rsaKey
+	}
+
+	switch k := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return &JwtSigningKey{Kid: kid, Method: jwt.SigningMethodRS256, PrivateKey: k}, nil
+	case ed25519.PrivateKey:
+		return &JwtSigningKey{Kid: kid, Method: jwt.SigningMethodEdDSA, PrivateKey: k}, nil
+	default:
+		return nil, errors.Errorf("jwt key %s must be RSA or Ed25519", kid)
+	}
+}
+
+func (s *JwtKeySet) Current() JwtSigningKey {
+	return s.keys[0]
+}
+
+// Keyfunc finds the public key of a token by its kid header
+func (s *JwtKeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, k := range s.keys {
+		if k.Kid == kid {
+			return k.PrivateKey.Public(), nil
+		}
+	}
+	return nil, ErrJwtKeyNotFound
+}
+
+// JWKS returns the public keys (RFC 7517)
+func (s *JwtKeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, k := range s.keys {
+		set.Keys = append(set.Keys, NewJWK(k.Kid, k.PrivateKey.Public()))
+	}
+	return set
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+func NewJWK(kid string, publicKey crypto.PublicKey) JWK {
+	switch k := publicKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Kid: kid,
+			Use: "sig",
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(k),
+		}
+	default:
+		return JWK{Kid: kid}
+	}
+}
+
+// PublicKey converts the JWK back to a public key
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, errors.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.Errorf("unsupported key type %s", k.Kty)
+	}
+}
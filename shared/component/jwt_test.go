@@ -0,0 +1,84 @@
+package sharecomponent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJwtComp_AsymmetricKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  JwtSigningKey
+	}{
+		{name: "TC 1: RS256", key: JwtSigningKey{Kid: "rsa-1", Method: jwt.SigningMethodRS256, PrivateKey: rsaKey}},
+		{name: "TC 2: EdDSA", key: JwtSigningKey{Kid: "ed-1", Method: jwt.SigningMethodEdDSA, PrivateKey: edKey}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comp := NewJwtCompWithKeys(NewJwtKeySet(tt.key), 60)
+			token, err := comp.IssueToken(context.Background(), "019615db-9adb-7eff-ba03-45017274084c", "ADMIN")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			claims, err := comp.Validate(token)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if claims.Role != "ADMIN" || claims.ID == "" {
+				t.Errorf("Validate() claims = %+v", claims)
+			}
+
+			// The published JWK verifies the token
+			jwks := comp.JWKS()
+			if len(jwks.Keys) != 1 || jwks.Keys[0].Kid != tt.key.Kid {
+				t.Fatalf("JWKS() = %+v", jwks)
+			}
+			publicKey, err := jwks.Keys[0].PublicKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return publicKey, nil }); err != nil {
+				t.Errorf("token not verified by JWK: %v", err)
+			}
+		})
+	}
+}
+
+func TestJwtComp_KeyRotation(t *testing.T) {
+	_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+	oldSigning := JwtSigningKey{Kid: "old", Method: jwt.SigningMethodEdDSA, PrivateKey: oldKey}
+	newSigning := JwtSigningKey{Kid: "new", Method: jwt.SigningMethodEdDSA, PrivateKey: newKey}
+
+	oldToken, err := NewJwtCompWithKeys(NewJwtKeySet(oldSigning), 60).IssueToken(context.Background(), "user", "USER")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// After rotation the old key is still published, so old tokens stay valid
+	rotated := NewJwtCompWithKeys(NewJwtKeySet(newSigning, oldSigning), 60)
+	if _, err := rotated.Validate(oldToken); err != nil {
+		t.Errorf("old token rejected after rotation: %v", err)
+	}
+
+	// HS256 tokens are not accepted in asymmetric mode
+	hsToken, _ := NewJwtComp("secret", 60).IssueToken(context.Background(), "user", "USER")
+	if _, err := rotated.Validate(hsToken); err == nil {
+		t.Error("expected HS256 token to be rejected")
+	}
+}
@@ -0,0 +1,92 @@
+package sharecomponent
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+var (
+	ErrKeyNotFound = errors.New("encryption key not found")
+	ErrKeyInvalid  = errors.New("encryption key is invalid")
+)
+
+// IKeyProvider gives the keys used to encrypt data at rest.
+// A KMS backed provider can replace the static one without touching the callers.
+type IKeyProvider interface {
+	CurrentKey(ctx context.Context) (keyId string, key []byte, err error)
+	GetKey(ctx context.Context, keyId string) ([]byte, error)
+	// FingerprintKey is the HMAC key of the fingerprints. It is never rotated, so that a card keeps its fingerprint.
+	FingerprintKey(ctx context.Context) ([]byte, error)
+}
+
+// StaticKeyProvider reads AES-256 keys from the config
+type StaticKeyProvider struct {
+	currentId      string
+	keys           map[string][]byte
+	fingerprintKey []byte
+}
+
+// NewStaticKeyProvider parses the keys of the config. A malformed key is an error rather than skipped,
+// otherwise the cards would be encrypted with another key than the configured one.
+// Empty keys leave the vault unconfigured, it refuses to tokenize then.
+func NewStaticKeyProvider(cfg datatype.VaultConfig) (*StaticKeyProvider, error) {
+	p := &StaticKeyProvider{keys: make(map[string][]byte)}
+
+	if cfg.FingerprintKey != "" {
+		key, err := base64.StdEncoding.DecodeString(cfg.FingerprintKey)
+		if err != nil || len(key) < 32 {
+			return nil, fmt.Errorf("%w: fingerprint key must be a base64 key of at least 32 bytes", ErrKeyInvalid)
+		}
+		p.fingerprintKey = key
+	}
+
+	if strings.TrimSpace(cfg.Keys) == "" {
+		return p, nil
+	}
+	for i, item := range strings.Split(cfg.Keys, ",") {
+		parts := strings.SplitN(strings.TrimSpace(item), ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%w: key %d must be keyId:base64Key", ErrKeyInvalid, i+1)
+		}
+		if _, ok := p.keys[parts[0]]; ok {
+			return nil, fmt.Errorf("%w: key id %s is duplicated", ErrKeyInvalid, parts[0])
+		}
+		key, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("%w: key %s must be a base64 AES-256 key", ErrKeyInvalid, parts[0])
+		}
+		if p.currentId == "" {
+			p.currentId = parts[0]
+		}
+		p.keys[parts[0]] = key
+	}
+
+	return p, nil
+}
+
+func (p *StaticKeyProvider) CurrentKey(ctx context.Context) (string, []byte, error) {
+	if p.currentId == "" {
+		return "", nil, ErrKeyNotFound
+	}
+	return p.currentId, p.keys[p.currentId], nil
+}
+
+func (p *StaticKeyProvider) FingerprintKey(ctx context.Context) ([]byte, error) {
+	if p.fingerprintKey == nil {
+		return nil, ErrKeyNotFound
+	}
+	return p.fingerprintKey, nil
+}
+
+func (p *StaticKeyProvider) GetKey(ctx context.Context, keyId string) ([]byte, error) {
+	key, ok := p.keys[keyId]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
@@ -0,0 +1,46 @@
+package sharecomponent
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+func TestNewStaticKeyProvider(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	shortKey := base64.StdEncoding.EncodeToString([]byte("short"))
+
+	tests := []struct {
+		name    string
+		cfg     datatype.VaultConfig
+		wantErr error
+		wantId  string
+	}{
+		{name: "TC 1: the first key encrypts", cfg: datatype.VaultConfig{Keys: "k2:" + key + ",k1:" + key, FingerprintKey: key}, wantId: "k2"},
+		{name: "TC 2: vault not configured", cfg: datatype.VaultConfig{}},
+		{name: "TC 3: key without id", cfg: datatype.VaultConfig{Keys: key}, wantErr: ErrKeyInvalid},
+		{name: "TC 4: key of the wrong size", cfg: datatype.VaultConfig{Keys: "k1:" + shortKey}, wantErr: ErrKeyInvalid},
+		{name: "TC 5: key which is not base64", cfg: datatype.VaultConfig{Keys: "k1:not base64!"}, wantErr: ErrKeyInvalid},
+		{name: "TC 6: duplicated key id", cfg: datatype.VaultConfig{Keys: "k1:" + key + ",k1:" + key}, wantErr: ErrKeyInvalid},
+		{name: "TC 7: fingerprint key too short", cfg: datatype.VaultConfig{Keys: "k1:" + key, FingerprintKey: shortKey}, wantErr: ErrKeyInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewStaticKeyProvider(tt.cfg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewStaticKeyProvider() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil || tt.wantId == "" {
+				return
+			}
+			if id, _, err := p.CurrentKey(context.Background()); err != nil || id != tt.wantId {
+				t.Errorf("CurrentKey() = %s, %v, want %s", id, err, tt.wantId)
+			}
+		})
+	}
+}
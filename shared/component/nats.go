@@ -2,18 +2,18 @@ package sharecomponent
 
 import (
 	"context"
-	"encoding/json"
 	"log"
+	"time"
 
 	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 	"go.opentelemetry.io/otel"
 
 	"github.com/nats-io/nats.go"
-	"github.com/pkg/errors"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
 type natsComp struct {
-	nc *nats.Conn
+	js *jetStreamComp
 }
 
 func NewNatsComp() *natsComp {
@@ -22,18 +22,31 @@ func NewNatsComp() *natsComp {
 		log.Fatal(err)
 	}
 
-	return &natsComp{nc: nc}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Events must be persisted for the durable consumers, a plain NATS publish would lose them
+	js, err := NewJetStreamComp(nc)
+	if err == nil {
+		err = js.EnsureStreams(ctx)
+	}
+	if err != nil {
+		log.Fatalf("JetStream is not available, run nats-server with -js: %v", err)
+	}
+
+	return &natsComp{js: js}
 }
 
 func (c *natsComp) Publish(ctx context.Context, topic string, evt *datatype.AppEvent) error {
 	_, dbSpanPlbNoti := otel.Tracer("").Start(ctx, "publish-msg")
 	defer dbSpanPlbNoti.End()
 
-	dataByte, err := json.Marshal(evt.Data)
+	dataByte, err := datatype.EncodeEvt(ctx, evt)
 
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
 
-	return c.nc.Publish(topic, dataByte)
+	// The stream drops an event published twice, e.g. by the outbox relay retrying
+	return c.js.Publish(ctx, topic, dataByte, jetstream.WithMsgID(evt.ID))
 }
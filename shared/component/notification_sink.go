@@ -0,0 +1,89 @@
+package sharecomponent
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+// notificationSink writes the notifications of the fake providers to a JSON lines file,
+// so that they can be checked locally without an SMS or push provider account
+type notificationSink struct {
+	mu   sync.Mutex
+	path string // Empty to only log
+}
+
+func newNotificationSink(dir string, filename string) *notificationSink {
+	if dir == "" {
+		return &notificationSink{}
+	}
+	return &notificationSink{path: filepath.Join(dir, filename)}
+}
+
+func (s *notificationSink) write(channel string, msg interface{}) error {
+	line, err := json.Marshal(map[string]interface{}{
+		"channel": channel,
+		"sentAt":  time.Now().UTC(),
+		"message": msg,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s notification: %w", channel, err)
+	}
+	log.Printf("Fake %s provider: %s", channel, line)
+
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s sink: %w", channel, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s sink: %w", channel, err)
+	}
+	return nil
+}
+
+// FakeSmsProvider records the SMS instead of sending them
+type FakeSmsProvider struct {
+	sink *notificationSink
+}
+
+func NewFakeSmsProvider(sinkDir string) *FakeSmsProvider {
+	return &FakeSmsProvider{sink: newNotificationSink(sinkDir, "sms.jsonl")}
+}
+
+func (p *FakeSmsProvider) SendSms(message sharedmodel.SmsMessage) error {
+	if message.To == "" {
+		return fmt.Errorf("failed to send sms: recipient is required")
+	}
+	return p.sink.write("sms", message)
+}
+
+// FakePushProvider records the push notifications instead of sending them to FCM/APNs
+type FakePushProvider struct {
+	sink *notificationSink
+}
+
+func NewFakePushProvider(sinkDir string) *FakePushProvider {
+	return &FakePushProvider{sink: newNotificationSink(sinkDir, "push.jsonl")}
+}
+
+func (p *FakePushProvider) SendPush(message sharedmodel.PushMessage) error {
+	if len(message.Tokens) == 0 {
+		return fmt.Errorf("failed to send push: device token is required")
+	}
+	return p.sink.write("push", message)
+}
@@ -0,0 +1,34 @@
+package sharecomponent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+)
+
+func TestFakeProviders_WriteSink(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := NewFakeSmsProvider(dir).SendSms(sharedmodel.SmsMessage{To: "+84901234567", Body: "Order 1 delivered."}); err != nil {
+		t.Fatalf("SendSms() error = %v", err)
+	}
+	if err := NewFakePushProvider(dir).SendPush(sharedmodel.PushMessage{Tokens: []string{"token-1"}, Title: "Order 1", Body: "Delivered."}); err != nil {
+		t.Fatalf("SendPush() error = %v", err)
+	}
+	if err := NewFakePushProvider(dir).SendPush(sharedmodel.PushMessage{Title: "Order 1"}); err == nil {
+		t.Error("SendPush() without token error = nil, want an error")
+	}
+
+	for filename, want := range map[string]string{"sms.jsonl": "+84901234567", "push.jsonl": "token-1"} {
+		content, err := os.ReadFile(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", filename, err)
+		}
+		if lines := strings.Count(string(content), "\n"); lines != 1 || !strings.Contains(string(content), want) {
+			t.Errorf("%s = %s, want 1 line with %s", filename, content, want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -50,3 +52,84 @@ func (a *RedisAdapter) Get(ctx context.Context, key string, dest interface{}) er
 func (a *RedisAdapter) Delete(ctx context.Context, key string) error {
 	return a.client.Del(key).Err()
 }
+
+// Incr increases a counter and (re)sets its expiration, returns the new value
+func (a *RedisAdapter) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	pipe := a.client.TxPipeline()
+	incr := pipe.Incr(key)
+	pipe.Expire(key, expiration)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+// PushCapped prepends a value to a list, keeps its first size items and (re)sets its expiration
+func (a *RedisAdapter) PushCapped(ctx context.Context, key string, value interface{}, size int64, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	pipe := a.client.TxPipeline()
+	pipe.LPush(key, data)
+	pipe.LTrim(key, 0, size-1)
+	pipe.Expire(key, expiration)
+	_, err = pipe.Exec()
+	return err
+}
+
+// GetList decodes the first limit items of a list into dest, a pointer to a slice
+func (a *RedisAdapter) GetList(ctx context.Context, key string, limit int64, dest interface{}) error {
+	items, err := a.client.LRange(key, 0, limit-1).Result()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte("["+strings.Join(items, ",")+"]"), dest)
+}
+
+// GetMany decodes the values of the keys into dest, a pointer to a slice. Missing keys are skipped.
+func (a *RedisAdapter) GetMany(ctx context.Context, keys []string, dest interface{}) error {
+	if len(keys) == 0 {
+		return json.Unmarshal([]byte("[]"), dest)
+	}
+
+	values, err := a.client.MGet(keys...).Result()
+	if err != nil {
+		return err
+	}
+
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			items = append(items, s)
+		}
+	}
+	return json.Unmarshal([]byte("["+strings.Join(items, ",")+"]"), dest)
+}
+
+// SetScore adds the member to a sorted set or updates its score
+func (a *RedisAdapter) SetScore(ctx context.Context, key string, member string, score float64) error {
+	return a.client.ZAdd(key, redis.Z{Score: score, Member: member}).Err()
+}
+
+// RemoveMember removes the member from a sorted set
+func (a *RedisAdapter) RemoveMember(ctx context.Context, key string, member string) error {
+	return a.client.ZRem(key, member).Err()
+}
+
+// MembersFrom removes the members of a sorted set scored below min and returns the others
+func (a *RedisAdapter) MembersFrom(ctx context.Context, key string, min float64) ([]string, error) {
+	bound := strconv.FormatFloat(min, 'f', -1, 64)
+
+	pipe := a.client.TxPipeline()
+	pipe.ZRemRangeByScore(key, "-inf", "("+bound)
+	members := pipe.ZRangeByScore(key, redis.ZRangeBy{Min: bound, Max: "+inf"})
+	if _, err := pipe.Exec(); err != nil {
+		return nil, err
+	}
+
+	return members.Val(), nil
+}
@@ -357,5 +357,11 @@ var ErrConflict = DefaultError{
 	CodeField:   http.StatusConflict,
 }
 
+var ErrTooManyRequests = DefaultError{
+	StatusField: http.StatusText(http.StatusTooManyRequests),
+	ErrorField:  "Too many requests, please try again later",
+	CodeField:   http.StatusTooManyRequests,
+}
+
 // ErrRecordNotFound is used to make our application logic independent of other libraries errors
 var ErrRecordNotFound = errors.New("record not found")
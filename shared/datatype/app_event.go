@@ -1,26 +1,81 @@
 package datatype
 
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// DefaultEvtProducer is the producer of the events published by this service
+const DefaultEvtProducer = "food-delivery-backend-service"
+
 type AppEvent struct {
-	Topic string
-	Data  interface{}
+	ID           string
+	Topic        string
+	Version      int
+	OccurredAt   time.Time
+	Producer     string
+	TraceContext map[string]string // W3C trace context of the producer
+	Data         interface{}
 }
 
 type AppEventOpt func(*AppEvent)
 
+func WithID(id string) AppEventOpt {
+	return func(evt *AppEvent) {
+		evt.ID = id
+	}
+}
+
 func WithTopic(topic string) AppEventOpt {
 	return func(evt *AppEvent) {
 		evt.Topic = topic
 	}
 }
 
+// WithData sets the payload, the topic and version are taken from a catalogue payload
 func WithData(data interface{}) AppEventOpt {
 	return func(evt *AppEvent) {
 		evt.Data = data
+		if payload, ok := data.(EvtPayload); ok {
+			evt.Topic = payload.EvtTopic()
+			evt.Version = payload.EvtVersion()
+		}
+	}
+}
+
+func WithOccurredAt(occurredAt time.Time) AppEventOpt {
+	return func(evt *AppEvent) {
+		evt.OccurredAt = occurredAt.UTC()
+	}
+}
+
+func WithProducer(producer string) AppEventOpt {
+	return func(evt *AppEvent) {
+		evt.Producer = producer
+	}
+}
+
+// WithTraceContext links the event to the span of ctx
+func WithTraceContext(ctx context.Context) AppEventOpt {
+	return func(evt *AppEvent) {
+		carrier := propagation.MapCarrier{}
+		propagation.TraceContext{}.Inject(ctx, carrier)
+		if len(carrier) > 0 {
+			evt.TraceContext = carrier
+		}
 	}
 }
 
 func NewAppEvent(opts ...AppEventOpt) *AppEvent {
-	evt := &AppEvent{}
+	evt := &AppEvent{
+		ID:         uuid.New().String(),
+		Version:    1,
+		OccurredAt: time.Now().UTC(),
+		Producer:   DefaultEvtProducer,
+	}
 
 	for _, opt := range opts {
 		opt(evt)
@@ -28,3 +83,12 @@ func NewAppEvent(opts ...AppEventOpt) *AppEvent {
 
 	return evt
 }
+
+// ContextWithTrace returns ctx carrying the trace context of the producer, so that the
+// spans of a consumer are children of the span which published the event
+func (evt *AppEvent) ContextWithTrace(ctx context.Context) context.Context {
+	if len(evt.TraceContext) == 0 {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier(evt.TraceContext))
+}
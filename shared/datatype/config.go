@@ -3,22 +3,44 @@ package datatype
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	EmailConfig   EmailConfig
-	RedisConfig   RedisConfig
-	GoogleConfig  GoogleConfig
-	Minio         MinIoConfig // Same as Amazon S3
-	ElasticSearch ElasticSearchConfig
-	NatsURL       string
+	EmailConfig        EmailConfig
+	NotificationConfig NotificationConfig
+	RedisConfig        RedisConfig
+	GoogleConfig       GoogleConfig
+	Minio              MinIoConfig // Same as Amazon S3
+	ElasticSearch      ElasticSearchConfig
+	NatsURL            string
+	MsgBroker          string // MsgBrokerNats or MsgBrokerMemory
+	PaymentConfig      PaymentConfig
+	VaultConfig        VaultConfig
+	AuthConfig         AuthConfig
+	OutboxConfig       OutboxConfig
+	EmailOutboxConfig  EmailOutboxConfig
+	WebhookConfig      WebhookConfig
+	DispatchConfig     DispatchConfig
+	ShipperConfig      ShipperConfig
+
+	OrderStateMachineFile string        // JSON transition table of the order lifecycle, the embedded one when empty
+	OrderTrackInterval    time.Duration // how often the tracking stream of an order is refreshed
+
+	TrustedProxies []string // proxies whose X-Forwarded-For is believed for the client ip, none when empty
 
 	// URL for RPC
-	UserServiceURL       string
-	FoodServiceURL       string
-	RestaurantServiceURL string
-	CartServiceURL       string
-	PaymentServiceURL    string
+	UserServiceURL         string
+	FoodServiceURL         string
+	RestaurantServiceURL   string
+	CartServiceURL         string
+	PaymentServiceURL      string
+	NotificationServiceURL string
+	WebhookServiceURL      string
+	OrderServiceURL        string
+	DispatchServiceURL     string
+	ShipperServiceURL      string
 
 	GrpcCatServiceURL  string
 	GrpcFoodServiceURL string
@@ -36,10 +58,18 @@ func NewConfig() *Config {
 	if config == nil {
 		config = &Config{
 			EmailConfig: EmailConfig{
-				SMTPHost:     os.Getenv("SMTP_HOST"),
-				SMTPPort:     smtpPort,
-				SMTPUsername: os.Getenv("SMTP_USERNAME"),
-				SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+				SMTPHost:      os.Getenv("SMTP_HOST"),
+				SMTPPort:      smtpPort,
+				SMTPUsername:  os.Getenv("SMTP_USERNAME"),
+				SMTPPassword:  os.Getenv("SMTP_PASSWORD"),
+				From:          envString("EMAIL_FROM", os.Getenv("SMTP_USERNAME")),
+				DefaultLocale: envString("EMAIL_DEFAULT_LOCALE", LocaleEn),
+				Transport:     envString("EMAIL_TRANSPORT", EmailTransportSmtp),
+				SinkDir:       envString("EMAIL_SINK_DIR", "./tmp/emails"),
+				AttachmentDir: os.Getenv("EMAIL_ATTACHMENT_DIR"),
+			},
+			NotificationConfig: NotificationConfig{
+				SinkDir: os.Getenv("NOTIFICATION_SINK_DIR"),
 			},
 			RedisConfig: RedisConfig{
 				Host:     os.Getenv("REDIS_ADDR"),
@@ -66,14 +96,77 @@ func NewConfig() *Config {
 				APIKey:    os.Getenv("ES_API_KEY"),
 				IndexName: os.Getenv("ES_INDEX_NAME"),
 			},
-			NatsURL:              os.Getenv("NATS_URL"),
-			UserServiceURL:       os.Getenv("USER_SERVICE_URL"),
-			FoodServiceURL:       os.Getenv("FOOD_SERVICE_URL"),
-			RestaurantServiceURL: os.Getenv("RESTAURANT_SERVICE_URL"),
-			CartServiceURL:       os.Getenv("CART_SERVICE_URL"),
-			PaymentServiceURL:    os.Getenv("PAYMENT_SERVICE_URL"),
-			GrpcCatServiceURL:    os.Getenv("GRPC_CAT_SERVICE_URL"),
-			GrpcFoodServiceURL:   os.Getenv("GRPC_FOOD_SERVICE_URL"),
+			PaymentConfig: PaymentConfig{
+				GatewayMode:      os.Getenv("PAYMENT_GATEWAY_MODE"),
+				FakeDeclineCards: splitEnvList(os.Getenv("PAYMENT_FAKE_DECLINE_CARDS")),
+				FakeTimeoutCards: splitEnvList(os.Getenv("PAYMENT_FAKE_TIMEOUT_CARDS")),
+				FakeTimeout:      envSeconds("PAYMENT_FAKE_TIMEOUT_SECONDS", 5),
+			},
+			VaultConfig: VaultConfig{
+				Keys:           os.Getenv("CARD_VAULT_KEYS"),
+				FingerprintKey: os.Getenv("CARD_FINGERPRINT_KEY"),
+			},
+			AuthConfig: AuthConfig{
+				SigningKeys:         os.Getenv("JWT_SIGNING_KEYS"),
+				ValidationMode:      os.Getenv("AUTH_VALIDATION_MODE"),
+				JwksURL:             os.Getenv("JWKS_URL"),
+				JwksRefreshInterval: envSeconds("JWKS_REFRESH_SECONDS", 300),
+				IntrospectCacheTTL:  envSeconds("INTROSPECT_CACHE_SECONDS", 0),
+				InternalToken:       os.Getenv("INTERNAL_RPC_TOKEN"),
+			},
+			OutboxConfig: OutboxConfig{
+				PollInterval: envSeconds("OUTBOX_POLL_SECONDS", 1),
+				BatchSize:    envInt("OUTBOX_BATCH_SIZE", 100),
+				MaxAttempts:  envInt("OUTBOX_MAX_ATTEMPTS", 10),
+				MaxBackoff:   envSeconds("OUTBOX_MAX_BACKOFF_SECONDS", 300),
+			},
+			EmailOutboxConfig: EmailOutboxConfig{
+				PollInterval: envSeconds("EMAIL_OUTBOX_POLL_SECONDS", 5),
+				BatchSize:    envInt("EMAIL_OUTBOX_BATCH_SIZE", 20),
+				MaxAttempts:  envInt("EMAIL_OUTBOX_MAX_ATTEMPTS", 8),
+				MaxBackoff:   envSeconds("EMAIL_OUTBOX_MAX_BACKOFF_SECONDS", 1800),
+				InProcess:    envBool("EMAIL_OUTBOX_IN_PROCESS", true),
+			},
+			WebhookConfig: WebhookConfig{
+				PollInterval:      envSeconds("WEBHOOK_POLL_SECONDS", 5),
+				BatchSize:         envInt("WEBHOOK_BATCH_SIZE", 20),
+				MaxAttempts:       envInt("WEBHOOK_MAX_ATTEMPTS", 10),
+				MaxBackoff:        envSeconds("WEBHOOK_MAX_BACKOFF_SECONDS", 3600),
+				Timeout:           envSeconds("WEBHOOK_TIMEOUT_SECONDS", 10),
+				InProcess:         envBool("WEBHOOK_IN_PROCESS", true),
+				AllowInsecureUrls: envBool("WEBHOOK_ALLOW_INSECURE_URLS", os.Getenv("ENV") == "dev"),
+			},
+			DispatchConfig: DispatchConfig{
+				PollInterval:   envSeconds("DISPATCH_POLL_SECONDS", 5),
+				BatchSize:      envInt("DISPATCH_BATCH_SIZE", 20),
+				OfferTimeout:   envSeconds("DISPATCH_OFFER_TIMEOUT_SECONDS", 45),
+				SearchRadiusKm: float64(envInt("DISPATCH_SEARCH_RADIUS_KM", 10)),
+				LocationTTL:    envSeconds("DISPATCH_LOCATION_TTL_SECONDS", 300),
+				RetryInterval:  envSeconds("DISPATCH_RETRY_SECONDS", 30),
+				MaxSearches:    envInt("DISPATCH_MAX_SEARCHES", 20),
+				HistorySize:    envInt("DISPATCH_LOCATION_HISTORY_SIZE", 20),
+			},
+			ShipperConfig: ShipperConfig{
+				ShiftTimezone:    envString("SHIPPER_SHIFT_TIMEZONE", "Asia/Ho_Chi_Minh"),
+				DefaultMaxOrders: envInt("SHIPPER_DEFAULT_MAX_ORDERS", 1),
+			},
+			OrderStateMachineFile:  os.Getenv("ORDER_STATE_MACHINE_FILE"),
+			OrderTrackInterval:     envSeconds("ORDER_TRACK_INTERVAL_SECONDS", 3),
+			TrustedProxies:         splitEnvList(os.Getenv("TRUSTED_PROXIES")),
+			NatsURL:                os.Getenv("NATS_URL"),
+			MsgBroker:              envString("MSG_BROKER", MsgBrokerNats),
+			UserServiceURL:         os.Getenv("USER_SERVICE_URL"),
+			FoodServiceURL:         os.Getenv("FOOD_SERVICE_URL"),
+			RestaurantServiceURL:   os.Getenv("RESTAURANT_SERVICE_URL"),
+			CartServiceURL:         os.Getenv("CART_SERVICE_URL"),
+			PaymentServiceURL:      os.Getenv("PAYMENT_SERVICE_URL"),
+			NotificationServiceURL: os.Getenv("NOTIFICATION_SERVICE_URL"),
+			WebhookServiceURL:      os.Getenv("WEBHOOK_SERVICE_URL"),
+			OrderServiceURL:        os.Getenv("ORDER_SERVICE_URL"),
+			DispatchServiceURL:     os.Getenv("DISPATCH_SERVICE_URL"),
+			ShipperServiceURL:      os.Getenv("SHIPPER_SERVICE_URL"),
+			GrpcCatServiceURL:      os.Getenv("GRPC_CAT_SERVICE_URL"),
+			GrpcFoodServiceURL:     os.Getenv("GRPC_FOOD_SERVICE_URL"),
 		}
 	}
 	return config
@@ -84,10 +177,21 @@ func GetConfig() *Config {
 }
 
 type EmailConfig struct {
-	SMTPHost     string
-	SMTPPort     int
-	SMTPUsername string
-	SMTPPassword string
+	SMTPHost      string
+	SMTPPort      int
+	SMTPUsername  string
+	SMTPPassword  string
+	From          string // Sender of the emails, the SMTP username by default
+	DefaultLocale string // Locale of the email templates for users without one
+	Transport     string // EmailTransportSmtp or EmailTransportEml
+	SinkDir       string // Directory of the .eml files of EmailTransportEml
+	AttachmentDir string // Only files of this directory are attached, attachments are refused when empty
+}
+
+// NotificationConfig configures the SMS and push channels. Only the fake providers exist for now,
+// they write the notifications to SinkDir (sms.jsonl, push.jsonl) or to the log when it is empty.
+type NotificationConfig struct {
+	SinkDir string
 }
 
 type RedisConfig struct {
@@ -117,3 +221,118 @@ type ElasticSearchConfig struct {
 	APIKey    string
 	IndexName string
 }
+
+type PaymentConfig struct {
+	GatewayMode      string        // "fake" (default) uses the in-process fake gateway for every provider
+	FakeDeclineCards []string      // card numbers (or last digits) declined by the fake gateway
+	FakeTimeoutCards []string      // card numbers (or last digits) that time out on the fake gateway
+	FakeTimeout      time.Duration // how long the fake gateway blocks on a timeout card
+}
+
+type VaultConfig struct {
+	Keys           string // "keyId:base64Key,..." the first key encrypts, the others can still decrypt
+	FingerprintKey string // base64 HMAC key of at least 32 bytes for the card fingerprints, never rotated
+}
+
+const (
+	AuthValidationIntrospect = "introspect"
+	AuthValidationLocal      = "local"
+)
+
+type AuthConfig struct {
+	SigningKeys         string        // "kid:/path/key.pem,..." RSA or Ed25519 keys, the first one signs. Empty signs HS256 with JWT_SECRET_KEY
+	ValidationMode      string        // "introspect" (default) asks the user service, "local" validates with the JWKS and skips revocation checks
+	JwksURL             string        // JWKS published by the user service, required in local mode
+	JwksRefreshInterval time.Duration // how long the JWKS is cached
+	IntrospectCacheTTL  time.Duration // cache of introspection results, 0 disables it
+	InternalToken       string        // shared secret of the RPC calls between the services, every call is refused when empty
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration // how often the relay looks for pending events
+	BatchSize    int           // events published per poll
+	MaxAttempts  int           // an event is marked failed after this many publish attempts
+	MaxBackoff   time.Duration // upper bound of the exponential backoff between attempts
+}
+
+// EmailOutboxConfig configures the dispatcher sending the queued emails
+type EmailOutboxConfig struct {
+	PollInterval time.Duration // how often the dispatcher looks for due emails
+	BatchSize    int           // emails sent per poll
+	MaxAttempts  int           // an email is marked failed after this many send attempts
+	MaxBackoff   time.Duration // upper bound of the exponential backoff between attempts
+	InProcess    bool          // run the dispatcher in the app process, otherwise with the email-dispatcher command
+}
+
+// WebhookConfig configures the dispatcher delivering the webhooks of the restaurant partners
+type WebhookConfig struct {
+	PollInterval      time.Duration // how often the dispatcher looks for due deliveries
+	BatchSize         int           // deliveries sent per poll
+	MaxAttempts       int           // a delivery is marked failed after this many attempts
+	MaxBackoff        time.Duration // upper bound of the exponential backoff between attempts
+	Timeout           time.Duration // how long a partner endpoint has to respond
+	InProcess         bool          // run the dispatcher in the app process, otherwise with the webhook-dispatcher command
+	AllowInsecureUrls bool          // accept http endpoints and endpoints in the private network, for development
+}
+
+// DispatchConfig configures the engine offering the orders waiting for a shipper to the nearby shippers
+type DispatchConfig struct {
+	PollInterval   time.Duration // how often expired offers and pending searches are handled
+	BatchSize      int           // offers or searches handled per poll
+	OfferTimeout   time.Duration // how long a shipper has to accept an offer before it goes to the next one
+	SearchRadiusKm float64       // shippers farther from the restaurant are not offered the order
+	LocationTTL    time.Duration // a shipper whose last location is older is considered offline
+	RetryInterval  time.Duration // delay before searching again when no shipper is available
+	MaxSearches    int           // a dispatch fails after this many searches without any shipper
+	HistorySize    int           // last locations kept per shipper, the trail shown to the customers
+}
+
+// ShipperConfig configures the shipper profiles
+type ShipperConfig struct {
+	ShiftTimezone    string // time zone of the shift schedules
+	DefaultMaxOrders int    // concurrent orders of a new shipper, until an admin changes it
+}
+
+// envSeconds reads a number of seconds from env, or returns the default
+func envSeconds(key string, defaultSeconds int) time.Duration {
+	return time.Duration(envInt(key, defaultSeconds)) * time.Second
+}
+
+// envInt reads a number from env, or returns the default
+func envInt(key string, defaultValue int) int {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// envBool reads a boolean from env, or returns the default
+func envBool(key string, defaultValue bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// envString reads a value from env, or returns the default
+func envString(key string, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+// splitEnvList splits a comma separated env value, ignoring empty items
+func splitEnvList(v string) []string {
+	var result []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -37,6 +37,9 @@ const (
 	KeyRequester = "requester"
 )
 
+// HeaderInternalToken carries INTERNAL_RPC_TOKEN on the calls between the services
+const HeaderInternalToken = "X-Internal-Token"
+
 type CartStatus string
 
 const (
@@ -46,6 +49,18 @@ const (
 	CartStatusProcessed CartStatus = "PROCESSED" // Auto updated by Backend. All items go to Order
 )
 
+// Message broker selected by MSG_BROKER
+const (
+	MsgBrokerNats   = "nats"
+	MsgBrokerMemory = "memory" // In-process, for tests and single-process mode
+)
+
+// Email transport selected by EMAIL_TRANSPORT
+const (
+	EmailTransportSmtp = "smtp"
+	EmailTransportEml  = "eml" // .eml files written to EMAIL_SINK_DIR, for local development and tests
+)
+
 const (
 	EvtNotifyOrderCreate         = "order-create"
 	EvtNotifyOrderStateChange    = "order-state-change"
@@ -53,3 +68,11 @@ const (
 	EvtNotifyShipperAssign       = "order-shipper-assign"
 	EvtNotifyPaymentStatusChange = "order-payment-status-change"
 )
+
+// Locales of the emails, a user without a locale gets the configured default
+const (
+	LocaleEn = "en"
+	LocaleVi = "vi"
+)
+
+var SupportedLocales = []string{LocaleEn, LocaleVi}
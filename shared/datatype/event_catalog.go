@@ -0,0 +1,77 @@
+package datatype
+
+import "sort"
+
+// EvtPayload is the data of an event of the catalogue.
+// Bump EvtVersion when a field is renamed, removed or changes meaning.
+type EvtPayload interface {
+	EvtTopic() string
+	EvtVersion() int
+}
+
+// OrderCreatedEvt is published when an order is placed
+type OrderCreatedEvt struct {
+	OrderID      string `json:"orderId"`
+	UserID       string `json:"userId"`
+	RestaurantID string `json:"restaurantId"`
+}
+
+func (OrderCreatedEvt) EvtTopic() string { return EvtNotifyOrderCreate }
+func (OrderCreatedEvt) EvtVersion() int  { return 1 }
+
+// OrderStateChangedEvt is published when an order moves to another state
+type OrderStateChangedEvt struct {
+	OrderID      string `json:"orderId"`
+	RestaurantID string `json:"restaurantId,omitempty"`
+	OldState     string `json:"oldState"`
+	NewState     string `json:"newState"`
+}
+
+func (OrderStateChangedEvt) EvtTopic() string { return EvtNotifyOrderStateChange }
+func (OrderStateChangedEvt) EvtVersion() int  { return 1 }
+
+// OrderCancelledEvt is published when an order is cancelled
+type OrderCancelledEvt struct {
+	OrderID      string `json:"orderId"`
+	CancelReason string `json:"cancelReason"`
+}
+
+func (OrderCancelledEvt) EvtTopic() string { return EvtNotifyOrderCancel }
+func (OrderCancelledEvt) EvtVersion() int  { return 1 }
+
+// ShipperAssignedEvt is published when a shipper is assigned to an order
+type ShipperAssignedEvt struct {
+	OrderID   string `json:"orderId"`
+	ShipperID string `json:"shipperId"`
+}
+
+func (ShipperAssignedEvt) EvtTopic() string { return EvtNotifyShipperAssign }
+func (ShipperAssignedEvt) EvtVersion() int  { return 1 }
+
+// PaymentStatusChangedEvt is published when the payment status of an order changes
+type PaymentStatusChangedEvt struct {
+	OrderID       string `json:"orderId"`
+	PaymentStatus string `json:"paymentStatus"`
+}
+
+func (PaymentStatusChangedEvt) EvtTopic() string { return EvtNotifyPaymentStatusChange }
+func (PaymentStatusChangedEvt) EvtVersion() int  { return 1 }
+
+// evtCatalog returns an empty payload of each topic to decode into
+var evtCatalog = map[string]func() EvtPayload{
+	EvtNotifyOrderCreate:         func() EvtPayload { return &OrderCreatedEvt{} },
+	EvtNotifyOrderStateChange:    func() EvtPayload { return &OrderStateChangedEvt{} },
+	EvtNotifyOrderCancel:         func() EvtPayload { return &OrderCancelledEvt{} },
+	EvtNotifyShipperAssign:       func() EvtPayload { return &ShipperAssignedEvt{} },
+	EvtNotifyPaymentStatusChange: func() EvtPayload { return &PaymentStatusChangedEvt{} },
+}
+
+// EvtTopics returns the topics of the catalogue
+func EvtTopics() []string {
+	topics := make([]string, 0, len(evtCatalog))
+	for topic := range evtCatalog {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
@@ -0,0 +1,96 @@
+package datatype
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidEvt            = errors.New("invalid event")
+	ErrUnknownEvt            = errors.New("unknown event type")
+	ErrUnsupportedEvtVersion = errors.New("unsupported event version")
+)
+
+// EvtEnvelope is the wire format of every event published on the message broker
+type EvtEnvelope struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	Version      int               `json:"version"`
+	OccurredAt   time.Time         `json:"occurredAt"`
+	Producer     string            `json:"producer"`
+	TraceContext map[string]string `json:"traceContext,omitempty"`
+	Data         json.RawMessage   `json:"data"`
+}
+
+// EncodeEvt wraps the event in its envelope. The trace context of ctx is used
+// when the event does not carry one yet.
+func EncodeEvt(ctx context.Context, evt *AppEvent) ([]byte, error) {
+	if evt.ID == "" || evt.Topic == "" {
+		return nil, errors.Wrap(ErrInvalidEvt, "id and topic are required")
+	}
+
+	data, ok := evt.Data.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(evt.Data); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	traceContext := evt.TraceContext
+	if len(traceContext) == 0 {
+		traceContext = NewAppEvent(WithTraceContext(ctx)).TraceContext
+	}
+
+	msg, err := json.Marshal(EvtEnvelope{
+		ID:           evt.ID,
+		Type:         evt.Topic,
+		Version:      evt.Version,
+		OccurredAt:   evt.OccurredAt,
+		Producer:     evt.Producer,
+		TraceContext: traceContext,
+		Data:         data,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return msg, nil
+}
+
+// DecodeEvt unwraps an envelope, Data is set to the catalogue payload of its type,
+// e.g. *OrderCreatedEvt. An event newer than the catalogue is rejected.
+func DecodeEvt(msg []byte) (*AppEvent, error) {
+	var envelope EvtEnvelope
+	if err := json.Unmarshal(msg, &envelope); err != nil {
+		return nil, errors.Wrap(ErrInvalidEvt, err.Error())
+	}
+	if envelope.ID == "" || envelope.Type == "" {
+		return nil, errors.Wrap(ErrInvalidEvt, "id and type are required")
+	}
+
+	newPayload, ok := evtCatalog[envelope.Type]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownEvt, envelope.Type)
+	}
+
+	payload := newPayload()
+	if envelope.Version > payload.EvtVersion() {
+		return nil, errors.Wrapf(ErrUnsupportedEvtVersion, "%s v%d", envelope.Type, envelope.Version)
+	}
+	if err := json.Unmarshal(envelope.Data, payload); err != nil {
+		return nil, errors.Wrap(ErrInvalidEvt, err.Error())
+	}
+
+	return &AppEvent{
+		ID:           envelope.ID,
+		Topic:        envelope.Type,
+		Version:      envelope.Version,
+		OccurredAt:   envelope.OccurredAt,
+		Producer:     envelope.Producer,
+		TraceContext: envelope.TraceContext,
+		Data:         payload,
+	}, nil
+}
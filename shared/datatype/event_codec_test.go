@@ -0,0 +1,141 @@
+package datatype
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// evtSamples holds one payload of each event of the catalogue
+var evtSamples = map[string]EvtPayload{
+	EvtNotifyOrderCreate:         &OrderCreatedEvt{OrderID: "order-1", UserID: "user-1", RestaurantID: "restaurant-1"},
+	EvtNotifyOrderStateChange:    &OrderStateChangedEvt{OrderID: "order-1", OldState: "WAITING_FOR_SHIPPER", NewState: "PREPARING"},
+	EvtNotifyOrderCancel:         &OrderCancelledEvt{OrderID: "order-1", CancelReason: "payment failed"},
+	EvtNotifyShipperAssign:       &ShipperAssignedEvt{OrderID: "order-1", ShipperID: "shipper-1"},
+	EvtNotifyPaymentStatusChange: &PaymentStatusChangedEvt{OrderID: "order-1", PaymentStatus: "PAID"},
+}
+
+func TestEvtCodec_RoundTrip(t *testing.T) {
+	for _, topic := range EvtTopics() {
+		t.Run(topic, func(t *testing.T) {
+			sample, ok := evtSamples[topic]
+			if !ok {
+				t.Fatalf("no sample for %s, add one to evtSamples", topic)
+			}
+			if sample.EvtTopic() != topic {
+				t.Fatalf("EvtTopic() = %s, want %s", sample.EvtTopic(), topic)
+			}
+
+			evt := NewAppEvent(WithData(sample), WithOccurredAt(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+			msg, err := EncodeEvt(context.Background(), evt)
+			if err != nil {
+				t.Fatalf("EncodeEvt() error = %v", err)
+			}
+
+			got, err := DecodeEvt(msg)
+			if err != nil {
+				t.Fatalf("DecodeEvt() error = %v", err)
+			}
+			if got.ID != evt.ID || got.Topic != topic || got.Version != sample.EvtVersion() ||
+				got.Producer != DefaultEvtProducer || !got.OccurredAt.Equal(evt.OccurredAt) {
+				t.Errorf("DecodeEvt() = %+v, want %+v", got, evt)
+			}
+			if !reflect.DeepEqual(got.Data, sample) {
+				t.Errorf("DecodeEvt() data = %+v, want %+v", got.Data, sample)
+			}
+		})
+	}
+}
+
+// The wire format is shared with other services, a field must not be renamed without a new version
+func TestEvtCodec_WireContract(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want EvtPayload
+	}{
+		{
+			name: "TC 1: order created",
+			msg:  `{"id":"1","type":"order-create","version":1,"data":{"orderId":"o","userId":"u","restaurantId":"r"}}`,
+			want: &OrderCreatedEvt{OrderID: "o", UserID: "u", RestaurantID: "r"},
+		},
+		{
+			name: "TC 2: order state changed",
+			msg:  `{"id":"1","type":"order-state-change","version":1,"data":{"orderId":"o","oldState":"a","newState":"b"}}`,
+			want: &OrderStateChangedEvt{OrderID: "o", OldState: "a", NewState: "b"},
+		},
+		{
+			name: "TC 3: order cancelled",
+			msg:  `{"id":"1","type":"order-cancel","version":1,"data":{"orderId":"o","cancelReason":"r"}}`,
+			want: &OrderCancelledEvt{OrderID: "o", CancelReason: "r"},
+		},
+		{
+			name: "TC 4: shipper assigned",
+			msg:  `{"id":"1","type":"order-shipper-assign","version":1,"data":{"orderId":"o","shipperId":"s"}}`,
+			want: &ShipperAssignedEvt{OrderID: "o", ShipperID: "s"},
+		},
+		{
+			name: "TC 5: payment status changed",
+			msg:  `{"id":"1","type":"order-payment-status-change","version":1,"data":{"orderId":"o","paymentStatus":"PAID"}}`,
+			want: &PaymentStatusChangedEvt{OrderID: "o", PaymentStatus: "PAID"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeEvt([]byte(tt.msg))
+			if err != nil {
+				t.Fatalf("DecodeEvt() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Data, tt.want) {
+				t.Errorf("DecodeEvt() data = %+v, want %+v", got.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvtCodec_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want error
+	}{
+		{name: "TC 1: not an envelope", msg: `{"orderId":"o"}`, want: ErrInvalidEvt},
+		{name: "TC 2: unknown type", msg: `{"id":"1","type":"unknown","version":1,"data":{}}`, want: ErrUnknownEvt},
+		{name: "TC 3: newer version", msg: `{"id":"1","type":"order-create","version":2,"data":{}}`, want: ErrUnsupportedEvtVersion},
+		{name: "TC 4: bad data", msg: `{"id":"1","type":"order-create","version":1,"data":{"orderId":1}}`, want: ErrInvalidEvt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeEvt([]byte(tt.msg)); !errors.Is(err, tt.want) {
+				t.Errorf("DecodeEvt() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvtCodec_TraceContext(t *testing.T) {
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	msg, err := EncodeEvt(ctx, NewAppEvent(WithData(OrderCreatedEvt{OrderID: "order-1"})))
+	if err != nil {
+		t.Fatalf("EncodeEvt() error = %v", err)
+	}
+	evt, err := DecodeEvt(msg)
+	if err != nil {
+		t.Fatalf("DecodeEvt() error = %v", err)
+	}
+
+	got := trace.SpanContextFromContext(evt.ContextWithTrace(context.Background()))
+	if got.TraceID() != spanCtx.TraceID() || got.SpanID() != spanCtx.SpanID() {
+		t.Errorf("trace context = %v, want %v", got, spanCtx)
+	}
+}
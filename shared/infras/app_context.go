@@ -14,6 +14,9 @@ import (
 
 type IMiddlewareProvider interface {
 	Auth() gin.HandlerFunc
+	RequireRole(roles ...datatype.UserRole) gin.HandlerFunc
+	RequireOwner(resourceId middleware.ResourceIdFunc, checker middleware.IOwnershipChecker) gin.HandlerFunc
+	RequireInternal() gin.HandlerFunc
 }
 
 type IDbContext interface {
@@ -45,9 +48,9 @@ func NewAppContext(db *gorm.DB) IAppContext {
 	dbCtx := NewDbContext(db)
 
 	config := datatype.GetConfig()
-	introspectRpcClient := sharerpc.NewIntrospectRpcClient(config.UserServiceURL)
+	tokenValidator := sharerpc.NewTokenValidator(config)
 
-	provider := middleware.NewMiddlewareProvider(introspectRpcClient)
+	provider := middleware.NewMiddlewareProvider(tokenValidator, config.AuthConfig.InternalToken)
 	var uploader IUploader
 	// Only initialize Minio uploader if the required environment variables are set
 	if config.Minio.Domain != "" && config.Minio.AccessKey != "" && config.Minio.SecretKey != "" {
@@ -58,14 +61,19 @@ func NewAppContext(db *gorm.DB) IAppContext {
 		}
 	}
 
-	natsComp := sharecomponent.NewNatsComp()
+	var msgBroker IMsgBroker
+	if config.MsgBroker == datatype.MsgBrokerMemory {
+		msgBroker = sharecomponent.NewInMemoryBroker(false)
+	} else {
+		msgBroker = sharecomponent.NewNatsComp()
+	}
 
 	return &appContext{
 		mldProvider: provider,
 		dbContext:   dbCtx,
 		config:      config,
 		uploader:    uploader,
-		msgBroker:   natsComp,
+		msgBroker:   msgBroker,
 	}
 }
 
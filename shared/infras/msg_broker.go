@@ -3,9 +3,15 @@ package shareinfras
 import (
 	"context"
 
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
 	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
 )
 
 type IMsgBroker interface {
 	Publish(ctx context.Context, topic string, evt *datatype.AppEvent) error
 }
+
+// IMsgSubscriber is implemented by the in-memory broker, whose events are consumed in the same process
+type IMsgSubscriber interface {
+	Subscribe(topic string, handler sharecomponent.MsgHandler) (unsubscribe func())
+}
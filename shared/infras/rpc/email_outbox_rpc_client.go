@@ -0,0 +1,49 @@
+package sharerpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	sharedmodel "github.com/ntttrang/go-food-delivery-backend-service/shared/model"
+	"github.com/pkg/errors"
+	"resty.dev/v3"
+)
+
+// emailOutboxTimeout bounds the enqueue call, sending is done later by the email dispatcher
+const emailOutboxTimeout = 10 * time.Second
+
+// EmailOutboxRpcClient queues the emails in the outbox of the notification service.
+// It replaces the SMTP email service for the senders, an error means the email was not queued.
+// The emails are sent from the configured sender.
+type EmailOutboxRpcClient struct {
+	notificationServiceURL string
+	client                 *resty.Client
+}
+
+func NewEmailOutboxRpcClient(notificationServiceURL string, internalToken string) *EmailOutboxRpcClient {
+	return &EmailOutboxRpcClient{
+		notificationServiceURL: notificationServiceURL,
+		client:                 resty.New().SetTimeout(emailOutboxTimeout).SetHeader(datatype.HeaderInternalToken, internalToken),
+	}
+}
+
+func (c *EmailOutboxRpcClient) SendEmail(message sharedmodel.EmailMessage) error {
+	url := fmt.Sprintf("%s/emails", c.notificationServiceURL)
+
+	resp, err := c.client.R().SetBody(map[string]any{
+		"to":          message.To,
+		"subject":     message.Subject,
+		"body":        message.Body,
+		"textBody":    message.TextBody,
+		"isHtml":      message.IsHTML,
+		"attachments": message.Attachments,
+	}).Post(url)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if resp.IsError() {
+		return errors.Errorf("notification service responded %s: %s", resp.Status(), resp.String())
+	}
+	return nil
+}
@@ -11,11 +11,13 @@ import (
 
 type IntrospectRpcClient struct {
 	userServiceURL string
+	client         *resty.Client
 }
 
 func NewIntrospectRpcClient(userServiceURL string) *IntrospectRpcClient {
 	return &IntrospectRpcClient{
 		userServiceURL: userServiceURL,
+		client:         resty.New(),
 	}
 }
 
@@ -33,8 +35,6 @@ func (r *dataRequester) GetRole() string {
 }
 
 func (c *IntrospectRpcClient) Validate(token string) (datatype.Requester, error) {
-	client := resty.New()
-
 	type ResponseDTO struct {
 		Data struct {
 			UserId string `json:"id"`
@@ -46,17 +46,25 @@ func (c *IntrospectRpcClient) Validate(token string) (datatype.Requester, error)
 
 	url := fmt.Sprintf("%s/introspect-token", c.userServiceURL)
 
-	_, err := client.R().SetBody(map[string]any{
+	resp, err := c.client.R().SetBody(map[string]any{
 		"token": token,
 	}).SetResult(&response).Post(url)
 	if err != nil {
-		fmt.Println(err)
 		return nil, errors.WithStack(err)
 	}
-	fmt.Println(response)
+
+	// Expired or revoked tokens are rejected by the user service
+	if resp.IsError() {
+		return nil, datatype.ErrUnauthorized.WithDebug(resp.String())
+	}
+
+	userId, err := uuid.Parse(response.Data.UserId)
+	if err != nil {
+		return nil, datatype.ErrUnauthorized.WithWrap(err).WithDebug(err.Error())
+	}
 
 	return &dataRequester{
-		UserID:    uuid.MustParse(response.Data.UserId),
+		UserID:    userId,
 		RoleValue: response.Data.Role,
 	}, nil
 }
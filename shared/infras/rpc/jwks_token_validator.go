@@ -0,0 +1,130 @@
+package sharerpc
+
+import (
+	"crypto"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+	"github.com/pkg/errors"
+	"resty.dev/v3"
+)
+
+// minJwksRefetchInterval limits refetches triggered by unknown kids or by a failing user service
+const minJwksRefetchInterval = 10 * time.Second
+
+// JwksTokenValidator validates access tokens locally with the public keys published by the user service.
+// Keys are cached and refetched when they get old or when a token is signed by an unknown (rotated) key.
+// Revoked tokens stay valid until they expire, use introspection when revocation matters.
+type JwksTokenValidator struct {
+	jwksURL         string
+	refreshInterval time.Duration
+	client          *resty.Client
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewJwksTokenValidator(jwksURL string, refreshInterval time.Duration) *JwksTokenValidator {
+	return &JwksTokenValidator{
+		jwksURL:         jwksURL,
+		refreshInterval: refreshInterval,
+		client:          resty.New(),
+		keys:            make(map[string]crypto.PublicKey),
+	}
+}
+
+func (v *JwksTokenValidator) Validate(token string) (datatype.Requester, error) {
+	var claims sharecomponent.AppClaims
+	parsed, err := jwt.ParseWithClaims(token, &claims, v.keyfunc, jwt.WithValidMethods(sharecomponent.AsymmetricMethods))
+	if err != nil || !parsed.Valid {
+		return nil, datatype.ErrUnauthorized.WithWrap(sharecomponent.ErrTokenInvalid).WithDebug(errorString(err))
+	}
+
+	userId, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, datatype.ErrUnauthorized.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return &dataRequester{
+		UserID:    userId,
+		RoleValue: claims.Role,
+	}, nil
+}
+
+func (v *JwksTokenValidator) keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no kid")
+	}
+
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	stale := time.Since(v.fetchedAt) > v.refreshInterval
+	canRefetch := time.Since(v.attemptedAt) > minJwksRefetchInterval
+	v.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if canRefetch {
+		if err := v.refresh(); err != nil {
+			// Keep using the cached key while the user service is unreachable
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, sharecomponent.ErrJwtKeyNotFound
+}
+
+func (v *JwksTokenValidator) refresh() error {
+	v.mu.Lock()
+	v.attemptedAt = time.Now()
+	v.mu.Unlock()
+
+	var set sharecomponent.JWKSet
+	resp, err := v.client.R().SetResult(&set).Get(v.jwksURL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if resp.IsError() {
+		return errors.Errorf("fetch jwks: %s", resp.Status())
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range set.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+
+	return nil
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,66 @@
+package sharerpc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	sharecomponent "github.com/ntttrang/go-food-delivery-backend-service/shared/component"
+)
+
+func TestJwksTokenValidator_Validate(t *testing.T) {
+	_, key1, _ := ed25519.GenerateKey(rand.Reader)
+	_, key2, _ := ed25519.GenerateKey(rand.Reader)
+	signing1 := sharecomponent.JwtSigningKey{Kid: "k1", Method: jwt.SigningMethodEdDSA, PrivateKey: key1}
+	signing2 := sharecomponent.JwtSigningKey{Kid: "k2", Method: jwt.SigningMethodEdDSA, PrivateKey: key2}
+
+	var published atomic.Value
+	published.Store(sharecomponent.NewJwtKeySet(signing1))
+	var fetches int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(published.Load().(*sharecomponent.JwtKeySet).JWKS())
+	}))
+	defer srv.Close()
+
+	userId := "019615db-9adb-7eff-ba03-45017274084c"
+	validator := NewJwksTokenValidator(srv.URL, time.Hour)
+
+	token1, _ := sharecomponent.NewJwtCompWithKeys(sharecomponent.NewJwtKeySet(signing1), 60).IssueToken(context.Background(), userId, "SHIPPER")
+	requester, err := validator.Validate(token1)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if requester.Subject().String() != userId || requester.GetRole() != "SHIPPER" {
+		t.Errorf("Validate() requester = %v %v", requester.Subject(), requester.GetRole())
+	}
+
+	// Cached keys are reused
+	if _, err := validator.Validate(token1); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("expected 1 jwks fetch, got %d", n)
+	}
+
+	// A token signed with a rotated key triggers a refetch
+	published.Store(sharecomponent.NewJwtKeySet(signing2, signing1))
+	validator.attemptedAt = time.Time{}
+	token2, _ := sharecomponent.NewJwtCompWithKeys(sharecomponent.NewJwtKeySet(signing2), 60).IssueToken(context.Background(), userId, "USER")
+	if _, err := validator.Validate(token2); err != nil {
+		t.Fatalf("Validate() rotated key error = %v", err)
+	}
+
+	// Tampered token is rejected
+	if _, err := validator.Validate(token2 + "x"); err == nil {
+		t.Error("expected tampered token to be rejected")
+	}
+}
@@ -0,0 +1,19 @@
+package sharerpc
+
+import (
+	"github.com/ntttrang/go-food-delivery-backend-service/middleware"
+	"github.com/ntttrang/go-food-delivery-backend-service/shared/datatype"
+)
+
+// NewTokenValidator returns the token validator used by the auth middleware of the modules
+func NewTokenValidator(cfg *datatype.Config) middleware.ITokenValidator {
+	if cfg.AuthConfig.ValidationMode == datatype.AuthValidationLocal {
+		return NewJwksTokenValidator(cfg.AuthConfig.JwksURL, cfg.AuthConfig.JwksRefreshInterval)
+	}
+
+	introspectRpcClient := NewIntrospectRpcClient(cfg.UserServiceURL)
+	if cfg.AuthConfig.IntrospectCacheTTL > 0 {
+		return middleware.NewCachedTokenValidator(introspectRpcClient, cfg.AuthConfig.IntrospectCacheTTL)
+	}
+	return introspectRpcClient
+}
@@ -1,10 +1,18 @@
 package sharedmodel
 
 type EmailMessage struct {
-	From        string
+	From        string // The configured sender when empty
 	To          []string
 	Subject     string
 	Body        string
+	TextBody    string // Plain text alternative of an HTML body
 	IsHTML      bool
-	Attachments []string
+	Attachments []string // File names of EMAIL_ATTACHMENT_DIR
+}
+
+// EmailContent is a rendered email template
+type EmailContent struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
 }
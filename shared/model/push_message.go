@@ -0,0 +1,8 @@
+package sharedmodel
+
+type PushMessage struct {
+	Tokens []string // FCM/APNs device tokens of the recipient
+	Title  string
+	Body   string
+	Data   map[string]string // Read by the app, e.g. the order to open
+}
@@ -0,0 +1,6 @@
+package sharedmodel
+
+type SmsMessage struct {
+	To   string // Phone number
+	Body string // Short text, no HTML
+}